@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	appService "github.com/ydonggwui/blog-api/internal/application/service"
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/database"
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	postgresRepo "github.com/ydonggwui/blog-api/internal/infrastructure/persistence/postgres"
+	minioStorage "github.com/ydonggwui/blog-api/internal/infrastructure/storage/minio"
+)
+
+const commandUsage = `usage: blog-api [command]
+
+Without a command the API server starts.
+
+Commands:
+  reset-password <username>   print a one-time token to set a new password for the admin
+  gc-media [--delete]         report stored files no media refers to and media whose files are missing;
+                              with --delete, remove those files once older than MEDIA_ORPHAN_GRACE_PERIOD`
+
+// runCommand runs a maintenance command instead of the server
+func runCommand(args []string, db *sql.DB, queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config) error {
+	switch args[0] {
+	case "reset-password":
+		if len(args) != 2 {
+			return errors.New(commandUsage)
+		}
+		return resetPassword(queries, redisClient, cfg, args[1])
+	case "gc-media":
+		if len(args) > 2 || (len(args) == 2 && args[1] != "--delete") {
+			return errors.New(commandUsage)
+		}
+		return gcMedia(db, queries, cfg, len(args) == 2)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], commandUsage)
+	}
+}
+
+// resetPassword issues a password reset token for an admin who cannot log in, such as
+// an owner who forgot their password or has no other owner to help them
+func resetPassword(queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reset, err := newAuthService(queries, redisClient, cfg).IssuePasswordReset(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Password reset token for %s (valid until %s):\n\n  %s\n\n",
+		reset.Admin.Username, reset.ExpiresAt.Format(time.RFC3339), reset.Token)
+	fmt.Println(`Set a new password with POST /api/admin/auth/reset-password {"token": "...", "password": "..."}`)
+	return nil
+}
+
+// gcMedia reconciles the bucket with the media records. Files are orphaned when a
+// request dies between uploading and saving the record, or when deleting a
+// variant fails; records dangle when their file was removed outside the API.
+func gcMedia(db *sql.DB, queries *sqlc.Queries, cfg *config.Config, deleteOrphans bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	minioClient, err := database.NewMinIOClient(&cfg.MinIO)
+	if err != nil {
+		return fmt.Errorf("connect to MinIO: %w", err)
+	}
+	mediaService := appService.NewMediaService(postgresRepo.NewMediaRepository(db, queries),
+		minioStorage.NewStorageRepository(minioClient, &cfg.MinIO),
+		postgresRepo.NewAuditLogRepository(queries), &cfg.Media)
+
+	report, err := mediaService.ReconcileStorage(ctx, deleteOrphans)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Orphaned files (%d):\n", len(report.Orphans))
+	for _, obj := range report.Orphans {
+		fmt.Printf("  %s  %d bytes  %s\n", obj.Path, obj.Size, obj.LastModified.Format(time.RFC3339))
+	}
+	fmt.Printf("Media with missing files (%d):\n", len(report.Dangling))
+	for _, r := range report.Dangling {
+		fmt.Printf("  media %d  %s\n", r.MediaID, r.Path)
+	}
+
+	if !deleteOrphans {
+		fmt.Println("Run with --delete to remove orphaned files older than", cfg.Media.OrphanGracePeriod)
+		return nil
+	}
+	if report.DeleteRefused {
+		return errors.New("refusing to delete while media records point to missing files; fix or remove those records first")
+	}
+	fmt.Printf("Deleted %d of %d orphaned files (kept files newer than %s)\n",
+		len(report.Deleted), len(report.Orphans), cfg.Media.OrphanGracePeriod)
+	return nil
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log"
+	"os"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/ydonggwui/blog-api/internal/config"
 	"github.com/ydonggwui/blog-api/internal/database"
 	"github.com/ydonggwui/blog-api/internal/database/sqlc"
@@ -13,7 +16,11 @@ import (
 
 	// Clean Architecture imports
 	appService "github.com/ydonggwui/blog-api/internal/application/service"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/infrastructure/identity/oidc"
 	postgresRepo "github.com/ydonggwui/blog-api/internal/infrastructure/persistence/postgres"
+	redisRepo "github.com/ydonggwui/blog-api/internal/infrastructure/persistence/redis"
 )
 
 // @title Blog API
@@ -41,6 +48,22 @@ func main() {
 
 	// Load configuration
 	cfg := config.Load()
+	if !entity.SigningAlgorithm(cfg.JWT.Algorithm).IsValid() {
+		log.Fatalf("Unsupported JWT_ALGORITHM %q, use HS256, RS256 or EdDSA", cfg.JWT.Algorithm)
+	}
+	// Without it the stored private keys would be sealed with a well-known key
+	if entity.SigningAlgorithm(cfg.JWT.Algorithm).IsAsymmetric() && cfg.JWT.KeyEncryptionKey == "" {
+		log.Fatalf("JWT_KEY_ENCRYPTION_KEY is required with JWT_ALGORITHM %s", cfg.JWT.Algorithm)
+	}
+	if cfg.JWT.TOTPEncryptionKey == "" {
+		log.Fatal("TOTP_ENCRYPTION_KEY is required when JWT_SECRET is not set")
+	}
+	if cfg.OIDC.Enabled() && (cfg.OIDC.ClientID == "" || cfg.OIDC.RedirectURL == "") {
+		log.Fatal("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC_ISSUER_URL is set")
+	}
+	if cfg.OIDC.MatchBy != "email" && cfg.OIDC.MatchBy != "subject" {
+		log.Fatalf("Unsupported OIDC_MATCH_BY %q, use email or subject", cfg.OIDC.MatchBy)
+	}
 
 	// Connect to PostgreSQL
 	db, err := database.NewPostgresDB(&cfg.Database)
@@ -61,6 +84,14 @@ func main() {
 	defer redisClient.Close()
 	log.Println("Connected to Redis")
 
+	// Maintenance commands such as "reset-password <username>" or "gc-media" run once and exit
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:], db, queries, redisClient, cfg); err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	// Connect to MinIO
 	minioClient, err := database.NewMinIOClient(&cfg.MinIO)
 	if err != nil {
@@ -69,10 +100,21 @@ func main() {
 	log.Println("Connected to MinIO")
 
 	// Seed initial admin
-	if err := seedAdmin(queries, cfg); err != nil {
+	if err := seedAdmin(queries, redisClient, cfg); err != nil {
 		log.Fatalf("Failed to seed admin: %v", err)
 	}
 
+	// Start scheduled post publisher
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	startPublishScheduler(schedulerCtx, db, queries, redisClient, cfg)
+
+	// Rotate RS256/EdDSA signing keys
+	startKeyRotation(schedulerCtx, queries, redisClient, cfg)
+
+	// Rebuild search suggestions in the background
+	go rebuildSuggestIndex(db, queries, redisClient)
+
 	// Setup router
 	r := router.New(cfg, db, queries, redisClient, minioClient)
 
@@ -83,7 +125,7 @@ func main() {
 	}
 }
 
-func seedAdmin(queries *sqlc.Queries, cfg *config.Config) error {
+func seedAdmin(queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config) error {
 	if cfg.Admin.Username == "" || cfg.Admin.Password == "" {
 		log.Println("Admin credentials not configured, skipping admin seed")
 		return nil
@@ -92,10 +134,7 @@ func seedAdmin(queries *sqlc.Queries, cfg *config.Config) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Use clean architecture components
-	adminRepo := postgresRepo.NewAdminRepository(queries)
-	authService := appService.NewAuthService(adminRepo, &cfg.JWT)
-
+	authService := newAuthService(queries, redisClient, cfg)
 	if err := authService.EnsureAdminExists(ctx, cfg.Admin.Username, cfg.Admin.Password); err != nil {
 		return err
 	}
@@ -103,3 +142,90 @@ func seedAdmin(queries *sqlc.Queries, cfg *config.Config) error {
 	log.Println("Admin user ensured")
 	return nil
 }
+
+// newAuthService builds the auth service outside of the router for seeding and commands
+func newAuthService(queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config) domainService.AuthService {
+	return appService.NewAuthService(postgresRepo.NewAdminRepository(queries, cfg.JWT.TOTPEncryptionKey),
+		redisRepo.NewRefreshTokenRepository(redisClient),
+		redisRepo.NewTokenDenylistRepository(redisClient),
+		redisRepo.NewMFAChallengeRepository(redisClient),
+		redisRepo.NewPasswordResetRepository(redisClient),
+		redisRepo.NewLoginAttemptRepository(redisClient),
+		postgresRepo.NewAuditLogRepository(queries),
+		newSigningKeyService(queries, redisClient, cfg),
+		oidc.NewProvider(&cfg.OIDC),
+		redisRepo.NewOIDCStateRepository(redisClient),
+		&cfg.JWT, &cfg.Login, &cfg.OIDC)
+}
+
+func newSigningKeyService(queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config) domainService.SigningKeyService {
+	return appService.NewSigningKeyService(postgresRepo.NewSigningKeyRepository(queries, cfg.JWT.KeyEncryptionKey),
+		redisRepo.NewLockRepository(redisClient), &cfg.JWT)
+}
+
+func startPublishScheduler(ctx context.Context, db *sql.DB, queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config) {
+	if !cfg.Scheduler.Enabled {
+		log.Println("Scheduled publisher disabled")
+		return
+	}
+
+	postRepo := postgresRepo.NewPostRepository(db, queries)
+	lockRepo := redisRepo.NewLockRepository(redisClient)
+	sitemapCacheRepo := redisRepo.NewSitemapCacheRepository(redisClient)
+	suggestRepo := redisRepo.NewSuggestRepository(redisClient)
+	mediaRepo := postgresRepo.NewMediaRepository(db, queries)
+	auditRepo := postgresRepo.NewAuditLogRepository(queries)
+	postService := appService.NewPostService(postRepo, suggestRepo, mediaRepo, auditRepo)
+	scheduler := appService.NewPublishScheduler(postService, lockRepo, sitemapCacheRepo, cfg.Scheduler.Interval)
+
+	go scheduler.Start(ctx)
+	log.Printf("Scheduled publisher started (interval %s)", cfg.Scheduler.Interval)
+}
+
+// startKeyRotation checks hourly whether the signing key is due for rotation. Replicas
+// share keys through the database, so any of them may rotate.
+func startKeyRotation(ctx context.Context, queries *sqlc.Queries, redisClient *redis.Client, cfg *config.Config) {
+	if !entity.SigningAlgorithm(cfg.JWT.Algorithm).IsAsymmetric() {
+		return
+	}
+
+	signingKeyService := newSigningKeyService(queries, redisClient, cfg)
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			if err := signingKeyService.Rotate(ctx); err != nil {
+				log.Printf("Failed to rotate signing keys: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Printf("Signing key rotation started (%s keys, every %s)", cfg.JWT.Algorithm, cfg.JWT.KeyRotation)
+}
+
+// rebuildSuggestIndex repopulates the autocomplete index so it matches the database,
+// covering data written before the index existed or while Redis was unavailable
+func rebuildSuggestIndex(db *sql.DB, queries *sqlc.Queries, redisClient *redis.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	suggestService := appService.NewSuggestService(
+		postgresRepo.NewPostRepository(db, queries),
+		postgresRepo.NewCategoryRepository(queries),
+		postgresRepo.NewTagRepository(queries),
+		redisRepo.NewSuggestRepository(redisClient),
+		redisRepo.NewLockRepository(redisClient),
+	)
+
+	if err := suggestService.Rebuild(ctx); err != nil {
+		log.Printf("Failed to rebuild search suggestions: %v", err)
+		return
+	}
+	log.Println("Search suggestions rebuilt")
+}
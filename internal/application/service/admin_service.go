@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+)
+
+type adminService struct {
+	adminRepo    repository.AdminRepository
+	inviteRepo   repository.AdminInviteRepository
+	loginRepo    repository.LoginAttemptRepository
+	refreshRepo  repository.RefreshTokenRepository
+	denylistRepo repository.TokenDenylistRepository
+	auditRepo    repository.AuditLogRepository
+	cfg          *config.AdminConfig
+	jwtConfig    *config.JWTConfig
+}
+
+// NewAdminService creates a new admin management service
+func NewAdminService(
+	adminRepo repository.AdminRepository,
+	inviteRepo repository.AdminInviteRepository,
+	loginRepo repository.LoginAttemptRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	denylistRepo repository.TokenDenylistRepository,
+	auditRepo repository.AuditLogRepository,
+	cfg *config.AdminConfig,
+	jwtConfig *config.JWTConfig,
+) domainService.AdminService {
+	return &adminService{
+		adminRepo:    adminRepo,
+		inviteRepo:   inviteRepo,
+		loginRepo:    loginRepo,
+		refreshRepo:  refreshRepo,
+		denylistRepo: denylistRepo,
+		auditRepo:    auditRepo,
+		cfg:          cfg,
+		jwtConfig:    jwtConfig,
+	}
+}
+
+func (s *adminService) ListAdmins(ctx context.Context) ([]entity.Admin, error) {
+	admins, err := s.adminRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.ListAdmins: %w", err)
+	}
+	return admins, nil
+}
+
+func (s *adminService) InviteAdmin(ctx context.Context, cmd domainService.InviteAdminCommand) (*entity.AdminInvitation, error) {
+	if !cmd.Role.IsValid() {
+		return nil, domain.ErrInvalidAdminRole
+	}
+	username := strings.TrimSpace(cmd.Username)
+	email := strings.TrimSpace(cmd.Email)
+
+	existing, err := s.adminRepo.FindByUsername(ctx, username)
+	if err != nil && !errors.Is(err, domain.ErrAdminNotFound) {
+		return nil, fmt.Errorf("adminService.InviteAdmin: find admin failed: %w", err)
+	}
+	if err == nil && !existing.IsInvited() {
+		return nil, domain.ErrAdminUsernameExists
+	}
+	reissue := err == nil
+
+	var admin *entity.Admin
+	if reissue {
+		// The earlier invitation was never accepted, e.g. because it expired, so keep the
+		// account and issue a new token with the requested role and email
+		admin, err = s.updateInvited(ctx, existing, cmd.Role, email)
+		if err != nil {
+			return nil, fmt.Errorf("adminService.InviteAdmin: %w", err)
+		}
+	} else {
+		if err := s.ensureIdentityFree(ctx, 0, email, ""); err != nil {
+			return nil, fmt.Errorf("adminService.InviteAdmin: %w", err)
+		}
+
+		// No password until the invitation is accepted, so the account cannot sign in yet
+		admin, err = s.adminRepo.Create(ctx, &entity.Admin{
+			Username: username,
+			Role:     cmd.Role,
+			Email:    email,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("adminService.InviteAdmin: create admin failed: %w", err)
+		}
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.InviteAdmin: %w", err)
+	}
+	if err := s.inviteRepo.Save(ctx, hashToken(token), admin.ID, s.cfg.InviteTTL); err != nil {
+		// Without an invitation the account could never be activated, so free the username again
+		if !reissue {
+			if delErr := s.adminRepo.DeleteInvited(context.WithoutCancel(ctx), admin.ID); delErr != nil {
+				logger.Error(ctx, "Failed to remove admin after invitation failure", "admin_id", admin.ID, "error", delErr)
+			}
+		}
+		return nil, fmt.Errorf("adminService.InviteAdmin: save invitation failed: %w", err)
+	}
+
+	auditLog := &entity.AuditLog{
+		Action:     entity.AuditActionAdminInvite,
+		TargetType: "admin",
+		TargetID:   auditTargetID(admin.ID),
+		After:      adminAudit(admin),
+	}
+	if reissue {
+		auditLog.Before = adminAudit(existing)
+	}
+	recordAudit(ctx, s.auditRepo, auditLog)
+
+	return &entity.AdminInvitation{
+		Admin:     admin,
+		Token:     token,
+		ExpiresAt: time.Now().Add(s.cfg.InviteTTL),
+	}, nil
+}
+
+func (s *adminService) AcceptInvite(ctx context.Context, token, password string) (*entity.Admin, error) {
+	adminID, err := s.inviteRepo.Consume(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrInviteNotFound) {
+			return nil, domain.ErrInvalidInviteToken
+		}
+		return nil, fmt.Errorf("adminService.AcceptInvite: %w", err)
+	}
+
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return nil, domain.ErrInvalidInviteToken
+		}
+		return nil, fmt.Errorf("adminService.AcceptInvite: find admin failed: %w", err)
+	}
+	if !admin.IsInvited() {
+		return nil, domain.ErrInvalidInviteToken
+	}
+
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.AcceptInvite: hash password failed: %w", err)
+	}
+	if err := s.adminRepo.UpdatePassword(ctx, admin.ID, hashedPassword); err != nil {
+		return nil, fmt.Errorf("adminService.AcceptInvite: %w", err)
+	}
+
+	admin.Password = hashedPassword
+	return admin, nil
+}
+
+func (s *adminService) UpdateRole(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error) {
+	if !role.IsValid() {
+		return nil, domain.ErrInvalidAdminRole
+	}
+
+	admin, err := s.adminRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.UpdateRole: find admin failed: %w", err)
+	}
+	if role != entity.AdminRoleOwner {
+		if err := s.ensureOtherOwner(ctx, admin); err != nil {
+			return nil, fmt.Errorf("adminService.UpdateRole: %w", err)
+		}
+	}
+
+	updated, err := s.adminRepo.UpdateRole(ctx, id, role)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.UpdateRole: %w", err)
+	}
+	// Tokens carry the role, so sessions started under the old one must not outlive it
+	if err := revokeSessions(ctx, s.refreshRepo, s.denylistRepo, id, s.jwtConfig.Expiry); err != nil {
+		return nil, fmt.Errorf("adminService.UpdateRole: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionAdminRole,
+		TargetType: "admin",
+		TargetID:   auditTargetID(id),
+		Before:     adminAudit(admin),
+		After:      adminAudit(updated),
+	})
+	return updated, nil
+}
+
+func (s *adminService) SetDisabled(ctx context.Context, id int32, disabled bool) (*entity.Admin, error) {
+	admin, err := s.adminRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.SetDisabled: find admin failed: %w", err)
+	}
+	if disabled {
+		if err := s.ensureOtherOwner(ctx, admin); err != nil {
+			return nil, fmt.Errorf("adminService.SetDisabled: %w", err)
+		}
+	}
+
+	updated, err := s.adminRepo.SetDisabled(ctx, id, disabled)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.SetDisabled: %w", err)
+	}
+	if err := revokeSessions(ctx, s.refreshRepo, s.denylistRepo, id, s.jwtConfig.Expiry); err != nil {
+		return nil, fmt.Errorf("adminService.SetDisabled: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionAdminStatus,
+		TargetType: "admin",
+		TargetID:   auditTargetID(id),
+		Before:     adminAudit(admin),
+		After:      adminAudit(updated),
+	})
+	return updated, nil
+}
+
+func (s *adminService) UpdateIdentity(ctx context.Context, id int32, email, subject string) (*entity.Admin, error) {
+	email = strings.TrimSpace(email)
+	subject = strings.TrimSpace(subject)
+
+	admin, err := s.adminRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.UpdateIdentity: find admin failed: %w", err)
+	}
+	if err := s.ensureIdentityFree(ctx, id, email, subject); err != nil {
+		return nil, fmt.Errorf("adminService.UpdateIdentity: %w", err)
+	}
+
+	updated, err := s.adminRepo.UpdateIdentity(ctx, id, email, subject)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.UpdateIdentity: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionAdminIdentity,
+		TargetType: "admin",
+		TargetID:   auditTargetID(id),
+		Before:     adminAudit(admin),
+		After:      adminAudit(updated),
+	})
+	return updated, nil
+}
+
+func (s *adminService) ListLoginLockouts(ctx context.Context) ([]entity.LoginLockout, error) {
+	lockouts, err := s.loginRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("adminService.ListLoginLockouts: %w", err)
+	}
+	return lockouts, nil
+}
+
+func (s *adminService) ClearLoginLockout(ctx context.Context, subject entity.LoginSubject) error {
+	if !subject.Kind.IsValid() || subject.Value == "" {
+		return domain.ErrInvalidLoginSubject
+	}
+	if err := s.loginRepo.Reset(ctx, subject); err != nil {
+		return fmt.Errorf("adminService.ClearLoginLockout: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionLockoutClear,
+		TargetType: "login_" + string(subject.Kind),
+		TargetID:   subject.Value,
+	})
+	return nil
+}
+
+// updateInvited applies the role and email of a repeated invitation to an admin who has
+// not accepted the earlier one yet
+func (s *adminService) updateInvited(ctx context.Context, admin *entity.Admin, role entity.AdminRole, email string) (*entity.Admin, error) {
+	if err := s.ensureIdentityFree(ctx, admin.ID, email, ""); err != nil {
+		return nil, err
+	}
+
+	updated := admin
+	var err error
+	if updated.Role != role {
+		if updated, err = s.adminRepo.UpdateRole(ctx, admin.ID, role); err != nil {
+			return nil, fmt.Errorf("update role failed: %w", err)
+		}
+	}
+	if updated.Email != email {
+		if updated, err = s.adminRepo.UpdateIdentity(ctx, admin.ID, email, updated.OIDCSubject); err != nil {
+			return nil, fmt.Errorf("update email failed: %w", err)
+		}
+	}
+	return updated, nil
+}
+
+// ensureIdentityFree returns ErrAdminIdentityExists if another admin than id already has
+// the email or OpenID Connect subject
+func (s *adminService) ensureIdentityFree(ctx context.Context, id int32, email, subject string) error {
+	if email != "" {
+		other, err := s.adminRepo.FindByEmail(ctx, email)
+		if err == nil && other.ID != id {
+			return domain.ErrAdminIdentityExists
+		}
+		if err != nil && !errors.Is(err, domain.ErrAdminNotFound) {
+			return fmt.Errorf("find admin by email failed: %w", err)
+		}
+	}
+	if subject != "" {
+		other, err := s.adminRepo.FindByOIDCSubject(ctx, subject)
+		if err == nil && other.ID != id {
+			return domain.ErrAdminIdentityExists
+		}
+		if err != nil && !errors.Is(err, domain.ErrAdminNotFound) {
+			return fmt.Errorf("find admin by subject failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// ensureOtherOwner returns ErrLastOwner if admin is the only active owner left
+func (s *adminService) ensureOtherOwner(ctx context.Context, admin *entity.Admin) error {
+	if admin.Role != entity.AdminRoleOwner || admin.IsDisabled() {
+		return nil
+	}
+
+	owners, err := s.adminRepo.CountActiveOwners(ctx)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return domain.ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+// newMemoryAdminRepo backs the admin repository mock with a map
+func newMemoryAdminRepo(admins ...entity.Admin) *mocks.MockAdminRepository {
+	store := map[int32]*entity.Admin{}
+	recoveryCodes := map[int32]map[string]bool{}
+	totpSteps := map[int32]int64{}
+	nextID := int32(1)
+	for i := range admins {
+		store[admins[i].ID] = &admins[i]
+		nextID = max(nextID, admins[i].ID+1)
+	}
+
+	return &mocks.MockAdminRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.Admin, error) {
+			if a, ok := store[id]; ok {
+				found := *a
+				return &found, nil
+			}
+			return nil, domain.ErrAdminNotFound
+		},
+		FindByUsernameFunc: func(ctx context.Context, username string) (*entity.Admin, error) {
+			for _, a := range store {
+				if a.Username == username {
+					found := *a
+					return &found, nil
+				}
+			}
+			return nil, domain.ErrAdminNotFound
+		},
+		FindByEmailFunc: func(ctx context.Context, email string) (*entity.Admin, error) {
+			for _, a := range store {
+				if a.Email != "" && strings.EqualFold(a.Email, email) {
+					found := *a
+					return &found, nil
+				}
+			}
+			return nil, domain.ErrAdminNotFound
+		},
+		FindByOIDCSubjectFunc: func(ctx context.Context, subject string) (*entity.Admin, error) {
+			for _, a := range store {
+				if a.OIDCSubject != "" && a.OIDCSubject == subject {
+					found := *a
+					return &found, nil
+				}
+			}
+			return nil, domain.ErrAdminNotFound
+		},
+		UpdateIdentityFunc: func(ctx context.Context, id int32, email, subject string) (*entity.Admin, error) {
+			store[id].Email = email
+			store[id].OIDCSubject = subject
+			updated := *store[id]
+			return &updated, nil
+		},
+		CreateFunc: func(ctx context.Context, admin *entity.Admin) (*entity.Admin, error) {
+			created := *admin
+			created.ID = nextID
+			nextID++
+			store[created.ID] = &created
+			return &created, nil
+		},
+		UpdatePasswordFunc: func(ctx context.Context, id int32, hashedPassword string) error {
+			store[id].Password = hashedPassword
+			return nil
+		},
+		DeleteInvitedFunc: func(ctx context.Context, id int32) error {
+			if a, ok := store[id]; ok && a.IsInvited() {
+				delete(store, id)
+			}
+			return nil
+		},
+		UpdateRoleFunc: func(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error) {
+			store[id].Role = role
+			updated := *store[id]
+			return &updated, nil
+		},
+		SetDisabledFunc: func(ctx context.Context, id int32, disabled bool) (*entity.Admin, error) {
+			store[id].DisabledAt = nil
+			if disabled {
+				now := time.Now()
+				store[id].DisabledAt = &now
+			}
+			updated := *store[id]
+			return &updated, nil
+		},
+		CountActiveOwnersFunc: func(ctx context.Context) (int64, error) {
+			var count int64
+			for _, a := range store {
+				if a.Role == entity.AdminRoleOwner && !a.IsDisabled() {
+					count++
+				}
+			}
+			return count, nil
+		},
+		SetTOTPSecretFunc: func(ctx context.Context, id int32, secret string) error {
+			store[id].TOTPSecret = secret
+			store[id].TOTPEnabledAt = nil
+			return nil
+		},
+		EnableTOTPFunc: func(ctx context.Context, id int32, recoveryCodeHashes []string) error {
+			now := time.Now()
+			store[id].TOTPEnabledAt = &now
+			recoveryCodes[id] = map[string]bool{}
+			for _, hash := range recoveryCodeHashes {
+				recoveryCodes[id][hash] = false
+			}
+			return nil
+		},
+		DisableTOTPFunc: func(ctx context.Context, id int32) error {
+			store[id].TOTPSecret = ""
+			store[id].TOTPEnabledAt = nil
+			delete(recoveryCodes, id)
+			return nil
+		},
+		UseRecoveryCodeFunc: func(ctx context.Context, id int32, codeHash string) (bool, error) {
+			used, ok := recoveryCodes[id][codeHash]
+			if !ok || used {
+				return false, nil
+			}
+			recoveryCodes[id][codeHash] = true
+			return true, nil
+		},
+		UseTOTPStepFunc: func(ctx context.Context, id int32, step int64) (bool, error) {
+			if last, ok := totpSteps[id]; ok && last >= step {
+				return false, nil
+			}
+			totpSteps[id] = step
+			return true, nil
+		},
+	}
+}
+
+func newMemoryInviteRepo() *mocks.MockAdminInviteRepository {
+	invites := map[string]int32{}
+	return &mocks.MockAdminInviteRepository{
+		SaveFunc: func(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+			invites[tokenHash] = adminID
+			return nil
+		},
+		ConsumeFunc: func(ctx context.Context, tokenHash string) (int32, error) {
+			id, ok := invites[tokenHash]
+			if !ok {
+				return 0, domain.ErrInviteNotFound
+			}
+			delete(invites, tokenHash)
+			return id, nil
+		},
+	}
+}
+
+func TestAdminService_InviteAndAccept(t *testing.T) {
+	ctx := context.Background()
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "owner", Password: "hash", Role: entity.AdminRoleOwner})
+	store := newMemoryTokenStore()
+	svc := NewAdminService(adminRepo, newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{InviteTTL: time.Hour}, &config.JWTConfig{Expiry: time.Hour})
+
+	if _, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: "superuser"}); !errors.Is(err, domain.ErrInvalidAdminRole) {
+		t.Errorf("expected ErrInvalidAdminRole, got %v", err)
+	}
+	if _, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "owner", Role: entity.AdminRoleEditor}); !errors.Is(err, domain.ErrAdminUsernameExists) {
+		t.Errorf("expected ErrAdminUsernameExists, got %v", err)
+	}
+
+	invitation, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: entity.AdminRoleAuthor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !invitation.Admin.IsInvited() || invitation.Token == "" {
+		t.Fatalf("expected an invited admin with a token, got %+v", invitation)
+	}
+
+	admin, err := svc.AcceptInvite(ctx, invitation.Token, "new-password")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if admin.IsInvited() || comparePassword(admin.Password, "new-password") != nil {
+		t.Error("expected the password to be set")
+	}
+
+	if _, err := svc.AcceptInvite(ctx, invitation.Token, "another-password"); !errors.Is(err, domain.ErrInvalidInviteToken) {
+		t.Errorf("expected the token to be single use, got %v", err)
+	}
+}
+
+func TestAdminService_InviteAdmin_SaveFailure(t *testing.T) {
+	ctx := context.Background()
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "owner", Password: "hash", Role: entity.AdminRoleOwner})
+	inviteRepo := &mocks.MockAdminInviteRepository{
+		SaveFunc: func(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+			return errors.New("redis unavailable")
+		},
+	}
+	store := newMemoryTokenStore()
+	svc := NewAdminService(adminRepo, inviteRepo, store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{InviteTTL: time.Hour}, &config.JWTConfig{Expiry: time.Hour})
+
+	if _, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: entity.AdminRoleAuthor}); err == nil {
+		t.Fatal("expected an error when the invitation cannot be saved")
+	}
+	if _, err := adminRepo.FindByUsername(ctx, "writer"); !errors.Is(err, domain.ErrAdminNotFound) {
+		t.Errorf("expected the invited admin to be removed, got %v", err)
+	}
+
+	// The username is free for a retry
+	svc = NewAdminService(adminRepo, newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{InviteTTL: time.Hour}, &config.JWTConfig{Expiry: time.Hour})
+	if _, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: entity.AdminRoleAuthor}); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+}
+
+func TestAdminService_InviteAdmin_Reissue(t *testing.T) {
+	ctx := context.Background()
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "owner", Password: "hash", Role: entity.AdminRoleOwner})
+	store := newMemoryTokenStore()
+	svc := NewAdminService(adminRepo, newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{InviteTTL: time.Hour}, &config.JWTConfig{Expiry: time.Hour})
+
+	first, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: entity.AdminRoleAuthor})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The first token was lost or expired, so the owner invites the same username again
+	second, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: entity.AdminRoleEditor, Email: "writer@example.com"})
+	if err != nil {
+		t.Fatalf("expected a new invitation, got %v", err)
+	}
+	if second.Admin.ID != first.Admin.ID || second.Token == first.Token {
+		t.Errorf("expected a new token for admin %d, got %+v", first.Admin.ID, second)
+	}
+	if second.Admin.Role != entity.AdminRoleEditor || second.Admin.Email != "writer@example.com" {
+		t.Errorf("expected the new role and email to apply, got %+v", second.Admin)
+	}
+
+	if _, err := svc.AcceptInvite(ctx, second.Token, "new-password"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := svc.InviteAdmin(ctx, domainService.InviteAdminCommand{Username: "writer", Role: entity.AdminRoleAuthor}); !errors.Is(err, domain.ErrAdminUsernameExists) {
+		t.Errorf("expected ErrAdminUsernameExists once accepted, got %v", err)
+	}
+}
+
+func TestAdminService_LastOwner(t *testing.T) {
+	ctx := context.Background()
+	adminRepo := newMemoryAdminRepo(
+		entity.Admin{ID: 1, Username: "owner", Password: "hash", Role: entity.AdminRoleOwner},
+		entity.Admin{ID: 2, Username: "editor", Password: "hash", Role: entity.AdminRoleEditor},
+	)
+	store := newMemoryTokenStore()
+	svc := NewAdminService(adminRepo, newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{}, &config.JWTConfig{Expiry: time.Hour})
+
+	if _, err := svc.UpdateRole(ctx, 1, entity.AdminRoleEditor); !errors.Is(err, domain.ErrLastOwner) {
+		t.Errorf("expected ErrLastOwner when demoting, got %v", err)
+	}
+	if _, err := svc.SetDisabled(ctx, 1, true); !errors.Is(err, domain.ErrLastOwner) {
+		t.Errorf("expected ErrLastOwner when disabling, got %v", err)
+	}
+
+	// With a second owner the first one can step down
+	if _, err := svc.UpdateRole(ctx, 2, entity.AdminRoleOwner); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	admin, err := svc.SetDisabled(ctx, 1, true)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !admin.IsDisabled() {
+		t.Error("expected admin to be disabled")
+	}
+}
+
+func TestAuthService_DisabledAdmin(t *testing.T) {
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "editor", Password: hashed, Role: entity.AdminRoleEditor})
+	store := newMemoryTokenStore()
+	svc := NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), nil, nil, &config.JWTConfig{
+		Secret:        "test-secret",
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: time.Hour,
+	}, &config.LoginThrottleConfig{}, &config.OIDCConfig{})
+	ctx := context.Background()
+
+	result, err := svc.Login(ctx, domainService.LoginCommand{Username: "editor", Password: "password"})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	tokenInfo := result.Tokens
+	claims, err := svc.ValidateToken(ctx, tokenInfo.Token)
+	if err != nil || claims.Role != entity.AdminRoleEditor {
+		t.Fatalf("expected editor role claim, got %+v, %v", claims, err)
+	}
+
+	if _, err := adminRepo.SetDisabled(ctx, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Refresh(ctx, tokenInfo.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("expected refresh to fail for a disabled admin, got %v", err)
+	}
+	if _, err := svc.Login(ctx, domainService.LoginCommand{Username: "editor", Password: "password"}); !errors.Is(err, domain.ErrAdminDisabled) {
+		t.Errorf("expected ErrAdminDisabled, got %v", err)
+	}
+}
+
+func TestAdminService_RoleAndStatusChangesEndSessions(t *testing.T) {
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	adminRepo := newMemoryAdminRepo(
+		entity.Admin{ID: 1, Username: "owner", Password: hashed, Role: entity.AdminRoleOwner},
+		entity.Admin{ID: 2, Username: "editor", Password: hashed, Role: entity.AdminRoleEditor},
+	)
+	store := newMemoryTokenStore()
+	jwtConfig := &config.JWTConfig{Secret: "test-secret", Expiry: 15 * time.Minute, RefreshExpiry: time.Hour}
+	authSvc := NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), nil, nil, jwtConfig, &config.LoginThrottleConfig{}, &config.OIDCConfig{})
+	adminSvc := NewAdminService(adminRepo, newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{}, jwtConfig)
+	ctx := context.Background()
+
+	for name, change := range map[string]func() error{
+		"role change": func() error {
+			_, err := adminSvc.UpdateRole(ctx, 2, entity.AdminRoleAuthor)
+			return err
+		},
+		"disable": func() error {
+			_, err := adminSvc.SetDisabled(ctx, 2, true)
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := adminRepo.SetDisabled(ctx, 2, false); err != nil {
+				t.Fatal(err)
+			}
+			delete(store.revokedBefore, 2)
+			result, err := authSvc.Login(ctx, domainService.LoginCommand{Username: "editor", Password: "password"})
+			if err != nil {
+				t.Fatalf("login failed: %v", err)
+			}
+
+			if err := change(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if _, err := authSvc.ValidateToken(ctx, result.Tokens.Token); err == nil {
+				t.Error("expected the access token to be revoked")
+			}
+			if _, err := authSvc.Refresh(ctx, result.Tokens.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+				t.Errorf("expected the refresh token to be revoked, got %v", err)
+			}
+		})
+	}
+}
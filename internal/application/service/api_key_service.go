@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+// apiKeyPrefix starts every API key so keys are recognisable, e.g. in secret scanners
+const apiKeyPrefix = "blog_"
+
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	auditRepo  repository.AuditLogRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, auditRepo repository.AuditLogRepository) domainService.APIKeyService {
+	return &apiKeyService{apiKeyRepo: apiKeyRepo, auditRepo: auditRepo}
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context, actor entity.Actor) ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	var err error
+	if actor.Can(entity.PermissionAdminsManage) {
+		keys, err = s.apiKeyRepo.List(ctx)
+	} else {
+		keys, err = s.apiKeyRepo.ListByAdmin(ctx, actor.AdminID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.ListAPIKeys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, actor entity.Actor, cmd domainService.CreateAPIKeyCommand) (*entity.CreatedAPIKey, error) {
+	scopes, err := validateScopes(actor, cmd.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(time.Now()) {
+		return nil, domain.ErrInvalidAPIKeyExpiry
+	}
+
+	// The prefix identifies the key in listings; the rest is the secret
+	id := make([]byte, 6)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("apiKeyService.CreateAPIKey: %w", err)
+	}
+	prefix := apiKeyPrefix + hex.EncodeToString(id)
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.CreateAPIKey: %w", err)
+	}
+	key := prefix + "_" + secret
+
+	created, err := s.apiKeyRepo.Create(ctx, &entity.APIKey{
+		AdminID:   actor.AdminID,
+		Name:      strings.TrimSpace(cmd.Name),
+		Prefix:    prefix,
+		Scopes:    scopes,
+		ExpiresAt: cmd.ExpiresAt,
+	}, hashToken(key))
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.CreateAPIKey: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionAPIKeyCreate,
+		TargetType: "api_key",
+		TargetID:   auditTargetID(created.ID),
+		After:      apiKeyAudit(created),
+	})
+	return &entity.CreatedAPIKey{Key: created, Secret: key}, nil
+}
+
+func (s *apiKeyService) UpdateAPIKey(ctx context.Context, actor entity.Actor, id int32, cmd domainService.UpdateAPIKeyCommand) (*entity.APIKey, error) {
+	existing, err := s.authorizeKey(ctx, actor, id)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.UpdateAPIKey: %w", err)
+	}
+	scopes, err := validateScopes(actor, cmd.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.apiKeyRepo.Update(ctx, id, strings.TrimSpace(cmd.Name), scopes)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.UpdateAPIKey: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionAPIKeyUpdate,
+		TargetType: "api_key",
+		TargetID:   auditTargetID(id),
+		Before:     apiKeyAudit(existing),
+		After:      apiKeyAudit(updated),
+	})
+	return updated, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, actor entity.Actor, id int32) (*entity.APIKey, error) {
+	existing, err := s.authorizeKey(ctx, actor, id)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.RevokeAPIKey: %w", err)
+	}
+
+	revoked, err := s.apiKeyRepo.Revoke(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyService.RevokeAPIKey: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionAPIKeyRevoke,
+		TargetType: "api_key",
+		TargetID:   auditTargetID(id),
+		Before:     apiKeyAudit(existing),
+		After:      apiKeyAudit(revoked),
+	})
+	return revoked, nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*entity.Claims, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	found, err := s.apiKeyRepo.FindByHash(ctx, hashToken(key))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("apiKeyService.Authenticate: %w", err)
+	}
+	if found.IsRevoked() || found.IsExpired(time.Now()) || found.Admin.IsDisabled() {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.Touch(ctx, found.ID); err != nil {
+		return nil, fmt.Errorf("apiKeyService.Authenticate: %w", err)
+	}
+
+	return &entity.Claims{
+		UserID:   found.Admin.ID,
+		Username: found.Admin.Username,
+		Role:     found.Admin.Role,
+		APIKeyID: found.ID,
+		Scopes:   found.Scopes,
+	}, nil
+}
+
+// authorizeKey returns the key if it belongs to the actor or the actor manages admins
+func (s *apiKeyService) authorizeKey(ctx context.Context, actor entity.Actor, id int32) (*entity.APIKey, error) {
+	key, err := s.apiKeyRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.AdminID != actor.AdminID && !actor.Can(entity.PermissionAdminsManage) {
+		// Other admins' keys are not revealed to exist
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// validateScopes checks that scopes are known permissions granted by the actor's role.
+// Managing admins is never delegated to an API key.
+func validateScopes(actor entity.Actor, scopes []entity.Permission) ([]entity.Permission, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidAPIKeyScope)
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() || scope == entity.PermissionAdminsManage {
+			return nil, fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidAPIKeyScope, scope)
+		}
+		if !actor.Role.Can(scope) {
+			return nil, fmt.Errorf("%w: your role does not grant %q", domain.ErrInvalidAPIKeyScope, scope)
+		}
+	}
+
+	result := slices.Clone(scopes)
+	slices.Sort(result)
+	return slices.Compact(result), nil
+}
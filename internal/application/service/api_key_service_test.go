@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+// newMemoryAPIKeyRepo backs the API key repository mock with maps
+func newMemoryAPIKeyRepo(admins ...entity.Admin) *mocks.MockAPIKeyRepository {
+	keys := map[int32]*entity.APIKey{}
+	hashes := map[string]int32{}
+	nextID := int32(1)
+
+	find := func(id int32) (*entity.APIKey, error) {
+		if k, ok := keys[id]; ok {
+			found := *k
+			return &found, nil
+		}
+		return nil, domain.ErrAPIKeyNotFound
+	}
+
+	return &mocks.MockAPIKeyRepository{
+		CreateFunc: func(ctx context.Context, key *entity.APIKey, keyHash string) (*entity.APIKey, error) {
+			created := *key
+			created.ID = nextID
+			created.CreatedAt = time.Now()
+			nextID++
+			keys[created.ID] = &created
+			hashes[keyHash] = created.ID
+			return find(created.ID)
+		},
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.APIKey, error) {
+			return find(id)
+		},
+		FindByHashFunc: func(ctx context.Context, keyHash string) (*entity.APIKeyWithAdmin, error) {
+			id, ok := hashes[keyHash]
+			if !ok {
+				return nil, domain.ErrAPIKeyNotFound
+			}
+			key := keys[id]
+			for _, a := range admins {
+				if a.ID == key.AdminID {
+					return &entity.APIKeyWithAdmin{APIKey: *key, Admin: a}, nil
+				}
+			}
+			return nil, domain.ErrAPIKeyNotFound
+		},
+		UpdateFunc: func(ctx context.Context, id int32, name string, scopes []entity.Permission) (*entity.APIKey, error) {
+			keys[id].Name = name
+			keys[id].Scopes = scopes
+			return find(id)
+		},
+		RevokeFunc: func(ctx context.Context, id int32) (*entity.APIKey, error) {
+			now := time.Now()
+			keys[id].RevokedAt = &now
+			return find(id)
+		},
+		TouchFunc: func(ctx context.Context, id int32) error {
+			now := time.Now()
+			keys[id].LastUsedAt = &now
+			return nil
+		},
+	}
+}
+
+func TestAPIKeyService_Scopes(t *testing.T) {
+	ctx := context.Background()
+	author := entity.Actor{AdminID: 2, Role: entity.AdminRoleAuthor}
+	svc := NewAPIKeyService(newMemoryAPIKeyRepo(), &mocks.MockAuditLogRepository{})
+
+	tests := []struct {
+		name   string
+		scopes []entity.Permission
+	}{
+		{"no scopes", nil},
+		{"unknown scope", []entity.Permission{"posts:everything"}},
+		{"not granted by role", []entity.Permission{entity.PermissionMediaDelete}},
+		{"admin management", []entity.Permission{entity.PermissionAdminsManage}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateAPIKey(ctx, author, domainService.CreateAPIKeyCommand{Name: "ci", Scopes: tt.scopes})
+			if !errors.Is(err, domain.ErrInvalidAPIKeyScope) {
+				t.Errorf("expected ErrInvalidAPIKeyScope, got %v", err)
+			}
+		})
+	}
+
+	created, err := svc.CreateAPIKey(ctx, author, domainService.CreateAPIKeyCommand{
+		Name:   "ci",
+		Scopes: []entity.Permission{entity.PermissionPostWrite, entity.PermissionMediaUpload, entity.PermissionPostWrite},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(created.Key.Scopes) != 2 {
+		t.Errorf("expected duplicate scopes to be dropped, got %v", created.Key.Scopes)
+	}
+
+	past := time.Now().Add(-time.Hour)
+	if _, err := svc.CreateAPIKey(ctx, author, domainService.CreateAPIKeyCommand{
+		Name:      "ci",
+		Scopes:    []entity.Permission{entity.PermissionPostWrite},
+		ExpiresAt: &past,
+	}); !errors.Is(err, domain.ErrInvalidAPIKeyExpiry) {
+		t.Errorf("expected ErrInvalidAPIKeyExpiry, got %v", err)
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	author := entity.Admin{ID: 2, Username: "writer", Role: entity.AdminRoleAuthor}
+	actor := entity.Actor{AdminID: author.ID, Role: author.Role}
+	repo := newMemoryAPIKeyRepo(author)
+	svc := NewAPIKeyService(repo, &mocks.MockAuditLogRepository{})
+
+	created, err := svc.CreateAPIKey(ctx, actor, domainService.CreateAPIKeyCommand{
+		Name:   "ci",
+		Scopes: []entity.Permission{entity.PermissionPostWrite},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(created.Secret, created.Key.Prefix+"_") {
+		t.Errorf("expected key %q to start with prefix %q", created.Secret, created.Key.Prefix)
+	}
+
+	claims, err := svc.Authenticate(ctx, created.Secret)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if claims.UserID != author.ID || claims.APIKeyID != created.Key.ID {
+		t.Errorf("expected claims for admin %d and key %d, got %+v", author.ID, created.Key.ID, claims)
+	}
+	scoped := entity.Actor{AdminID: claims.UserID, Role: claims.Role, Scopes: claims.Scopes}
+	if !scoped.Can(entity.PermissionPostWrite) || scoped.Can(entity.PermissionMediaUpload) {
+		t.Errorf("expected the key to be limited to its scopes, got %v", claims.Scopes)
+	}
+	if key, _ := repo.FindByID(ctx, created.Key.ID); key.LastUsedAt == nil {
+		t.Error("expected last use to be recorded")
+	}
+
+	if _, err := svc.Authenticate(ctx, created.Secret+"x"); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("expected ErrInvalidAPIKey for an unknown key, got %v", err)
+	}
+
+	// Another author cannot see or revoke the key
+	other := entity.Actor{AdminID: 3, Role: entity.AdminRoleAuthor}
+	if _, err := svc.RevokeAPIKey(ctx, other, created.Key.ID); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+
+	if _, err := svc.RevokeAPIKey(ctx, actor, created.Key.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, created.Secret); !errors.Is(err, domain.ErrInvalidAPIKey) {
+		t.Errorf("expected ErrInvalidAPIKey for a revoked key, got %v", err)
+	}
+}
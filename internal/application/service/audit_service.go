@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/audit"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+)
+
+// auditUsernameMaxLen matches the length of audit_logs.username
+const auditUsernameMaxLen = 50
+
+type auditService struct {
+	auditRepo repository.AuditLogRepository
+}
+
+// NewAuditService creates a new audit log service
+func NewAuditService(auditRepo repository.AuditLogRepository) domainService.AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+func (s *auditService) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, limit, offset int32) ([]entity.AuditLog, int64, error) {
+	logs, err := s.auditRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("auditService.ListAuditLogs: list failed: %w", err)
+	}
+
+	count, err := s.auditRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("auditService.ListAuditLogs: count failed: %w", err)
+	}
+
+	return logs, count, nil
+}
+
+// recordAudit appends an entry for the admin, client IP and request ID in ctx.
+// The action has already happened, so a failure is logged rather than returned.
+func recordAudit(ctx context.Context, repo repository.AuditLogRepository, log *entity.AuditLog) {
+	if actor, ok := audit.ActorFromContext(ctx); ok && log.AdminID == nil {
+		log.AdminID = &actor.AdminID
+		log.Username = actor.Username
+		if actor.APIKeyID != 0 {
+			log.APIKeyID = &actor.APIKeyID
+		}
+	}
+	// Failed logins carry whatever username was tried; keep it within the column
+	if name := []rune(log.Username); len(name) > auditUsernameMaxLen {
+		log.Username = string(name[:auditUsernameMaxLen])
+	}
+	log.IP = audit.ClientIPFromContext(ctx)
+	log.RequestID, _ = ctx.Value(logger.RequestIDKey).(string)
+
+	if err := repo.Create(ctx, log); err != nil {
+		logger.Error(ctx, "Failed to record audit log", "action", log.Action, "target_id", log.TargetID, "error", err)
+	}
+}
+
+func auditTargetID(id int32) string {
+	return strconv.Itoa(int(id))
+}
+
+// The summaries below keep the fields worth comparing in the audit log, leaving out bodies
+
+func postAudit(p *entity.Post) map[string]any {
+	return map[string]any{
+		"title":        p.Title,
+		"slug":         p.Slug,
+		"status":       p.Status,
+		"category_id":  p.CategoryID,
+		"published_at": p.PublishedAt,
+	}
+}
+
+func categoryAudit(c *entity.Category) map[string]any {
+	return map[string]any{"name": c.Name, "slug": c.Slug, "sort_order": c.SortOrder}
+}
+
+func tagAudit(t *entity.Tag) map[string]any {
+	return map[string]any{"name": t.Name, "slug": t.Slug}
+}
+
+func projectAudit(p *entity.Project) map[string]any {
+	return map[string]any{
+		"title":       p.Title,
+		"slug":        p.Slug,
+		"is_featured": p.IsFeatured,
+		"sort_order":  p.SortOrder,
+	}
+}
+
+func mediaAudit(m *entity.Media) map[string]any {
+	return map[string]any{"filename": m.Filename, "original_name": m.OriginalName, "size": m.Size}
+}
+
+func mediaUsagesAudit(usages []entity.MediaUsage) []string {
+	refs := make([]string, len(usages))
+	for i, u := range usages {
+		refs[i] = fmt.Sprintf("%s:%d:%s", u.SourceType, u.SourceID, u.Field)
+	}
+	return refs
+}
+
+func adminAudit(a *entity.Admin) map[string]any {
+	return map[string]any{
+		"username": a.Username, "role": a.Role, "disabled": a.IsDisabled(),
+		"email": a.Email, "oidc_subject": a.OIDCSubject,
+	}
+}
+
+func apiKeyAudit(k *entity.APIKey) map[string]any {
+	return map[string]any{"name": k.Name, "prefix": k.Prefix, "scopes": k.Scopes, "revoked": k.IsRevoked()}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/audit"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+)
+
+func TestAuditLog_PostPublish(t *testing.T) {
+	post := newTestPost()
+	post.Status = entity.PostStatusDraft
+	postRepo := &mocks.MockPostRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			found := *post
+			return &found, nil
+		},
+		PublishFunc: func(ctx context.Context, id int32) (*entity.Post, error) {
+			post.Status = entity.PostStatusPublished
+			return &post.Post, nil
+		},
+	}
+
+	var logs []*entity.AuditLog
+	auditRepo := &mocks.MockAuditLogRepository{
+		CreateFunc: func(ctx context.Context, log *entity.AuditLog) error {
+			logs = append(logs, log)
+			return nil
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), logger.RequestIDKey, "req-1")
+	ctx = audit.WithClientIP(ctx, "203.0.113.7")
+	ctx = audit.WithActor(ctx, audit.Actor{AdminID: 3, Username: "editor", APIKeyID: 9})
+
+	svc := NewPostService(postRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, auditRepo)
+	actor := entity.Actor{AdminID: 3, Role: entity.AdminRoleEditor}
+	if _, err := svc.PublishPost(ctx, actor, 1, true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(logs) != 1 {
+		t.Fatalf("expected one audit log entry, got %d", len(logs))
+	}
+	log := logs[0]
+	if log.Action != entity.AuditActionPostPublish || log.TargetType != "post" || log.TargetID != "1" {
+		t.Errorf("unexpected action or target: %+v", log)
+	}
+	if log.AdminID == nil || *log.AdminID != 3 || log.Username != "editor" || log.APIKeyID == nil || *log.APIKeyID != 9 {
+		t.Errorf("expected the actor from the context, got %+v", log)
+	}
+	if log.IP != "203.0.113.7" || log.RequestID != "req-1" {
+		t.Errorf("expected client IP and request ID from the context, got %q, %q", log.IP, log.RequestID)
+	}
+	if log.Before["status"] != entity.PostStatusDraft || log.After["status"] != entity.PostStatusPublished {
+		t.Errorf("expected status to change from draft to published, got %v -> %v", log.Before["status"], log.After["status"])
+	}
+}
+
+func TestAuditLog_ClearLockout(t *testing.T) {
+	var logs []*entity.AuditLog
+	auditRepo := &mocks.MockAuditLogRepository{
+		CreateFunc: func(ctx context.Context, log *entity.AuditLog) error {
+			logs = append(logs, log)
+			return nil
+		},
+	}
+	store := newMemoryTokenStore()
+	svc := NewAdminService(newMemoryAdminRepo(), newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), auditRepo, nil, &config.JWTConfig{Expiry: time.Hour})
+	ctx := audit.WithActor(context.Background(), audit.Actor{AdminID: 1, Username: "owner"})
+
+	subject := entity.LoginSubject{Kind: entity.LoginSubjectIP, Value: "198.51.100.1"}
+	if err := svc.ClearLoginLockout(ctx, subject); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(logs) != 1 || logs[0].Action != entity.AuditActionLockoutClear || logs[0].TargetID != "198.51.100.1" {
+		t.Fatalf("expected a lockout clear entry for the IP, got %+v", logs)
+	}
+}
+
+func TestAuditLog_FailedLogin(t *testing.T) {
+	var logs []*entity.AuditLog
+	auditRepo := &mocks.MockAuditLogRepository{
+		CreateFunc: func(ctx context.Context, log *entity.AuditLog) error {
+			logs = append(logs, log)
+			return nil
+		},
+	}
+	store := newMemoryTokenStore()
+	svc := NewAuthService(newMemoryAdminRepo(), store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), auditRepo, newHS256SigningKeys(), nil, nil, &config.JWTConfig{
+		Secret: "test-secret",
+		Expiry: 15 * time.Minute,
+	}, &config.LoginThrottleConfig{}, &config.OIDCConfig{})
+	ctx := audit.WithClientIP(context.Background(), "198.51.100.1")
+
+	if _, err := svc.Login(ctx, domainService.LoginCommand{Username: "nobody", Password: "password", ClientIP: "198.51.100.1"}); err == nil {
+		t.Fatal("expected login to fail")
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected one audit log entry, got %d", len(logs))
+	}
+	if logs[0].Action != entity.AuditActionLoginFailed || logs[0].Username != "nobody" || logs[0].AdminID != nil {
+		t.Errorf("expected a failed login for an unknown admin, got %+v", logs[0])
+	}
+	if logs[0].IP != "198.51.100.1" {
+		t.Errorf("expected the client IP, got %q", logs[0].IP)
+	}
+}
@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/ydonggwui/blog-api/internal/config"
@@ -18,47 +25,314 @@ import (
 
 // jwtClaims represents internal JWT claims structure
 type jwtClaims struct {
-	UserID   int32  `json:"user_id"`
-	Username string `json:"username"`
+	UserID    int32  `json:"user_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// maxMFAAttempts is how many codes can be tried against one login challenge
+const maxMFAAttempts = 5
+
+// recoveryCodeCount is how many recovery codes are issued when two-factor authentication is enabled
+const recoveryCodeCount = 10
+
+// totpPeriod is the length of a TOTP time step in seconds, the authenticator app default
+const totpPeriod = 30
+
 type authService struct {
-	adminRepo repository.AdminRepository
-	jwtConfig *config.JWTConfig
+	adminRepo     repository.AdminRepository
+	refreshRepo   repository.RefreshTokenRepository
+	denylistRepo  repository.TokenDenylistRepository
+	challengeRepo repository.MFAChallengeRepository
+	resetRepo     repository.PasswordResetRepository
+	loginRepo     repository.LoginAttemptRepository
+	auditRepo     repository.AuditLogRepository
+	signingKeys   domainService.SigningKeyService
+	oidcProvider  domainService.OIDCProvider
+	oidcStateRepo repository.OIDCStateRepository
+	jwtConfig     *config.JWTConfig
+	loginConfig   *config.LoginThrottleConfig
+	oidcConfig    *config.OIDCConfig
+	now           func() time.Time
 }
 
-func NewAuthService(adminRepo repository.AdminRepository, jwtConfig *config.JWTConfig) domainService.AuthService {
+func NewAuthService(
+	adminRepo repository.AdminRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	denylistRepo repository.TokenDenylistRepository,
+	challengeRepo repository.MFAChallengeRepository,
+	resetRepo repository.PasswordResetRepository,
+	loginRepo repository.LoginAttemptRepository,
+	auditRepo repository.AuditLogRepository,
+	signingKeys domainService.SigningKeyService,
+	oidcProvider domainService.OIDCProvider,
+	oidcStateRepo repository.OIDCStateRepository,
+	jwtConfig *config.JWTConfig,
+	loginConfig *config.LoginThrottleConfig,
+	oidcConfig *config.OIDCConfig,
+) domainService.AuthService {
 	return &authService{
-		adminRepo: adminRepo,
-		jwtConfig: jwtConfig,
+		adminRepo:     adminRepo,
+		refreshRepo:   refreshRepo,
+		denylistRepo:  denylistRepo,
+		challengeRepo: challengeRepo,
+		resetRepo:     resetRepo,
+		loginRepo:     loginRepo,
+		auditRepo:     auditRepo,
+		signingKeys:   signingKeys,
+		oidcProvider:  oidcProvider,
+		oidcStateRepo: oidcStateRepo,
+		jwtConfig:     jwtConfig,
+		loginConfig:   loginConfig,
+		oidcConfig:    oidcConfig,
+		now:           time.Now,
 	}
 }
 
-func (s *authService) Login(ctx context.Context, cmd domainService.LoginCommand) (*entity.TokenInfo, error) {
-	admin, err := s.adminRepo.FindByUsername(ctx, cmd.Username)
+func (s *authService) Login(ctx context.Context, cmd domainService.LoginCommand) (*entity.LoginResult, error) {
+	subjects := []entity.LoginSubject{{Kind: entity.LoginSubjectUsername, Value: cmd.Username}}
+	if cmd.ClientIP != "" {
+		subjects = append(subjects, entity.LoginSubject{Kind: entity.LoginSubjectIP, Value: cmd.ClientIP})
+	}
+	if err := s.checkLoginBlocked(ctx, subjects); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.checkPassword(ctx, cmd)
 	if err != nil {
-		if errors.Is(err, domain.ErrAdminNotFound) {
-			return nil, domain.ErrInvalidCredentials
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			s.auditLoginFailure(ctx, nil, cmd.Username, "invalid_credentials")
+			if err := s.recordLoginFailure(ctx, subjects); err != nil {
+				return nil, fmt.Errorf("authService.Login: %w", err)
+			}
 		}
-		return nil, fmt.Errorf("authService.Login: find admin failed: %w", err)
+		return nil, err
 	}
 
-	if err := comparePassword(admin.Password, cmd.Password); err != nil {
-		return nil, domain.ErrInvalidCredentials
+	// Only the username's failures are forgiven; an IP may be trying several accounts
+	if err := s.loginRepo.Reset(ctx, subjects[0]); err != nil {
+		return nil, fmt.Errorf("authService.Login: reset login failures failed: %w", err)
+	}
+	if admin.IsDisabled() {
+		s.auditLoginFailure(ctx, &admin.ID, admin.Username, "disabled")
+		return nil, domain.ErrAdminDisabled
 	}
 
-	token, expiresAt, err := s.generateToken(admin.ID, admin.Username)
+	result, err := s.completeFirstFactor(ctx, admin)
 	if err != nil {
-		return nil, fmt.Errorf("authService.Login: generate token failed: %w", err)
+		return nil, fmt.Errorf("authService.Login: %w", err)
 	}
+	return result, nil
+}
 
-	return &entity.TokenInfo{
-		Token:     token,
-		ExpiresAt: expiresAt,
+func (s *authService) StartOIDCLogin(ctx context.Context) (*entity.OIDCAuthorization, error) {
+	if !s.oidcConfig.Enabled() {
+		return nil, domain.ErrOIDCDisabled
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+	nonce, err := randomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+	// 32 random bytes encode to 43 characters, the shortest PKCE verifier allowed
+	verifier, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+
+	authURL, err := s.oidcProvider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: %w", err)
+	}
+	login := &entity.OIDCLoginState{Verifier: verifier, Nonce: nonce}
+	if err := s.oidcStateRepo.Save(ctx, hashToken(state), login, s.oidcConfig.StateTTL); err != nil {
+		return nil, fmt.Errorf("authService.StartOIDCLogin: save state failed: %w", err)
+	}
+
+	return &entity.OIDCAuthorization{
+		URL:       authURL,
+		State:     state,
+		ExpiresAt: time.Now().Add(s.oidcConfig.StateTTL),
 	}, nil
 }
 
+func (s *authService) CompleteOIDCLogin(ctx context.Context, code, state string) (*entity.LoginResult, error) {
+	if !s.oidcConfig.Enabled() {
+		return nil, domain.ErrOIDCDisabled
+	}
+
+	login, err := s.oidcStateRepo.Consume(ctx, hashToken(state))
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCStateNotFound) {
+			return nil, domain.ErrInvalidOIDCState
+		}
+		return nil, fmt.Errorf("authService.CompleteOIDCLogin: %w", err)
+	}
+
+	identity, err := s.oidcProvider.Exchange(ctx, code, login.Verifier, login.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("authService.CompleteOIDCLogin: %w", err)
+	}
+
+	admin, err := s.findOIDCAdmin(ctx, identity)
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCAccountNotFound) {
+			s.auditLoginFailure(ctx, nil, identity.Email, "oidc_unknown_identity")
+			return nil, err
+		}
+		return nil, fmt.Errorf("authService.CompleteOIDCLogin: %w", err)
+	}
+	if admin.IsDisabled() {
+		s.auditLoginFailure(ctx, &admin.ID, admin.Username, "disabled")
+		return nil, domain.ErrAdminDisabled
+	}
+
+	result, err := s.completeFirstFactor(ctx, admin)
+	if err != nil {
+		return nil, fmt.Errorf("authService.CompleteOIDCLogin: %w", err)
+	}
+	return result, nil
+}
+
+func (s *authService) VerifyMFA(ctx context.Context, challengeToken, code string) (*entity.TokenInfo, error) {
+	challengeHash := hashToken(challengeToken)
+
+	adminID, attempts, err := s.challengeRepo.Attempt(ctx, challengeHash)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFAChallengeNotFound) {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, fmt.Errorf("authService.VerifyMFA: %w", err)
+	}
+	if attempts > maxMFAAttempts {
+		if err := s.challengeRepo.Delete(ctx, challengeHash); err != nil && !errors.Is(err, domain.ErrMFAChallengeNotFound) {
+			return nil, fmt.Errorf("authService.VerifyMFA: delete challenge failed: %w", err)
+		}
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, fmt.Errorf("authService.VerifyMFA: find admin failed: %w", err)
+	}
+	if admin.IsDisabled() {
+		return nil, domain.ErrAdminDisabled
+	}
+	if !admin.TOTPEnabled() {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	ok, err := s.verifySecondFactor(ctx, admin, code)
+	if err != nil {
+		return nil, fmt.Errorf("authService.VerifyMFA: %w", err)
+	}
+	if !ok {
+		s.auditLoginFailure(ctx, &admin.ID, admin.Username, "invalid_code")
+		return nil, domain.ErrInvalidTOTPCode
+	}
+
+	// Deleting the challenge makes it single use even when two valid codes race
+	if err := s.challengeRepo.Delete(ctx, challengeHash); err != nil {
+		if errors.Is(err, domain.ErrMFAChallengeNotFound) {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, fmt.Errorf("authService.VerifyMFA: delete challenge failed: %w", err)
+	}
+
+	tokenInfo, err := s.startSession(ctx, admin)
+	if err != nil {
+		return nil, fmt.Errorf("authService.VerifyMFA: %w", err)
+	}
+	return tokenInfo, nil
+}
+
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*entity.TokenInfo, error) {
+	stored, err := s.refreshRepo.Consume(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("authService.Refresh: %w", err)
+	}
+
+	// A rotated token coming back means it was copied: end the whole session
+	if stored.Used {
+		if err := s.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, fmt.Errorf("authService.Refresh: revoke family failed: %w", err)
+		}
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	active, err := s.refreshRepo.IsFamilyActive(ctx, stored.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.Refresh: %w", err)
+	}
+	if !active {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	admin, err := s.adminRepo.FindByID(ctx, stored.AdminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("authService.Refresh: find admin failed: %w", err)
+	}
+
+	// Role changes take effect here; disabled admins lose their sessions
+	if admin.IsDisabled() {
+		if err := s.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, fmt.Errorf("authService.Refresh: revoke family failed: %w", err)
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	tokenInfo, err := s.issueTokens(ctx, admin, stored.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.Refresh: %w", err)
+	}
+	return tokenInfo, nil
+}
+
+func (s *authService) Logout(ctx context.Context, accessToken, refreshToken string) error {
+	var familyID string
+
+	if accessToken != "" {
+		if claims, err := s.ValidateToken(ctx, accessToken); err == nil {
+			familyID = claims.SessionID
+			if err := s.denylistRepo.Add(ctx, claims.TokenID, time.Until(claims.ExpiresAt)); err != nil {
+				return fmt.Errorf("authService.Logout: %w", err)
+			}
+		}
+	}
+
+	if familyID == "" && refreshToken != "" {
+		stored, err := s.refreshRepo.Find(ctx, hashToken(refreshToken))
+		if err != nil && !errors.Is(err, domain.ErrRefreshTokenNotFound) {
+			return fmt.Errorf("authService.Logout: %w", err)
+		}
+		if stored != nil {
+			familyID = stored.FamilyID
+		}
+	}
+
+	if familyID != "" {
+		if err := s.refreshRepo.RevokeFamily(ctx, familyID); err != nil {
+			return fmt.Errorf("authService.Logout: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *authService) GetAdminByID(ctx context.Context, id int32) (*entity.Admin, error) {
 	admin, err := s.adminRepo.FindByID(ctx, id)
 	if err != nil {
@@ -67,30 +341,212 @@ func (s *authService) GetAdminByID(ctx context.Context, id int32) (*entity.Admin
 	return admin, nil
 }
 
-func (s *authService) ValidateToken(tokenString string) (*entity.Claims, error) {
+func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*entity.Claims, error) {
 	claims := &jwtClaims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
-		}
-		return []byte(s.jwtConfig.Secret), nil
+		return s.verificationKey(ctx, token)
 	})
+	if err != nil || !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, domain.ErrInvalidToken
+	}
 
+	revoked, err := s.denylistRepo.Contains(ctx, claims.ID)
 	if err != nil {
-		return nil, fmt.Errorf("authService.ValidateToken: parse token failed: %w", err)
+		return nil, fmt.Errorf("authService.ValidateToken: %w", err)
+	}
+	if revoked {
+		return nil, domain.ErrTokenRevoked
 	}
 
-	if !token.Valid {
-		return nil, fmt.Errorf("authService.ValidateToken: invalid token")
+	// Changing or resetting the password ends every session, including access tokens in flight
+	revokedBefore, err := s.denylistRepo.RevokedBefore(ctx, claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.ValidateToken: %w", err)
+	}
+	if claims.IssuedAt != nil && claims.IssuedAt.Before(revokedBefore) {
+		return nil, domain.ErrTokenRevoked
 	}
 
 	return &entity.Claims{
-		UserID:   claims.UserID,
-		Username: claims.Username,
+		UserID:    claims.UserID,
+		Username:  claims.Username,
+		Role:      entity.AdminRole(claims.Role),
+		TokenID:   claims.ID,
+		SessionID: claims.SessionID,
+		ExpiresAt: claims.ExpiresAt.Time,
 	}, nil
 }
 
+func (s *authService) EnrollTOTP(ctx context.Context, adminID int32) (*entity.TOTPEnrollment, error) {
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.EnrollTOTP: find admin failed: %w", err)
+	}
+	if admin.TOTPEnabled() {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.jwtConfig.TOTPIssuer,
+		AccountName: admin.Username,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("authService.EnrollTOTP: generate secret failed: %w", err)
+	}
+	if err := s.adminRepo.SetTOTPSecret(ctx, admin.ID, key.Secret()); err != nil {
+		return nil, fmt.Errorf("authService.EnrollTOTP: %w", err)
+	}
+
+	return &entity.TOTPEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+func (s *authService) ActivateTOTP(ctx context.Context, adminID int32, code string) ([]string, error) {
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("authService.ActivateTOTP: find admin failed: %w", err)
+	}
+	if admin.TOTPEnabled() {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+	if admin.TOTPSecret == "" {
+		return nil, domain.ErrTOTPNotEnrolled
+	}
+	ok, err := s.useTOTPCode(ctx, admin, strings.TrimSpace(code))
+	if err != nil {
+		return nil, fmt.Errorf("authService.ActivateTOTP: %w", err)
+	}
+	if !ok {
+		return nil, domain.ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		recoveryCode, err := randomRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("authService.ActivateTOTP: %w", err)
+		}
+		codes[i] = recoveryCode
+		hashes[i] = hashToken(normalizeRecoveryCode(recoveryCode))
+	}
+
+	if err := s.adminRepo.EnableTOTP(ctx, admin.ID, hashes); err != nil {
+		return nil, fmt.Errorf("authService.ActivateTOTP: %w", err)
+	}
+	return codes, nil
+}
+
+func (s *authService) DisableTOTP(ctx context.Context, adminID int32, code string) error {
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return fmt.Errorf("authService.DisableTOTP: find admin failed: %w", err)
+	}
+	if !admin.TOTPEnabled() {
+		return domain.ErrTOTPNotEnabled
+	}
+
+	ok, err := s.verifySecondFactor(ctx, admin, code)
+	if err != nil {
+		return fmt.Errorf("authService.DisableTOTP: %w", err)
+	}
+	if !ok {
+		return domain.ErrInvalidTOTPCode
+	}
+
+	if err := s.adminRepo.DisableTOTP(ctx, admin.ID); err != nil {
+		return fmt.Errorf("authService.DisableTOTP: %w", err)
+	}
+	return nil
+}
+
+func (s *authService) ChangePassword(ctx context.Context, adminID int32, currentPassword, newPassword string) error {
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		return fmt.Errorf("authService.ChangePassword: find admin failed: %w", err)
+	}
+	if admin.IsInvited() || comparePassword(admin.Password, currentPassword) != nil {
+		return domain.ErrWrongPassword
+	}
+
+	if err := s.setPassword(ctx, admin, newPassword); err != nil {
+		return fmt.Errorf("authService.ChangePassword: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionPasswordChange,
+		TargetType: "admin",
+		TargetID:   auditTargetID(admin.ID),
+	})
+	return nil
+}
+
+func (s *authService) IssuePasswordReset(ctx context.Context, username string) (*entity.PasswordReset, error) {
+	admin, err := s.adminRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("authService.IssuePasswordReset: find admin failed: %w", err)
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("authService.IssuePasswordReset: %w", err)
+	}
+	if err := s.resetRepo.Save(ctx, hashToken(token), admin.ID, s.jwtConfig.PasswordResetExpiry); err != nil {
+		return nil, fmt.Errorf("authService.IssuePasswordReset: save reset token failed: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionPasswordIssue,
+		TargetType: "admin",
+		TargetID:   auditTargetID(admin.ID),
+	})
+
+	return &entity.PasswordReset{
+		Admin:     admin,
+		Token:     token,
+		ExpiresAt: time.Now().Add(s.jwtConfig.PasswordResetExpiry),
+	}, nil
+}
+
+func (s *authService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	adminID, err := s.resetRepo.Consume(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrPasswordResetNotFound) {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("authService.ResetPassword: %w", err)
+	}
+
+	admin, err := s.adminRepo.FindByID(ctx, adminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return domain.ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("authService.ResetPassword: find admin failed: %w", err)
+	}
+
+	if err := s.setPassword(ctx, admin, newPassword); err != nil {
+		return fmt.Errorf("authService.ResetPassword: %w", err)
+	}
+	// The owner may have locked themselves out while trying to remember the old password
+	subject := entity.LoginSubject{Kind: entity.LoginSubjectUsername, Value: admin.Username}
+	if err := s.loginRepo.Reset(ctx, subject); err != nil {
+		return fmt.Errorf("authService.ResetPassword: reset login failures failed: %w", err)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		AdminID:    &admin.ID,
+		Username:   admin.Username,
+		Action:     entity.AuditActionPasswordReset,
+		TargetType: "admin",
+		TargetID:   auditTargetID(admin.ID),
+	})
+	return nil
+}
+
 func (s *authService) EnsureAdminExists(ctx context.Context, username, password string) error {
 	_, err := s.adminRepo.FindByUsername(ctx, username)
 	if err == nil {
@@ -111,6 +567,7 @@ func (s *authService) EnsureAdminExists(ctx context.Context, username, password
 	_, err = s.adminRepo.Create(ctx, &entity.Admin{
 		Username: username,
 		Password: hashedPassword,
+		Role:     entity.AdminRoleOwner,
 	})
 	if err != nil {
 		return fmt.Errorf("authService.EnsureAdminExists: create admin failed: %w", err)
@@ -119,21 +576,313 @@ func (s *authService) EnsureAdminExists(ctx context.Context, username, password
 	return nil
 }
 
-// generateToken creates a new JWT token
-func (s *authService) generateToken(userID int32, username string) (string, time.Time, error) {
+// findOIDCAdmin returns the admin linked to the identity's subject. With matching by email,
+// an admin with the same verified email and no linked subject yet is linked on first login.
+func (s *authService) findOIDCAdmin(ctx context.Context, identity *entity.OIDCIdentity) (*entity.Admin, error) {
+	if identity.Subject == "" {
+		return nil, domain.ErrOIDCAccountNotFound
+	}
+
+	admin, err := s.adminRepo.FindByOIDCSubject(ctx, identity.Subject)
+	if err == nil {
+		return admin, nil
+	}
+	if !errors.Is(err, domain.ErrAdminNotFound) {
+		return nil, fmt.Errorf("find admin by subject failed: %w", err)
+	}
+
+	// An unverified email could be claimed by anyone at the identity provider
+	if s.oidcConfig.MatchBy != "email" || identity.Email == "" || !identity.EmailVerified {
+		return nil, domain.ErrOIDCAccountNotFound
+	}
+	admin, err = s.adminRepo.FindByEmail(ctx, identity.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return nil, domain.ErrOIDCAccountNotFound
+		}
+		return nil, fmt.Errorf("find admin by email failed: %w", err)
+	}
+	if admin.OIDCSubject != "" {
+		return nil, domain.ErrOIDCAccountNotFound
+	}
+
+	linked, err := s.adminRepo.UpdateIdentity(ctx, admin.ID, admin.Email, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("link subject failed: %w", err)
+	}
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		AdminID:    &admin.ID,
+		Username:   admin.Username,
+		Action:     entity.AuditActionOIDCLink,
+		TargetType: "admin",
+		TargetID:   auditTargetID(admin.ID),
+		After:      map[string]any{"issuer": identity.Issuer, "subject": identity.Subject},
+	})
+	return linked, nil
+}
+
+// completeFirstFactor starts a session, or hands out a challenge to exchange with a code
+// when the admin has two-factor authentication enabled
+func (s *authService) completeFirstFactor(ctx context.Context, admin *entity.Admin) (*entity.LoginResult, error) {
+	if admin.TOTPEnabled() {
+		token, err := randomToken(32)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.challengeRepo.Save(ctx, hashToken(token), admin.ID, s.jwtConfig.MFAChallengeExpiry); err != nil {
+			return nil, fmt.Errorf("save challenge failed: %w", err)
+		}
+		return &entity.LoginResult{
+			MFAChallenge: &entity.MFAChallenge{
+				Token:     token,
+				ExpiresAt: time.Now().Add(s.jwtConfig.MFAChallengeExpiry),
+			},
+		}, nil
+	}
+
+	tokenInfo, err := s.startSession(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{Tokens: tokenInfo}, nil
+}
+
+// checkPassword returns the admin if the username and password match
+func (s *authService) checkPassword(ctx context.Context, cmd domainService.LoginCommand) (*entity.Admin, error) {
+	admin, err := s.adminRepo.FindByUsername(ctx, cmd.Username)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminNotFound) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("authService.Login: find admin failed: %w", err)
+	}
+
+	if admin.IsInvited() {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err := comparePassword(admin.Password, cmd.Password); err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return admin, nil
+}
+
+// checkLoginBlocked returns a LoginThrottledError if any of the subjects is blocked,
+// with the longest remaining block
+func (s *authService) checkLoginBlocked(ctx context.Context, subjects []entity.LoginSubject) error {
+	var retryAfter time.Duration
+	var locked bool
+	for _, subject := range subjects {
+		remaining, isLocked, err := s.loginRepo.Blocked(ctx, subject)
+		if err != nil {
+			return fmt.Errorf("authService.Login: %w", err)
+		}
+		retryAfter = max(retryAfter, remaining)
+		locked = locked || isLocked
+	}
+
+	switch {
+	case retryAfter <= 0:
+		return nil
+	case locked:
+		return &domain.LoginThrottledError{Err: domain.ErrAccountLocked, RetryAfter: retryAfter}
+	default:
+		return &domain.LoginThrottledError{Err: domain.ErrTooManyLoginAttempts, RetryAfter: retryAfter}
+	}
+}
+
+// recordLoginFailure counts a failed login and blocks subjects that reached the backoff
+// or lockout threshold
+func (s *authService) recordLoginFailure(ctx context.Context, subjects []entity.LoginSubject) error {
+	cfg := s.loginConfig
+	for _, subject := range subjects {
+		failures, err := s.loginRepo.RecordFailure(ctx, subject, cfg.FailureWindow)
+		if err != nil {
+			return err
+		}
+
+		threshold := cfg.UserLockoutThreshold
+		if subject.Kind == entity.LoginSubjectIP {
+			threshold = cfg.IPLockoutThreshold
+		}
+
+		switch {
+		case threshold > 0 && failures >= int64(threshold):
+			err = s.loginRepo.Block(ctx, subject, cfg.LockoutDuration, true)
+		case cfg.BackoffAfter > 0 && failures >= int64(cfg.BackoffAfter):
+			err = s.loginRepo.Block(ctx, subject, loginBackoff(cfg, failures), false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loginBackoff doubles BackoffBase for every failure past BackoffAfter, up to BackoffMax
+func loginBackoff(cfg *config.LoginThrottleConfig, failures int64) time.Duration {
+	steps := failures - int64(cfg.BackoffAfter)
+	if steps >= 30 {
+		return cfg.BackoffMax
+	}
+	return min(cfg.BackoffBase<<steps, cfg.BackoffMax)
+}
+
+// setPassword stores a new password hash and ends every session of the admin
+func (s *authService) setPassword(ctx context.Context, admin *entity.Admin, password string) error {
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("hash password failed: %w", err)
+	}
+	if err := s.adminRepo.UpdatePassword(ctx, admin.ID, hashedPassword); err != nil {
+		return err
+	}
+
+	return revokeSessions(ctx, s.refreshRepo, s.denylistRepo, admin.ID, s.jwtConfig.Expiry)
+}
+
+// revokeSessions ends every refresh token family of an admin and rejects the access
+// tokens issued so far; accessTTL is how long those tokens stay valid
+func revokeSessions(ctx context.Context, refreshRepo repository.RefreshTokenRepository, denylistRepo repository.TokenDenylistRepository, adminID int32, accessTTL time.Duration) error {
+	if err := refreshRepo.RevokeAdminFamilies(ctx, adminID); err != nil {
+		return fmt.Errorf("revoke sessions failed: %w", err)
+	}
+	// Issued-at claims have second precision: revoke up to the end of this second so no
+	// token issued before the change survives, at the cost of logins in the same second
+	cutoff := time.Now().Truncate(time.Second).Add(time.Second)
+	if err := denylistRepo.RevokeIssuedBefore(ctx, adminID, cutoff, accessTTL); err != nil {
+		return fmt.Errorf("revoke access tokens failed: %w", err)
+	}
+	return nil
+}
+
+// startSession issues tokens in a new refresh token family; every login starts one
+func (s *authService) startSession(ctx context.Context, admin *entity.Admin) (*entity.TokenInfo, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	tokenInfo, err := s.issueTokens(ctx, admin, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		AdminID:    &admin.ID,
+		Username:   admin.Username,
+		Action:     entity.AuditActionLogin,
+		TargetType: "admin",
+		TargetID:   auditTargetID(admin.ID),
+		After:      map[string]any{"mfa": admin.TOTPEnabled()},
+	})
+	return tokenInfo, nil
+}
+
+// auditLoginFailure records a rejected login; adminID is nil when the username is unknown
+func (s *authService) auditLoginFailure(ctx context.Context, adminID *int32, username, reason string) {
+	log := &entity.AuditLog{
+		AdminID:    adminID,
+		Username:   username,
+		Action:     entity.AuditActionLoginFailed,
+		TargetType: "admin",
+		After:      map[string]any{"reason": reason},
+	}
+	if adminID != nil {
+		log.TargetID = auditTargetID(*adminID)
+	}
+	recordAudit(ctx, s.auditRepo, log)
+}
+
+// verifySecondFactor checks a six-digit TOTP code, or otherwise consumes a recovery code
+func (s *authService) verifySecondFactor(ctx context.Context, admin *entity.Admin, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		return s.useTOTPCode(ctx, admin, code)
+	}
+
+	used, err := s.adminRepo.UseRecoveryCode(ctx, admin.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, fmt.Errorf("use recovery code failed: %w", err)
+	}
+	return used, nil
+}
+
+// useTOTPCode checks a code from the authenticator app and records its time step,
+// so a code that was accepted once cannot be replayed while it is still valid
+func (s *authService) useTOTPCode(ctx context.Context, admin *entity.Admin, code string) (bool, error) {
+	step, ok := totpStep(code, admin.TOTPSecret, s.now())
+	if !ok {
+		return false, nil
+	}
+	used, err := s.adminRepo.UseTOTPStep(ctx, admin.ID, step)
+	if err != nil {
+		return false, fmt.Errorf("record TOTP step failed: %w", err)
+	}
+	return used, nil
+}
+
+// issueTokens creates an access token and a new refresh token in the given family
+func (s *authService) issueTokens(ctx context.Context, admin *entity.Admin, familyID string) (*entity.TokenInfo, error) {
+	token, expiresAt, err := s.generateToken(ctx, admin, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("generate token failed: %w", err)
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refreshRepo.Save(ctx, &entity.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		FamilyID:  familyID,
+		AdminID:   admin.ID,
+	}, s.jwtConfig.RefreshExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("save refresh token failed: %w", err)
+	}
+
+	return &entity.TokenInfo{
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: time.Now().Add(s.jwtConfig.RefreshExpiry),
+	}, nil
+}
+
+// generateToken creates a new JWT access token
+func (s *authService) generateToken(ctx context.Context, admin *entity.Admin, sessionID string) (string, time.Time, error) {
 	expiresAt := time.Now().Add(s.jwtConfig.Expiry)
 
+	jti, err := randomToken(16)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("generateToken: %w", err)
+	}
+
 	claims := &jwtClaims{
-		UserID:   userID,
-		Username: username,
+		UserID:    admin.ID,
+		Username:  admin.Username,
+		Role:      string(admin.Role),
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.jwtConfig.Secret))
+	var tokenString string
+	if entity.SigningAlgorithm(s.jwtConfig.Algorithm).IsAsymmetric() {
+		key, keyErr := s.signingKeys.SigningKey(ctx)
+		if keyErr != nil {
+			return "", time.Time{}, fmt.Errorf("generateToken: %w", keyErr)
+		}
+		token := jwt.NewWithClaims(jwt.GetSigningMethod(string(key.Algorithm)), claims)
+		token.Header["kid"] = key.ID
+		tokenString, err = token.SignedString(key.PrivateKey)
+	} else {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, err = token.SignedString([]byte(s.jwtConfig.Secret))
+	}
 	if err != nil {
 		return "", time.Time{}, fmt.Errorf("generateToken: sign token failed: %w", err)
 	}
@@ -141,6 +890,90 @@ func (s *authService) generateToken(userID int32, username string) (string, time
 	return tokenString, expiresAt, nil
 }
 
+// verificationKey returns the key to check a token's signature with. HS256 tokens are only
+// accepted while HS256 is configured, so switching algorithms cannot be used for key confusion.
+func (s *authService) verificationKey(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	if !entity.SigningAlgorithm(s.jwtConfig.Algorithm).IsAsymmetric() {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(s.jwtConfig.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, err := s.signingKeys.VerificationKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != string(key.Algorithm) {
+		return nil, errors.New("invalid signing method")
+	}
+	return key.PublicKey(), nil
+}
+
+// randomToken returns n random bytes encoded as URL-safe base64
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("randomToken: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken keeps raw refresh, invitation, password reset, challenge and single sign-on state tokens and recovery codes out of storage
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// isTOTPCode returns true if code looks like a code from an authenticator app
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// totpStep returns the time step a TOTP code is valid for, allowing one step of clock
+// skew either way like totp.Validate
+func totpStep(code, secret string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for _, step := range []int64{current, current - 1, current + 1} {
+		ok, err := totp.ValidateCustom(code, secret, time.Unix(step*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && ok {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// randomRecoveryCode returns a code like "7hq2x-m4kpa" from the lowercase base32 alphabet
+func randomRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("randomRecoveryCode: %w", err)
+	}
+	const alphabet = "abcdefghijklmnopqrstuvwxyz234567"
+	for i := range b {
+		b[i] = alphabet[int(b[i])%len(alphabet)]
+	}
+	return string(b[:5]) + "-" + string(b[5:]), nil
+}
+
+// normalizeRecoveryCode ignores case and the separator so codes can be typed loosely
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
 // hashPassword hashes a password using bcrypt
 func hashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
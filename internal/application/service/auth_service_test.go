@@ -0,0 +1,903 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	oidcIdentity "github.com/ydonggwui/blog-api/internal/infrastructure/identity/oidc"
+)
+
+// memoryTokenStore backs the token repository mocks with maps
+type memoryTokenStore struct {
+	tokens        map[string]*entity.RefreshToken
+	families      map[string]int32
+	denied        map[string]time.Duration
+	revokedBefore map[int32]time.Time
+	resets        map[string]int32
+	challenges    map[string]*memoryChallenge
+	oidcStates    map[string]*entity.OIDCLoginState
+	failures      map[string]int64
+	blocks        map[string]memoryBlock
+}
+
+type memoryBlock struct {
+	until  time.Time
+	locked bool
+}
+
+type memoryChallenge struct {
+	adminID  int32
+	attempts int64
+}
+
+func newMemoryTokenStore() *memoryTokenStore {
+	return &memoryTokenStore{
+		tokens:        map[string]*entity.RefreshToken{},
+		families:      map[string]int32{},
+		denied:        map[string]time.Duration{},
+		revokedBefore: map[int32]time.Time{},
+		resets:        map[string]int32{},
+		challenges:    map[string]*memoryChallenge{},
+		oidcStates:    map[string]*entity.OIDCLoginState{},
+		failures:      map[string]int64{},
+		blocks:        map[string]memoryBlock{},
+	}
+}
+
+func (s *memoryTokenStore) refreshRepo() *mocks.MockRefreshTokenRepository {
+	return &mocks.MockRefreshTokenRepository{
+		SaveFunc: func(ctx context.Context, token *entity.RefreshToken, ttl time.Duration) error {
+			stored := *token
+			s.tokens[token.TokenHash] = &stored
+			s.families[token.FamilyID] = token.AdminID
+			return nil
+		},
+		FindFunc: func(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+			token, ok := s.tokens[tokenHash]
+			if !ok {
+				return nil, domain.ErrRefreshTokenNotFound
+			}
+			found := *token
+			return &found, nil
+		},
+		ConsumeFunc: func(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+			token, ok := s.tokens[tokenHash]
+			if !ok {
+				return nil, domain.ErrRefreshTokenNotFound
+			}
+			before := *token
+			token.Used = true
+			return &before, nil
+		},
+		IsFamilyActiveFunc: func(ctx context.Context, familyID string) (bool, error) {
+			_, ok := s.families[familyID]
+			return ok, nil
+		},
+		RevokeFamilyFunc: func(ctx context.Context, familyID string) error {
+			delete(s.families, familyID)
+			return nil
+		},
+		RevokeAdminFamiliesFunc: func(ctx context.Context, adminID int32) error {
+			for familyID, id := range s.families {
+				if id == adminID {
+					delete(s.families, familyID)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (s *memoryTokenStore) denylistRepo() *mocks.MockTokenDenylistRepository {
+	return &mocks.MockTokenDenylistRepository{
+		AddFunc: func(ctx context.Context, tokenID string, ttl time.Duration) error {
+			s.denied[tokenID] = ttl
+			return nil
+		},
+		ContainsFunc: func(ctx context.Context, tokenID string) (bool, error) {
+			_, ok := s.denied[tokenID]
+			return ok, nil
+		},
+		RevokeIssuedBeforeFunc: func(ctx context.Context, adminID int32, before time.Time, ttl time.Duration) error {
+			s.revokedBefore[adminID] = before
+			return nil
+		},
+		RevokedBeforeFunc: func(ctx context.Context, adminID int32) (time.Time, error) {
+			return s.revokedBefore[adminID], nil
+		},
+	}
+}
+
+func (s *memoryTokenStore) resetRepo() *mocks.MockPasswordResetRepository {
+	return &mocks.MockPasswordResetRepository{
+		SaveFunc: func(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+			s.resets[tokenHash] = adminID
+			return nil
+		},
+		ConsumeFunc: func(ctx context.Context, tokenHash string) (int32, error) {
+			id, ok := s.resets[tokenHash]
+			if !ok {
+				return 0, domain.ErrPasswordResetNotFound
+			}
+			delete(s.resets, tokenHash)
+			return id, nil
+		},
+	}
+}
+
+func (s *memoryTokenStore) challengeRepo() *mocks.MockMFAChallengeRepository {
+	return &mocks.MockMFAChallengeRepository{
+		SaveFunc: func(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+			s.challenges[tokenHash] = &memoryChallenge{adminID: adminID}
+			return nil
+		},
+		AttemptFunc: func(ctx context.Context, tokenHash string) (int32, int64, error) {
+			ch, ok := s.challenges[tokenHash]
+			if !ok {
+				return 0, 0, domain.ErrMFAChallengeNotFound
+			}
+			ch.attempts++
+			return ch.adminID, ch.attempts, nil
+		},
+		DeleteFunc: func(ctx context.Context, tokenHash string) error {
+			if _, ok := s.challenges[tokenHash]; !ok {
+				return domain.ErrMFAChallengeNotFound
+			}
+			delete(s.challenges, tokenHash)
+			return nil
+		},
+	}
+}
+
+func (s *memoryTokenStore) oidcStateRepo() *mocks.MockOIDCStateRepository {
+	return &mocks.MockOIDCStateRepository{
+		SaveFunc: func(ctx context.Context, stateHash string, state *entity.OIDCLoginState, ttl time.Duration) error {
+			stored := *state
+			s.oidcStates[stateHash] = &stored
+			return nil
+		},
+		ConsumeFunc: func(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+			state, ok := s.oidcStates[stateHash]
+			if !ok {
+				return nil, domain.ErrOIDCStateNotFound
+			}
+			delete(s.oidcStates, stateHash)
+			return state, nil
+		},
+	}
+}
+
+func (s *memoryTokenStore) loginRepo() *mocks.MockLoginAttemptRepository {
+	return &mocks.MockLoginAttemptRepository{
+		RecordFailureFunc: func(ctx context.Context, subject entity.LoginSubject, window time.Duration) (int64, error) {
+			s.failures[subject.Key()]++
+			return s.failures[subject.Key()], nil
+		},
+		BlockFunc: func(ctx context.Context, subject entity.LoginSubject, d time.Duration, locked bool) error {
+			s.blocks[subject.Key()] = memoryBlock{until: time.Now().Add(d), locked: locked}
+			return nil
+		},
+		BlockedFunc: func(ctx context.Context, subject entity.LoginSubject) (time.Duration, bool, error) {
+			block, ok := s.blocks[subject.Key()]
+			if !ok || time.Now().After(block.until) {
+				return 0, false, nil
+			}
+			return time.Until(block.until), block.locked, nil
+		},
+		ResetFunc: func(ctx context.Context, subject entity.LoginSubject) error {
+			delete(s.failures, subject.Key())
+			delete(s.blocks, subject.Key())
+			return nil
+		},
+	}
+}
+
+func newTestAuthService(t *testing.T, store *memoryTokenStore) domainService.AuthService {
+	t.Helper()
+
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	admin := &entity.Admin{ID: 1, Username: "admin", Password: hashed}
+
+	adminRepo := &mocks.MockAdminRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.Admin, error) {
+			if id != admin.ID {
+				return nil, domain.ErrAdminNotFound
+			}
+			return admin, nil
+		},
+		FindByUsernameFunc: func(ctx context.Context, username string) (*entity.Admin, error) {
+			if username != admin.Username {
+				return nil, domain.ErrAdminNotFound
+			}
+			return admin, nil
+		},
+	}
+
+	return NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), nil, nil, &config.JWTConfig{
+		Secret:        "test-secret",
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: 24 * time.Hour,
+	}, &config.LoginThrottleConfig{}, &config.OIDCConfig{})
+}
+
+func login(t *testing.T, svc domainService.AuthService) *entity.TokenInfo {
+	t.Helper()
+
+	result, err := svc.Login(context.Background(), domainService.LoginCommand{Username: "admin", Password: "password"})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if result.Tokens == nil {
+		t.Fatal("expected tokens without two-factor authentication")
+	}
+	return result.Tokens
+}
+
+func TestAuthService_Login(t *testing.T) {
+	store := newMemoryTokenStore()
+	svc := newTestAuthService(t, store)
+
+	tokenInfo := login(t, svc)
+	if tokenInfo.Token == "" || tokenInfo.RefreshToken == "" {
+		t.Fatal("expected access and refresh tokens")
+	}
+	if _, ok := store.tokens[hashToken(tokenInfo.RefreshToken)]; !ok {
+		t.Error("expected refresh token to be stored by hash")
+	}
+
+	claims, err := svc.ValidateToken(context.Background(), tokenInfo.Token)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.UserID != 1 || claims.TokenID == "" || store.families[claims.SessionID] != 1 {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	_, err = svc.Login(context.Background(), domainService.LoginCommand{Username: "admin", Password: "wrong"})
+	if !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rotates refresh token", func(t *testing.T) {
+		svc := newTestAuthService(t, newMemoryTokenStore())
+		first := login(t, svc)
+
+		second, err := svc.Refresh(ctx, first.RefreshToken)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if second.RefreshToken == first.RefreshToken {
+			t.Error("expected a new refresh token")
+		}
+		if _, err := svc.Refresh(ctx, second.RefreshToken); err != nil {
+			t.Errorf("expected rotated token to work, got %v", err)
+		}
+	})
+
+	t.Run("reuse revokes the family", func(t *testing.T) {
+		svc := newTestAuthService(t, newMemoryTokenStore())
+		first := login(t, svc)
+
+		second, err := svc.Refresh(ctx, first.RefreshToken)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if _, err := svc.Refresh(ctx, first.RefreshToken); !errors.Is(err, domain.ErrRefreshTokenReused) {
+			t.Fatalf("expected ErrRefreshTokenReused, got %v", err)
+		}
+		if _, err := svc.Refresh(ctx, second.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("expected the latest token to be revoked too, got %v", err)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		svc := newTestAuthService(t, newMemoryTokenStore())
+		if _, err := svc.Refresh(ctx, "unknown"); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("expected ErrInvalidRefreshToken, got %v", err)
+		}
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("with access token", func(t *testing.T) {
+		store := newMemoryTokenStore()
+		svc := newTestAuthService(t, store)
+		tokenInfo := login(t, svc)
+
+		if err := svc.Logout(ctx, tokenInfo.Token, ""); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := svc.ValidateToken(ctx, tokenInfo.Token); !errors.Is(err, domain.ErrTokenRevoked) {
+			t.Errorf("expected ErrTokenRevoked, got %v", err)
+		}
+		for jti, ttl := range store.denied {
+			if ttl <= 0 || ttl > 15*time.Minute {
+				t.Errorf("expected %s to be denylisted for the remaining lifetime, got %v", jti, ttl)
+			}
+		}
+		if _, err := svc.Refresh(ctx, tokenInfo.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("expected refresh token to be revoked, got %v", err)
+		}
+	})
+
+	t.Run("with refresh token only", func(t *testing.T) {
+		store := newMemoryTokenStore()
+		svc := newTestAuthService(t, store)
+		tokenInfo := login(t, svc)
+
+		if err := svc.Logout(ctx, "", tokenInfo.RefreshToken); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := svc.Refresh(ctx, tokenInfo.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("expected refresh token to be revoked, got %v", err)
+		}
+	})
+}
+
+func TestAuthService_ValidateToken_Invalid(t *testing.T) {
+	svc := newTestAuthService(t, newMemoryTokenStore())
+
+	if _, err := svc.ValidateToken(context.Background(), "not-a-jwt"); !errors.Is(err, domain.ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestAuthService_TOTP(t *testing.T) {
+	ctx := context.Background()
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "admin", Password: hashed, Role: entity.AdminRoleOwner})
+	store := newMemoryTokenStore()
+	svc := NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), nil, nil, &config.JWTConfig{
+		Secret:             "test-secret",
+		Expiry:             15 * time.Minute,
+		RefreshExpiry:      time.Hour,
+		MFAChallengeExpiry: 5 * time.Minute,
+		TOTPIssuer:         "Blog",
+	}, &config.LoginThrottleConfig{}, &config.OIDCConfig{})
+	cmd := domainService.LoginCommand{Username: "admin", Password: "password"}
+
+	// Each accepted code uses up its time step, so the clock moves on after every one
+	clock := time.Now()
+	svc.(*authService).now = func() time.Time { return clock }
+	nextStep := func() { clock = clock.Add(totpPeriod * time.Second) }
+
+	challenge := func(t *testing.T) string {
+		t.Helper()
+		result, err := svc.Login(ctx, cmd)
+		if err != nil {
+			t.Fatalf("login failed: %v", err)
+		}
+		if result.Tokens != nil || result.MFAChallenge == nil {
+			t.Fatalf("expected an MFA challenge instead of tokens, got %+v", result)
+		}
+		return result.MFAChallenge.Token
+	}
+	currentCode := func(t *testing.T, secret string) string {
+		t.Helper()
+		code, err := totp.GenerateCode(secret, clock)
+		if err != nil {
+			t.Fatalf("generate code failed: %v", err)
+		}
+		return code
+	}
+
+	enrollment, err := svc.EnrollTOTP(ctx, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(enrollment.URI, "otpauth://totp/Blog:admin?") {
+		t.Errorf("unexpected otpauth URI %q", enrollment.URI)
+	}
+
+	// Enrollment alone does not change login
+	if _, err := svc.Login(ctx, cmd); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if _, err := svc.ActivateTOTP(ctx, 1, "abcdef"); !errors.Is(err, domain.ErrInvalidTOTPCode) {
+		t.Fatalf("expected ErrInvalidTOTPCode, got %v", err)
+	}
+	recoveryCodes, err := svc.ActivateTOTP(ctx, 1, currentCode(t, enrollment.Secret))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(recoveryCodes) != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(recoveryCodes))
+	}
+	nextStep()
+	if _, err := svc.EnrollTOTP(ctx, 1); !errors.Is(err, domain.ErrTOTPAlreadyEnabled) {
+		t.Errorf("expected ErrTOTPAlreadyEnabled, got %v", err)
+	}
+
+	t.Run("totp code", func(t *testing.T) {
+		token := challenge(t)
+		if _, err := svc.VerifyMFA(ctx, token, "aaaaa-aaaaa"); !errors.Is(err, domain.ErrInvalidTOTPCode) {
+			t.Fatalf("expected ErrInvalidTOTPCode, got %v", err)
+		}
+		code := currentCode(t, enrollment.Secret)
+		tokenInfo, err := svc.VerifyMFA(ctx, token, code)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if tokenInfo.Token == "" || tokenInfo.RefreshToken == "" {
+			t.Error("expected access and refresh tokens")
+		}
+		if _, err := svc.VerifyMFA(ctx, token, code); !errors.Is(err, domain.ErrInvalidMFAChallenge) {
+			t.Errorf("expected the challenge to be single use, got %v", err)
+		}
+		if _, err := svc.VerifyMFA(ctx, challenge(t), code); !errors.Is(err, domain.ErrInvalidTOTPCode) {
+			t.Errorf("expected a used code to be rejected, got %v", err)
+		}
+		nextStep()
+	})
+
+	t.Run("recovery code is single use", func(t *testing.T) {
+		code := strings.ToUpper(recoveryCodes[0])
+		if _, err := svc.VerifyMFA(ctx, challenge(t), code); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := svc.VerifyMFA(ctx, challenge(t), code); !errors.Is(err, domain.ErrInvalidTOTPCode) {
+			t.Errorf("expected a used recovery code to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("attempt limit", func(t *testing.T) {
+		token := challenge(t)
+		for i := 0; i < maxMFAAttempts; i++ {
+			if _, err := svc.VerifyMFA(ctx, token, "wrong"); !errors.Is(err, domain.ErrInvalidTOTPCode) {
+				t.Fatalf("expected ErrInvalidTOTPCode, got %v", err)
+			}
+		}
+		if _, err := svc.VerifyMFA(ctx, token, currentCode(t, enrollment.Secret)); !errors.Is(err, domain.ErrInvalidMFAChallenge) {
+			t.Errorf("expected the challenge to be used up, got %v", err)
+		}
+	})
+
+	if err := svc.DisableTOTP(ctx, 1, "wrong"); !errors.Is(err, domain.ErrInvalidTOTPCode) {
+		t.Errorf("expected ErrInvalidTOTPCode, got %v", err)
+	}
+	if err := svc.DisableTOTP(ctx, 1, currentCode(t, enrollment.Secret)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	result, err := svc.Login(ctx, cmd)
+	if err != nil || result.Tokens == nil {
+		t.Errorf("expected tokens after disabling two-factor authentication, got %+v, %v", result, err)
+	}
+}
+
+func TestAuthService_LoginThrottling(t *testing.T) {
+	ctx := context.Background()
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	newService := func(store *memoryTokenStore, cfg *config.LoginThrottleConfig) domainService.AuthService {
+		adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "admin", Password: hashed, Role: entity.AdminRoleOwner})
+		return NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), nil, nil, &config.JWTConfig{
+			Secret:        "test-secret",
+			Expiry:        15 * time.Minute,
+			RefreshExpiry: time.Hour,
+		}, cfg, &config.OIDCConfig{})
+	}
+	expectThrottled := func(t *testing.T, err, target error) *domain.LoginThrottledError {
+		t.Helper()
+		var throttled *domain.LoginThrottledError
+		if !errors.As(err, &throttled) || !errors.Is(err, target) {
+			t.Fatalf("expected a LoginThrottledError wrapping %v, got %v", target, err)
+		}
+		return throttled
+	}
+
+	t.Run("username backoff and lockout", func(t *testing.T) {
+		store := newMemoryTokenStore()
+		svc := newService(store, &config.LoginThrottleConfig{
+			BackoffAfter:         2,
+			BackoffBase:          time.Minute,
+			BackoffMax:           90 * time.Second,
+			UserLockoutThreshold: 4,
+			LockoutDuration:      30 * time.Minute,
+		})
+		wrong := domainService.LoginCommand{Username: "admin", Password: "wrong"}
+		right := domainService.LoginCommand{Username: "admin", Password: "password"}
+		subject := entity.LoginSubject{Kind: entity.LoginSubjectUsername, Value: "admin"}
+
+		for i := 0; i < 2; i++ {
+			if _, err := svc.Login(ctx, wrong); !errors.Is(err, domain.ErrInvalidCredentials) {
+				t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+			}
+		}
+		// Even the right password has to wait for the backoff
+		_, err := svc.Login(ctx, right)
+		throttled := expectThrottled(t, err, domain.ErrTooManyLoginAttempts)
+		if throttled.RetryAfter <= 55*time.Second || throttled.RetryAfter > time.Minute {
+			t.Errorf("expected a one minute backoff, got %v", throttled.RetryAfter)
+		}
+
+		// Let the backoff expire: the next failure doubles it up to the maximum
+		delete(store.blocks, subject.Key())
+		if _, err := svc.Login(ctx, wrong); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+		}
+		if d := time.Until(store.blocks[subject.Key()].until); d <= 85*time.Second || d > 90*time.Second {
+			t.Errorf("expected the backoff to be capped at 90s, got %v", d)
+		}
+
+		delete(store.blocks, subject.Key())
+		if _, err := svc.Login(ctx, wrong); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+		}
+		_, err = svc.Login(ctx, right)
+		expectThrottled(t, err, domain.ErrAccountLocked)
+
+		adminSvc := NewAdminService(newMemoryAdminRepo(), newMemoryInviteRepo(), store.loginRepo(), store.refreshRepo(), store.denylistRepo(), &mocks.MockAuditLogRepository{}, &config.AdminConfig{}, &config.JWTConfig{Expiry: time.Hour})
+		if err := adminSvc.ClearLoginLockout(ctx, entity.LoginSubject{Kind: "email", Value: "admin"}); !errors.Is(err, domain.ErrInvalidLoginSubject) {
+			t.Errorf("expected ErrInvalidLoginSubject, got %v", err)
+		}
+		if err := adminSvc.ClearLoginLockout(ctx, subject); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := svc.Login(ctx, right); err != nil {
+			t.Errorf("expected login to work after clearing the lockout, got %v", err)
+		}
+	})
+
+	t.Run("ip lockout across usernames", func(t *testing.T) {
+		store := newMemoryTokenStore()
+		svc := newService(store, &config.LoginThrottleConfig{
+			IPLockoutThreshold: 3,
+			LockoutDuration:    30 * time.Minute,
+		})
+
+		for _, username := range []string{"root", "admin", "editor"} {
+			_, err := svc.Login(ctx, domainService.LoginCommand{Username: username, Password: "guess", ClientIP: "203.0.113.7"})
+			if !errors.Is(err, domain.ErrInvalidCredentials) {
+				t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+			}
+		}
+		_, err := svc.Login(ctx, domainService.LoginCommand{Username: "admin", Password: "password", ClientIP: "203.0.113.7"})
+		expectThrottled(t, err, domain.ErrAccountLocked)
+
+		if _, err := svc.Login(ctx, domainService.LoginCommand{Username: "admin", Password: "password", ClientIP: "198.51.100.1"}); err != nil {
+			t.Errorf("expected other clients to be unaffected, got %v", err)
+		}
+	})
+}
+
+func newPasswordTestAuthService(t *testing.T, store *memoryTokenStore) domainService.AuthService {
+	t.Helper()
+
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "admin", Password: hashed, Role: entity.AdminRoleOwner})
+	svc := NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), nil, nil, &config.JWTConfig{
+		Secret:              "test-secret",
+		Expiry:              15 * time.Minute,
+		RefreshExpiry:       24 * time.Hour,
+		PasswordResetExpiry: time.Hour,
+	}, &config.LoginThrottleConfig{UserLockoutThreshold: 1, LockoutDuration: time.Hour, FailureWindow: time.Hour}, &config.OIDCConfig{})
+	return svc
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryTokenStore()
+	svc := newPasswordTestAuthService(t, store)
+	tokenInfo := login(t, svc)
+
+	if err := svc.ChangePassword(ctx, 1, "wrong", "new-password"); !errors.Is(err, domain.ErrWrongPassword) {
+		t.Fatalf("expected ErrWrongPassword, got %v", err)
+	}
+	if err := svc.ChangePassword(ctx, 1, "password", "new-password"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := svc.ValidateToken(ctx, tokenInfo.Token); !errors.Is(err, domain.ErrTokenRevoked) {
+		t.Errorf("expected the access token to be revoked, got %v", err)
+	}
+	if _, err := svc.Refresh(ctx, tokenInfo.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("expected the refresh token to be revoked, got %v", err)
+	}
+	if _, err := svc.Login(ctx, domainService.LoginCommand{Username: "admin", Password: "password"}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Errorf("expected the old password to be rejected, got %v", err)
+	}
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryTokenStore()
+	svc := newPasswordTestAuthService(t, store)
+	tokenInfo := login(t, svc)
+
+	// Lock the username out with a failed login
+	if _, err := svc.Login(ctx, domainService.LoginCommand{Username: "admin", Password: "wrong"}); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+
+	if _, err := svc.IssuePasswordReset(ctx, "nobody"); !errors.Is(err, domain.ErrAdminNotFound) {
+		t.Errorf("expected ErrAdminNotFound, got %v", err)
+	}
+	reset, err := svc.IssuePasswordReset(ctx, "admin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := store.resets[hashToken(reset.Token)]; !ok {
+		t.Error("expected reset token to be stored by hash")
+	}
+
+	if err := svc.ResetPassword(ctx, "unknown", "new-password"); !errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+		t.Errorf("expected ErrInvalidPasswordResetToken, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, reset.Token, "new-password"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, reset.Token, "another-password"); !errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+		t.Errorf("expected the token to be single use, got %v", err)
+	}
+
+	if _, err := svc.Refresh(ctx, tokenInfo.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("expected existing sessions to be revoked, got %v", err)
+	}
+	if _, err := svc.Login(ctx, domainService.LoginCommand{Username: "admin", Password: "new-password"}); err != nil {
+		t.Errorf("expected the lockout to be cleared and the new password to work, got %v", err)
+	}
+}
+
+// mockOIDCProvider is a minimal OpenID Connect identity provider serving discovery, JWKS and
+// a token endpoint that checks the PKCE verifier
+type mockOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	codes  map[string]mockOIDCGrant
+}
+
+type mockOIDCGrant struct {
+	challenge string
+	claims    jwt.MapClaims
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key failed: %v", err)
+	}
+	idp := &mockOIDCProvider{key: key, codes: map[string]mockOIDCGrant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                idp.server.URL,
+			"authorization_endpoint":                idp.server.URL + "/authorize",
+			"token_endpoint":                        idp.server.URL + "/token",
+			"jwks_uri":                              idp.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "idp-key",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		idp.mu.Lock()
+		grant, ok := idp.codes[r.FormValue("code")]
+		delete(idp.codes, r.FormValue("code"))
+		idp.mu.Unlock()
+
+		verifierHash := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(verifierHash[:]) != grant.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, grant.claims)
+		idToken.Header["kid"] = "idp-key"
+		signed, err := idToken.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]any{"access_token": "idp-access-token", "token_type": "Bearer", "id_token": signed})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize plays the user signing in at the authorization URL and returns the code
+// the provider would redirect back with. Claims override the defaults of the ID token.
+func (idp *mockOIDCProvider) authorize(t *testing.T, authURL string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization URL failed: %v", err)
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("expected a PKCE S256 challenge, got %q", authURL)
+	}
+
+	idTokenClaims := jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"aud":   query.Get("client_id"),
+		"nonce": query.Get("nonce"),
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		idTokenClaims[k] = v
+	}
+
+	code := "code-" + query.Get("state")
+	idp.mu.Lock()
+	idp.codes[code] = mockOIDCGrant{challenge: query.Get("code_challenge"), claims: idTokenClaims}
+	idp.mu.Unlock()
+	return code
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestAuthService_OIDCLogin(t *testing.T) {
+	ctx := context.Background()
+	idp := newMockOIDCProvider(t)
+	store := newMemoryTokenStore()
+	adminRepo := newMemoryAdminRepo(
+		entity.Admin{ID: 1, Username: "owner", Role: entity.AdminRoleOwner, Email: "owner@example.com"},
+		entity.Admin{ID: 2, Username: "editor", Role: entity.AdminRoleEditor, Email: "editor@example.com"},
+	)
+	oidcCfg := &config.OIDCConfig{
+		IssuerURL:   idp.server.URL,
+		ClientID:    "blog-admin",
+		RedirectURL: "http://localhost:3000/auth/callback",
+		Scopes:      []string{"openid", "email"},
+		MatchBy:     "email",
+		StateTTL:    10 * time.Minute,
+	}
+	svc := NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, newHS256SigningKeys(), oidcIdentity.NewProvider(oidcCfg), store.oidcStateRepo(), &config.JWTConfig{
+		Secret:        "test-secret",
+		Expiry:        15 * time.Minute,
+		RefreshExpiry: time.Hour,
+	}, &config.LoginThrottleConfig{}, oidcCfg)
+
+	signIn := func(t *testing.T, claims jwt.MapClaims) (*entity.LoginResult, error) {
+		t.Helper()
+		authorization, err := svc.StartOIDCLogin(ctx)
+		if err != nil {
+			t.Fatalf("start login failed: %v", err)
+		}
+		return svc.CompleteOIDCLogin(ctx, idp.authorize(t, authorization.URL, claims), authorization.State)
+	}
+
+	t.Run("links a verified email on first login", func(t *testing.T) {
+		result, err := signIn(t, jwt.MapClaims{"sub": "idp-owner", "email": "Owner@Example.com", "email_verified": true})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		claims, err := svc.ValidateToken(ctx, result.Tokens.Token)
+		if err != nil {
+			t.Fatalf("expected a valid access token, got %v", err)
+		}
+		if claims.UserID != 1 || claims.Role != entity.AdminRoleOwner {
+			t.Errorf("expected owner claims, got %+v", claims)
+		}
+		if owner, _ := adminRepo.FindByID(ctx, 1); owner.OIDCSubject != "idp-owner" {
+			t.Errorf("expected subject to be linked, got %q", owner.OIDCSubject)
+		}
+	})
+
+	t.Run("finds linked admins by subject", func(t *testing.T) {
+		result, err := signIn(t, jwt.MapClaims{"sub": "idp-owner", "email": "renamed@example.com"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Tokens == nil {
+			t.Error("expected tokens")
+		}
+	})
+
+	t.Run("rejects unverified emails", func(t *testing.T) {
+		_, err := signIn(t, jwt.MapClaims{"sub": "idp-editor", "email": "editor@example.com", "email_verified": false})
+		if !errors.Is(err, domain.ErrOIDCAccountNotFound) {
+			t.Errorf("expected ErrOIDCAccountNotFound, got %v", err)
+		}
+	})
+
+	t.Run("rejects a second subject for a linked email", func(t *testing.T) {
+		_, err := signIn(t, jwt.MapClaims{"sub": "someone-else", "email": "owner@example.com", "email_verified": true})
+		if !errors.Is(err, domain.ErrOIDCAccountNotFound) {
+			t.Errorf("expected ErrOIDCAccountNotFound, got %v", err)
+		}
+	})
+
+	t.Run("rejects a mismatched nonce", func(t *testing.T) {
+		_, err := signIn(t, jwt.MapClaims{"sub": "idp-owner", "nonce": "stolen"})
+		if !errors.Is(err, domain.ErrOIDCExchangeFailed) {
+			t.Errorf("expected ErrOIDCExchangeFailed, got %v", err)
+		}
+	})
+
+	t.Run("rejects ID tokens for another client", func(t *testing.T) {
+		_, err := signIn(t, jwt.MapClaims{"sub": "idp-owner", "aud": "other-client"})
+		if !errors.Is(err, domain.ErrOIDCExchangeFailed) {
+			t.Errorf("expected ErrOIDCExchangeFailed, got %v", err)
+		}
+	})
+
+	t.Run("uses each state once", func(t *testing.T) {
+		authorization, err := svc.StartOIDCLogin(ctx)
+		if err != nil {
+			t.Fatalf("start login failed: %v", err)
+		}
+		code := idp.authorize(t, authorization.URL, jwt.MapClaims{"sub": "idp-owner"})
+		if _, err := svc.CompleteOIDCLogin(ctx, code, authorization.State); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := svc.CompleteOIDCLogin(ctx, code, authorization.State); !errors.Is(err, domain.ErrInvalidOIDCState) {
+			t.Errorf("expected ErrInvalidOIDCState, got %v", err)
+		}
+	})
+
+	t.Run("disabled admins cannot sign in", func(t *testing.T) {
+		if _, err := adminRepo.SetDisabled(ctx, 1, true); err != nil {
+			t.Fatal(err)
+		}
+		_, err := signIn(t, jwt.MapClaims{"sub": "idp-owner"})
+		if !errors.Is(err, domain.ErrAdminDisabled) {
+			t.Errorf("expected ErrAdminDisabled, got %v", err)
+		}
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		disabled := newTestAuthService(t, store)
+		if _, err := disabled.StartOIDCLogin(ctx); !errors.Is(err, domain.ErrOIDCDisabled) {
+			t.Errorf("expected ErrOIDCDisabled, got %v", err)
+		}
+	})
+}
@@ -13,12 +13,16 @@ import (
 
 type categoryService struct {
 	categoryRepo repository.CategoryRepository
+	suggestRepo  repository.SuggestRepository
+	auditRepo    repository.AuditLogRepository
 }
 
 // NewCategoryService creates a new category service
-func NewCategoryService(categoryRepo repository.CategoryRepository) domainService.CategoryService {
+func NewCategoryService(categoryRepo repository.CategoryRepository, suggestRepo repository.SuggestRepository, auditRepo repository.AuditLogRepository) domainService.CategoryService {
 	return &categoryService{
 		categoryRepo: categoryRepo,
+		suggestRepo:  suggestRepo,
+		auditRepo:    auditRepo,
 	}
 }
 
@@ -73,6 +77,14 @@ func (s *categoryService) CreateCategory(ctx context.Context, cmd domainService.
 	if err != nil {
 		return nil, fmt.Errorf("categoryService.CreateCategory: fetch result failed: %w", err)
 	}
+
+	indexSuggestion(ctx, s.suggestRepo, categorySuggestion(result))
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionCategoryCreate,
+		TargetType: "category",
+		TargetID:   auditTargetID(result.ID),
+		After:      categoryAudit(result),
+	})
 	return result, nil
 }
 
@@ -117,12 +129,21 @@ func (s *categoryService) UpdateCategory(ctx context.Context, id int32, cmd doma
 	if err != nil {
 		return nil, fmt.Errorf("categoryService.UpdateCategory: fetch result failed: %w", err)
 	}
+
+	indexSuggestion(ctx, s.suggestRepo, categorySuggestion(result))
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionCategoryUpdate,
+		TargetType: "category",
+		TargetID:   auditTargetID(id),
+		Before:     categoryAudit(existing),
+		After:      categoryAudit(result),
+	})
 	return result, nil
 }
 
 func (s *categoryService) DeleteCategory(ctx context.Context, id int32) error {
 	// Check if category exists
-	_, err := s.categoryRepo.FindByID(ctx, id)
+	existing, err := s.categoryRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("categoryService.DeleteCategory: find category failed: %w", err)
 	}
@@ -139,5 +160,13 @@ func (s *categoryService) DeleteCategory(ctx context.Context, id int32) error {
 	if err := s.categoryRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("categoryService.DeleteCategory: delete failed: %w", err)
 	}
+
+	removeSuggestion(ctx, s.suggestRepo, entity.SuggestionTypeCategory, id)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionCategoryDelete,
+		TargetType: "category",
+		TargetID:   auditTargetID(id),
+		Before:     categoryAudit(existing),
+	})
 	return nil
 }
@@ -22,7 +22,7 @@ func TestCategoryService_ListCategories(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoryService(mockRepo)
+	svc := NewCategoryService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 	categories, err := svc.ListCategories(context.Background())
 
 	if err != nil {
@@ -50,7 +50,7 @@ func TestCategoryService_GetCategoryByID(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoryService(mockRepo)
+	svc := NewCategoryService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("existing category", func(t *testing.T) {
 		category, err := svc.GetCategoryByID(context.Background(), 1)
@@ -91,7 +91,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoryService(mockRepo)
+	svc := NewCategoryService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("successful creation", func(t *testing.T) {
 		cmd := domainService.CreateCategoryCommand{
@@ -146,7 +146,7 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoryService(mockRepo)
+	svc := NewCategoryService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("successful update", func(t *testing.T) {
 		cmd := domainService.UpdateCategoryCommand{
@@ -197,7 +197,7 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 		},
 	}
 
-	svc := NewCategoryService(mockRepo)
+	svc := NewCategoryService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("successful delete", func(t *testing.T) {
 		err := svc.DeleteCategory(context.Background(), 1)
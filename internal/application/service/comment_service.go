@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+// githubHandlePattern follows GitHub's username rules: alphanumerics and single
+// hyphens, not starting or ending with a hyphen, at most 39 characters
+var githubHandlePattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,37}[A-Za-z0-9])?$`)
+
+type commentService struct {
+	commentRepo repository.CommentRepository
+	postRepo    repository.PostRepository
+	spamService domainService.SpamService
+	auditRepo   repository.AuditLogRepository
+	cfg         *config.CommentConfig
+}
+
+func NewCommentService(
+	commentRepo repository.CommentRepository,
+	postRepo repository.PostRepository,
+	spamService domainService.SpamService,
+	auditRepo repository.AuditLogRepository,
+	cfg *config.CommentConfig,
+) domainService.CommentService {
+	return &commentService{
+		commentRepo: commentRepo,
+		postRepo:    postRepo,
+		spamService: spamService,
+		auditRepo:   auditRepo,
+		cfg:         cfg,
+	}
+}
+
+func (s *commentService) ListPostComments(ctx context.Context, postSlug string) ([]*entity.CommentThread, error) {
+	post, err := s.postRepo.FindPublishedBySlug(ctx, postSlug)
+	if err != nil {
+		return nil, fmt.Errorf("commentService.ListPostComments: %w", err)
+	}
+
+	comments, err := s.commentRepo.ListVisibleByPost(ctx, post.ID)
+	if err != nil {
+		return nil, fmt.Errorf("commentService.ListPostComments: list comments failed: %w", err)
+	}
+
+	return buildCommentThreads(comments), nil
+}
+
+func (s *commentService) CreateComment(ctx context.Context, postSlug string, cmd domainService.CreateCommentCommand) (*entity.Comment, error) {
+	content := strings.TrimSpace(cmd.Content)
+	if content == "" {
+		return nil, domain.ErrCommentContentRequired
+	}
+
+	handle := strings.TrimPrefix(strings.TrimSpace(cmd.GitHubHandle), "@")
+	if handle != "" && !isValidGitHubHandle(handle) {
+		return nil, domain.ErrInvalidGitHubHandle
+	}
+
+	name := strings.TrimSpace(cmd.AuthorName)
+	if name == "" {
+		if handle == "" {
+			return nil, domain.ErrCommentAuthorRequired
+		}
+		name = handle
+	}
+
+	post, err := s.postRepo.FindPublishedBySlug(ctx, postSlug)
+	if err != nil {
+		return nil, fmt.Errorf("commentService.CreateComment: %w", err)
+	}
+
+	// Replies are only allowed on approved comments of the same post
+	if cmd.ParentID != nil {
+		parent, err := s.commentRepo.FindByID(ctx, *cmd.ParentID)
+		if err != nil {
+			if errors.Is(err, domain.ErrCommentNotFound) {
+				return nil, domain.ErrInvalidCommentParent
+			}
+			return nil, fmt.Errorf("commentService.CreateComment: find parent failed: %w", err)
+		}
+		if parent.PostID != post.ID || !parent.IsApproved() {
+			return nil, domain.ErrInvalidCommentParent
+		}
+	}
+
+	comment := &entity.Comment{
+		PostID:       post.ID,
+		ParentID:     cmd.ParentID,
+		AuthorName:   name,
+		AuthorEmail:  strings.TrimSpace(cmd.AuthorEmail),
+		GitHubHandle: handle,
+		Content:      content,
+		Status:       entity.CommentStatusPending,
+		IPHash:       hashCommentIP(s.cfg.IPHashSecret, cmd.ClientIP),
+	}
+
+	// High spam scores skip the moderation queue
+	verdict, err := s.spamService.Check(ctx, &entity.SpamCandidate{
+		PostID:       post.ID,
+		AuthorName:   comment.AuthorName,
+		AuthorEmail:  comment.AuthorEmail,
+		GitHubHandle: comment.GitHubHandle,
+		Content:      comment.Content,
+		Honeypot:     cmd.Honeypot,
+		FormToken:    cmd.FormToken,
+		SubmittedAt:  time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("commentService.CreateComment: spam check failed: %w", err)
+	}
+	comment.SpamScore = verdict.Score
+	if verdict.Spam {
+		comment.Status = entity.CommentStatusSpam
+	}
+
+	created, err := s.commentRepo.Create(ctx, comment)
+	if err != nil {
+		return nil, fmt.Errorf("commentService.CreateComment: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *commentService) IssueFormToken(ctx context.Context, postSlug string) (string, error) {
+	post, err := s.postRepo.FindPublishedBySlug(ctx, postSlug)
+	if err != nil {
+		return "", fmt.Errorf("commentService.IssueFormToken: %w", err)
+	}
+	return s.spamService.IssueFormToken(post.ID), nil
+}
+
+func (s *commentService) ListComments(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, int64, error) {
+	if status == "" {
+		status = entity.CommentStatusPending
+	}
+	if !status.IsValid() {
+		return nil, 0, domain.ErrInvalidCommentStatus
+	}
+
+	comments, err := s.commentRepo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("commentService.ListComments: %w", err)
+	}
+
+	total, err := s.commentRepo.CountByStatus(ctx, status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("commentService.ListComments: count failed: %w", err)
+	}
+
+	return comments, total, nil
+}
+
+func (s *commentService) ModerateComments(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error) {
+	if !status.IsValid() {
+		return 0, domain.ErrInvalidCommentStatus
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	updated, err := s.commentRepo.UpdateStatus(ctx, ids, status)
+	if err != nil {
+		return 0, fmt.Errorf("commentService.ModerateComments: %w", err)
+	}
+
+	switch status {
+	case entity.CommentStatusApproved:
+		s.trainSpamFilter(ctx, ids, entity.SpamLabelHam)
+	case entity.CommentStatusSpam:
+		s.trainSpamFilter(ctx, ids, entity.SpamLabelSpam)
+	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionCommentModerate,
+		TargetType: "comment",
+		After:      map[string]any{"ids": ids, "status": status, "updated": updated},
+	})
+	return updated, nil
+}
+
+// trainSpamFilter feeds moderation decisions back to the spam classifier.
+// Best effort: the moderation itself has already been applied.
+func (s *commentService) trainSpamFilter(ctx context.Context, ids []int32, label entity.SpamLabel) {
+	comments, err := s.commentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return
+	}
+	for i := range comments {
+		_ = s.spamService.Train(ctx, &comments[i], label)
+	}
+}
+
+// buildCommentThreads nests comments (oldest first) under their parents.
+// Comments that are not approved are dropped unless they still have visible replies,
+// in which case they stay in the tree as placeholders without author or content.
+func buildCommentThreads(comments []entity.Comment) []*entity.CommentThread {
+	nodes := make(map[int32]*entity.CommentThread, len(comments))
+	for _, c := range comments {
+		nodes[c.ID] = &entity.CommentThread{Comment: c}
+	}
+
+	var roots []*entity.CommentThread
+	for _, c := range comments {
+		node := nodes[c.ID]
+		if c.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[*c.ParentID]; ok {
+			parent.Replies = append(parent.Replies, node)
+		}
+	}
+
+	return pruneCommentThreads(roots)
+}
+
+func pruneCommentThreads(threads []*entity.CommentThread) []*entity.CommentThread {
+	result := make([]*entity.CommentThread, 0, len(threads))
+	for _, t := range threads {
+		t.Replies = pruneCommentThreads(t.Replies)
+		if t.IsApproved() {
+			result = append(result, t)
+			continue
+		}
+		if len(t.Replies) > 0 {
+			t.Comment = entity.Comment{
+				ID:        t.ID,
+				PostID:    t.PostID,
+				ParentID:  t.ParentID,
+				Status:    entity.CommentStatusDeleted,
+				CreatedAt: t.CreatedAt,
+			}
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func isValidGitHubHandle(handle string) bool {
+	return githubHandlePattern.MatchString(handle) && !strings.Contains(handle, "--")
+}
+
+// hashCommentIP stores an HMAC-SHA256 of the commenter IP instead of the raw address.
+// The server-side key stops the hash from being reversed by hashing every IPv4 address.
+func hashCommentIP(secret, clientIP string) string {
+	if clientIP == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(clientIP))
+	return hex.EncodeToString(mac.Sum(nil))
+}
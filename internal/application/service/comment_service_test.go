@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+var testCommentConfig = &config.CommentConfig{IPHashSecret: "test-ip-hash-secret"}
+
+func newTestCommentPostRepo() *mocks.MockPostRepository {
+	return &mocks.MockPostRepository{
+		FindPublishedBySlugFunc: func(ctx context.Context, slug string) (*entity.PostWithDetails, error) {
+			if slug != "hello-world" {
+				return nil, domain.ErrPostNotFound
+			}
+			return &entity.PostWithDetails{Post: entity.Post{ID: 1, Slug: slug}}, nil
+		},
+	}
+}
+
+func newTestSpamService(commentRepo *mocks.MockCommentRepository, filters ...domainService.SpamFilter) domainService.SpamService {
+	return NewSpamService(&mocks.MockSpamRepository{}, commentRepo, &config.SpamConfig{Threshold: 0.9}, filters...)
+}
+
+func TestCommentService_ListPostComments(t *testing.T) {
+	parent := func(id int32) *int32 { return &id }
+	commentRepo := &mocks.MockCommentRepository{
+		ListVisibleByPostFunc: func(ctx context.Context, postID int32) ([]entity.Comment, error) {
+			return []entity.Comment{
+				{ID: 1, PostID: 1, AuthorName: "alice", Content: "first", Status: entity.CommentStatusApproved},
+				{ID: 2, PostID: 1, AuthorName: "spammer", Content: "buy now", Status: entity.CommentStatusSpam},
+				{ID: 3, PostID: 1, ParentID: parent(1), AuthorName: "bob", Content: "reply", Status: entity.CommentStatusApproved},
+				{ID: 4, PostID: 1, AuthorName: "carol", Content: "removed", Status: entity.CommentStatusDeleted},
+				{ID: 5, PostID: 1, ParentID: parent(4), AuthorName: "dave", Content: "reply to removed", Status: entity.CommentStatusApproved},
+				{ID: 6, PostID: 1, ParentID: parent(99), AuthorName: "eve", Content: "orphan", Status: entity.CommentStatusApproved},
+			}, nil
+		},
+	}
+	svc := NewCommentService(commentRepo, newTestCommentPostRepo(), newTestSpamService(commentRepo), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	threads, err := svc.ListPostComments(context.Background(), "hello-world")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(threads))
+	}
+	if threads[0].ID != 1 || len(threads[0].Replies) != 1 || threads[0].Replies[0].ID != 3 {
+		t.Errorf("expected comment 1 with reply 3, got %+v", threads[0])
+	}
+
+	placeholder := threads[1]
+	if placeholder.ID != 4 || placeholder.Status != entity.CommentStatusDeleted {
+		t.Errorf("expected deleted placeholder for comment 4, got %+v", placeholder.Comment)
+	}
+	if placeholder.Content != "" || placeholder.AuthorName != "" {
+		t.Errorf("expected placeholder without author or content, got %+v", placeholder.Comment)
+	}
+	if len(placeholder.Replies) != 1 || placeholder.Replies[0].ID != 5 {
+		t.Errorf("expected placeholder to keep reply 5, got %+v", placeholder.Replies)
+	}
+}
+
+func TestCommentService_CreateComment(t *testing.T) {
+	var created *entity.Comment
+	commentRepo := &mocks.MockCommentRepository{
+		CreateFunc: func(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+			created = comment
+			return comment, nil
+		},
+	}
+	svc := NewCommentService(commentRepo, newTestCommentPostRepo(), newTestSpamService(commentRepo), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	comment, err := svc.CreateComment(context.Background(), "hello-world", domainService.CreateCommentCommand{
+		GitHubHandle: "@octo-cat",
+		Content:      "  Nice post  ",
+		ClientIP:     "127.0.0.1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if comment.Status != entity.CommentStatusPending {
+		t.Errorf("expected pending status, got %s", comment.Status)
+	}
+	if created.AuthorName != "octo-cat" || created.GitHubHandle != "octo-cat" {
+		t.Errorf("expected handle to be used as author name, got %q/%q", created.AuthorName, created.GitHubHandle)
+	}
+	if created.Content != "Nice post" {
+		t.Errorf("expected trimmed content, got %q", created.Content)
+	}
+	if created.IPHash == "" || created.IPHash == "127.0.0.1" {
+		t.Errorf("expected hashed IP, got %q", created.IPHash)
+	}
+	if created.IPHash == hashCommentIP("", "127.0.0.1") || created.IPHash != hashCommentIP(testCommentConfig.IPHashSecret, "127.0.0.1") {
+		t.Errorf("expected IP hash keyed with the configured secret, got %q", created.IPHash)
+	}
+}
+
+func TestCommentService_CreateComment_Validation(t *testing.T) {
+	parentID := int32(10)
+	otherPostParentID := int32(11)
+	pendingParentID := int32(12)
+
+	commentRepo := &mocks.MockCommentRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.Comment, error) {
+			switch id {
+			case parentID:
+				return &entity.Comment{ID: id, PostID: 1, Status: entity.CommentStatusApproved}, nil
+			case otherPostParentID:
+				return &entity.Comment{ID: id, PostID: 2, Status: entity.CommentStatusApproved}, nil
+			case pendingParentID:
+				return &entity.Comment{ID: id, PostID: 1, Status: entity.CommentStatusPending}, nil
+			}
+			return nil, domain.ErrCommentNotFound
+		},
+		CreateFunc: func(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+			return comment, nil
+		},
+	}
+	svc := NewCommentService(commentRepo, newTestCommentPostRepo(), newTestSpamService(commentRepo), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	missingID := int32(99)
+	tests := []struct {
+		name    string
+		slug    string
+		cmd     domainService.CreateCommentCommand
+		wantErr error
+	}{
+		{"valid reply", "hello-world", domainService.CreateCommentCommand{AuthorName: "alice", Content: "hi", ParentID: &parentID}, nil},
+		{"empty content", "hello-world", domainService.CreateCommentCommand{AuthorName: "alice", Content: "   "}, domain.ErrCommentContentRequired},
+		{"no author", "hello-world", domainService.CreateCommentCommand{Content: "hi"}, domain.ErrCommentAuthorRequired},
+		{"leading hyphen handle", "hello-world", domainService.CreateCommentCommand{GitHubHandle: "-octo", Content: "hi"}, domain.ErrInvalidGitHubHandle},
+		{"double hyphen handle", "hello-world", domainService.CreateCommentCommand{GitHubHandle: "oc--to", Content: "hi"}, domain.ErrInvalidGitHubHandle},
+		{"unknown post", "missing", domainService.CreateCommentCommand{AuthorName: "alice", Content: "hi"}, domain.ErrPostNotFound},
+		{"missing parent", "hello-world", domainService.CreateCommentCommand{AuthorName: "alice", Content: "hi", ParentID: &missingID}, domain.ErrInvalidCommentParent},
+		{"parent on other post", "hello-world", domainService.CreateCommentCommand{AuthorName: "alice", Content: "hi", ParentID: &otherPostParentID}, domain.ErrInvalidCommentParent},
+		{"pending parent", "hello-world", domainService.CreateCommentCommand{AuthorName: "alice", Content: "hi", ParentID: &pendingParentID}, domain.ErrInvalidCommentParent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateComment(context.Background(), tt.slug, tt.cmd)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCommentService_ListComments_DefaultsToPending(t *testing.T) {
+	var listed entity.CommentStatus
+	commentRepo := &mocks.MockCommentRepository{
+		ListByStatusFunc: func(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, error) {
+			listed = status
+			return nil, nil
+		},
+	}
+	svc := NewCommentService(commentRepo, &mocks.MockPostRepository{}, newTestSpamService(commentRepo), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	if _, _, err := svc.ListComments(context.Background(), "", 10, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if listed != entity.CommentStatusPending {
+		t.Errorf("expected pending queue, got %q", listed)
+	}
+
+	if _, _, err := svc.ListComments(context.Background(), "bogus", 10, 0); !errors.Is(err, domain.ErrInvalidCommentStatus) {
+		t.Errorf("expected ErrInvalidCommentStatus, got %v", err)
+	}
+}
+
+func TestCommentService_ModerateComments(t *testing.T) {
+	commentRepo := &mocks.MockCommentRepository{
+		UpdateStatusFunc: func(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error) {
+			return int64(len(ids)), nil
+		},
+	}
+	svc := NewCommentService(commentRepo, &mocks.MockPostRepository{}, newTestSpamService(commentRepo), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	updated, err := svc.ModerateComments(context.Background(), []int32{1, 2, 3}, entity.CommentStatusSpam)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if updated != 3 {
+		t.Errorf("expected 3 updated, got %d", updated)
+	}
+
+	if _, err := svc.ModerateComments(context.Background(), []int32{1}, "published"); !errors.Is(err, domain.ErrInvalidCommentStatus) {
+		t.Errorf("expected ErrInvalidCommentStatus, got %v", err)
+	}
+}
+
+func TestCommentService_CreateComment_Spam(t *testing.T) {
+	commentRepo := &mocks.MockCommentRepository{
+		CreateFunc: func(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+			return comment, nil
+		},
+	}
+	svc := NewCommentService(commentRepo, newTestCommentPostRepo(), newTestSpamService(commentRepo, NewHoneypotSpamFilter()), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	comment, err := svc.CreateComment(context.Background(), "hello-world", domainService.CreateCommentCommand{
+		AuthorName: "bot",
+		Content:    "hi",
+		Honeypot:   "http://spam.example.com",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if comment.Status != entity.CommentStatusSpam {
+		t.Errorf("expected spam status, got %s", comment.Status)
+	}
+	if comment.SpamScore != 1 {
+		t.Errorf("expected spam score 1, got %v", comment.SpamScore)
+	}
+}
+
+func TestCommentService_ModerateComments_TrainsSpamFilter(t *testing.T) {
+	trained := make(map[int32]entity.SpamLabel)
+	commentRepo := &mocks.MockCommentRepository{
+		UpdateStatusFunc: func(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error) {
+			return int64(len(ids)), nil
+		},
+		FindByIDsFunc: func(ctx context.Context, ids []int32) ([]entity.Comment, error) {
+			comments := make([]entity.Comment, len(ids))
+			for i, id := range ids {
+				comments[i] = entity.Comment{ID: id, Content: "cheap pills"}
+			}
+			return comments, nil
+		},
+		SetSpamTrainedAsFunc: func(ctx context.Context, id int32, label entity.SpamLabel) error {
+			trained[id] = label
+			return nil
+		},
+	}
+	svc := NewCommentService(commentRepo, &mocks.MockPostRepository{}, newTestSpamService(commentRepo), &mocks.MockAuditLogRepository{}, testCommentConfig)
+
+	if _, err := svc.ModerateComments(context.Background(), []int32{1, 2}, entity.CommentStatusSpam); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if trained[1] != entity.SpamLabelSpam || trained[2] != entity.SpamLabelSpam {
+		t.Errorf("expected comments to be trained as spam, got %v", trained)
+	}
+
+	if _, err := svc.ModerateComments(context.Background(), []int32{3}, entity.CommentStatusDeleted); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := trained[3]; ok {
+		t.Error("expected deleted comments not to be used for training")
+	}
+}
@@ -38,9 +38,16 @@ func (s *dashboardService) GetStats(ctx context.Context) (*entity.DashboardStats
 		return nil, fmt.Errorf("dashboardService.GetStats: get recent posts failed: %w", err)
 	}
 
+	// Get upcoming scheduled posts (limit 10)
+	scheduledPosts, err := s.dashboardRepo.GetScheduledPosts(ctx, 10)
+	if err != nil {
+		return nil, fmt.Errorf("dashboardService.GetStats: get scheduled posts failed: %w", err)
+	}
+
 	return &entity.DashboardStats{
-		Posts:       *postStats,
-		Categories:  categoryStats,
-		RecentPosts: recentPosts,
+		Posts:          *postStats,
+		Categories:     categoryStats,
+		RecentPosts:    recentPosts,
+		ScheduledPosts: scheduledPosts,
 	}, nil
 }
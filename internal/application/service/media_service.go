@@ -3,16 +3,22 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
-	"strings"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ydonggwui/blog-api/internal/config"
 	"github.com/ydonggwui/blog-api/internal/domain"
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	"github.com/ydonggwui/blog-api/internal/domain/repository"
 	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+	"github.com/ydonggwui/blog-api/internal/util"
 	imageutil "github.com/ydonggwui/blog-api/internal/util/image"
 )
 
@@ -34,24 +40,30 @@ var skipProcessingTypes = map[string]bool{
 // Maximum file size (10MB)
 const maxFileSize = 10 * 1024 * 1024
 
+// uploadPathPattern matches the year/month/file layout uploads are stored
+// under; the storage cleanup leaves any other object in the bucket alone
+var uploadPathPattern = regexp.MustCompile(`^\d{4}/\d{2}/[^/]+$`)
+
 // Image processing settings
 const (
-	compressionQuality = 85  // WebP quality (0-100)
-	thumbnailSmallSize = 150 // Small thumbnail width
-	thumbnailMediumSize = 400 // Medium thumbnail width
+	compressionQuality = 85 // JPEG and WebP quality (0-100)
 )
 
 type mediaService struct {
 	mediaRepo      repository.MediaRepository
 	storageRepo    repository.StorageRepository
+	auditRepo      repository.AuditLogRepository
 	imageProcessor *imageutil.Processor
+	cfg            *config.MediaConfig
 }
 
-func NewMediaService(mediaRepo repository.MediaRepository, storageRepo repository.StorageRepository) domainService.MediaService {
+func NewMediaService(mediaRepo repository.MediaRepository, storageRepo repository.StorageRepository, auditRepo repository.AuditLogRepository, cfg *config.MediaConfig) domainService.MediaService {
 	return &mediaService{
 		mediaRepo:      mediaRepo,
 		storageRepo:    storageRepo,
+		auditRepo:      auditRepo,
 		imageProcessor: imageutil.NewProcessor(compressionQuality),
+		cfg:            cfg,
 	}
 }
 
@@ -69,6 +81,20 @@ func (s *mediaService) ListMedia(ctx context.Context, limit, offset int32) ([]en
 	return media, total, nil
 }
 
+func (s *mediaService) ListUnusedMedia(ctx context.Context, limit, offset int32) ([]entity.Media, int64, error) {
+	media, err := s.mediaRepo.ListUnused(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("mediaService.ListUnusedMedia: list failed: %w", err)
+	}
+
+	total, err := s.mediaRepo.CountUnused(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("mediaService.ListUnusedMedia: count failed: %w", err)
+	}
+
+	return media, total, nil
+}
+
 func (s *mediaService) GetMediaByID(ctx context.Context, id int32) (*entity.Media, error) {
 	media, err := s.mediaRepo.FindByID(ctx, id)
 	if err != nil {
@@ -88,6 +114,22 @@ func (s *mediaService) UploadMedia(ctx context.Context, cmd domainService.Upload
 		return nil, domain.ErrFileTooLarge
 	}
 
+	fileData, err := io.ReadAll(cmd.File)
+	if err != nil {
+		return nil, fmt.Errorf("mediaService.UploadMedia: %w: failed to read file: %v", domain.ErrUploadFailed, err)
+	}
+
+	// The same file uploaded again returns the existing media
+	sum := sha256.Sum256(fileData)
+	contentHash := hex.EncodeToString(sum[:])
+	existing, err := s.mediaRepo.FindByContentHash(ctx, contentHash)
+	if err == nil {
+		return toDeduplicatedFile(existing), nil
+	}
+	if !errors.Is(err, domain.ErrMediaNotFound) {
+		return nil, fmt.Errorf("mediaService.UploadMedia: find duplicate failed: %w", err)
+	}
+
 	// Generate unique base filename with UUID
 	baseFilename := uuid.New().String()
 
@@ -96,30 +138,51 @@ func (s *mediaService) UploadMedia(ctx context.Context, cmd domainService.Upload
 	pathPrefix := fmt.Sprintf("%d/%02d/", now.Year(), now.Month())
 
 	// Check if we should skip image processing
+	var result *entity.UploadedFile
 	if skipProcessingTypes[cmd.MimeType] {
-		result, err := s.uploadOriginal(ctx, cmd, baseFilename, pathPrefix)
+		result, err = s.uploadOriginal(ctx, cmd, fileData, contentHash, baseFilename, pathPrefix)
+	} else {
+		// Process image (compress and generate thumbnails)
+		result, err = s.uploadProcessed(ctx, cmd, fileData, contentHash, baseFilename, pathPrefix)
+	}
+	if errors.Is(err, domain.ErrMediaDuplicate) {
+		// A concurrent upload of the same file was saved first
+		existing, err := s.mediaRepo.FindByContentHash(ctx, contentHash)
 		if err != nil {
-			return nil, fmt.Errorf("mediaService.UploadMedia: %w", err)
+			return nil, fmt.Errorf("mediaService.UploadMedia: find duplicate failed: %w", err)
 		}
-		return result, nil
+		return toDeduplicatedFile(existing), nil
 	}
-
-	// Process image (compress and generate thumbnails)
-	result, err := s.uploadProcessed(ctx, cmd, baseFilename, pathPrefix)
 	if err != nil {
 		return nil, fmt.Errorf("mediaService.UploadMedia: %w", err)
 	}
+
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionMediaUpload,
+		TargetType: "media",
+		TargetID:   auditTargetID(result.ID),
+		After:      map[string]any{"filename": result.Filename, "original_name": result.OriginalName, "size": result.Size},
+	})
 	return result, nil
 }
 
-// uploadOriginal uploads the file without any processing (for GIF, SVG)
-func (s *mediaService) uploadOriginal(ctx context.Context, cmd domainService.UploadMediaCommand, baseFilename, pathPrefix string) (*entity.UploadedFile, error) {
+// uploadOriginal uploads the file without image processing (for GIF, SVG);
+// only embedded metadata is removed
+func (s *mediaService) uploadOriginal(ctx context.Context, cmd domainService.UploadMediaCommand, fileData []byte, contentHash, baseFilename, pathPrefix string) (*entity.UploadedFile, error) {
 	ext := getExtensionFromMimeType(cmd.MimeType)
 	filename := baseFilename + ext
 	path := pathPrefix + filename
 
+	// Files kept as-is must not leak a location either; a file that doesn't
+	// parse as its declared type is rejected rather than stored unchecked
+	fileData, removed, err := stripOriginalMetadata(cmd.MimeType, fileData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidFileType, err)
+	}
+	size := int64(len(fileData))
+
 	// Upload to storage
-	err := s.storageRepo.Upload(ctx, path, cmd.File, cmd.Size, cmd.MimeType)
+	err = s.storageRepo.Upload(ctx, path, bytes.NewReader(fileData), size, cmd.MimeType)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrUploadFailed, err)
 	}
@@ -134,7 +197,8 @@ func (s *mediaService) uploadOriginal(ctx context.Context, cmd domainService.Upl
 		Path:         path,
 		URL:          url,
 		MimeType:     cmd.MimeType,
-		Size:         cmd.Size,
+		Size:         size,
+		ContentHash:  contentHash,
 	}
 
 	created, err := s.mediaRepo.Create(ctx, media)
@@ -150,16 +214,16 @@ func (s *mediaService) uploadOriginal(ctx context.Context, cmd domainService.Upl
 		URL:          created.URL,
 		MimeType:     created.MimeType,
 		Size:         created.Size,
+
+		MetadataRemoved: removed,
 	}, nil
 }
 
-// uploadProcessed processes the image (compress to JPEG, generate thumbnails)
-func (s *mediaService) uploadProcessed(ctx context.Context, cmd domainService.UploadMediaCommand, baseFilename, pathPrefix string) (*entity.UploadedFile, error) {
-	// Read file content into buffer
-	fileData, err := io.ReadAll(cmd.File)
-	if err != nil {
-		return nil, fmt.Errorf("%w: failed to read file: %v", domain.ErrUploadFailed, err)
-	}
+// uploadProcessed processes the image (downscale, compress to JPEG and WebP, generate variants)
+func (s *mediaService) uploadProcessed(ctx context.Context, cmd domainService.UploadMediaCommand, fileData []byte, contentHash, baseFilename, pathPrefix string) (*entity.UploadedFile, error) {
+	// Read camera information before processing; re-encoding drops all
+	// embedded metadata from the stored files
+	exifData, removed := readUploadMetadata(fileData)
 
 	// Decode image
 	img, err := s.imageProcessor.DecodeImage(bytes.NewReader(fileData))
@@ -167,6 +231,11 @@ func (s *mediaService) uploadProcessed(ctx context.Context, cmd domainService.Up
 		return nil, fmt.Errorf("%w: failed to decode image: %v", domain.ErrUploadFailed, err)
 	}
 
+	// Downscale oversized originals (e.g. full-resolution camera photos)
+	if s.cfg.MaxDimension > 0 {
+		img = s.imageProcessor.FitWithin(img, s.cfg.MaxDimension)
+	}
+
 	// Get original dimensions
 	width, height := s.imageProcessor.GetDimensions(img)
 
@@ -176,64 +245,99 @@ func (s *mediaService) uploadProcessed(ctx context.Context, cmd domainService.Up
 		return nil, fmt.Errorf("%w: failed to encode to jpeg: %v", domain.ErrUploadFailed, err)
 	}
 
-	// Generate thumbnails
-	thumbnails, err := s.imageProcessor.GenerateThumbnails(img, map[string]int{
-		"_sm": thumbnailSmallSize,
-		"_md": thumbnailMediumSize,
-	})
+	// Encode original to WebP; images beyond VP8's size limit keep only the JPEG
+	webpData, err := s.imageProcessor.EncodeToWebP(img)
+	if err != nil && !errors.Is(err, imageutil.ErrWebPTooLarge) {
+		return nil, fmt.Errorf("%w: failed to encode to webp: %v", domain.ErrUploadFailed, err)
+	}
+
+	// Compute the previews shown while the image loads
+	placeholder, err := s.imageProcessor.GeneratePlaceholder(img)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to generate placeholder: %v", domain.ErrUploadFailed, err)
+	}
+
+	// Generate the configured variants narrower than the original; wider ones
+	// would only duplicate it
+	sizes := make(map[string]int, len(s.cfg.Variants))
+	for _, v := range s.cfg.Variants {
+		if v.Width < width {
+			sizes[v.Name] = v.Width
+		}
+	}
+	resized, err := s.imageProcessor.GenerateThumbnails(img, sizes)
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to generate thumbnails: %v", domain.ErrUploadFailed, err)
+		return nil, fmt.Errorf("%w: failed to generate variants: %v", domain.ErrUploadFailed, err)
 	}
 
-	// Prepare file paths
+	// Prepare the stored files: the JPEG original, its WebP rendition, then
+	// each variant in both formats
 	filename := baseFilename + ".jpg"
 	mainPath := pathPrefix + filename
-	smPath := pathPrefix + baseFilename + "_sm.jpg"
-	mdPath := pathPrefix + baseFilename + "_md.jpg"
+	variants := []pendingVariant{{
+		MediaVariant: entity.MediaVariant{Name: entity.MediaVariantOriginal, MimeType: "image/webp", Width: int32(width), Height: int32(height)},
+		data:         webpData,
+	}}
+	for _, v := range s.cfg.Variants {
+		r, ok := resized[v.Name]
+		if !ok {
+			continue
+		}
+		variants = append(variants,
+			pendingVariant{
+				MediaVariant: entity.MediaVariant{Name: v.Name, MimeType: "image/webp", Width: int32(r.Width), Height: int32(r.Height)},
+				data:         r.WebPData,
+			},
+			pendingVariant{
+				MediaVariant: entity.MediaVariant{Name: v.Name, MimeType: "image/jpeg", Width: int32(r.Width), Height: int32(r.Height)},
+				data:         r.Data,
+			},
+		)
+	}
 
 	// Upload main image
 	err = s.storageRepo.Upload(ctx, mainPath, bytes.NewReader(jpegData), int64(len(jpegData)), "image/jpeg")
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to upload main image: %v", domain.ErrUploadFailed, err)
 	}
+	uploadedPaths := []string{mainPath}
 
-	// Upload thumbnails
-	var uploadedPaths []string
-	uploadedPaths = append(uploadedPaths, mainPath)
-
-	smData := thumbnails["_sm"].Data
-	err = s.storageRepo.Upload(ctx, smPath, bytes.NewReader(smData), int64(len(smData)), "image/jpeg")
-	if err != nil {
-		s.cleanupFiles(ctx, uploadedPaths)
-		return nil, fmt.Errorf("%w: failed to upload small thumbnail: %v", domain.ErrUploadFailed, err)
-	}
-	uploadedPaths = append(uploadedPaths, smPath)
-
-	mdData := thumbnails["_md"].Data
-	err = s.storageRepo.Upload(ctx, mdPath, bytes.NewReader(mdData), int64(len(mdData)), "image/jpeg")
-	if err != nil {
-		s.cleanupFiles(ctx, uploadedPaths)
-		return nil, fmt.Errorf("%w: failed to upload medium thumbnail: %v", domain.ErrUploadFailed, err)
+	// Upload variants
+	var stored []entity.MediaVariant
+	for _, v := range variants {
+		if len(v.data) == 0 {
+			continue
+		}
+		v.Path = pathPrefix + baseFilename + variantSuffix(v.Name) + getExtensionFromMimeType(v.MimeType)
+		err = s.storageRepo.Upload(ctx, v.Path, bytes.NewReader(v.data), int64(len(v.data)), v.MimeType)
+		if err != nil {
+			s.cleanupFiles(ctx, uploadedPaths)
+			return nil, fmt.Errorf("%w: failed to upload %s %s variant: %v", domain.ErrUploadFailed, v.Name, v.MimeType, err)
+		}
+		uploadedPaths = append(uploadedPaths, v.Path)
+		v.URL = s.storageRepo.GenerateURL(v.Path)
+		v.Size = int64(len(v.data))
+		stored = append(stored, v.MediaVariant)
 	}
-	uploadedPaths = append(uploadedPaths, mdPath)
-
-	// Generate URLs
-	mainURL := s.storageRepo.GenerateURL(mainPath)
-	smURL := s.storageRepo.GenerateURL(smPath)
-	mdURL := s.storageRepo.GenerateURL(mdPath)
 
 	// Save to database
 	media := &entity.Media{
 		Filename:     filename,
 		OriginalName: cmd.OriginalName,
 		Path:         mainPath,
-		URL:          mainURL,
+		URL:          s.storageRepo.GenerateURL(mainPath),
 		MimeType:     "image/jpeg",
 		Size:         int64(len(jpegData)),
 		Width:        int32(width),
 		Height:       int32(height),
-		ThumbnailSM:  smURL,
-		ThumbnailMD:  mdURL,
+		Variants:     stored,
+		Placeholder: &entity.MediaPlaceholder{
+			BlurHash:      placeholder.BlurHash,
+			LQIP:          placeholder.LQIP,
+			DominantColor: placeholder.DominantColor,
+		},
+		EXIF:        exifData,
+		ContentHash: contentHash,
 	}
 
 	created, err := s.mediaRepo.Create(ctx, media)
@@ -249,11 +353,91 @@ func (s *mediaService) uploadProcessed(ctx context.Context, cmd domainService.Up
 		URL:          created.URL,
 		MimeType:     created.MimeType,
 		Size:         created.Size,
-		ThumbnailSM:  created.ThumbnailSM,
-		ThumbnailMD:  created.ThumbnailMD,
+		Width:        created.Width,
+		Height:       created.Height,
+		Variants:     created.Variants,
+		Placeholder:  created.Placeholder,
+		EXIF:         created.EXIF,
+
+		MetadataRemoved: removed,
 	}, nil
 }
 
+// toDeduplicatedFile describes existing media returned for a repeated upload
+func toDeduplicatedFile(m *entity.Media) *entity.UploadedFile {
+	return &entity.UploadedFile{
+		ID:           m.ID,
+		Filename:     m.Filename,
+		OriginalName: m.OriginalName,
+		URL:          m.URL,
+		MimeType:     m.MimeType,
+		Size:         m.Size,
+		Width:        m.Width,
+		Height:       m.Height,
+		Variants:     m.Variants,
+		Placeholder:  m.Placeholder,
+		EXIF:         m.EXIF,
+		Deduplicated: true,
+	}
+}
+
+// readUploadMetadata returns the camera information of a photo worth keeping,
+// and the kinds of metadata the file carries (all of which re-encoding drops)
+func readUploadMetadata(data []byte) (*entity.MediaEXIF, []string) {
+	var removed []string
+	info := imageutil.ReadEXIF(data)
+	if info != nil {
+		if info.HasGPS {
+			removed = append(removed, imageutil.MetadataGPS)
+		}
+		removed = append(removed, imageutil.MetadataEXIF)
+	}
+	if imageutil.HasXMP(data) {
+		removed = append(removed, imageutil.MetadataXMP)
+	}
+
+	if info == nil || *info == (imageutil.EXIF{HasGPS: info.HasGPS}) {
+		return nil, removed
+	}
+	return &entity.MediaEXIF{
+		CameraMake:   info.CameraMake,
+		CameraModel:  info.CameraModel,
+		LensModel:    info.LensModel,
+		FocalLength:  info.FocalLength,
+		FNumber:      info.FNumber,
+		ExposureTime: info.ExposureTime,
+		ISO:          info.ISO,
+		CapturedAt:   info.CapturedAt,
+	}, removed
+}
+
+// stripOriginalMetadata removes embedded metadata from a file stored without processing
+func stripOriginalMetadata(mimeType string, data []byte) ([]byte, []string, error) {
+	switch mimeType {
+	case "image/gif":
+		return imageutil.StripGIFMetadata(data)
+	case "image/svg+xml":
+		return imageutil.StripSVGMetadata(data)
+	default:
+		return data, nil, nil
+	}
+}
+
+// pendingVariant is an encoded variant waiting to be uploaded
+type pendingVariant struct {
+	entity.MediaVariant
+	data []byte
+}
+
+// variantSuffix is appended to the base filename of a variant's object;
+// the full-size rendition shares the original's name with another extension
+func variantSuffix(name string) string {
+	if name == entity.MediaVariantOriginal {
+		return ""
+	}
+	return "_" + name
+}
+
 // cleanupFiles deletes uploaded files on error
 func (s *mediaService) cleanupFiles(ctx context.Context, paths []string) {
 	for _, path := range paths {
@@ -261,30 +445,45 @@ func (s *mediaService) cleanupFiles(ctx context.Context, paths []string) {
 	}
 }
 
-func (s *mediaService) DeleteMedia(ctx context.Context, id int32) error {
+func (s *mediaService) GetMediaUsages(ctx context.Context, id int32) ([]entity.MediaUsage, error) {
+	if _, err := s.mediaRepo.FindByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("mediaService.GetMediaUsages: find media failed: %w", err)
+	}
+
+	usages, err := s.mediaRepo.FindUsages(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("mediaService.GetMediaUsages: %w", err)
+	}
+	return usages, nil
+}
+
+func (s *mediaService) DeleteMedia(ctx context.Context, id int32, force bool) error {
 	// Get media info
 	media, err := s.mediaRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("mediaService.DeleteMedia: find media failed: %w", err)
 	}
 
+	// Deleting media that is still embedded leaves broken images behind
+	usages, err := s.mediaRepo.FindUsages(ctx, id)
+	if err != nil {
+		return fmt.Errorf("mediaService.DeleteMedia: find usages failed: %w", err)
+	}
+	if len(usages) > 0 && !force {
+		return domain.ErrMediaInUse
+	}
+
 	// Delete main file from storage
 	err = s.storageRepo.Delete(ctx, media.Path)
 	if err != nil {
 		return fmt.Errorf("mediaService.DeleteMedia: delete file from storage failed: %w", err)
 	}
 
-	// Delete thumbnails if they exist
-	if media.ThumbnailSM != "" {
-		smPath := extractPathFromURL(media.ThumbnailSM)
-		if smPath != "" {
-			_ = s.storageRepo.Delete(ctx, smPath)
-		}
-	}
-	if media.ThumbnailMD != "" {
-		mdPath := extractPathFromURL(media.ThumbnailMD)
-		if mdPath != "" {
-			_ = s.storageRepo.Delete(ctx, mdPath)
+	// Delete variants; failures leave orphaned objects for `gc-media` to clean
+	// up but shouldn't block removal
+	for _, v := range media.Variants {
+		if err := s.storageRepo.Delete(ctx, v.Path); err != nil {
+			logger.Error(ctx, "Failed to delete media variant", "media_id", id, "path", v.Path, "error", err)
 		}
 	}
 
@@ -292,20 +491,114 @@ func (s *mediaService) DeleteMedia(ctx context.Context, id int32) error {
 	if err := s.mediaRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("mediaService.DeleteMedia: delete record failed: %w", err)
 	}
+
+	before := mediaAudit(media)
+	if len(usages) > 0 {
+		// Forced deletion; keep a record of what now shows a broken image
+		before["usages"] = mediaUsagesAudit(usages)
+	}
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionMediaDelete,
+		TargetType: "media",
+		TargetID:   auditTargetID(id),
+		Before:     before,
+	})
 	return nil
 }
 
-// extractPathFromURL extracts the storage path from a full URL
-// URL format: {publicURL}/{bucket}/{path}
-func extractPathFromURL(url string) string {
-	// Find the path part after the bucket name
-	// Example: http://localhost:9000/blog/2024/01/uuid_sm.webp -> 2024/01/uuid_sm.webp
-	parts := strings.Split(url, "/")
-	if len(parts) < 5 {
-		return ""
+func (s *mediaService) ReconcileStorage(ctx context.Context, deleteOrphans bool) (*entity.StorageReport, error) {
+	// Records are listed before objects: an upload finishing in between then
+	// shows up as a recent orphan, which the grace period protects
+	recorded, err := s.mediaRepo.ListObjectPaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("mediaService.ReconcileStorage: list records failed: %w", err)
+	}
+	objects, err := s.storageRepo.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("mediaService.ReconcileStorage: list objects failed: %w", err)
+	}
+
+	known := make(map[string]bool, len(recorded))
+	for _, r := range recorded {
+		known[r.Path] = true
+	}
+	stored := make(map[string]bool, len(objects))
+	report := &entity.StorageReport{}
+	for _, obj := range objects {
+		stored[obj.Path] = true
+		if !known[obj.Path] && uploadPathPattern.MatchString(obj.Path) {
+			report.Orphans = append(report.Orphans, obj)
+		}
+	}
+	for _, r := range recorded {
+		if !stored[r.Path] {
+			report.Dangling = append(report.Dangling, r)
+		}
+	}
+
+	if !deleteOrphans {
+		return report, nil
+	}
+	// A record without its object usually means its path is wrong, in which case
+	// the object it names is among the orphans; deleting them would lose it
+	if len(report.Dangling) > 0 {
+		report.DeleteRefused = true
+		return report, nil
+	}
+	cutoff := time.Now().Add(-s.cfg.OrphanGracePeriod)
+	for _, obj := range report.Orphans {
+		if obj.LastModified.After(cutoff) {
+			continue
+		}
+		if err := s.storageRepo.Delete(ctx, obj.Path); err != nil {
+			logger.Error(ctx, "Failed to delete orphaned object", "path", obj.Path, "error", err)
+			continue
+		}
+		report.Deleted = append(report.Deleted, obj.Path)
+	}
+	return report, nil
+}
+
+// syncMediaUsages records the media referenced by a post or project. A failure
+// is logged rather than returned: the save has already committed, and the
+// usages are rebuilt the next time the record is saved.
+func syncMediaUsages(ctx context.Context, repo repository.MediaRepository, sourceType entity.MediaUsageSource, id int32, refs []entity.MediaReference) {
+	if err := repo.ReplaceUsages(ctx, sourceType, id, refs); err != nil {
+		logger.Error(ctx, "Failed to record media usages", "source_type", sourceType, "source_id", id, "error", err)
+	}
+}
+
+// clearMediaUsages drops the usages of a deleted post or project. A failure is
+// logged: the record is already gone, and leftover usages only make media look
+// in use until it is deleted with force.
+func clearMediaUsages(ctx context.Context, repo repository.MediaRepository, sourceType entity.MediaUsageSource, id int32) {
+	if err := repo.ReplaceUsages(ctx, sourceType, id, nil); err != nil {
+		logger.Error(ctx, "Failed to clear media usages", "source_type", sourceType, "source_id", id, "error", err)
+	}
+}
+
+// postMediaRefs returns the URLs a post references from its content and thumbnail
+func postMediaRefs(p *entity.Post) []entity.MediaReference {
+	var refs []entity.MediaReference
+	for _, u := range util.ExtractURLs(p.Content) {
+		refs = append(refs, entity.MediaReference{Field: entity.MediaUsageFieldContent, URL: u})
+	}
+	if p.Thumbnail != "" {
+		refs = append(refs, entity.MediaReference{Field: entity.MediaUsageFieldThumbnail, URL: p.Thumbnail})
+	}
+	return refs
+}
+
+// projectMediaRefs returns the URLs a project references from its thumbnail and images
+func projectMediaRefs(p *entity.Project) []entity.MediaReference {
+	var refs []entity.MediaReference
+	if p.Thumbnail != "" {
+		refs = append(refs, entity.MediaReference{Field: entity.MediaUsageFieldThumbnail, URL: p.Thumbnail})
+	}
+	for _, u := range p.Images {
+		refs = append(refs, entity.MediaReference{Field: entity.MediaUsageFieldImages, URL: u})
 	}
-	// Skip protocol, host, and bucket, join the rest
-	return strings.Join(parts[4:], "/")
+	return refs
 }
 
 // getExtensionFromMimeType returns the file extension for a MIME type
@@ -0,0 +1,514 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"golang.org/x/image/webp"
+)
+
+// memoryStorage keeps uploaded objects in a map
+type memoryStorage struct {
+	objects      map[string][]byte
+	contentTypes map[string]string
+	modified     map[string]time.Time
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{objects: map[string][]byte{}, contentTypes: map[string]string{}, modified: map[string]time.Time{}}
+}
+
+func (s *memoryStorage) repo() *mocks.MockStorageRepository {
+	return &mocks.MockStorageRepository{
+		UploadFunc: func(ctx context.Context, path string, file io.Reader, size int64, contentType string) error {
+			data, err := io.ReadAll(file)
+			if err != nil {
+				return err
+			}
+			s.objects[path] = data
+			s.contentTypes[path] = contentType
+			s.modified[path] = time.Now()
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, path string) error {
+			delete(s.objects, path)
+			return nil
+		},
+		ListFunc: func(ctx context.Context, prefix string) ([]entity.StoredObject, error) {
+			var objects []entity.StoredObject
+			for _, p := range s.paths() {
+				if strings.HasPrefix(p, prefix) {
+					objects = append(objects, entity.StoredObject{Path: p, Size: int64(len(s.objects[p])), LastModified: s.modified[p]})
+				}
+			}
+			return objects, nil
+		},
+		GenerateURLFunc: func(path string) string {
+			return "http://minio.test/blog/" + path
+		},
+	}
+}
+
+func (s *memoryStorage) paths() []string {
+	paths := make([]string, 0, len(s.objects))
+	for p := range s.objects {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// newMemoryMediaRepo stores created media by ID
+func newMemoryMediaRepo() (*mocks.MockMediaRepository, map[int32]*entity.Media) {
+	rows := map[int32]*entity.Media{}
+	return &mocks.MockMediaRepository{
+		CreateFunc: func(ctx context.Context, media *entity.Media) (*entity.Media, error) {
+			for _, m := range rows {
+				if media.ContentHash != "" && m.ContentHash == media.ContentHash {
+					return nil, domain.ErrMediaDuplicate
+				}
+			}
+			created := *media
+			created.ID = int32(len(rows) + 1)
+			rows[created.ID] = &created
+			return &created, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.Media, error) {
+			if m, ok := rows[id]; ok {
+				return m, nil
+			}
+			return nil, domain.ErrMediaNotFound
+		},
+		FindByContentHashFunc: func(ctx context.Context, hash string) (*entity.Media, error) {
+			for _, m := range rows {
+				if m.ContentHash == hash {
+					return m, nil
+				}
+			}
+			return nil, domain.ErrMediaNotFound
+		},
+		ListObjectPathsFunc: func(ctx context.Context) ([]entity.MediaObject, error) {
+			var objects []entity.MediaObject
+			for _, m := range rows {
+				objects = append(objects, entity.MediaObject{MediaID: m.ID, Path: m.Path})
+				for _, v := range m.Variants {
+					objects = append(objects, entity.MediaObject{MediaID: m.ID, Path: v.Path})
+				}
+			}
+			return objects, nil
+		},
+		DeleteFunc: func(ctx context.Context, id int32) error {
+			delete(rows, id)
+			return nil
+		},
+	}, rows
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestMediaService_UploadMedia_Variants(t *testing.T) {
+	storage := newMemoryStorage()
+	mediaRepo, rows := newMemoryMediaRepo()
+	cfg := &config.MediaConfig{
+		Variants: []config.MediaVariantSize{
+			{Name: "sm", Width: 320},
+			{Name: "md", Width: 640},
+			{Name: "xl", Width: 1600},
+		},
+		MaxDimension: 1000,
+	}
+	svc := NewMediaService(mediaRepo, storage.repo(), &mocks.MockAuditLogRepository{}, cfg)
+
+	data := testPNG(t, 1200, 600)
+	uploaded, err := svc.UploadMedia(context.Background(), domainService.UploadMediaCommand{
+		File:         bytes.NewReader(data),
+		OriginalName: "photo.png",
+		MimeType:     "image/png",
+		Size:         int64(len(data)),
+	})
+	if err != nil {
+		t.Fatalf("UploadMedia: %v", err)
+	}
+
+	// The original is capped at 1000px on its longer side
+	if uploaded.Width != 1000 || uploaded.Height != 500 {
+		t.Errorf("original size = %dx%d, want 1000x500", uploaded.Width, uploaded.Height)
+	}
+	original, err := jpeg.DecodeConfig(bytes.NewReader(storage.objects[rows[uploaded.ID].Path]))
+	if err != nil || original.Width != 1000 {
+		t.Errorf("stored original width = %d (err %v), want 1000", original.Width, err)
+	}
+
+	ph := uploaded.Placeholder
+	if ph == nil || ph.BlurHash == "" || !strings.HasPrefix(ph.LQIP, "data:image/jpeg;base64,") || len(ph.DominantColor) != 7 {
+		t.Errorf("placeholder = %+v, want BlurHash, LQIP and dominant color", ph)
+	} else if rows[uploaded.ID].Placeholder != ph {
+		t.Error("placeholder not saved with the media row")
+	}
+
+	// xl is wider than the capped original and is skipped
+	want := map[string]int32{
+		"original image/webp": 1000,
+		"sm image/webp":       320,
+		"sm image/jpeg":       320,
+		"md image/webp":       640,
+		"md image/jpeg":       640,
+	}
+	if len(uploaded.Variants) != len(want) {
+		t.Fatalf("got %d variants, want %d: %+v", len(uploaded.Variants), len(want), uploaded.Variants)
+	}
+	for _, v := range uploaded.Variants {
+		key := v.Name + " " + v.MimeType
+		width, ok := want[key]
+		if !ok {
+			t.Errorf("unexpected variant %s", key)
+			continue
+		}
+		if v.Width != width || v.Height != width/2 {
+			t.Errorf("%s size = %dx%d, want %dx%d", key, v.Width, v.Height, width, width/2)
+		}
+		obj, ok := storage.objects[v.Path]
+		if !ok {
+			t.Errorf("%s not uploaded to %s", key, v.Path)
+			continue
+		}
+		if storage.contentTypes[v.Path] != v.MimeType || v.Size != int64(len(obj)) {
+			t.Errorf("%s stored as %s with %d bytes, variant says %s/%d", key, storage.contentTypes[v.Path], len(obj), v.MimeType, v.Size)
+		}
+		if v.URL != "http://minio.test/blog/"+v.Path {
+			t.Errorf("%s URL = %s", key, v.URL)
+		}
+		if v.MimeType == "image/webp" {
+			if wc, err := webp.DecodeConfig(bytes.NewReader(obj)); err != nil || int32(wc.Width) != width {
+				t.Errorf("%s does not decode as a %dpx WebP: %v", key, width, err)
+			}
+		}
+	}
+	if len(storage.objects) != len(want)+1 {
+		t.Errorf("stored %d objects, want %d: %v", len(storage.objects), len(want)+1, storage.paths())
+	}
+
+	// Deleting removes the original and every variant
+	if err := svc.DeleteMedia(context.Background(), uploaded.ID, false); err != nil {
+		t.Fatalf("DeleteMedia: %v", err)
+	}
+	if len(storage.objects) != 0 {
+		t.Errorf("objects left after delete: %v", storage.paths())
+	}
+}
+
+func TestMediaService_UploadMedia_OriginalKeptForGIF(t *testing.T) {
+	storage := newMemoryStorage()
+	mediaRepo, _ := newMemoryMediaRepo()
+	cfg := &config.MediaConfig{Variants: []config.MediaVariantSize{{Name: "sm", Width: 320}}, MaxDimension: 1000}
+	svc := NewMediaService(mediaRepo, storage.repo(), &mocks.MockAuditLogRepository{}, cfg)
+
+	var buf bytes.Buffer
+	frame := image.NewPaletted(image.Rect(0, 0, 4, 4), color.Palette{color.Black, color.White})
+	if err := gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}); err != nil {
+		t.Fatalf("gif.EncodeAll: %v", err)
+	}
+	clean := buf.Bytes()
+	// A comment extension right after the logical screen descriptor
+	data := append(append(append([]byte{}, clean[:13]...), 0x21, 0xFE, 0x02, 'h', 'i', 0x00), clean[13:]...)
+
+	uploaded, err := svc.UploadMedia(context.Background(), domainService.UploadMediaCommand{
+		File:         bytes.NewReader(data),
+		OriginalName: "anim.gif",
+		MimeType:     "image/gif",
+		Size:         int64(len(data)),
+	})
+	if err != nil {
+		t.Fatalf("UploadMedia: %v", err)
+	}
+	if len(uploaded.Variants) != 0 || uploaded.Placeholder != nil || len(storage.objects) != 1 {
+		t.Errorf("GIF should be stored as-is, got %d variants and objects %v", len(uploaded.Variants), storage.paths())
+	}
+	for _, obj := range storage.objects {
+		if !bytes.Equal(obj, clean) || uploaded.Size != int64(len(clean)) {
+			t.Errorf("stored GIF still carries its comment (%d bytes, size %d)", len(obj), uploaded.Size)
+		}
+	}
+	if len(uploaded.MetadataRemoved) != 1 || uploaded.MetadataRemoved[0] != "comment" {
+		t.Errorf("MetadataRemoved = %v, want [comment]", uploaded.MetadataRemoved)
+	}
+}
+
+func TestMediaService_UploadMedia_StripsLocation(t *testing.T) {
+	// Little-endian TIFF: IFD0 with Make "Cam" and a GPS IFD holding GPSLatitudeRef "N"
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = append(tiff, 2, 0)
+	tiff = append(tiff, 0x0F, 0x01, 2, 0, 4, 0, 0, 0, 'C', 'a', 'm', 0)
+	tiff = append(tiff, 0x25, 0x88, 4, 0, 1, 0, 0, 0, 38, 0, 0, 0)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, 1, 0)
+	tiff = append(tiff, 0x01, 0x00, 2, 0, 2, 0, 0, 0, 'N', 0, 0, 0)
+	tiff = append(tiff, 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 64, 48)), nil); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	data := append([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0, byte(len(payload) + 2)}, payload...)
+	data = append(data, buf.Bytes()[2:]...)
+
+	tests := []struct {
+		name     string
+		mimeType string
+		wantErr  error
+	}{
+		{"processed", "image/jpeg", nil},
+		{"sent as gif", "image/gif", domain.ErrInvalidFileType},
+		{"sent as svg", "image/svg+xml", domain.ErrInvalidFileType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newMemoryStorage()
+			mediaRepo, rows := newMemoryMediaRepo()
+			svc := NewMediaService(mediaRepo, storage.repo(), &mocks.MockAuditLogRepository{}, &config.MediaConfig{MaxDimension: 1000})
+
+			uploaded, err := svc.UploadMedia(context.Background(), domainService.UploadMediaCommand{
+				File:         bytes.NewReader(data),
+				OriginalName: "photo.jpg",
+				MimeType:     tt.mimeType,
+				Size:         int64(len(data)),
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || len(storage.objects) != 0 {
+					t.Fatalf("err = %v with objects %v, want %v and nothing stored", err, storage.paths(), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UploadMedia: %v", err)
+			}
+
+			for path, obj := range storage.objects {
+				if bytes.Contains(obj, []byte("Exif")) {
+					t.Errorf("%s still carries EXIF", path)
+				}
+			}
+			if strings.Join(uploaded.MetadataRemoved, ",") != "gps,exif" {
+				t.Errorf("MetadataRemoved = %v, want [gps exif]", uploaded.MetadataRemoved)
+			}
+			if e := rows[uploaded.ID].EXIF; e == nil || e.CameraMake != "Cam" {
+				t.Errorf("saved EXIF = %+v, want camera make Cam", e)
+			}
+		})
+	}
+}
+
+func TestMediaService_UploadMedia_Deduplicates(t *testing.T) {
+	data := testPNG(t, 200, 100)
+	upload := func(svc domainService.MediaService) *entity.UploadedFile {
+		t.Helper()
+		uploaded, err := svc.UploadMedia(context.Background(), domainService.UploadMediaCommand{
+			File:         bytes.NewReader(data),
+			OriginalName: "screenshot.png",
+			MimeType:     "image/png",
+			Size:         int64(len(data)),
+		})
+		if err != nil {
+			t.Fatalf("UploadMedia: %v", err)
+		}
+		return uploaded
+	}
+	cfg := &config.MediaConfig{Variants: []config.MediaVariantSize{{Name: "sm", Width: 100}}, MaxDimension: 1000}
+
+	t.Run("repeated upload", func(t *testing.T) {
+		storage := newMemoryStorage()
+		mediaRepo, rows := newMemoryMediaRepo()
+		svc := NewMediaService(mediaRepo, storage.repo(), &mocks.MockAuditLogRepository{}, cfg)
+
+		first := upload(svc)
+		stored := len(storage.objects)
+		second := upload(svc)
+
+		if first.Deduplicated || !second.Deduplicated {
+			t.Errorf("Deduplicated = %v, %v; want false, true", first.Deduplicated, second.Deduplicated)
+		}
+		if second.ID != first.ID || second.URL != first.URL || len(second.Variants) != len(first.Variants) {
+			t.Errorf("second upload = %+v, want the first media %+v", second, first)
+		}
+		if len(rows) != 1 || len(storage.objects) != stored {
+			t.Errorf("got %d rows and %d objects, want 1 and %d", len(rows), len(storage.objects), stored)
+		}
+	})
+
+	t.Run("concurrent upload saved first", func(t *testing.T) {
+		storage := newMemoryStorage()
+		mediaRepo, rows := newMemoryMediaRepo()
+		svc := NewMediaService(mediaRepo, storage.repo(), &mocks.MockAuditLogRepository{}, cfg)
+		first := upload(svc)
+		stored := len(storage.objects)
+
+		// The duplicate check misses once, as if the other upload hadn't been saved yet
+		findByHash := mediaRepo.FindByContentHashFunc
+		missed := false
+		mediaRepo.FindByContentHashFunc = func(ctx context.Context, hash string) (*entity.Media, error) {
+			if !missed {
+				missed = true
+				return nil, domain.ErrMediaNotFound
+			}
+			return findByHash(ctx, hash)
+		}
+
+		second := upload(svc)
+		if !second.Deduplicated || second.ID != first.ID {
+			t.Errorf("second upload = %+v, want the first media deduplicated", second)
+		}
+		if len(rows) != 1 || len(storage.objects) != stored {
+			t.Errorf("got %d rows and %d objects, want 1 and %d; the losing upload must be cleaned up", len(rows), len(storage.objects), stored)
+		}
+	})
+}
+
+func TestMediaService_DeleteMedia_InUse(t *testing.T) {
+	storage := newMemoryStorage()
+	mediaRepo, rows := newMemoryMediaRepo()
+	mediaRepo.FindUsagesFunc = func(ctx context.Context, mediaID int32) ([]entity.MediaUsage, error) {
+		return []entity.MediaUsage{{MediaID: mediaID, SourceType: entity.MediaUsageSourcePost, SourceID: 7, Field: entity.MediaUsageFieldContent}}, nil
+	}
+	var audited *entity.AuditLog
+	auditRepo := &mocks.MockAuditLogRepository{
+		CreateFunc: func(ctx context.Context, log *entity.AuditLog) error {
+			audited = log
+			return nil
+		},
+	}
+	cfg := &config.MediaConfig{Variants: []config.MediaVariantSize{{Name: "sm", Width: 100}}, MaxDimension: 1000}
+	svc := NewMediaService(mediaRepo, storage.repo(), auditRepo, cfg)
+
+	data := testPNG(t, 200, 100)
+	uploaded, err := svc.UploadMedia(context.Background(), domainService.UploadMediaCommand{
+		File:         bytes.NewReader(data),
+		OriginalName: "used.png",
+		MimeType:     "image/png",
+		Size:         int64(len(data)),
+	})
+	if err != nil {
+		t.Fatalf("UploadMedia: %v", err)
+	}
+	stored := len(storage.objects)
+
+	if err := svc.DeleteMedia(context.Background(), uploaded.ID, false); !errors.Is(err, domain.ErrMediaInUse) {
+		t.Fatalf("DeleteMedia without force: err = %v, want ErrMediaInUse", err)
+	}
+	if len(rows) != 1 || len(storage.objects) != stored {
+		t.Errorf("media in use was removed: %d rows, %d objects", len(rows), len(storage.objects))
+	}
+
+	if err := svc.DeleteMedia(context.Background(), uploaded.ID, true); err != nil {
+		t.Fatalf("DeleteMedia with force: %v", err)
+	}
+	if len(rows) != 0 || len(storage.objects) != 0 {
+		t.Errorf("forced delete left %d rows and objects %v", len(rows), storage.paths())
+	}
+	if audited == nil || audited.Action != entity.AuditActionMediaDelete || audited.Before["usages"] == nil {
+		t.Errorf("audit log = %+v, want the delete with the usages it broke", audited)
+	}
+}
+
+func TestMediaService_ReconcileStorage(t *testing.T) {
+	storage := newMemoryStorage()
+	mediaRepo, rows := newMemoryMediaRepo()
+	cfg := &config.MediaConfig{Variants: []config.MediaVariantSize{{Name: "sm", Width: 100}}, MaxDimension: 1000, OrphanGracePeriod: time.Hour}
+	svc := NewMediaService(mediaRepo, storage.repo(), &mocks.MockAuditLogRepository{}, cfg)
+
+	data := testPNG(t, 200, 100)
+	uploaded, err := svc.UploadMedia(context.Background(), domainService.UploadMediaCommand{
+		File:         bytes.NewReader(data),
+		OriginalName: "kept.png",
+		MimeType:     "image/png",
+		Size:         int64(len(data)),
+	})
+	if err != nil {
+		t.Fatalf("UploadMedia: %v", err)
+	}
+	media := rows[uploaded.ID]
+
+	// A variant whose object is gone, an upload that died before its record was
+	// saved, one that may still be in flight and a file the uploads don't own
+	missing := media.Variants[0].Path
+	delete(storage.objects, missing)
+	for path, age := range map[string]time.Duration{
+		"2024/01/stale.jpg":  2 * time.Hour,
+		"2024/01/recent.jpg": time.Minute,
+		"backups/db.sql":     48 * time.Hour,
+	} {
+		storage.objects[path] = []byte("x")
+		storage.modified[path] = time.Now().Add(-age)
+	}
+	objects := len(storage.objects)
+
+	report, err := svc.ReconcileStorage(context.Background(), false)
+	if err != nil {
+		t.Fatalf("ReconcileStorage: %v", err)
+	}
+	var orphans []string
+	for _, o := range report.Orphans {
+		orphans = append(orphans, o.Path)
+	}
+	if strings.Join(orphans, ",") != "2024/01/recent.jpg,2024/01/stale.jpg" {
+		t.Errorf("orphans = %v, want the two unrecorded uploads", orphans)
+	}
+	if len(report.Dangling) != 1 || report.Dangling[0] != (entity.MediaObject{MediaID: media.ID, Path: missing}) {
+		t.Errorf("dangling = %+v, want %s of media %d", report.Dangling, missing, media.ID)
+	}
+	if len(report.Deleted) != 0 || len(storage.objects) != objects {
+		t.Errorf("report-only run deleted %v", report.Deleted)
+	}
+
+	// Dangling records may mean wrong paths, so nothing is deleted while they exist
+	report, err = svc.ReconcileStorage(context.Background(), true)
+	if err != nil {
+		t.Fatalf("ReconcileStorage with delete: %v", err)
+	}
+	if !report.DeleteRefused || len(report.Deleted) != 0 || len(storage.objects) != objects {
+		t.Errorf("delete with dangling records: refused = %v, deleted %v", report.DeleteRefused, report.Deleted)
+	}
+
+	storage.objects[missing] = []byte("x")
+	objects++
+	report, err = svc.ReconcileStorage(context.Background(), true)
+	if err != nil {
+		t.Fatalf("ReconcileStorage with delete: %v", err)
+	}
+	if report.DeleteRefused || len(report.Deleted) != 1 || report.Deleted[0] != "2024/01/stale.jpg" {
+		t.Errorf("deleted = %v, want only the orphan past the grace period", report.Deleted)
+	}
+	if _, ok := storage.objects["2024/01/stale.jpg"]; ok || len(storage.objects) != objects-1 {
+		t.Errorf("objects after delete: %v", storage.paths())
+	}
+}
@@ -3,20 +3,33 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/ydonggwui/blog-api/internal/domain"
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	"github.com/ydonggwui/blog-api/internal/domain/repository"
 	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
 	"github.com/ydonggwui/blog-api/internal/util"
 )
 
+const (
+	// Content highlights are limited to a few snippets; title and excerpt are highlighted whole
+	searchContentFragments = 3
+	searchFragmentSize     = 160
+)
+
 type postService struct {
-	postRepo repository.PostRepository
+	postRepo    repository.PostRepository
+	suggestRepo repository.SuggestRepository
+	mediaRepo   repository.MediaRepository
+	auditRepo   repository.AuditLogRepository
 }
 
-func NewPostService(postRepo repository.PostRepository) domainService.PostService {
-	return &postService{postRepo: postRepo}
+func NewPostService(postRepo repository.PostRepository, suggestRepo repository.SuggestRepository, mediaRepo repository.MediaRepository, auditRepo repository.AuditLogRepository) domainService.PostService {
+	return &postService{postRepo: postRepo, suggestRepo: suggestRepo, mediaRepo: mediaRepo, auditRepo: auditRepo}
 }
 
 // Public API
@@ -26,6 +39,7 @@ func (s *postService) GetPublishedPost(ctx context.Context, slug string) (*entit
 	if err != nil {
 		return nil, fmt.Errorf("postService.GetPublishedPost: %w", err)
 	}
+	s.attachThumbnailPlaceholders(ctx, post)
 	return post, nil
 }
 
@@ -40,6 +54,7 @@ func (s *postService) ListPublishedPosts(ctx context.Context, limit, offset int3
 		return nil, 0, fmt.Errorf("postService.ListPublishedPosts: count failed: %w", err)
 	}
 
+	s.attachThumbnailPlaceholders(ctx, postRefs(posts)...)
 	return posts, count, nil
 }
 
@@ -54,6 +69,7 @@ func (s *postService) ListPublishedPostsByCategory(ctx context.Context, category
 		return nil, 0, fmt.Errorf("postService.ListPublishedPostsByCategory: count failed: %w", err)
 	}
 
+	s.attachThumbnailPlaceholders(ctx, postRefs(posts)...)
 	return posts, count, nil
 }
 
@@ -68,16 +84,24 @@ func (s *postService) ListPublishedPostsByTag(ctx context.Context, tagID int32,
 		return nil, 0, fmt.Errorf("postService.ListPublishedPostsByTag: count failed: %w", err)
 	}
 
+	s.attachThumbnailPlaceholders(ctx, postRefs(posts)...)
 	return posts, count, nil
 }
 
-func (s *postService) SearchPublishedPosts(ctx context.Context, query string, limit, offset int32) ([]entity.PostWithDetails, int64, error) {
-	posts, err := s.postRepo.SearchPublished(ctx, query, limit, offset)
+func (s *postService) SearchPublishedPosts(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, int64, error) {
+	posts, err := s.postRepo.SearchPublished(ctx, query, filter, limit, offset)
 	if err != nil {
 		return nil, 0, fmt.Errorf("postService.SearchPublishedPosts: search failed: %w", err)
 	}
 
-	count, err := s.postRepo.CountSearchPublished(ctx, query)
+	refs := make([]*entity.PostWithDetails, len(posts))
+	for i := range posts {
+		posts[i].Highlights = searchHighlights(&posts[i].PostWithDetails, query)
+		refs[i] = &posts[i].PostWithDetails
+	}
+	s.attachThumbnailPlaceholders(ctx, refs...)
+
+	count, err := s.postRepo.CountSearchPublished(ctx, query, filter)
 	if err != nil {
 		return nil, 0, fmt.Errorf("postService.SearchPublishedPosts: count failed: %w", err)
 	}
@@ -85,6 +109,14 @@ func (s *postService) SearchPublishedPosts(ctx context.Context, query string, li
 	return posts, count, nil
 }
 
+func (s *postService) GetSearchFacets(ctx context.Context, query string, filter entity.PostSearchFilter) (*entity.PostSearchFacets, error) {
+	facets, err := s.postRepo.SearchFacets(ctx, query, filter)
+	if err != nil {
+		return nil, fmt.Errorf("postService.GetSearchFacets: %w", err)
+	}
+	return facets, nil
+}
+
 // Admin API
 
 func (s *postService) GetPost(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
@@ -92,6 +124,7 @@ func (s *postService) GetPost(ctx context.Context, id int32) (*entity.PostWithDe
 	if err != nil {
 		return nil, fmt.Errorf("postService.GetPost: %w", err)
 	}
+	s.attachThumbnailPlaceholders(ctx, post)
 	return post, nil
 }
 
@@ -106,6 +139,7 @@ func (s *postService) ListAllPosts(ctx context.Context, limit, offset int32) ([]
 		return nil, 0, fmt.Errorf("postService.ListAllPosts: count failed: %w", err)
 	}
 
+	s.attachThumbnailPlaceholders(ctx, postRefs(posts)...)
 	return posts, count, nil
 }
 
@@ -120,10 +154,15 @@ func (s *postService) ListPostsByStatus(ctx context.Context, status entity.PostS
 		return nil, 0, fmt.Errorf("postService.ListPostsByStatus: count failed: %w", err)
 	}
 
+	s.attachThumbnailPlaceholders(ctx, postRefs(posts)...)
 	return posts, count, nil
 }
 
-func (s *postService) CreatePost(ctx context.Context, cmd domainService.CreatePostCommand) (*entity.PostWithDetails, error) {
+func (s *postService) CreatePost(ctx context.Context, actor entity.Actor, cmd domainService.CreatePostCommand) (*entity.PostWithDetails, error) {
+	if !actor.Can(entity.PermissionPostWrite) {
+		return nil, domain.ErrForbidden
+	}
+
 	// Generate slug if not provided
 	slug := cmd.Slug
 	if slug == "" {
@@ -143,8 +182,9 @@ func (s *postService) CreatePost(ctx context.Context, cmd domainService.CreatePo
 	readingTime := util.CalculateReadingTime(cmd.Content)
 
 	// Determine status
+	// Scheduling needs a publish time, so it goes through SchedulePost instead
 	status := cmd.Status
-	if status == "" {
+	if status == "" || status == entity.PostStatusScheduled {
 		status = entity.PostStatusDraft
 	}
 
@@ -158,6 +198,7 @@ func (s *postService) CreatePost(ctx context.Context, cmd domainService.CreatePo
 		Status:      status,
 		ReadingTime: int32(readingTime),
 		Thumbnail:   cmd.Thumbnail,
+		AuthorID:    &actor.AdminID,
 	}
 
 	created, err := s.postRepo.Create(ctx, post)
@@ -177,15 +218,28 @@ func (s *postService) CreatePost(ctx context.Context, cmd domainService.CreatePo
 	if err != nil {
 		return nil, fmt.Errorf("postService.CreatePost: fetch result failed: %w", err)
 	}
+
+	syncMediaUsages(ctx, s.mediaRepo, entity.MediaUsageSourcePost, result.ID, postMediaRefs(&result.Post))
+	syncPostSuggestion(ctx, s.suggestRepo, &result.Post)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionPostCreate,
+		TargetType: "post",
+		TargetID:   auditTargetID(result.ID),
+		After:      postAudit(&result.Post),
+	})
+	s.attachThumbnailPlaceholders(ctx, result)
 	return result, nil
 }
 
-func (s *postService) UpdatePost(ctx context.Context, id int32, cmd domainService.UpdatePostCommand) (*entity.PostWithDetails, error) {
+func (s *postService) UpdatePost(ctx context.Context, actor entity.Actor, id int32, cmd domainService.UpdatePostCommand) (*entity.PostWithDetails, error) {
 	// Check if post exists
 	existing, err := s.postRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("postService.UpdatePost: find post failed: %w", err)
 	}
+	if !canWritePost(actor, &existing.Post) {
+		return nil, domain.ErrForbidden
+	}
 
 	// Generate slug if not provided
 	slug := cmd.Slug
@@ -218,7 +272,12 @@ func (s *postService) UpdatePost(ctx context.Context, id int32, cmd domainServic
 		Thumbnail:   cmd.Thumbnail,
 	}
 
-	_, err = s.postRepo.Update(ctx, post)
+	// Snapshot the current version in the same transaction that overwrites it
+	if hasRevisionChanges(existing, cmd) {
+		_, err = s.postRepo.UpdateWithRevision(ctx, post, toPostRevision(existing))
+	} else {
+		_, err = s.postRepo.Update(ctx, post)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("postService.UpdatePost: update failed: %w", err)
 	}
@@ -233,15 +292,29 @@ func (s *postService) UpdatePost(ctx context.Context, id int32, cmd domainServic
 	if err != nil {
 		return nil, fmt.Errorf("postService.UpdatePost: fetch result failed: %w", err)
 	}
+
+	syncMediaUsages(ctx, s.mediaRepo, entity.MediaUsageSourcePost, id, postMediaRefs(&result.Post))
+	syncPostSuggestion(ctx, s.suggestRepo, &result.Post)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionPostUpdate,
+		TargetType: "post",
+		TargetID:   auditTargetID(id),
+		Before:     postAudit(&existing.Post),
+		After:      postAudit(&result.Post),
+	})
+	s.attachThumbnailPlaceholders(ctx, result)
 	return result, nil
 }
 
-func (s *postService) DeletePost(ctx context.Context, id int32) error {
+func (s *postService) DeletePost(ctx context.Context, actor entity.Actor, id int32) error {
 	// Check if post exists
-	_, err := s.postRepo.FindByID(ctx, id)
+	existing, err := s.postRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("postService.DeletePost: find post failed: %w", err)
 	}
+	if !canWritePost(actor, &existing.Post) {
+		return domain.ErrForbidden
+	}
 
 	// Remove tags first
 	if err := s.postRepo.RemoveAllTags(ctx, id); err != nil {
@@ -251,12 +324,27 @@ func (s *postService) DeletePost(ctx context.Context, id int32) error {
 	if err := s.postRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("postService.DeletePost: delete failed: %w", err)
 	}
+
+	removeSuggestion(ctx, s.suggestRepo, entity.SuggestionTypePost, id)
+	clearMediaUsages(ctx, s.mediaRepo, entity.MediaUsageSourcePost, id)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionPostDelete,
+		TargetType: "post",
+		TargetID:   auditTargetID(id),
+		Before:     postAudit(&existing.Post),
+	})
 	return nil
 }
 
-func (s *postService) PublishPost(ctx context.Context, id int32, publish bool) (*entity.PostWithDetails, error) {
-	var err error
+func (s *postService) PublishPost(ctx context.Context, actor entity.Actor, id int32, publish bool) (*entity.PostWithDetails, error) {
+	existing, err := s.authorizePostWrite(ctx, actor, id)
+	if err != nil {
+		return nil, fmt.Errorf("postService.PublishPost: %w", err)
+	}
+
+	action := entity.AuditActionPostUnpublish
 	if publish {
+		action = entity.AuditActionPostPublish
 		_, err = s.postRepo.Publish(ctx, id)
 	} else {
 		_, err = s.postRepo.Unpublish(ctx, id)
@@ -269,9 +357,63 @@ func (s *postService) PublishPost(ctx context.Context, id int32, publish bool) (
 	if err != nil {
 		return nil, fmt.Errorf("postService.PublishPost: fetch result failed: %w", err)
 	}
+
+	syncPostSuggestion(ctx, s.suggestRepo, &result.Post)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     action,
+		TargetType: "post",
+		TargetID:   auditTargetID(id),
+		Before:     postAudit(&existing.Post),
+		After:      postAudit(&result.Post),
+	})
+	s.attachThumbnailPlaceholders(ctx, result)
 	return result, nil
 }
 
+// Scheduled publishing
+
+func (s *postService) SchedulePost(ctx context.Context, actor entity.Actor, id int32, publishAt time.Time) (*entity.PostWithDetails, error) {
+	if !publishAt.After(time.Now()) {
+		return nil, domain.ErrScheduleInPast
+	}
+	existing, err := s.authorizePostWrite(ctx, actor, id)
+	if err != nil {
+		return nil, fmt.Errorf("postService.SchedulePost: %w", err)
+	}
+
+	if _, err := s.postRepo.Schedule(ctx, id, publishAt); err != nil {
+		return nil, fmt.Errorf("postService.SchedulePost: schedule failed: %w", err)
+	}
+
+	result, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("postService.SchedulePost: fetch result failed: %w", err)
+	}
+
+	syncPostSuggestion(ctx, s.suggestRepo, &result.Post)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionPostSchedule,
+		TargetType: "post",
+		TargetID:   auditTargetID(id),
+		Before:     postAudit(&existing.Post),
+		After:      postAudit(&result.Post),
+	})
+	s.attachThumbnailPlaceholders(ctx, result)
+	return result, nil
+}
+
+func (s *postService) PublishDuePosts(ctx context.Context, now time.Time) ([]entity.Post, error) {
+	posts, err := s.postRepo.PublishDue(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("postService.PublishDuePosts: %w", err)
+	}
+
+	for i := range posts {
+		syncPostSuggestion(ctx, s.suggestRepo, &posts[i])
+	}
+	return posts, nil
+}
+
 // View tracking
 
 func (s *postService) IncrementViewCount(ctx context.Context, id int32) error {
@@ -288,3 +430,236 @@ func (s *postService) GetPostIDBySlug(ctx context.Context, slug string) (int32,
 	}
 	return post.ID, nil
 }
+
+// Revisions
+
+func (s *postService) ListRevisions(ctx context.Context, postID int32, limit, offset int32) ([]entity.PostRevision, int64, error) {
+	if _, err := s.postRepo.FindByID(ctx, postID); err != nil {
+		return nil, 0, fmt.Errorf("postService.ListRevisions: find post failed: %w", err)
+	}
+
+	revisions, err := s.postRepo.ListRevisions(ctx, postID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("postService.ListRevisions: list failed: %w", err)
+	}
+
+	count, err := s.postRepo.CountRevisions(ctx, postID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("postService.ListRevisions: count failed: %w", err)
+	}
+
+	return revisions, count, nil
+}
+
+func (s *postService) GetRevision(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error) {
+	revision, err := s.postRepo.FindRevision(ctx, postID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("postService.GetRevision: %w", err)
+	}
+	return revision, nil
+}
+
+func (s *postService) DiffRevisions(ctx context.Context, postID, fromRevisionID int32, toRevisionID *int32) (*entity.PostRevisionDiff, error) {
+	from, err := s.postRepo.FindRevision(ctx, postID, fromRevisionID)
+	if err != nil {
+		return nil, fmt.Errorf("postService.DiffRevisions: find from revision failed: %w", err)
+	}
+
+	// Compare against the current post when no target revision is given
+	var to *entity.PostRevision
+	toLabel := "current"
+	if toRevisionID != nil {
+		to, err = s.postRepo.FindRevision(ctx, postID, *toRevisionID)
+		if err != nil {
+			return nil, fmt.Errorf("postService.DiffRevisions: find to revision failed: %w", err)
+		}
+		toLabel = fmt.Sprintf("revision %d", to.ID)
+	} else {
+		current, err := s.postRepo.FindByID(ctx, postID)
+		if err != nil {
+			return nil, fmt.Errorf("postService.DiffRevisions: find post failed: %w", err)
+		}
+		to = toPostRevision(current)
+	}
+	fromLabel := fmt.Sprintf("revision %d", from.ID)
+
+	result := &entity.PostRevisionDiff{
+		PostID:         postID,
+		FromRevisionID: from.ID,
+		ToRevisionID:   toRevisionID,
+	}
+
+	fields := []struct {
+		target   *string
+		from, to string
+	}{
+		{&result.Title, from.Title, to.Title},
+		{&result.Excerpt, from.Excerpt, to.Excerpt},
+		{&result.Tags, formatRevisionTags(from.Tags), formatRevisionTags(to.Tags)},
+		{&result.Content, from.Content, to.Content},
+	}
+	for _, f := range fields {
+		diff, err := util.UnifiedDiff(ensureTrailingNewline(f.from), ensureTrailingNewline(f.to), fromLabel, toLabel)
+		if err != nil {
+			return nil, fmt.Errorf("postService.DiffRevisions: diff failed: %w", err)
+		}
+		*f.target = diff
+	}
+
+	return result, nil
+}
+
+func (s *postService) RestoreRevision(ctx context.Context, actor entity.Actor, postID, revisionID int32) (*entity.PostWithDetails, error) {
+	revision, err := s.postRepo.FindRevision(ctx, postID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("postService.RestoreRevision: find revision failed: %w", err)
+	}
+
+	current, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("postService.RestoreRevision: find post failed: %w", err)
+	}
+
+	// Restore goes through UpdatePost so the replaced version is snapshotted too
+	result, err := s.UpdatePost(ctx, actor, postID, domainService.UpdatePostCommand{
+		Title:      revision.Title,
+		Slug:       current.Slug,
+		Content:    revision.Content,
+		Excerpt:    revision.Excerpt,
+		CategoryID: current.CategoryID,
+		TagIDs:     revision.TagIDs(),
+		Thumbnail:  current.Thumbnail,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postService.RestoreRevision: %w", err)
+	}
+	return result, nil
+}
+
+// authorizePostWrite loads a post and checks that the actor may change it
+func (s *postService) authorizePostWrite(ctx context.Context, actor entity.Actor, id int32) (*entity.PostWithDetails, error) {
+	post, err := s.postRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("find post failed: %w", err)
+	}
+	if !canWritePost(actor, &post.Post) {
+		return nil, domain.ErrForbidden
+	}
+	return post, nil
+}
+
+// attachThumbnailPlaceholders fills in the placeholder of posts whose thumbnail
+// is an uploaded image or one of its variants. The placeholder is cosmetic, so
+// a failed lookup is logged and the posts are returned without it.
+func (s *postService) attachThumbnailPlaceholders(ctx context.Context, posts ...*entity.PostWithDetails) {
+	var urls []string
+	for _, p := range posts {
+		if p.Thumbnail != "" {
+			urls = append(urls, p.Thumbnail)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	placeholders, err := s.mediaRepo.FindPlaceholdersByURLs(ctx, urls)
+	if err != nil {
+		logger.Error(ctx, "Failed to load thumbnail placeholders", "error", err)
+		return
+	}
+	for _, p := range posts {
+		if ph, ok := placeholders[p.Thumbnail]; ok {
+			p.ThumbnailPlaceholder = &ph
+		}
+	}
+}
+
+// postRefs returns pointers into posts so they can be updated in place
+func postRefs(posts []entity.PostWithDetails) []*entity.PostWithDetails {
+	refs := make([]*entity.PostWithDetails, len(posts))
+	for i := range posts {
+		refs[i] = &posts[i]
+	}
+	return refs
+}
+
+// canWritePost reports whether the actor may change the post:
+// any post with PermissionPostEditAny, otherwise only posts they authored
+func canWritePost(actor entity.Actor, post *entity.Post) bool {
+	if actor.Can(entity.PermissionPostEditAny) {
+		return true
+	}
+	return actor.Can(entity.PermissionPostWrite) && post.AuthorID != nil && *post.AuthorID == actor.AdminID
+}
+
+// toPostRevision creates a revision snapshot from the post's current state
+func toPostRevision(p *entity.PostWithDetails) *entity.PostRevision {
+	return &entity.PostRevision{
+		PostID:  p.ID,
+		Title:   p.Title,
+		Content: p.Content,
+		Excerpt: p.Excerpt,
+		Tags:    p.Tags,
+	}
+}
+
+// hasRevisionChanges reports whether an update changes any snapshotted field
+func hasRevisionChanges(existing *entity.PostWithDetails, cmd domainService.UpdatePostCommand) bool {
+	if existing.Title != cmd.Title || existing.Content != cmd.Content || existing.Excerpt != cmd.Excerpt {
+		return true
+	}
+
+	if len(existing.Tags) != len(cmd.TagIDs) {
+		return true
+	}
+	tagIDs := make(map[int32]bool, len(existing.Tags))
+	for _, t := range existing.Tags {
+		tagIDs[t.ID] = true
+	}
+	for _, id := range cmd.TagIDs {
+		if !tagIDs[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// searchHighlights collects the matched fragments of each searchable field
+func searchHighlights(post *entity.PostWithDetails, query string) map[string][]string {
+	fields := []struct {
+		name      string
+		text      string
+		fragments int
+		size      int
+	}{
+		{"title", post.Title, 1, 0},
+		{"excerpt", post.Excerpt, 1, 0},
+		{"content", post.Content, searchContentFragments, searchFragmentSize},
+	}
+
+	highlights := make(map[string][]string)
+	for _, f := range fields {
+		if fragments := util.Highlight(f.text, query, f.fragments, f.size); len(fragments) > 0 {
+			highlights[f.name] = fragments
+		}
+	}
+	return highlights
+}
+
+// formatRevisionTags renders tag names one per line in a stable order for diffing
+func formatRevisionTags(tags []entity.TagBrief) string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, "\n")
+}
+
+// ensureTrailingNewline keeps the last line comparable in line-based diffs
+func ensureTrailingNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
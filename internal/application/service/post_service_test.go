@@ -0,0 +1,600 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+)
+
+// testEditor may change any post
+var testEditor = entity.Actor{AdminID: 1, Role: entity.AdminRoleEditor}
+
+func newTestPost() *entity.PostWithDetails {
+	return &entity.PostWithDetails{
+		Post: entity.Post{
+			ID:      1,
+			Title:   "Original Title",
+			Slug:    "original-title",
+			Content: "line one\nline two\n",
+			Excerpt: "Original excerpt",
+			Status:  entity.PostStatusPublished,
+		},
+		Tags: []entity.TagBrief{
+			{ID: 1, Name: "Go", Slug: "go"},
+		},
+	}
+}
+
+func TestPostService_UpdatePost_CreatesRevision(t *testing.T) {
+	existing := newTestPost()
+
+	var saved *entity.PostRevision
+	mockRepo := &mocks.MockPostRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			return existing, nil
+		},
+		SlugExistsExceptFunc: func(ctx context.Context, slug string, excludeID int32) (bool, error) {
+			return false, nil
+		},
+		UpdateWithRevisionFunc: func(ctx context.Context, post *entity.Post, revision *entity.PostRevision) (*entity.Post, error) {
+			saved = revision
+			return post, nil
+		},
+	}
+
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	t.Run("content changed", func(t *testing.T) {
+		saved = nil
+		_, err := svc.UpdatePost(context.Background(), testEditor, 1, domainService.UpdatePostCommand{
+			Title:   "New Title",
+			Slug:    "original-title",
+			Content: existing.Content,
+			Excerpt: existing.Excerpt,
+			TagIDs:  []int32{1},
+		})
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if saved == nil {
+			t.Fatal("expected revision to be created")
+		}
+		if saved.PostID != 1 || saved.Title != "Original Title" {
+			t.Errorf("expected snapshot of previous version, got %+v", saved)
+		}
+		if len(saved.Tags) != 1 || saved.Tags[0].ID != 1 {
+			t.Errorf("expected snapshot tags to be preserved, got %+v", saved.Tags)
+		}
+	})
+
+	t.Run("tags changed", func(t *testing.T) {
+		saved = nil
+		_, err := svc.UpdatePost(context.Background(), testEditor, 1, domainService.UpdatePostCommand{
+			Title:   existing.Title,
+			Slug:    existing.Slug,
+			Content: existing.Content,
+			Excerpt: existing.Excerpt,
+			TagIDs:  []int32{2},
+		})
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if saved == nil {
+			t.Error("expected revision to be created")
+		}
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		saved = nil
+		_, err := svc.UpdatePost(context.Background(), testEditor, 1, domainService.UpdatePostCommand{
+			Title:   existing.Title,
+			Slug:    existing.Slug,
+			Content: existing.Content,
+			Excerpt: existing.Excerpt,
+			TagIDs:  []int32{1},
+		})
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if saved != nil {
+			t.Error("expected no revision to be created")
+		}
+	})
+}
+
+func TestPostService_RestoreRevision(t *testing.T) {
+	existing := newTestPost()
+	revision := &entity.PostRevision{
+		ID:      5,
+		PostID:  1,
+		Title:   "Old Title",
+		Content: "old content\n",
+		Excerpt: "Old excerpt",
+		Tags:    []entity.TagBrief{{ID: 3, Name: "Rust", Slug: "rust"}},
+	}
+
+	var updated *entity.Post
+	var tagIDs []int32
+	revisionCreated := false
+	mockRepo := &mocks.MockPostRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			return existing, nil
+		},
+		FindRevisionFunc: func(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error) {
+			if postID == 1 && revisionID == 5 {
+				return revision, nil
+			}
+			return nil, domain.ErrRevisionNotFound
+		},
+		SlugExistsExceptFunc: func(ctx context.Context, slug string, excludeID int32) (bool, error) {
+			return false, nil
+		},
+		UpdateWithRevisionFunc: func(ctx context.Context, post *entity.Post, r *entity.PostRevision) (*entity.Post, error) {
+			revisionCreated = true
+			updated = post
+			return post, nil
+		},
+		SetTagsFunc: func(ctx context.Context, postID int32, ids []int32) error {
+			tagIDs = ids
+			return nil
+		},
+	}
+
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	t.Run("existing revision", func(t *testing.T) {
+		_, err := svc.RestoreRevision(context.Background(), testEditor, 1, 5)
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if updated == nil || updated.Title != "Old Title" || updated.Content != "old content\n" {
+			t.Errorf("expected post to be restored from revision, got %+v", updated)
+		}
+		if updated != nil && updated.Slug != existing.Slug {
+			t.Errorf("expected slug %s to be kept, got %s", existing.Slug, updated.Slug)
+		}
+		if len(tagIDs) != 1 || tagIDs[0] != 3 {
+			t.Errorf("expected tags [3], got %v", tagIDs)
+		}
+		if !revisionCreated {
+			t.Error("expected current version to be saved as a revision")
+		}
+	})
+
+	t.Run("non-existing revision", func(t *testing.T) {
+		_, err := svc.RestoreRevision(context.Background(), testEditor, 1, 999)
+
+		if !errors.Is(err, domain.ErrRevisionNotFound) {
+			t.Errorf("expected ErrRevisionNotFound, got %v", err)
+		}
+	})
+}
+
+func TestPostService_DiffRevisions(t *testing.T) {
+	existing := newTestPost()
+	revisions := map[int32]*entity.PostRevision{
+		1: {ID: 1, PostID: 1, Title: "Original Title", Content: "line one\n", Excerpt: "Original excerpt"},
+		2: {ID: 2, PostID: 1, Title: "Original Title", Content: "line one\nline two\n", Excerpt: "Original excerpt"},
+	}
+
+	mockRepo := &mocks.MockPostRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			return existing, nil
+		},
+		FindRevisionFunc: func(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error) {
+			if r, ok := revisions[revisionID]; ok {
+				return r, nil
+			}
+			return nil, domain.ErrRevisionNotFound
+		},
+	}
+
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	t.Run("between revisions", func(t *testing.T) {
+		to := int32(2)
+		diff, err := svc.DiffRevisions(context.Background(), 1, 1, &to)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff.Title != "" {
+			t.Errorf("expected empty title diff, got %q", diff.Title)
+		}
+		if !strings.Contains(diff.Content, "+line two") {
+			t.Errorf("expected content diff to add line two, got %q", diff.Content)
+		}
+		if !strings.Contains(diff.Content, "+++ revision 2") {
+			t.Errorf("expected content diff to be labeled with revision 2, got %q", diff.Content)
+		}
+	})
+
+	t.Run("against current post", func(t *testing.T) {
+		diff, err := svc.DiffRevisions(context.Background(), 1, 2, nil)
+
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff.Content != "" {
+			t.Errorf("expected empty content diff, got %q", diff.Content)
+		}
+		if !strings.Contains(diff.Tags, "+Go") {
+			t.Errorf("expected tags diff to add Go, got %q", diff.Tags)
+		}
+		if !strings.Contains(diff.Tags, "+++ current") {
+			t.Errorf("expected tags diff to be labeled current, got %q", diff.Tags)
+		}
+	})
+
+	t.Run("non-existing revision", func(t *testing.T) {
+		_, err := svc.DiffRevisions(context.Background(), 1, 999, nil)
+
+		if !errors.Is(err, domain.ErrRevisionNotFound) {
+			t.Errorf("expected ErrRevisionNotFound, got %v", err)
+		}
+	})
+}
+
+func TestPostService_SchedulePost(t *testing.T) {
+	var scheduledAt time.Time
+	mockRepo := &mocks.MockPostRepository{
+		ScheduleFunc: func(ctx context.Context, id int32, publishAt time.Time) (*entity.Post, error) {
+			if id != 1 {
+				return nil, domain.ErrPostNotFound
+			}
+			scheduledAt = publishAt
+			return &entity.Post{ID: id, Status: entity.PostStatusScheduled, PublishedAt: &publishAt}, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			return &entity.PostWithDetails{Post: entity.Post{ID: id, Status: entity.PostStatusScheduled}}, nil
+		},
+	}
+
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	t.Run("future time", func(t *testing.T) {
+		publishAt := time.Now().Add(time.Hour)
+		post, err := svc.SchedulePost(context.Background(), testEditor, 1, publishAt)
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if !scheduledAt.Equal(publishAt) {
+			t.Errorf("expected publish time %v, got %v", publishAt, scheduledAt)
+		}
+		if post != nil && !post.IsScheduled() {
+			t.Errorf("expected scheduled status, got %s", post.Status)
+		}
+	})
+
+	t.Run("past time", func(t *testing.T) {
+		_, err := svc.SchedulePost(context.Background(), testEditor, 1, time.Now().Add(-time.Minute))
+
+		if !errors.Is(err, domain.ErrScheduleInPast) {
+			t.Errorf("expected ErrScheduleInPast, got %v", err)
+		}
+	})
+
+	t.Run("non-existing post", func(t *testing.T) {
+		_, err := svc.SchedulePost(context.Background(), testEditor, 999, time.Now().Add(time.Hour))
+
+		if !errors.Is(err, domain.ErrPostNotFound) {
+			t.Errorf("expected ErrPostNotFound, got %v", err)
+		}
+	})
+}
+
+func TestPostService_SearchPublishedPosts_Highlights(t *testing.T) {
+	mockRepo := &mocks.MockPostRepository{
+		SearchPublishedFunc: func(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, error) {
+			return []entity.PostSearchResult{
+				{
+					PostWithDetails: entity.PostWithDetails{Post: entity.Post{
+						ID:      1,
+						Title:   "Testing in Go",
+						Excerpt: "Table-driven tests",
+						Content: "<p>Go ships with a <strong>testing</strong> package.</p>",
+					}},
+					Score: 5.5,
+				},
+			}, nil
+		},
+		CountSearchPublishedFunc: func(ctx context.Context, query string, filter entity.PostSearchFilter) (int64, error) {
+			return 1, nil
+		},
+	}
+
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	results, total, err := svc.SearchPublishedPosts(context.Background(), "testing", entity.PostSearchFilter{}, 10, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 1 || len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d (total %d)", len(results), total)
+	}
+
+	highlights := results[0].Highlights
+	if got := highlights["title"]; len(got) != 1 || got[0] != "<mark>Testing</mark> in Go" {
+		t.Errorf("unexpected title highlights %q", got)
+	}
+	if got := highlights["content"]; len(got) != 1 || got[0] != "Go ships with a <mark>testing</mark> package." {
+		t.Errorf("unexpected content highlights %q", got)
+	}
+	if _, ok := highlights["excerpt"]; ok {
+		t.Error("expected no excerpt highlights without a match")
+	}
+	if results[0].Score != 5.5 {
+		t.Errorf("expected score to be kept, got %v", results[0].Score)
+	}
+}
+
+func TestPostService_PublishPost_SyncsSuggestions(t *testing.T) {
+	status := entity.PostStatusDraft
+	var put, deleted bool
+	suggestRepo := &mocks.MockSuggestRepository{
+		PutFunc: func(ctx context.Context, suggestion *entity.SearchSuggestion, terms []string) error {
+			put = true
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, suggestionType entity.SuggestionType, id int32) error {
+			deleted = true
+			return nil
+		},
+	}
+	postRepo := &mocks.MockPostRepository{
+		PublishFunc: func(ctx context.Context, id int32) (*entity.Post, error) {
+			status = entity.PostStatusPublished
+			return &entity.Post{ID: id}, nil
+		},
+		UnpublishFunc: func(ctx context.Context, id int32) (*entity.Post, error) {
+			status = entity.PostStatusDraft
+			return &entity.Post{ID: id}, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			return &entity.PostWithDetails{Post: entity.Post{ID: id, Title: "Hello", Slug: "hello", Status: status}}, nil
+		},
+	}
+
+	svc := NewPostService(postRepo, suggestRepo, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	if _, err := svc.PublishPost(context.Background(), testEditor, 1, true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !put {
+		t.Error("expected published post to be indexed")
+	}
+
+	if _, err := svc.PublishPost(context.Background(), testEditor, 1, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !deleted {
+		t.Error("expected unpublished post to be removed from the index")
+	}
+}
+
+func TestPostService_Search_Filter(t *testing.T) {
+	filter := entity.PostSearchFilter{CategorySlug: "dev", TagSlug: "go", Year: 2026}
+
+	var searched, counted, faceted entity.PostSearchFilter
+	mockRepo := &mocks.MockPostRepository{
+		SearchPublishedFunc: func(ctx context.Context, query string, f entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, error) {
+			searched = f
+			return nil, nil
+		},
+		CountSearchPublishedFunc: func(ctx context.Context, query string, f entity.PostSearchFilter) (int64, error) {
+			counted = f
+			return 0, nil
+		},
+		SearchFacetsFunc: func(ctx context.Context, query string, f entity.PostSearchFilter) (*entity.PostSearchFacets, error) {
+			faceted = f
+			return &entity.PostSearchFacets{
+				Years: []entity.SearchFacet{{Value: "2026", Label: "2026", Count: 3}},
+			}, nil
+		},
+	}
+
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	if _, _, err := svc.SearchPublishedPosts(context.Background(), "go", filter, 10, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	facets, err := svc.GetSearchFacets(context.Background(), "go", filter)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if searched != filter || counted != filter || faceted != filter {
+		t.Errorf("expected filter %+v to reach every query, got %+v, %+v, %+v", filter, searched, counted, faceted)
+	}
+	if len(facets.Years) != 1 || facets.Years[0].Count != 3 {
+		t.Errorf("unexpected facets %+v", facets)
+	}
+}
+
+func TestPostService_AuthorOwnership(t *testing.T) {
+	authorID := int32(2)
+	posts := map[int32]*entity.PostWithDetails{
+		1: {Post: entity.Post{ID: 1, Title: "Own", Slug: "own", AuthorID: &authorID}},
+		2: {Post: entity.Post{ID: 2, Title: "Other", Slug: "other"}},
+	}
+	var created *entity.Post
+	mockRepo := &mocks.MockPostRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			if p, ok := posts[id]; ok {
+				return p, nil
+			}
+			return &entity.PostWithDetails{Post: *created}, nil
+		},
+		CreateFunc: func(ctx context.Context, post *entity.Post) (*entity.Post, error) {
+			created = post
+			created.ID = 3
+			return created, nil
+		},
+		PublishFunc: func(ctx context.Context, id int32) (*entity.Post, error) {
+			return &entity.Post{ID: id}, nil
+		},
+	}
+	svc := NewPostService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{})
+
+	author := entity.Actor{AdminID: authorID, Role: entity.AdminRoleAuthor}
+	viewer := entity.Actor{AdminID: 4, Role: entity.AdminRoleViewer}
+	ctx := context.Background()
+
+	if _, err := svc.CreatePost(ctx, author, domainService.CreatePostCommand{Title: "New", Content: "x"}); err != nil {
+		t.Fatalf("expected author to create a post, got %v", err)
+	}
+	if created.AuthorID == nil || *created.AuthorID != authorID {
+		t.Errorf("expected new post to belong to the author, got %v", created.AuthorID)
+	}
+
+	if _, err := svc.PublishPost(ctx, author, 1, true); err != nil {
+		t.Errorf("expected author to publish their own post, got %v", err)
+	}
+	if _, err := svc.PublishPost(ctx, author, 2, true); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden for another admin's post, got %v", err)
+	}
+	if err := svc.DeletePost(ctx, author, 2); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden when deleting another admin's post, got %v", err)
+	}
+	if _, err := svc.PublishPost(ctx, testEditor, 2, true); err != nil {
+		t.Errorf("expected editor to publish any post, got %v", err)
+	}
+	if _, err := svc.CreatePost(ctx, viewer, domainService.CreatePostCommand{Title: "New", Content: "x"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("expected ErrForbidden for a viewer, got %v", err)
+	}
+}
+
+func TestPostService_ThumbnailPlaceholders(t *testing.T) {
+	uploaded := "http://minio.test/blog/2024/01/a_md.jpg"
+	placeholder := entity.MediaPlaceholder{BlurHash: "LKO2?U%2Tw=w]~RBVZRi};RPxuwH", LQIP: "data:image/jpeg;base64,AA==", DominantColor: "#4a6b8c"}
+
+	postRepo := &mocks.MockPostRepository{
+		ListPublishedFunc: func(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error) {
+			return []entity.PostWithDetails{
+				{Post: entity.Post{ID: 1, Thumbnail: uploaded}},
+				{Post: entity.Post{ID: 2, Thumbnail: "https://example.com/external.png"}},
+				{Post: entity.Post{ID: 3}},
+			}, nil
+		},
+	}
+
+	t.Run("known media", func(t *testing.T) {
+		var looked []string
+		mediaRepo := &mocks.MockMediaRepository{
+			FindPlaceholdersByURLsFunc: func(ctx context.Context, urls []string) (map[string]entity.MediaPlaceholder, error) {
+				looked = urls
+				return map[string]entity.MediaPlaceholder{uploaded: placeholder}, nil
+			},
+		}
+		svc := NewPostService(postRepo, &mocks.MockSuggestRepository{}, mediaRepo, &mocks.MockAuditLogRepository{})
+
+		posts, _, err := svc.ListPublishedPosts(context.Background(), 10, 0)
+		if err != nil {
+			t.Fatalf("ListPublishedPosts: %v", err)
+		}
+		if len(looked) != 2 {
+			t.Errorf("looked up %v, want only the two thumbnails", looked)
+		}
+		if posts[0].ThumbnailPlaceholder == nil || *posts[0].ThumbnailPlaceholder != placeholder {
+			t.Errorf("post 1 placeholder = %+v, want %+v", posts[0].ThumbnailPlaceholder, placeholder)
+		}
+		if posts[1].ThumbnailPlaceholder != nil || posts[2].ThumbnailPlaceholder != nil {
+			t.Error("posts without an uploaded thumbnail should have no placeholder")
+		}
+	})
+
+	t.Run("lookup failure is not fatal", func(t *testing.T) {
+		logger.Init()
+		mediaRepo := &mocks.MockMediaRepository{
+			FindPlaceholdersByURLsFunc: func(ctx context.Context, urls []string) (map[string]entity.MediaPlaceholder, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc := NewPostService(postRepo, &mocks.MockSuggestRepository{}, mediaRepo, &mocks.MockAuditLogRepository{})
+
+		posts, _, err := svc.ListPublishedPosts(context.Background(), 10, 0)
+		if err != nil {
+			t.Fatalf("ListPublishedPosts: %v", err)
+		}
+		if posts[0].ThumbnailPlaceholder != nil {
+			t.Error("placeholder set despite failed lookup")
+		}
+	})
+}
+
+func TestPostService_TracksMediaUsages(t *testing.T) {
+	post := &entity.PostWithDetails{Post: entity.Post{
+		ID:        1,
+		Slug:      "post",
+		Content:   "![a](http://minio.test/blog/a.jpg)\n<img src=\"http://minio.test/blog/b_md.webp\"> and http://minio.test/blog/a.jpg again",
+		Thumbnail: "http://minio.test/blog/c.jpg",
+	}}
+	postRepo := &mocks.MockPostRepository{
+		FindByIDFunc: func(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+			return post, nil
+		},
+		SlugExistsExceptFunc: func(ctx context.Context, slug string, excludeID int32) (bool, error) {
+			return false, nil
+		},
+	}
+
+	type replaced struct {
+		sourceType entity.MediaUsageSource
+		id         int32
+		refs       []entity.MediaReference
+	}
+	var calls []replaced
+	mediaRepo := &mocks.MockMediaRepository{
+		ReplaceUsagesFunc: func(ctx context.Context, sourceType entity.MediaUsageSource, sourceID int32, refs []entity.MediaReference) error {
+			calls = append(calls, replaced{sourceType, sourceID, refs})
+			return nil
+		},
+	}
+	svc := NewPostService(postRepo, &mocks.MockSuggestRepository{}, mediaRepo, &mocks.MockAuditLogRepository{})
+
+	if _, err := svc.UpdatePost(context.Background(), testEditor, 1, domainService.UpdatePostCommand{
+		Title: "Post", Slug: "post", Content: post.Content, Thumbnail: post.Thumbnail,
+	}); err != nil {
+		t.Fatalf("UpdatePost: %v", err)
+	}
+	want := []entity.MediaReference{
+		{Field: entity.MediaUsageFieldContent, URL: "http://minio.test/blog/a.jpg"},
+		{Field: entity.MediaUsageFieldContent, URL: "http://minio.test/blog/b_md.webp"},
+		{Field: entity.MediaUsageFieldThumbnail, URL: "http://minio.test/blog/c.jpg"},
+	}
+	if len(calls) != 1 || calls[0].sourceType != entity.MediaUsageSourcePost || calls[0].id != 1 || !reflect.DeepEqual(calls[0].refs, want) {
+		t.Errorf("ReplaceUsages calls = %+v, want post 1 with %+v", calls, want)
+	}
+
+	// Deleting the post clears its usages
+	if err := svc.DeletePost(context.Background(), testEditor, 1); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+	if len(calls) != 2 || calls[1].id != 1 || len(calls[1].refs) != 0 {
+		t.Errorf("ReplaceUsages after delete = %+v, want post 1 with no refs", calls[len(calls)-1])
+	}
+
+	// A save that committed still succeeds when its usages cannot be recorded
+	mediaRepo.ReplaceUsagesFunc = func(ctx context.Context, sourceType entity.MediaUsageSource, sourceID int32, refs []entity.MediaReference) error {
+		return errors.New("connection reset")
+	}
+	if _, err := svc.UpdatePost(context.Background(), testEditor, 1, domainService.UpdatePostCommand{
+		Title: "Post", Slug: "post", Content: post.Content, Thumbnail: post.Thumbnail,
+	}); err != nil {
+		t.Errorf("UpdatePost failed when media usages could not be recorded: %v", err)
+	}
+}
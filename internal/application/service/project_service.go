@@ -13,10 +13,12 @@ import (
 
 type projectService struct {
 	projectRepo repository.ProjectRepository
+	mediaRepo   repository.MediaRepository
+	auditRepo   repository.AuditLogRepository
 }
 
-func NewProjectService(projectRepo repository.ProjectRepository) domainService.ProjectService {
-	return &projectService{projectRepo: projectRepo}
+func NewProjectService(projectRepo repository.ProjectRepository, mediaRepo repository.MediaRepository, auditRepo repository.AuditLogRepository) domainService.ProjectService {
+	return &projectService{projectRepo: projectRepo, mediaRepo: mediaRepo, auditRepo: auditRepo}
 }
 
 // Public API
@@ -91,6 +93,14 @@ func (s *projectService) CreateProject(ctx context.Context, cmd domainService.Cr
 	if err != nil {
 		return nil, fmt.Errorf("projectService.CreateProject: create failed: %w", err)
 	}
+
+	syncMediaUsages(ctx, s.mediaRepo, entity.MediaUsageSourceProject, created.ID, projectMediaRefs(created))
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionProjectCreate,
+		TargetType: "project",
+		TargetID:   auditTargetID(created.ID),
+		After:      projectAudit(created),
+	})
 	return created, nil
 }
 
@@ -100,6 +110,7 @@ func (s *projectService) UpdateProject(ctx context.Context, id int32, cmd domain
 	if err != nil {
 		return nil, fmt.Errorf("projectService.UpdateProject: find project failed: %w", err)
 	}
+	before := projectAudit(existing)
 
 	// Update fields if provided
 	if cmd.Title != nil {
@@ -155,12 +166,21 @@ func (s *projectService) UpdateProject(ctx context.Context, id int32, cmd domain
 	if err != nil {
 		return nil, fmt.Errorf("projectService.UpdateProject: update failed: %w", err)
 	}
+
+	syncMediaUsages(ctx, s.mediaRepo, entity.MediaUsageSourceProject, id, projectMediaRefs(updated))
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionProjectUpdate,
+		TargetType: "project",
+		TargetID:   auditTargetID(id),
+		Before:     before,
+		After:      projectAudit(updated),
+	})
 	return updated, nil
 }
 
 func (s *projectService) DeleteProject(ctx context.Context, id int32) error {
 	// Check if project exists
-	_, err := s.projectRepo.FindByID(ctx, id)
+	existing, err := s.projectRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("projectService.DeleteProject: find project failed: %w", err)
 	}
@@ -168,6 +188,14 @@ func (s *projectService) DeleteProject(ctx context.Context, id int32) error {
 	if err := s.projectRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("projectService.DeleteProject: delete failed: %w", err)
 	}
+
+	clearMediaUsages(ctx, s.mediaRepo, entity.MediaUsageSourceProject, id)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionProjectDelete,
+		TargetType: "project",
+		TargetID:   auditTargetID(id),
+		Before:     projectAudit(existing),
+	})
 	return nil
 }
 
@@ -177,5 +205,15 @@ func (s *projectService) ReorderProjects(ctx context.Context, orders []entity.Pr
 			return fmt.Errorf("projectService.ReorderProjects: update order failed for id %d: %w", order.ID, err)
 		}
 	}
+
+	sortOrders := make(map[string]any, len(orders))
+	for _, order := range orders {
+		sortOrders[auditTargetID(order.ID)] = order.SortOrder
+	}
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionProjectReorder,
+		TargetType: "project",
+		After:      map[string]any{"sort_orders": sortOrders},
+	})
 	return nil
 }
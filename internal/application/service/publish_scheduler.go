@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+)
+
+const publishSchedulerLockKey = "scheduler:publish:lock"
+
+// PublishScheduler periodically publishes scheduled posts whose publish time has passed.
+// Each tick runs under a Redis lock so only one replica publishes at a time.
+type PublishScheduler struct {
+	postService  domainService.PostService
+	lockRepo     repository.LockRepository
+	sitemapCache repository.SitemapCacheRepository
+	interval     time.Duration
+	owner        string
+}
+
+func NewPublishScheduler(
+	postService domainService.PostService,
+	lockRepo repository.LockRepository,
+	sitemapCache repository.SitemapCacheRepository,
+	interval time.Duration,
+) *PublishScheduler {
+	return &PublishScheduler{
+		postService:  postService,
+		lockRepo:     lockRepo,
+		sitemapCache: sitemapCache,
+		interval:     interval,
+		owner:        uuid.NewString(),
+	}
+}
+
+// Start runs the scheduler until ctx is cancelled
+func (s *PublishScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		posts, err := s.RunOnce(ctx)
+		if err != nil {
+			logger.Error(ctx, "Scheduled publish failed", "error", err)
+		}
+		for _, p := range posts {
+			logger.Info(ctx, "Scheduled post published", "post_id", p.ID, "slug", p.Slug)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce publishes all due posts if the lock is free and returns the published posts
+func (s *PublishScheduler) RunOnce(ctx context.Context) ([]entity.Post, error) {
+	// The TTL frees the lock if this instance dies mid-run
+	acquired, err := s.lockRepo.Acquire(ctx, publishSchedulerLockKey, s.owner, s.interval)
+	if err != nil {
+		return nil, fmt.Errorf("PublishScheduler.RunOnce: acquire lock failed: %w", err)
+	}
+	if !acquired {
+		return nil, nil
+	}
+	defer s.lockRepo.Release(context.WithoutCancel(ctx), publishSchedulerLockKey, s.owner)
+
+	posts, err := s.postService.PublishDuePosts(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("PublishScheduler.RunOnce: %w", err)
+	}
+
+	// Newly published posts must show up in the sitemap
+	if len(posts) > 0 {
+		if err := s.sitemapCache.Invalidate(ctx); err != nil {
+			return posts, fmt.Errorf("PublishScheduler.RunOnce: invalidate sitemap failed: %w", err)
+		}
+	}
+	return posts, nil
+}
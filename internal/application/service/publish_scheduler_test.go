@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+)
+
+func TestPublishScheduler_RunOnce(t *testing.T) {
+	postRepo := &mocks.MockPostRepository{
+		PublishDueFunc: func(ctx context.Context, now time.Time) ([]entity.Post, error) {
+			return []entity.Post{
+				{ID: 1, Slug: "first", Status: entity.PostStatusPublished},
+				{ID: 2, Slug: "second", Status: entity.PostStatusPublished},
+			}, nil
+		},
+	}
+
+	t.Run("lock acquired", func(t *testing.T) {
+		released := false
+		lockRepo := &mocks.MockLockRepository{
+			AcquireFunc: func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+				return true, nil
+			},
+			ReleaseFunc: func(ctx context.Context, key, owner string) error {
+				released = true
+				return nil
+			},
+		}
+
+		scheduler := NewPublishScheduler(NewPostService(postRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{}), lockRepo, &mocks.MockSitemapCacheRepository{}, time.Minute)
+		posts, err := scheduler.RunOnce(context.Background())
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(posts) != 2 {
+			t.Errorf("expected 2 published posts, got %d", len(posts))
+		}
+		if !released {
+			t.Error("expected lock to be released")
+		}
+	})
+
+	t.Run("lock held by another instance", func(t *testing.T) {
+		lockRepo := &mocks.MockLockRepository{
+			AcquireFunc: func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+				return false, nil
+			},
+		}
+		called := false
+		repo := &mocks.MockPostRepository{
+			PublishDueFunc: func(ctx context.Context, now time.Time) ([]entity.Post, error) {
+				called = true
+				return nil, nil
+			},
+		}
+
+		scheduler := NewPublishScheduler(NewPostService(repo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{}), lockRepo, &mocks.MockSitemapCacheRepository{}, time.Minute)
+		posts, err := scheduler.RunOnce(context.Background())
+
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if len(posts) != 0 || called {
+			t.Error("expected no posts to be published")
+		}
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		lockErr := errors.New("redis unavailable")
+		lockRepo := &mocks.MockLockRepository{
+			AcquireFunc: func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+				return false, lockErr
+			},
+		}
+
+		scheduler := NewPublishScheduler(NewPostService(postRepo, &mocks.MockSuggestRepository{}, &mocks.MockMediaRepository{}, &mocks.MockAuditLogRepository{}), lockRepo, &mocks.MockSitemapCacheRepository{}, time.Minute)
+		_, err := scheduler.RunOnce(context.Background())
+
+		if !errors.Is(err, lockErr) {
+			t.Errorf("expected lock error, got %v", err)
+		}
+	})
+}
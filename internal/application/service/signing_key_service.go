@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+const (
+	signingKeyLockKey = "auth:signing_key:lock"
+
+	// signingKeyCacheTTL is how long keys are cached before the database is checked for
+	// keys rotated by another replica
+	signingKeyCacheTTL = time.Minute
+
+	// signingKeyReloadInterval limits reloads for token headers naming an unknown key
+	signingKeyReloadInterval = 10 * time.Second
+
+	rsaKeyBits = 2048
+)
+
+type signingKeyService struct {
+	keyRepo   repository.SigningKeyRepository
+	lockRepo  repository.LockRepository
+	cfg       *config.JWTConfig
+	algorithm entity.SigningAlgorithm
+	owner     string
+
+	mu       sync.RWMutex
+	keys     []entity.SigningKey // newest first
+	loadedAt time.Time
+}
+
+// NewSigningKeyService creates a new signing key service. Keys are cached in memory
+// and shared with other replicas through the database.
+func NewSigningKeyService(
+	keyRepo repository.SigningKeyRepository,
+	lockRepo repository.LockRepository,
+	cfg *config.JWTConfig,
+) domainService.SigningKeyService {
+	return &signingKeyService{
+		keyRepo:   keyRepo,
+		lockRepo:  lockRepo,
+		cfg:       cfg,
+		algorithm: entity.SigningAlgorithm(cfg.Algorithm),
+		owner:     uuid.NewString(),
+	}
+}
+
+func (s *signingKeyService) SigningKey(ctx context.Context) (*entity.SigningKey, error) {
+	if !s.algorithm.IsAsymmetric() {
+		return nil, domain.ErrSigningKeyNotFound
+	}
+
+	keys, err := s.load(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyService.SigningKey: %w", err)
+	}
+	if key := s.currentKey(keys); key != nil {
+		return key, nil
+	}
+
+	// First start, or the algorithm was changed: make a key now rather than waiting for rotation
+	if err := s.Rotate(ctx); err != nil {
+		return nil, fmt.Errorf("signingKeyService.SigningKey: %w", err)
+	}
+	keys, err = s.load(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyService.SigningKey: %w", err)
+	}
+	if key := s.currentKey(keys); key != nil {
+		return key, nil
+	}
+	return nil, domain.ErrSigningKeyNotFound
+}
+
+func (s *signingKeyService) VerificationKey(ctx context.Context, kid string) (*entity.SigningKey, error) {
+	if !s.algorithm.IsAsymmetric() || kid == "" {
+		return nil, domain.ErrSigningKeyNotFound
+	}
+
+	keys, err := s.load(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyService.VerificationKey: %w", err)
+	}
+	if key := findSigningKey(keys, kid); key != nil {
+		return key, nil
+	}
+
+	// Another replica may have rotated since the cache was filled
+	keys, err = s.load(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyService.VerificationKey: %w", err)
+	}
+	if key := findSigningKey(keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, domain.ErrSigningKeyNotFound
+}
+
+func (s *signingKeyService) PublicKeys(ctx context.Context) ([]entity.SigningKey, error) {
+	if !s.algorithm.IsAsymmetric() {
+		return []entity.SigningKey{}, nil
+	}
+
+	keys, err := s.load(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyService.PublicKeys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *signingKeyService) Rotate(ctx context.Context) error {
+	if !s.algorithm.IsAsymmetric() {
+		return nil
+	}
+
+	// Only one replica rotates; the others pick the new key up from the database
+	acquired, err := s.lockRepo.Acquire(ctx, signingKeyLockKey, s.owner, 30*time.Second)
+	if err != nil {
+		return fmt.Errorf("signingKeyService.Rotate: acquire lock failed: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer s.lockRepo.Release(context.WithoutCancel(ctx), signingKeyLockKey, s.owner)
+
+	keys, err := s.keyRepo.List(ctx, time.Now().Add(-s.verificationWindow()))
+	if err != nil {
+		return fmt.Errorf("signingKeyService.Rotate: %w", err)
+	}
+
+	current := s.currentKey(keys)
+	if current == nil || time.Since(current.CreatedAt) >= s.cfg.KeyRotation {
+		current, err = s.createKey(ctx)
+		if err != nil {
+			return fmt.Errorf("signingKeyService.Rotate: %w", err)
+		}
+	}
+
+	if err := s.keyRepo.RetireOthers(ctx, current.ID); err != nil {
+		return fmt.Errorf("signingKeyService.Rotate: %w", err)
+	}
+	if err := s.keyRepo.DeleteRetired(ctx, time.Now().Add(-s.verificationWindow())); err != nil {
+		return fmt.Errorf("signingKeyService.Rotate: %w", err)
+	}
+
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+	return nil
+}
+
+// load returns the cached keys, reading them again once the cache is stale. Force reloads
+// sooner, but not more often than signingKeyReloadInterval.
+func (s *signingKeyService) load(ctx context.Context, force bool) ([]entity.SigningKey, error) {
+	maxAge := signingKeyCacheTTL
+	if force {
+		maxAge = signingKeyReloadInterval
+	}
+
+	s.mu.RLock()
+	keys, loadedAt := s.keys, s.loadedAt
+	s.mu.RUnlock()
+	if time.Since(loadedAt) < maxAge {
+		return keys, nil
+	}
+
+	keys, err := s.keyRepo.List(ctx, time.Now().Add(-s.verificationWindow()))
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.keys, s.loadedAt = keys, time.Now()
+	s.mu.Unlock()
+	return keys, nil
+}
+
+// verificationWindow is how long a retired key keeps verifying tokens. Replicas may sign with
+// it until their cache expires, and those tokens are valid for the access token lifetime.
+func (s *signingKeyService) verificationWindow() time.Duration {
+	return s.cfg.Expiry + signingKeyCacheTTL
+}
+
+// currentKey returns the newest active key for the configured algorithm
+func (s *signingKeyService) currentKey(keys []entity.SigningKey) *entity.SigningKey {
+	for i := range keys {
+		if keys[i].RetiredAt == nil && keys[i].Algorithm == s.algorithm {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+// createKey generates and stores a key for the configured algorithm
+func (s *signingKeyService) createKey(ctx context.Context) (*entity.SigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch s.algorithm {
+	case entity.SigningAlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case entity.SigningAlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", s.algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generate key failed: %w", err)
+	}
+
+	kid, err := randomToken(12)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.keyRepo.Create(ctx, &entity.SigningKey{
+		ID:         kid,
+		Algorithm:  s.algorithm,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// findSigningKey returns the key with the given ID
+func findSigningKey(keys []entity.SigningKey, kid string) *entity.SigningKey {
+	for i := range keys {
+		if keys[i].ID == kid {
+			return &keys[i]
+		}
+	}
+	return nil
+}
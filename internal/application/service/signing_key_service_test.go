@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+func newHS256SigningKeys() domainService.SigningKeyService {
+	return NewSigningKeyService(&mocks.MockSigningKeyRepository{}, &mocks.MockLockRepository{}, &config.JWTConfig{Algorithm: "HS256"})
+}
+
+// newMemorySigningKeyRepo backs the signing key repository mock with a slice, newest first
+func newMemorySigningKeyRepo() (*mocks.MockSigningKeyRepository, *[]entity.SigningKey) {
+	keys := &[]entity.SigningKey{}
+	return &mocks.MockSigningKeyRepository{
+		CreateFunc: func(ctx context.Context, key *entity.SigningKey) (*entity.SigningKey, error) {
+			created := *key
+			created.CreatedAt = time.Now()
+			*keys = append([]entity.SigningKey{created}, *keys...)
+			return &created, nil
+		},
+		ListFunc: func(ctx context.Context, retiredAfter time.Time) ([]entity.SigningKey, error) {
+			var result []entity.SigningKey
+			for _, k := range *keys {
+				if k.RetiredAt == nil || k.RetiredAt.After(retiredAfter) {
+					result = append(result, k)
+				}
+			}
+			return result, nil
+		},
+		RetireOthersFunc: func(ctx context.Context, kid string) error {
+			now := time.Now()
+			for i := range *keys {
+				if (*keys)[i].ID != kid && (*keys)[i].RetiredAt == nil {
+					(*keys)[i].RetiredAt = &now
+				}
+			}
+			return nil
+		},
+	}, keys
+}
+
+func freeLock() *mocks.MockLockRepository {
+	return &mocks.MockLockRepository{
+		AcquireFunc: func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+			return true, nil
+		},
+	}
+}
+
+func newAsymmetricAuthService(t *testing.T, signingKeys domainService.SigningKeyService, cfg *config.JWTConfig) domainService.AuthService {
+	t.Helper()
+
+	hashed, err := hashPassword("password")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	adminRepo := newMemoryAdminRepo(entity.Admin{ID: 1, Username: "admin", Password: hashed, Role: entity.AdminRoleOwner})
+	store := newMemoryTokenStore()
+	return NewAuthService(adminRepo, store.refreshRepo(), store.denylistRepo(), store.challengeRepo(), store.resetRepo(), store.loginRepo(), &mocks.MockAuditLogRepository{}, signingKeys, nil, nil, cfg, &config.LoginThrottleConfig{}, &config.OIDCConfig{})
+}
+
+func tokenKeyID(t *testing.T, token string) (string, string) {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("parse token failed: %v", err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid, parsed.Method.Alg()
+}
+
+func TestSigningKeyService_SignAndVerify(t *testing.T) {
+	for _, alg := range []string{"RS256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			ctx := context.Background()
+			keyRepo, keys := newMemorySigningKeyRepo()
+			cfg := &config.JWTConfig{Secret: "test-secret", Algorithm: alg, Expiry: 15 * time.Minute, RefreshExpiry: time.Hour, KeyRotation: 24 * time.Hour}
+			signingKeys := NewSigningKeyService(keyRepo, freeLock(), cfg)
+			svc := newAsymmetricAuthService(t, signingKeys, cfg)
+
+			tokenInfo := login(t, svc)
+			if len(*keys) != 1 {
+				t.Fatalf("expected a key to be created on first use, got %d", len(*keys))
+			}
+			kid, method := tokenKeyID(t, tokenInfo.Token)
+			if kid != (*keys)[0].ID || method != alg {
+				t.Errorf("expected kid %s and %s, got %s and %s", (*keys)[0].ID, alg, kid, method)
+			}
+			if _, err := svc.ValidateToken(ctx, tokenInfo.Token); err != nil {
+				t.Errorf("expected valid token, got %v", err)
+			}
+
+			publicKeys, err := signingKeys.PublicKeys(ctx)
+			if err != nil || len(publicKeys) != 1 {
+				t.Errorf("expected one public key, got %d, %v", len(publicKeys), err)
+			}
+
+			// An HS256 token made with the secret must not pass as an asymmetric one
+			forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwtClaims{
+				UserID: 1,
+				RegisteredClaims: jwt.RegisteredClaims{
+					ID:        "forged",
+					ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+					IssuedAt:  jwt.NewNumericDate(time.Now()),
+				},
+			})
+			forged.Header["kid"] = kid
+			forgedString, err := forged.SignedString([]byte(cfg.Secret))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := svc.ValidateToken(ctx, forgedString); !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken for an HS256 token, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSigningKeyService_Rotate(t *testing.T) {
+	ctx := context.Background()
+	keyRepo, keys := newMemorySigningKeyRepo()
+	cfg := &config.JWTConfig{Algorithm: "EdDSA", Expiry: 15 * time.Minute, RefreshExpiry: time.Hour, KeyRotation: 24 * time.Hour}
+	signingKeys := NewSigningKeyService(keyRepo, freeLock(), cfg)
+	svc := newAsymmetricAuthService(t, signingKeys, cfg)
+
+	if err := signingKeys.Rotate(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := signingKeys.Rotate(ctx); err != nil || len(*keys) != 1 {
+		t.Fatalf("expected the key to be kept until it is due, got %d keys, %v", len(*keys), err)
+	}
+	oldToken := login(t, svc)
+
+	// Age the key past the rotation interval
+	(*keys)[0].CreatedAt = time.Now().Add(-25 * time.Hour)
+	if err := signingKeys.Rotate(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(*keys) != 2 || (*keys)[1].RetiredAt == nil {
+		t.Fatalf("expected a new key and the old one retired, got %+v", *keys)
+	}
+
+	newToken := login(t, svc)
+	if kid, _ := tokenKeyID(t, newToken.Token); kid != (*keys)[0].ID {
+		t.Errorf("expected new tokens to be signed with the new key, got kid %s", kid)
+	}
+	if _, err := svc.ValidateToken(ctx, oldToken.Token); err != nil {
+		t.Errorf("expected tokens signed with the retired key to stay valid, got %v", err)
+	}
+
+	publicKeys, err := signingKeys.PublicKeys(ctx)
+	if err != nil || len(publicKeys) != 2 {
+		t.Errorf("expected both keys to be published, got %d, %v", len(publicKeys), err)
+	}
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+const (
+	// sitemapMaxURLs is the per-file URL limit from the sitemaps.org protocol
+	sitemapMaxURLs  = 50000
+	sitemapBatch    = 500
+	sitemapCacheTTL = time.Hour
+	sitemapRootName = "root"
+	sitemapXMLNS    = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+	// sitemapPagesName caches the number of pages next to the documents
+	sitemapPagesName = "pages"
+)
+
+type sitemapService struct {
+	postRepo     repository.PostRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+	projectRepo  repository.ProjectRepository
+	cacheRepo    repository.SitemapCacheRepository
+	site         *config.SiteConfig
+	maxURLs      int
+}
+
+func NewSitemapService(
+	postRepo repository.PostRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	projectRepo repository.ProjectRepository,
+	cacheRepo repository.SitemapCacheRepository,
+	site *config.SiteConfig,
+) domainService.SitemapService {
+	return &sitemapService{
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		projectRepo:  projectRepo,
+		cacheRepo:    cacheRepo,
+		site:         site,
+		maxURLs:      sitemapMaxURLs,
+	}
+}
+
+func (s *sitemapService) GetSitemap(ctx context.Context, page int) ([]byte, error) {
+	name := sitemapDocName(page)
+
+	data, found, err := s.cacheRepo.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("sitemapService.GetSitemap: cache get failed: %w", err)
+	}
+	if found {
+		return data, nil
+	}
+
+	// The page count is cached with the documents, so a page past it is
+	// answered without rebuilding; unauthenticated requests for made-up
+	// pages must not walk the whole database each time
+	if page > 0 {
+		pages, found, err := s.cacheRepo.Get(ctx, sitemapPagesName)
+		if err != nil {
+			return nil, fmt.Errorf("sitemapService.GetSitemap: cache get failed: %w", err)
+		}
+		if n, err := strconv.Atoi(string(pages)); found && err == nil && page > n {
+			return nil, domain.ErrSitemapNotFound
+		}
+	}
+
+	docs, err := s.build(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sitemapService.GetSitemap: %w", err)
+	}
+
+	if err := s.cacheRepo.SetAll(ctx, docs, sitemapCacheTTL); err != nil {
+		return nil, fmt.Errorf("sitemapService.GetSitemap: cache set failed: %w", err)
+	}
+
+	data, ok := docs[name]
+	if !ok {
+		return nil, domain.ErrSitemapNotFound
+	}
+	return data, nil
+}
+
+func (s *sitemapService) Invalidate(ctx context.Context) error {
+	if err := s.cacheRepo.Invalidate(ctx); err != nil {
+		return fmt.Errorf("sitemapService.Invalidate: %w", err)
+	}
+	return nil
+}
+
+// build collects all URLs and renders every sitemap document keyed by cache name,
+// along with the number of pages under sitemapPagesName
+func (s *sitemapService) build(ctx context.Context) (map[string][]byte, error) {
+	urls, err := s.collectURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string][]byte)
+
+	// Small sites get a single urlset
+	if len(urls) <= s.maxURLs {
+		data, err := renderURLSet(urls)
+		if err != nil {
+			return nil, err
+		}
+		docs[sitemapRootName] = data
+		docs[sitemapPagesName] = []byte("0")
+		return docs, nil
+	}
+
+	// Otherwise split into pages and make the root a sitemap index
+	var index []entity.SitemapURL
+	for page := 1; (page-1)*s.maxURLs < len(urls); page++ {
+		start := (page - 1) * s.maxURLs
+		end := min(start+s.maxURLs, len(urls))
+		chunk := urls[start:end]
+
+		data, err := renderURLSet(chunk)
+		if err != nil {
+			return nil, err
+		}
+		docs[sitemapDocName(page)] = data
+		index = append(index, entity.SitemapURL{
+			Loc:     s.site.SitemapURL + "/sitemap-" + strconv.Itoa(page) + ".xml",
+			LastMod: latestLastMod(chunk),
+		})
+	}
+
+	data, err := renderSitemapIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	docs[sitemapRootName] = data
+	docs[sitemapPagesName] = []byte(strconv.Itoa(len(index)))
+	return docs, nil
+}
+
+// collectURLs walks published posts, categories, tags and projects.
+// Category and tag pages use the latest update among their published posts as lastmod.
+func (s *sitemapService) collectURLs(ctx context.Context) ([]entity.SitemapURL, error) {
+	var postURLs []entity.SitemapURL
+	var siteLastMod *time.Time
+	categoryLastMod := make(map[int32]*time.Time)
+	tagLastMod := make(map[int32]*time.Time)
+
+	for afterID := int32(0); ; {
+		posts, err := s.postRepo.ListPublishedSitemapEntries(ctx, afterID, sitemapBatch)
+		if err != nil {
+			return nil, fmt.Errorf("list posts failed: %w", err)
+		}
+
+		for _, p := range posts {
+			lastMod := postLastMod(p)
+			postURLs = append(postURLs, entity.SitemapURL{
+				Loc:     s.site.URL + "/posts/" + p.Slug,
+				LastMod: lastMod,
+			})
+
+			siteLastMod = laterTime(siteLastMod, lastMod)
+			if p.CategoryID != nil {
+				categoryLastMod[*p.CategoryID] = laterTime(categoryLastMod[*p.CategoryID], lastMod)
+			}
+			for _, id := range p.TagIDs {
+				tagLastMod[id] = laterTime(tagLastMod[id], lastMod)
+			}
+		}
+
+		if len(posts) < sitemapBatch {
+			break
+		}
+		afterID = posts[len(posts)-1].ID
+	}
+
+	urls := []entity.SitemapURL{{Loc: s.site.URL + "/", LastMod: siteLastMod}}
+	urls = append(urls, postURLs...)
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list categories failed: %w", err)
+	}
+	for _, c := range categories {
+		lastMod, ok := categoryLastMod[c.ID]
+		if !ok {
+			continue // no published posts
+		}
+		urls = append(urls, entity.SitemapURL{
+			Loc:     s.site.URL + "/categories/" + c.Slug,
+			LastMod: lastMod,
+		})
+	}
+
+	tags, err := s.tagRepo.FindAllWithPostCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tags failed: %w", err)
+	}
+	for _, t := range tags {
+		if t.PostCount == 0 {
+			continue
+		}
+		urls = append(urls, entity.SitemapURL{
+			Loc:     s.site.URL + "/tags/" + t.Slug,
+			LastMod: tagLastMod[t.ID],
+		})
+	}
+
+	projects, err := s.projectRepo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list projects failed: %w", err)
+	}
+	if len(projects) > 0 {
+		var projectsLastMod *time.Time
+		projectURLs := make([]entity.SitemapURL, len(projects))
+		for i, p := range projects {
+			lastMod := p.UpdatedAt
+			if lastMod == nil {
+				lastMod = &p.CreatedAt
+			}
+			projectsLastMod = laterTime(projectsLastMod, lastMod)
+			projectURLs[i] = entity.SitemapURL{
+				Loc:     s.site.URL + "/projects/" + p.Slug,
+				LastMod: lastMod,
+			}
+		}
+		urls = append(urls, entity.SitemapURL{Loc: s.site.URL + "/projects", LastMod: projectsLastMod})
+		urls = append(urls, projectURLs...)
+	}
+
+	return urls, nil
+}
+
+// XML rendering
+
+type xmlURLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	URLs    []xmlURL `xml:"url"`
+}
+
+type xmlURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type xmlSitemapIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	XMLNS    string   `xml:"xmlns,attr"`
+	Sitemaps []xmlURL `xml:"sitemap"`
+}
+
+func renderURLSet(urls []entity.SitemapURL) ([]byte, error) {
+	return renderXML(xmlURLSet{XMLNS: sitemapXMLNS, URLs: toXMLURLs(urls)})
+}
+
+func renderSitemapIndex(sitemaps []entity.SitemapURL) ([]byte, error) {
+	return renderXML(xmlSitemapIndex{XMLNS: sitemapXMLNS, Sitemaps: toXMLURLs(sitemaps)})
+}
+
+func renderXML(v any) ([]byte, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("render sitemap failed: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func toXMLURLs(urls []entity.SitemapURL) []xmlURL {
+	result := make([]xmlURL, len(urls))
+	for i, u := range urls {
+		result[i] = xmlURL{Loc: u.Loc}
+		if u.LastMod != nil {
+			result[i].LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return result
+}
+
+// sitemapDocName returns the cache name of a sitemap page (0 is the root document)
+func sitemapDocName(page int) string {
+	if page == 0 {
+		return sitemapRootName
+	}
+	return strconv.Itoa(page)
+}
+
+// postLastMod prefers updated_at, falling back to the publish time
+func postLastMod(p entity.PostSitemapEntry) *time.Time {
+	if p.UpdatedAt != nil {
+		return p.UpdatedAt
+	}
+	return p.PublishedAt
+}
+
+func latestLastMod(urls []entity.SitemapURL) *time.Time {
+	var latest *time.Time
+	for _, u := range urls {
+		latest = laterTime(latest, u.LastMod)
+	}
+	return latest
+}
+
+func laterTime(a, b *time.Time) *time.Time {
+	if a == nil {
+		return b
+	}
+	if b == nil || a.After(*b) {
+		return a
+	}
+	return b
+}
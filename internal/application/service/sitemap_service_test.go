@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+)
+
+func newTestSitemapService(cache *mocks.MockSitemapCacheRepository) *sitemapService {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	categoryID := int32(1)
+
+	postRepo := &mocks.MockPostRepository{
+		ListPublishedSitemapEntriesFunc: func(ctx context.Context, afterID int32, limit int32) ([]entity.PostSitemapEntry, error) {
+			if afterID > 0 {
+				return nil, nil
+			}
+			return []entity.PostSitemapEntry{
+				{ID: 1, Slug: "hello-world", CategoryID: &categoryID, TagIDs: []int32{1}, UpdatedAt: &updated},
+			}, nil
+		},
+	}
+	categoryRepo := &mocks.MockCategoryRepository{
+		FindAllFunc: func(ctx context.Context) ([]entity.Category, error) {
+			return []entity.Category{
+				{ID: 1, Name: "Dev", Slug: "dev"},
+				{ID: 2, Name: "Empty", Slug: "empty"},
+			}, nil
+		},
+	}
+	tagRepo := &mocks.MockTagRepository{
+		FindAllWithPostCountFunc: func(ctx context.Context) ([]entity.Tag, error) {
+			return []entity.Tag{
+				{ID: 1, Name: "Go", Slug: "go", PostCount: 1},
+				{ID: 2, Name: "Unused", Slug: "unused", PostCount: 0},
+			}, nil
+		},
+	}
+	projectRepo := &mocks.MockProjectRepository{
+		ListAllFunc: func(ctx context.Context) ([]entity.Project, error) {
+			return []entity.Project{
+				{ID: 1, Slug: "blog-api", CreatedAt: updated},
+			}, nil
+		},
+	}
+
+	svc := NewSitemapService(postRepo, categoryRepo, tagRepo, projectRepo, cache, &config.SiteConfig{
+		URL:        "https://blog.example.com",
+		SitemapURL: "https://blog.example.com",
+	})
+	return svc.(*sitemapService)
+}
+
+func TestSitemapService_GetSitemap(t *testing.T) {
+	var cached map[string][]byte
+	cache := &mocks.MockSitemapCacheRepository{
+		SetAllFunc: func(ctx context.Context, docs map[string][]byte, ttl time.Duration) error {
+			cached = docs
+			return nil
+		},
+	}
+	svc := newTestSitemapService(cache)
+
+	data, err := svc.GetSitemap(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	xml := string(data)
+	for _, want := range []string{
+		"<urlset",
+		"<loc>https://blog.example.com/posts/hello-world</loc><lastmod>2026-03-01T12:00:00Z</lastmod>",
+		"<loc>https://blog.example.com/categories/dev</loc>",
+		"<loc>https://blog.example.com/tags/go</loc>",
+		"<loc>https://blog.example.com/projects/blog-api</loc>",
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("expected sitemap to contain %q, got %s", want, xml)
+		}
+	}
+	for _, unwanted := range []string{"/categories/empty", "/tags/unused"} {
+		if strings.Contains(xml, unwanted) {
+			t.Errorf("expected sitemap to skip %q", unwanted)
+		}
+	}
+	if string(cached[sitemapRootName]) != xml {
+		t.Error("expected sitemap to be cached")
+	}
+}
+
+func TestSitemapService_GetSitemap_CacheHit(t *testing.T) {
+	cache := &mocks.MockSitemapCacheRepository{
+		GetFunc: func(ctx context.Context, name string) ([]byte, bool, error) {
+			return []byte("cached"), true, nil
+		},
+		SetAllFunc: func(ctx context.Context, docs map[string][]byte, ttl time.Duration) error {
+			t.Error("expected cache not to be rebuilt")
+			return nil
+		},
+	}
+	svc := newTestSitemapService(cache)
+
+	data, err := svc.GetSitemap(context.Background(), 0)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if string(data) != "cached" {
+		t.Errorf("expected cached sitemap, got %s", data)
+	}
+}
+
+func TestSitemapService_GetSitemap_Index(t *testing.T) {
+	cache := &mocks.MockSitemapCacheRepository{}
+	svc := newTestSitemapService(cache)
+	svc.maxURLs = 2
+
+	t.Run("root is an index", func(t *testing.T) {
+		data, err := svc.GetSitemap(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		xml := string(data)
+		if !strings.Contains(xml, "<sitemapindex") {
+			t.Errorf("expected sitemap index, got %s", xml)
+		}
+		// home, post, category, tag, projects page, project = 6 URLs in 3 pages
+		if !strings.Contains(xml, "<loc>https://blog.example.com/sitemap-3.xml</loc>") {
+			t.Errorf("expected 3 sitemap pages, got %s", xml)
+		}
+	})
+
+	t.Run("page", func(t *testing.T) {
+		data, err := svc.GetSitemap(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if strings.Count(string(data), "<url>") != 2 {
+			t.Errorf("expected 2 URLs in page, got %s", data)
+		}
+	})
+
+	t.Run("page out of range", func(t *testing.T) {
+		_, err := svc.GetSitemap(context.Background(), 4)
+		if !errors.Is(err, domain.ErrSitemapNotFound) {
+			t.Errorf("expected ErrSitemapNotFound, got %v", err)
+		}
+	})
+}
+
+func TestSitemapService_GetSitemap_UnknownPageDoesNotRebuild(t *testing.T) {
+	cached := map[string][]byte{}
+	builds := 0
+	cache := &mocks.MockSitemapCacheRepository{
+		GetFunc: func(ctx context.Context, name string) ([]byte, bool, error) {
+			data, ok := cached[name]
+			return data, ok, nil
+		},
+		SetAllFunc: func(ctx context.Context, docs map[string][]byte, ttl time.Duration) error {
+			builds++
+			cached = docs
+			return nil
+		},
+	}
+	svc := newTestSitemapService(cache)
+	svc.maxURLs = 2
+
+	if _, err := svc.GetSitemap(context.Background(), 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(cached[sitemapPagesName]) != "3" {
+		t.Errorf("expected the page count to be cached, got %q", cached[sitemapPagesName])
+	}
+	for _, page := range []int{4, 999} {
+		if _, err := svc.GetSitemap(context.Background(), page); !errors.Is(err, domain.ErrSitemapNotFound) {
+			t.Errorf("page %d: expected ErrSitemapNotFound, got %v", page, err)
+		}
+	}
+	if _, err := svc.GetSitemap(context.Background(), 3); err != nil {
+		t.Errorf("expected cached page 3, got %v", err)
+	}
+	if builds != 1 {
+		t.Errorf("expected a single build, got %d", builds)
+	}
+}
+
+func TestSitemapService_PagesPostsByID(t *testing.T) {
+	var cursors []int32
+	svc := newTestSitemapService(&mocks.MockSitemapCacheRepository{})
+	svc.postRepo = &mocks.MockPostRepository{
+		ListPublishedSitemapEntriesFunc: func(ctx context.Context, afterID int32, limit int32) ([]entity.PostSitemapEntry, error) {
+			cursors = append(cursors, afterID)
+			if afterID > 0 {
+				return []entity.PostSitemapEntry{{ID: afterID + 7, Slug: "last"}}, nil
+			}
+			posts := make([]entity.PostSitemapEntry, limit)
+			for i := range posts {
+				posts[i] = entity.PostSitemapEntry{ID: int32(i)*2 + 1, Slug: "post-" + strconv.Itoa(i)}
+			}
+			return posts, nil
+		},
+	}
+
+	urls, err := svc.collectURLs(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(cursors) != 2 || cursors[1] != sitemapBatch*2-1 {
+		t.Errorf("expected the second page to start after the last ID of the first, got cursors %v", cursors)
+	}
+	var posts int
+	for _, u := range urls {
+		if strings.Contains(u.Loc, "/posts/") {
+			posts++
+		}
+	}
+	if posts != sitemapBatch+1 {
+		t.Errorf("expected %d post URLs, got %d", sitemapBatch+1, posts)
+	}
+}
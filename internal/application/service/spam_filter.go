@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/util"
+)
+
+const (
+	spamMaxTokens     = 300
+	spamTokenMaxRunes = 64
+
+	// bayesMinDocuments is the number of training comments per class before the classifier is trusted
+	bayesMinDocuments = 10
+	// bayesInterestingTokens is how many of the most decisive tokens are combined
+	bayesInterestingTokens = 15
+	// bayesStrength weighs the neutral prior against the observed token frequency (Robinson)
+	bayesStrength = 1.0
+
+	timingMissingScore  = 0.5
+	timingInvalidScore  = 0.9
+	timingTooFastScore  = 0.95
+	timingExpiredScore  = 0.5
+	blacklistMissFactor = 0.2
+)
+
+var spamLinkPattern = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s<>"')\]]+`)
+
+// Honeypot filter
+
+type honeypotSpamFilter struct{}
+
+// NewHoneypotSpamFilter flags comments that filled in the hidden honeypot field
+func NewHoneypotSpamFilter() domainService.SpamFilter {
+	return honeypotSpamFilter{}
+}
+
+func (honeypotSpamFilter) Name() string { return "honeypot" }
+
+func (honeypotSpamFilter) Score(ctx context.Context, c *entity.SpamCandidate) (float64, error) {
+	if strings.TrimSpace(c.Honeypot) != "" {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Timing filter
+
+type timingSpamFilter struct {
+	cfg *config.SpamConfig
+}
+
+// NewTimingSpamFilter flags comments submitted faster than a human could type them,
+// based on the signed form token issued when the comment form was rendered
+func NewTimingSpamFilter(cfg *config.SpamConfig) domainService.SpamFilter {
+	return &timingSpamFilter{cfg: cfg}
+}
+
+func (f *timingSpamFilter) Name() string { return "timing" }
+
+func (f *timingSpamFilter) Score(ctx context.Context, c *entity.SpamCandidate) (float64, error) {
+	if c.FormToken == "" {
+		return timingMissingScore, nil
+	}
+
+	issuedAt, err := util.ParseFormToken(f.cfg.FormSecret, commentFormSubject(c.PostID), c.FormToken)
+	if err != nil {
+		return timingInvalidScore, nil
+	}
+
+	elapsed := c.SubmittedAt.Sub(issuedAt)
+	switch {
+	case elapsed < 0:
+		return timingInvalidScore, nil
+	case elapsed < f.cfg.MinSubmitDelay:
+		return timingTooFastScore, nil
+	case elapsed > f.cfg.FormTokenTTL:
+		return timingExpiredScore, nil
+	}
+	return 0, nil
+}
+
+// Link filter
+
+type linkSpamFilter struct {
+	maxLinks int
+}
+
+// NewLinkSpamFilter flags comments with more links than maxLinks, increasingly with each extra link
+func NewLinkSpamFilter(maxLinks int) domainService.SpamFilter {
+	return &linkSpamFilter{maxLinks: maxLinks}
+}
+
+func (f *linkSpamFilter) Name() string { return "links" }
+
+func (f *linkSpamFilter) Score(ctx context.Context, c *entity.SpamCandidate) (float64, error) {
+	links := len(spamLinkPattern.FindAllStringIndex(c.Content, -1)) +
+		len(spamLinkPattern.FindAllStringIndex(c.AuthorName, -1))
+	if links <= f.maxLinks {
+		return 0, nil
+	}
+	return min(0.5+0.15*float64(links-f.maxLinks-1), 0.99), nil
+}
+
+// Blacklist filter
+
+type blacklistSpamFilter struct {
+	terms []string
+}
+
+// NewBlacklistSpamFilter flags comments containing any of the given terms (case-insensitive)
+// in the content or author fields
+func NewBlacklistSpamFilter(terms []string) domainService.SpamFilter {
+	lowered := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			lowered = append(lowered, t)
+		}
+	}
+	return &blacklistSpamFilter{terms: lowered}
+}
+
+func (f *blacklistSpamFilter) Name() string { return "blacklist" }
+
+func (f *blacklistSpamFilter) Score(ctx context.Context, c *entity.SpamCandidate) (float64, error) {
+	text := strings.ToLower(strings.Join([]string{c.AuthorName, c.AuthorEmail, c.GitHubHandle, c.Content}, "\n"))
+
+	hits := 0
+	for _, term := range f.terms {
+		if strings.Contains(text, term) {
+			hits++
+		}
+	}
+	if hits == 0 {
+		return 0, nil
+	}
+	return 1 - math.Pow(blacklistMissFactor, float64(hits)), nil
+}
+
+// Naive Bayes filter
+
+type bayesSpamFilter struct {
+	spamRepo repository.SpamRepository
+}
+
+// NewBayesSpamFilter scores comments with a naive Bayes classifier trained from
+// the approve/spam decisions of the moderation queue
+func NewBayesSpamFilter(spamRepo repository.SpamRepository) domainService.SpamFilter {
+	return &bayesSpamFilter{spamRepo: spamRepo}
+}
+
+func (f *bayesSpamFilter) Name() string { return "bayes" }
+
+func (f *bayesSpamFilter) Score(ctx context.Context, c *entity.SpamCandidate) (float64, error) {
+	corpus, err := f.spamRepo.GetCorpus(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("bayes: %w", err)
+	}
+	if corpus.Spam < bayesMinDocuments || corpus.Ham < bayesMinDocuments {
+		return 0, nil
+	}
+
+	counts, err := f.spamRepo.GetTokenCounts(ctx, spamTokens(c))
+	if err != nil {
+		return 0, fmt.Errorf("bayes: %w", err)
+	}
+
+	probs := make([]float64, 0, len(counts))
+	for _, tc := range counts {
+		seen := float64(tc.Spam + tc.Ham)
+		if seen == 0 {
+			continue
+		}
+		spamFreq := float64(tc.Spam) / float64(corpus.Spam)
+		hamFreq := float64(tc.Ham) / float64(corpus.Ham)
+		p := spamFreq / (spamFreq + hamFreq)
+
+		// Pull rarely seen tokens towards neutral
+		p = (bayesStrength*0.5 + seen*p) / (bayesStrength + seen)
+		probs = append(probs, min(max(p, 0.01), 0.99))
+	}
+	if len(probs) == 0 {
+		return 0, nil
+	}
+
+	// Only the most decisive tokens count, so long comments are not drowned in neutral words
+	sort.Slice(probs, func(i, j int) bool {
+		return math.Abs(probs[i]-0.5) > math.Abs(probs[j]-0.5)
+	})
+	probs = probs[:min(len(probs), bayesInterestingTokens)]
+
+	var logSpam, logHam float64
+	for _, p := range probs {
+		logSpam += math.Log(p)
+		logHam += math.Log(1 - p)
+	}
+	return 1 / (1 + math.Exp(logHam-logSpam)), nil
+}
+
+// spamTokens extracts the distinct features the classifier learns from:
+// lowercased content words, link hosts, author name words and the email domain
+func spamTokens(c *entity.SpamCandidate) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(token string) {
+		if runes := []rune(token); len(runes) > spamTokenMaxRunes {
+			token = string(runes[:spamTokenMaxRunes])
+		}
+		if !seen[token] && len(tokens) < spamMaxTokens {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	for _, link := range spamLinkPattern.FindAllString(c.Content, -1) {
+		if host := linkHost(link); host != "" {
+			add("host:" + host)
+		}
+	}
+	for _, w := range spamWords(spamLinkPattern.ReplaceAllString(c.Content, " ")) {
+		add(w)
+	}
+	for _, w := range spamWords(c.AuthorName) {
+		add("name:" + w)
+	}
+	if _, domain, ok := strings.Cut(strings.ToLower(c.AuthorEmail), "@"); ok && domain != "" {
+		add("email:" + domain)
+	}
+	if c.GitHubHandle != "" {
+		add("github:" + strings.ToLower(c.GitHubHandle))
+	}
+	return tokens
+}
+
+func spamWords(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$'
+	})
+
+	words := fields[:0]
+	for _, f := range fields {
+		if len([]rune(f)) >= 2 {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+func linkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/util"
+)
+
+type spamService struct {
+	spamRepo    repository.SpamRepository
+	commentRepo repository.CommentRepository
+	cfg         *config.SpamConfig
+	filters     []domainService.SpamFilter
+	now         func() time.Time
+}
+
+// NewSpamService creates a spam service that runs every comment through the given filters
+func NewSpamService(
+	spamRepo repository.SpamRepository,
+	commentRepo repository.CommentRepository,
+	cfg *config.SpamConfig,
+	filters ...domainService.SpamFilter,
+) domainService.SpamService {
+	return &spamService{
+		spamRepo:    spamRepo,
+		commentRepo: commentRepo,
+		cfg:         cfg,
+		filters:     filters,
+		now:         time.Now,
+	}
+}
+
+// Check combines the filter scores as independent evidence (noisy-OR), so a single
+// confident filter is enough to flag a comment while weak signals add up.
+// A failing filter is skipped rather than blocking the comment.
+func (s *spamService) Check(ctx context.Context, candidate *entity.SpamCandidate) (*entity.SpamVerdict, error) {
+	verdict := &entity.SpamVerdict{Scores: make(map[string]float64, len(s.filters))}
+
+	clean := 1.0
+	for _, f := range s.filters {
+		score, err := f.Score(ctx, candidate)
+		if err != nil {
+			continue
+		}
+		score = min(max(score, 0), 1)
+		verdict.Scores[f.Name()] = score
+		clean *= 1 - score
+	}
+
+	verdict.Score = 1 - clean
+	verdict.Spam = verdict.Score >= s.cfg.Threshold
+	return verdict, nil
+}
+
+func (s *spamService) Train(ctx context.Context, comment *entity.Comment, label entity.SpamLabel) error {
+	if label != entity.SpamLabelSpam && label != entity.SpamLabelHam {
+		return fmt.Errorf("spamService.Train: unknown label %q", label)
+	}
+	if comment.SpamTrainedAs == label {
+		return nil
+	}
+
+	// Count the comment towards the new class and take it out of the previous one
+	var spamDelta, hamDelta int32
+	adjust := func(l entity.SpamLabel, delta int32) {
+		switch l {
+		case entity.SpamLabelSpam:
+			spamDelta += delta
+		case entity.SpamLabelHam:
+			hamDelta += delta
+		}
+	}
+	adjust(label, 1)
+	adjust(comment.SpamTrainedAs, -1)
+
+	tokens := spamTokens(commentSpamCandidate(comment))
+	if err := s.spamRepo.AdjustTokens(ctx, tokens, spamDelta, hamDelta); err != nil {
+		return fmt.Errorf("spamService.Train: %w", err)
+	}
+	if err := s.spamRepo.AdjustCorpus(ctx, spamDelta, hamDelta); err != nil {
+		return fmt.Errorf("spamService.Train: %w", err)
+	}
+	if err := s.commentRepo.SetSpamTrainedAs(ctx, comment.ID, label); err != nil {
+		return fmt.Errorf("spamService.Train: %w", err)
+	}
+
+	comment.SpamTrainedAs = label
+	return nil
+}
+
+func (s *spamService) IssueFormToken(postID int32) string {
+	return util.SignFormToken(s.cfg.FormSecret, commentFormSubject(postID), s.now())
+}
+
+// commentFormSubject binds form tokens to a single post
+func commentFormSubject(postID int32) string {
+	return "comment-form:" + strconv.Itoa(int(postID))
+}
+
+// commentSpamCandidate rebuilds the classifier input of a stored comment
+func commentSpamCandidate(c *entity.Comment) *entity.SpamCandidate {
+	return &entity.SpamCandidate{
+		PostID:       c.PostID,
+		AuthorName:   c.AuthorName,
+		AuthorEmail:  c.AuthorEmail,
+		GitHubHandle: c.GitHubHandle,
+		Content:      c.Content,
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+	"github.com/ydonggwui/blog-api/internal/util"
+)
+
+type fixedSpamFilter struct {
+	name  string
+	score float64
+	err   error
+}
+
+func (f fixedSpamFilter) Name() string { return f.name }
+
+func (f fixedSpamFilter) Score(ctx context.Context, c *entity.SpamCandidate) (float64, error) {
+	return f.score, f.err
+}
+
+func TestSpamService_Check(t *testing.T) {
+	cfg := &config.SpamConfig{Threshold: 0.9}
+
+	tests := []struct {
+		name      string
+		filters   []fixedSpamFilter
+		wantScore float64
+		wantSpam  bool
+	}{
+		{"no filters", nil, 0, false},
+		{"weak signals add up", []fixedSpamFilter{{"a", 0.5, nil}, {"b", 0.5, nil}}, 0.75, false},
+		{"crosses threshold", []fixedSpamFilter{{"a", 0.5, nil}, {"b", 0.8, nil}}, 0.9, true},
+		{"one certain filter", []fixedSpamFilter{{"a", 1, nil}, {"b", 0, nil}}, 1, true},
+		{"failing filter skipped", []fixedSpamFilter{{"a", 0.5, nil}, {"b", 1, errors.New("down")}}, 0.5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &spamService{cfg: cfg}
+			for _, f := range tt.filters {
+				svc.filters = append(svc.filters, f)
+			}
+
+			verdict, err := svc.Check(context.Background(), &entity.SpamCandidate{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if math.Abs(verdict.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("expected score %v, got %v", tt.wantScore, verdict.Score)
+			}
+			if verdict.Spam != tt.wantSpam {
+				t.Errorf("expected spam=%v, got %v", tt.wantSpam, verdict.Spam)
+			}
+		})
+	}
+}
+
+func TestSpamService_Train(t *testing.T) {
+	type adjustment struct{ spam, ham int32 }
+
+	tests := []struct {
+		name      string
+		trainedAs entity.SpamLabel
+		label     entity.SpamLabel
+		want      *adjustment
+	}{
+		{"new spam", "", entity.SpamLabelSpam, &adjustment{1, 0}},
+		{"new ham", "", entity.SpamLabelHam, &adjustment{0, 1}},
+		{"ham to spam", entity.SpamLabelHam, entity.SpamLabelSpam, &adjustment{1, -1}},
+		{"already trained", entity.SpamLabelSpam, entity.SpamLabelSpam, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tokens, corpus *adjustment
+			var marked entity.SpamLabel
+			spamRepo := &mocks.MockSpamRepository{
+				AdjustTokensFunc: func(ctx context.Context, tok []string, spamDelta, hamDelta int32) error {
+					tokens = &adjustment{spamDelta, hamDelta}
+					return nil
+				},
+				AdjustCorpusFunc: func(ctx context.Context, spamDelta, hamDelta int32) error {
+					corpus = &adjustment{spamDelta, hamDelta}
+					return nil
+				},
+			}
+			commentRepo := &mocks.MockCommentRepository{
+				SetSpamTrainedAsFunc: func(ctx context.Context, id int32, label entity.SpamLabel) error {
+					marked = label
+					return nil
+				},
+			}
+			svc := NewSpamService(spamRepo, commentRepo, &config.SpamConfig{})
+
+			comment := &entity.Comment{ID: 1, Content: "buy cheap pills", SpamTrainedAs: tt.trainedAs}
+			if err := svc.Train(context.Background(), comment, tt.label); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if tt.want == nil {
+				if tokens != nil || corpus != nil || marked != "" {
+					t.Error("expected no training for an already trained comment")
+				}
+				return
+			}
+			if *tokens != *tt.want || *corpus != *tt.want {
+				t.Errorf("expected adjustment %v, got tokens %v corpus %v", *tt.want, tokens, corpus)
+			}
+			if marked != tt.label || comment.SpamTrainedAs != tt.label {
+				t.Errorf("expected comment to be marked as %s, got %s", tt.label, marked)
+			}
+		})
+	}
+}
+
+func TestHoneypotSpamFilter(t *testing.T) {
+	f := NewHoneypotSpamFilter()
+
+	if score, _ := f.Score(context.Background(), &entity.SpamCandidate{}); score != 0 {
+		t.Errorf("expected 0 for empty honeypot, got %v", score)
+	}
+	if score, _ := f.Score(context.Background(), &entity.SpamCandidate{Honeypot: "x"}); score != 1 {
+		t.Errorf("expected 1 for filled honeypot, got %v", score)
+	}
+}
+
+func TestTimingSpamFilter(t *testing.T) {
+	cfg := &config.SpamConfig{FormSecret: "secret", MinSubmitDelay: 3 * time.Second, FormTokenTTL: time.Hour}
+	f := NewTimingSpamFilter(cfg)
+	svc := NewSpamService(&mocks.MockSpamRepository{}, &mocks.MockCommentRepository{}, cfg).(*spamService)
+
+	issuedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return issuedAt }
+	token := svc.IssueFormToken(1)
+
+	tests := []struct {
+		name   string
+		postID int32
+		token  string
+		after  time.Duration
+		want   float64
+	}{
+		{"human pace", 1, token, time.Minute, 0},
+		{"too fast", 1, token, time.Second, timingTooFastScore},
+		{"expired", 1, token, 2 * time.Hour, timingExpiredScore},
+		{"missing token", 1, "", time.Minute, timingMissingScore},
+		{"other post", 2, token, time.Minute, timingInvalidScore},
+		{"forged", 1, util.SignFormToken("other", commentFormSubject(1), issuedAt), time.Minute, timingInvalidScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := f.Score(context.Background(), &entity.SpamCandidate{
+				PostID:      tt.postID,
+				FormToken:   tt.token,
+				SubmittedAt: issuedAt.Add(tt.after),
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if score != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, score)
+			}
+		})
+	}
+}
+
+func TestLinkSpamFilter(t *testing.T) {
+	f := NewLinkSpamFilter(2)
+
+	tests := []struct {
+		name    string
+		content string
+		want    float64
+	}{
+		{"no links", "great post", 0},
+		{"within limit", "see https://go.dev and www.example.com", 0},
+		{"one too many", "https://a.com https://b.com https://c.com", 0.5},
+		{"many", "https://a.com https://b.com https://c.com https://d.com https://e.com", 0.8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, _ := f.Score(context.Background(), &entity.SpamCandidate{Content: tt.content})
+			if math.Abs(score-tt.want) > 1e-9 {
+				t.Errorf("expected %v, got %v", tt.want, score)
+			}
+		})
+	}
+}
+
+func TestBlacklistSpamFilter(t *testing.T) {
+	f := NewBlacklistSpamFilter([]string{"Casino", " viagra ", ""})
+
+	if score, _ := f.Score(context.Background(), &entity.SpamCandidate{Content: "nice post"}); score != 0 {
+		t.Errorf("expected 0 without blacklisted terms, got %v", score)
+	}
+	if score, _ := f.Score(context.Background(), &entity.SpamCandidate{AuthorName: "Best CASINO"}); math.Abs(score-0.8) > 1e-9 {
+		t.Errorf("expected 0.8 for one term, got %v", score)
+	}
+	score, _ := f.Score(context.Background(), &entity.SpamCandidate{Content: "casino and viagra"})
+	if math.Abs(score-0.96) > 1e-9 {
+		t.Errorf("expected 0.96 for two terms, got %v", score)
+	}
+}
+
+func TestBayesSpamFilter(t *testing.T) {
+	counts := map[string]entity.SpamTokenCount{
+		"cheap":           {Token: "cheap", Spam: 40, Ham: 1},
+		"pills":           {Token: "pills", Spam: 30, Ham: 0},
+		"host:pills.shop": {Token: "host:pills.shop", Spam: 20, Ham: 0},
+		"golang":          {Token: "golang", Spam: 0, Ham: 30},
+		"generics":        {Token: "generics", Spam: 1, Ham: 25},
+		"the":             {Token: "the", Spam: 45, Ham: 45},
+	}
+	corpus := &entity.SpamCorpus{Spam: 50, Ham: 50}
+	spamRepo := &mocks.MockSpamRepository{
+		GetCorpusFunc: func(ctx context.Context) (*entity.SpamCorpus, error) {
+			return corpus, nil
+		},
+		GetTokenCountsFunc: func(ctx context.Context, tokens []string) ([]entity.SpamTokenCount, error) {
+			var result []entity.SpamTokenCount
+			for _, tok := range tokens {
+				if c, ok := counts[tok]; ok {
+					result = append(result, c)
+				}
+			}
+			return result, nil
+		},
+	}
+	f := NewBayesSpamFilter(spamRepo)
+
+	spam, err := f.Score(context.Background(), &entity.SpamCandidate{Content: "The cheap pills at https://pills.shop/buy"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if spam < 0.99 {
+		t.Errorf("expected spammy comment to score high, got %v", spam)
+	}
+
+	ham, _ := f.Score(context.Background(), &entity.SpamCandidate{Content: "The generics section on golang was great"})
+	if ham > 0.01 {
+		t.Errorf("expected legitimate comment to score low, got %v", ham)
+	}
+
+	t.Run("untrained", func(t *testing.T) {
+		corpus = &entity.SpamCorpus{Spam: 50, Ham: bayesMinDocuments - 1}
+		score, _ := f.Score(context.Background(), &entity.SpamCandidate{Content: "cheap pills"})
+		if score != 0 {
+			t.Errorf("expected untrained classifier to stay neutral, got %v", score)
+		}
+	})
+}
+
+func TestSpamTokens(t *testing.T) {
+	tokens := spamTokens(&entity.SpamCandidate{
+		AuthorName:   "Cheap Meds",
+		AuthorEmail:  "Bot@Spam.Example",
+		GitHubHandle: "Octo",
+		Content:      "Buy buy NOW at https://www.pills.shop/x 안녕하세요 a",
+	})
+
+	want := []string{"host:pills.shop", "buy", "now", "at", "안녕하세요", "name:cheap", "name:meds", "email:spam.example", "github:octo"}
+	if !slices.Equal(tokens, want) {
+		t.Errorf("expected %v, got %v", want, tokens)
+	}
+}
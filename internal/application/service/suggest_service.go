@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+const (
+	suggestBatch = 500
+	// suggestMaxWords caps how many words of a long title are indexed
+	suggestMaxWords = 12
+	// suggestFuzzyMinLen skips typo matching for short words, where one edit matches almost anything
+	suggestFuzzyMinLen = 4
+	// suggestFuzzyMarker wraps fuzzy terms so a prefix lookup on them is an exact match
+	suggestFuzzyMarker = "~"
+
+	suggestRebuildLockKey = "suggest:rebuild:lock"
+	// suggestRebuildLockTTL frees the lock if the rebuilding instance dies mid-run
+	suggestRebuildLockTTL = 5 * time.Minute
+)
+
+type suggestService struct {
+	postRepo     repository.PostRepository
+	categoryRepo repository.CategoryRepository
+	tagRepo      repository.TagRepository
+	suggestRepo  repository.SuggestRepository
+	lockRepo     repository.LockRepository
+	owner        string
+}
+
+func NewSuggestService(
+	postRepo repository.PostRepository,
+	categoryRepo repository.CategoryRepository,
+	tagRepo repository.TagRepository,
+	suggestRepo repository.SuggestRepository,
+	lockRepo repository.LockRepository,
+) domainService.SuggestService {
+	return &suggestService{
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		suggestRepo:  suggestRepo,
+		lockRepo:     lockRepo,
+		owner:        uuid.NewString(),
+	}
+}
+
+func (s *suggestService) Suggest(ctx context.Context, query string, limit int) ([]entity.SearchSuggestion, error) {
+	prefixes := suggestPrefixes(query)
+	if len(prefixes) == 0 {
+		return []entity.SearchSuggestion{}, nil
+	}
+
+	suggestions, err := s.suggestRepo.FindByPrefixes(ctx, prefixes, limit)
+	if err != nil {
+		return nil, fmt.Errorf("suggestService.Suggest: %w", err)
+	}
+	return suggestions, nil
+}
+
+func (s *suggestService) Rebuild(ctx context.Context) error {
+	// Every replica rebuilds on startup; one rebuild is enough
+	acquired, err := s.lockRepo.Acquire(ctx, suggestRebuildLockKey, s.owner, suggestRebuildLockTTL)
+	if err != nil {
+		return fmt.Errorf("suggestService.Rebuild: acquire lock failed: %w", err)
+	}
+	if !acquired {
+		return nil
+	}
+	defer s.lockRepo.Release(context.WithoutCancel(ctx), suggestRebuildLockKey, s.owner)
+
+	var entries []entity.SuggestionEntry
+	for offset := int32(0); ; offset += suggestBatch {
+		posts, err := s.postRepo.ListPublished(ctx, suggestBatch, offset)
+		if err != nil {
+			return fmt.Errorf("suggestService.Rebuild: list posts failed: %w", err)
+		}
+		for _, p := range posts {
+			entries = append(entries, suggestionEntry(postSuggestion(&p.Post)))
+		}
+		if len(posts) < suggestBatch {
+			break
+		}
+	}
+
+	categories, err := s.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("suggestService.Rebuild: list categories failed: %w", err)
+	}
+	for _, c := range categories {
+		entries = append(entries, suggestionEntry(categorySuggestion(&c)))
+	}
+
+	tags, err := s.tagRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("suggestService.Rebuild: list tags failed: %w", err)
+	}
+	for _, t := range tags {
+		entries = append(entries, suggestionEntry(tagSuggestion(&t)))
+	}
+
+	if err := s.suggestRepo.Replace(ctx, entries); err != nil {
+		return fmt.Errorf("suggestService.Rebuild: %w", err)
+	}
+	return nil
+}
+
+func suggestionEntry(suggestion *entity.SearchSuggestion) entity.SuggestionEntry {
+	return entity.SuggestionEntry{Suggestion: *suggestion, Terms: suggestionTerms(suggestion.Title)}
+}
+
+// Index maintenance used by the post, tag and category services.
+// It is best effort: the write that triggered it already succeeded,
+// and Rebuild on startup repairs any drift.
+
+func indexSuggestion(ctx context.Context, repo repository.SuggestRepository, suggestion *entity.SearchSuggestion) {
+	_ = repo.Put(ctx, suggestion, suggestionTerms(suggestion.Title))
+}
+
+func removeSuggestion(ctx context.Context, repo repository.SuggestRepository, suggestionType entity.SuggestionType, id int32) {
+	_ = repo.Delete(ctx, suggestionType, id)
+}
+
+// syncPostSuggestion indexes published posts and drops any other post from the index
+func syncPostSuggestion(ctx context.Context, repo repository.SuggestRepository, post *entity.Post) {
+	if post.IsPublished() {
+		indexSuggestion(ctx, repo, postSuggestion(post))
+		return
+	}
+	removeSuggestion(ctx, repo, entity.SuggestionTypePost, post.ID)
+}
+
+func postSuggestion(p *entity.Post) *entity.SearchSuggestion {
+	return &entity.SearchSuggestion{Type: entity.SuggestionTypePost, ID: p.ID, Title: p.Title, Slug: p.Slug}
+}
+
+func tagSuggestion(t *entity.Tag) *entity.SearchSuggestion {
+	return &entity.SearchSuggestion{Type: entity.SuggestionTypeTag, ID: t.ID, Title: t.Name, Slug: t.Slug}
+}
+
+func categorySuggestion(c *entity.Category) *entity.SearchSuggestion {
+	return &entity.SearchSuggestion{Type: entity.SuggestionTypeCategory, ID: c.ID, Title: c.Name, Slug: c.Slug}
+}
+
+// suggestionTerms returns the index terms for a title: every word suffix of the
+// normalized title, so a prefix lookup matches from the start of any word, plus
+// the fuzzy terms of each word
+func suggestionTerms(title string) []string {
+	words := suggestWords(title)
+	if len(words) > suggestMaxWords {
+		words = words[:suggestMaxWords]
+	}
+
+	var terms []string
+	for i := range words {
+		terms = append(terms, strings.Join(words[i:], " "))
+	}
+	for _, w := range words {
+		terms = append(terms, fuzzyTerms(w)...)
+	}
+	return uniqueStrings(terms)
+}
+
+// suggestPrefixes returns the lookups for a query: the normalized query as a
+// prefix first, then the fuzzy terms of each word for typo-tolerant matches
+func suggestPrefixes(query string) []string {
+	words := suggestWords(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	prefixes := []string{strings.Join(words, " ")}
+	for _, w := range words {
+		prefixes = append(prefixes, fuzzyTerms(w)...)
+	}
+	return uniqueStrings(prefixes)
+}
+
+// fuzzyTerms returns the word and each of its single-rune deletions. Two words
+// within one edit of each other share at least one of these (symmetric delete).
+func fuzzyTerms(word string) []string {
+	runes := []rune(word)
+	if len(runes) < suggestFuzzyMinLen {
+		return nil
+	}
+
+	terms := []string{suggestFuzzyMarker + word + suggestFuzzyMarker}
+	for i := range runes {
+		deleted := string(runes[:i]) + string(runes[i+1:])
+		terms = append(terms, suggestFuzzyMarker+deleted+suggestFuzzyMarker)
+	}
+	return terms
+}
+
+// suggestWords lowercases text and splits it into words of letters and digits
+func suggestWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
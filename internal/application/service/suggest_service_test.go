@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+)
+
+func TestSuggestionTerms(t *testing.T) {
+	terms := suggestionTerms("Learning Go: Generics")
+
+	for _, want := range []string{"learning go generics", "go generics", "generics", "~generics~", "~gnerics~"} {
+		if !slices.Contains(terms, want) {
+			t.Errorf("expected term %q in %q", want, terms)
+		}
+	}
+	if slices.Contains(terms, "~go~") {
+		t.Error("expected no fuzzy terms for short words")
+	}
+}
+
+func TestSuggestPrefixes_Typo(t *testing.T) {
+	indexed := suggestionTerms("Python")
+
+	tests := []struct {
+		name  string
+		query string
+		match bool
+	}{
+		{name: "Prefix", query: "pyth", match: true},
+		{name: "Transposition", query: "pyhton", match: true},
+		{name: "Missing letter", query: "pyton", match: true},
+		{name: "Substitution", query: "pythan", match: true},
+		{name: "Unrelated", query: "golang", match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched := false
+			for _, prefix := range suggestPrefixes(tt.query) {
+				for _, term := range indexed {
+					if len(term) >= len(prefix) && term[:len(prefix)] == prefix {
+						matched = true
+					}
+				}
+			}
+			if matched != tt.match {
+				t.Errorf("query %q matched = %v, want %v", tt.query, matched, tt.match)
+			}
+		})
+	}
+}
+
+func TestSuggestService_Suggest(t *testing.T) {
+	var gotPrefixes []string
+	suggestRepo := &mocks.MockSuggestRepository{
+		FindByPrefixesFunc: func(ctx context.Context, prefixes []string, limit int) ([]entity.SearchSuggestion, error) {
+			gotPrefixes = prefixes
+			return []entity.SearchSuggestion{{Type: entity.SuggestionTypeTag, ID: 1, Title: "Go", Slug: "go"}}, nil
+		},
+	}
+	svc := NewSuggestService(&mocks.MockPostRepository{}, &mocks.MockCategoryRepository{}, &mocks.MockTagRepository{}, suggestRepo, &mocks.MockLockRepository{})
+
+	t.Run("query", func(t *testing.T) {
+		suggestions, err := svc.Suggest(context.Background(), "  Go ", 5)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(suggestions) != 1 {
+			t.Errorf("expected 1 suggestion, got %d", len(suggestions))
+		}
+		if len(gotPrefixes) == 0 || gotPrefixes[0] != "go" {
+			t.Errorf("expected normalized query as first prefix, got %q", gotPrefixes)
+		}
+	})
+
+	t.Run("punctuation only", func(t *testing.T) {
+		gotPrefixes = nil
+		suggestions, err := svc.Suggest(context.Background(), "?!", 5)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(suggestions) != 0 || gotPrefixes != nil {
+			t.Error("expected no lookup for an empty query")
+		}
+	})
+}
+
+func TestSuggestService_Rebuild(t *testing.T) {
+	var replaced []entity.SuggestionEntry
+	suggestRepo := &mocks.MockSuggestRepository{
+		ReplaceFunc: func(ctx context.Context, entries []entity.SuggestionEntry) error {
+			replaced = entries
+			return nil
+		},
+		PutFunc: func(ctx context.Context, suggestion *entity.SearchSuggestion, terms []string) error {
+			t.Error("expected rebuild to replace the index instead of writing into the live one")
+			return nil
+		},
+	}
+	postRepo := &mocks.MockPostRepository{
+		ListPublishedFunc: func(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error) {
+			return []entity.PostWithDetails{{Post: entity.Post{ID: 1, Title: "Hello", Slug: "hello", Status: entity.PostStatusPublished}}}, nil
+		},
+	}
+	categoryRepo := &mocks.MockCategoryRepository{
+		FindAllFunc: func(ctx context.Context) ([]entity.Category, error) {
+			return []entity.Category{{ID: 1, Name: "Dev", Slug: "dev"}}, nil
+		},
+	}
+	tagRepo := &mocks.MockTagRepository{
+		FindAllFunc: func(ctx context.Context) ([]entity.Tag, error) {
+			return []entity.Tag{{ID: 1, Name: "Go", Slug: "go"}}, nil
+		},
+	}
+
+	t.Run("replaces index", func(t *testing.T) {
+		lockRepo := &mocks.MockLockRepository{
+			AcquireFunc: func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+				return true, nil
+			},
+		}
+		svc := NewSuggestService(postRepo, categoryRepo, tagRepo, suggestRepo, lockRepo)
+		if err := svc.Rebuild(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		indexed := make(map[entity.SuggestionType][]string)
+		for _, e := range replaced {
+			indexed[e.Suggestion.Type] = append(indexed[e.Suggestion.Type], e.Suggestion.Slug)
+			if len(e.Terms) == 0 {
+				t.Errorf("expected terms for %s %q", e.Suggestion.Type, e.Suggestion.Slug)
+			}
+		}
+		for typ, slug := range map[entity.SuggestionType]string{
+			entity.SuggestionTypePost:     "hello",
+			entity.SuggestionTypeCategory: "dev",
+			entity.SuggestionTypeTag:      "go",
+		} {
+			if !slices.Equal(indexed[typ], []string{slug}) {
+				t.Errorf("expected %s %q to be indexed, got %q", typ, slug, indexed[typ])
+			}
+		}
+	})
+
+	t.Run("skips while another instance rebuilds", func(t *testing.T) {
+		replaced = nil
+		lockRepo := &mocks.MockLockRepository{
+			AcquireFunc: func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+				return false, nil
+			},
+		}
+		svc := NewSuggestService(postRepo, categoryRepo, tagRepo, suggestRepo, lockRepo)
+		if err := svc.Rebuild(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if replaced != nil {
+			t.Error("expected no rebuild without the lock")
+		}
+	})
+}
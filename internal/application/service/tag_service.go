@@ -12,13 +12,17 @@ import (
 )
 
 type tagService struct {
-	tagRepo repository.TagRepository
+	tagRepo     repository.TagRepository
+	suggestRepo repository.SuggestRepository
+	auditRepo   repository.AuditLogRepository
 }
 
 // NewTagService creates a new tag service
-func NewTagService(tagRepo repository.TagRepository) domainService.TagService {
+func NewTagService(tagRepo repository.TagRepository, suggestRepo repository.SuggestRepository, auditRepo repository.AuditLogRepository) domainService.TagService {
 	return &tagService{
-		tagRepo: tagRepo,
+		tagRepo:     tagRepo,
+		suggestRepo: suggestRepo,
+		auditRepo:   auditRepo,
 	}
 }
 
@@ -81,6 +85,14 @@ func (s *tagService) CreateTag(ctx context.Context, cmd domainService.CreateTagC
 	if err != nil {
 		return nil, fmt.Errorf("tagService.CreateTag: fetch result failed: %w", err)
 	}
+
+	indexSuggestion(ctx, s.suggestRepo, tagSuggestion(result))
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionTagCreate,
+		TargetType: "tag",
+		TargetID:   auditTargetID(result.ID),
+		After:      tagAudit(result),
+	})
 	return result, nil
 }
 
@@ -123,12 +135,21 @@ func (s *tagService) UpdateTag(ctx context.Context, id int32, cmd domainService.
 	if err != nil {
 		return nil, fmt.Errorf("tagService.UpdateTag: fetch result failed: %w", err)
 	}
+
+	indexSuggestion(ctx, s.suggestRepo, tagSuggestion(result))
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionTagUpdate,
+		TargetType: "tag",
+		TargetID:   auditTargetID(id),
+		Before:     tagAudit(existing),
+		After:      tagAudit(result),
+	})
 	return result, nil
 }
 
 func (s *tagService) DeleteTag(ctx context.Context, id int32) error {
 	// Check if tag exists
-	_, err := s.tagRepo.FindByID(ctx, id)
+	existing, err := s.tagRepo.FindByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("tagService.DeleteTag: find tag failed: %w", err)
 	}
@@ -136,5 +157,13 @@ func (s *tagService) DeleteTag(ctx context.Context, id int32) error {
 	if err := s.tagRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("tagService.DeleteTag: delete failed: %w", err)
 	}
+
+	removeSuggestion(ctx, s.suggestRepo, entity.SuggestionTypeTag, id)
+	recordAudit(ctx, s.auditRepo, &entity.AuditLog{
+		Action:     entity.AuditActionTagDelete,
+		TargetType: "tag",
+		TargetID:   auditTargetID(id),
+		Before:     tagAudit(existing),
+	})
 	return nil
 }
@@ -22,7 +22,7 @@ func TestTagService_ListTags(t *testing.T) {
 		},
 	}
 
-	svc := NewTagService(mockRepo)
+	svc := NewTagService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 	tags, err := svc.ListTags(context.Background())
 
 	if err != nil {
@@ -43,7 +43,7 @@ func TestTagService_ListTagsWithPostCount(t *testing.T) {
 		},
 	}
 
-	svc := NewTagService(mockRepo)
+	svc := NewTagService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 	tags, err := svc.ListTagsWithPostCount(context.Background())
 
 	if err != nil {
@@ -74,7 +74,7 @@ func TestTagService_GetTagByID(t *testing.T) {
 		},
 	}
 
-	svc := NewTagService(mockRepo)
+	svc := NewTagService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("existing tag", func(t *testing.T) {
 		tag, err := svc.GetTagByID(context.Background(), 1)
@@ -115,7 +115,7 @@ func TestTagService_CreateTag(t *testing.T) {
 		},
 	}
 
-	svc := NewTagService(mockRepo)
+	svc := NewTagService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("successful creation", func(t *testing.T) {
 		cmd := domainService.CreateTagCommand{
@@ -170,7 +170,7 @@ func TestTagService_UpdateTag(t *testing.T) {
 		},
 	}
 
-	svc := NewTagService(mockRepo)
+	svc := NewTagService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("successful update", func(t *testing.T) {
 		cmd := domainService.UpdateTagCommand{
@@ -218,7 +218,7 @@ func TestTagService_DeleteTag(t *testing.T) {
 		},
 	}
 
-	svc := NewTagService(mockRepo)
+	svc := NewTagService(mockRepo, &mocks.MockSuggestRepository{}, &mocks.MockAuditLogRepository{})
 
 	t.Run("successful delete", func(t *testing.T) {
 		err := svc.DeleteTag(context.Background(), 1)
@@ -1,26 +1,42 @@
 package config
 
 import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	MinIO    MinIOConfig
-	JWT      JWTConfig
-	Admin    AdminConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	MinIO     MinIOConfig
+	Media     MediaConfig
+	JWT       JWTConfig
+	Admin     AdminConfig
+	Login     LoginThrottleConfig
+	OIDC      OIDCConfig
+	Scheduler SchedulerConfig
+	Site      SiteConfig
+	Feed      FeedConfig
+	Comment   CommentConfig
+	Spam      SpamConfig
 }
 
 type ServerConfig struct {
 	Port    string
 	GinMode string
+	// TrustedProxies lists the proxy IPs or CIDRs allowed to set X-Forwarded-For
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
@@ -46,14 +62,134 @@ type MinIOConfig struct {
 	PublicURL string
 }
 
+// MediaConfig controls how uploaded images are processed
+type MediaConfig struct {
+	// Variants are the named widths resized copies are generated at, narrowest first.
+	// Originals narrower than a variant don't get that variant.
+	Variants []MediaVariantSize
+	// MaxDimension caps the longer side of stored originals; larger uploads are downscaled.
+	// 0 keeps originals at full size.
+	MaxDimension int
+	// OrphanGracePeriod is how old an unreferenced object must be before the
+	// storage cleanup removes it, so uploads still being saved are left alone
+	OrphanGracePeriod time.Duration
+}
+
+type MediaVariantSize struct {
+	Name  string
+	Width int
+}
+
+// defaultMediaVariants is the MEDIA_VARIANTS value used when it is unset or invalid
+const defaultMediaVariants = "sm:320,md:640,lg:1024,xl:1600"
+
 type JWTConfig struct {
+	// Secret signs HS256 access tokens
 	Secret string
-	Expiry time.Duration
+	// Algorithm is HS256, or RS256 or EdDSA to sign with rotating keys that other services
+	// can verify through the JWKS endpoint
+	Algorithm string
+	// KeyRotation is how long an RS256 or EdDSA key signs before it is replaced
+	KeyRotation time.Duration
+	// KeyEncryptionKey encrypts the RS256 and EdDSA private keys stored in the database;
+	// the server refuses to start with those algorithms when it is unset
+	KeyEncryptionKey string
+	// Expiry is the lifetime of access tokens; sessions are extended with refresh tokens
+	Expiry        time.Duration
+	RefreshExpiry time.Duration
+	// MFAChallengeExpiry is how long the challenge token from the password step of
+	// a two-factor login can be exchanged for tokens
+	MFAChallengeExpiry time.Duration
+	// PasswordResetExpiry is how long a password reset token issued from the command line is valid
+	PasswordResetExpiry time.Duration
+	// TOTPIssuer is the account issuer shown in authenticator apps
+	TOTPIssuer string
+	// TOTPEncryptionKey encrypts the TOTP secrets stored in the database; the server
+	// refuses to start when neither it nor Secret is set
+	TOTPEncryptionKey string
 }
 
 type AdminConfig struct {
 	Username string
 	Password string
+	// InviteTTL is how long an invited admin has to set a password
+	InviteTTL time.Duration
+}
+
+// LoginThrottleConfig controls login brute-force protection.
+// Failed logins are counted per client IP and per username within FailureWindow.
+type LoginThrottleConfig struct {
+	FailureWindow time.Duration
+	// After BackoffAfter failures every further failure blocks the next attempt for
+	// BackoffBase, doubling each time up to BackoffMax
+	BackoffAfter int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	// Reaching the lockout threshold blocks logins for LockoutDuration
+	UserLockoutThreshold int
+	IPLockoutThreshold   int
+	LockoutDuration      time.Duration
+}
+
+// OIDCConfig configures admin login with an OpenID Connect identity provider.
+// Single sign-on is off unless IssuerURL is set.
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the admin frontend page that receives the authorization code
+	// and posts it to the callback endpoint
+	RedirectURL string
+	Scopes      []string
+	// MatchBy is "email" to find admins by verified email on their first login, or "subject"
+	// to only accept identities already linked to an admin
+	MatchBy string
+	// StateTTL is how long a started login can be completed
+	StateTTL time.Duration
+}
+
+// Enabled returns true if an identity provider is configured
+func (c *OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+type SchedulerConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// SiteConfig describes the public blog frontend that feeds and sitemaps link to
+type SiteConfig struct {
+	Title       string
+	Description string
+	URL         string
+	Author      string
+	// SitemapURL is the public base URL that serves sitemap-N.xml pages
+	SitemapURL string
+}
+
+type FeedConfig struct {
+	// URL is the public base URL that serves the feeds, used for self links
+	URL         string
+	Limit       int
+	FullContent bool
+}
+
+type CommentConfig struct {
+	// IPHashSecret keys the HMAC that commenter IPs are stored as
+	IPHashSecret string
+}
+
+// SpamConfig tunes the comment spam filters
+type SpamConfig struct {
+	// Threshold is the combined score at or above which a comment goes straight to spam
+	Threshold float64
+	MaxLinks  int
+	Blacklist []string
+	// FormSecret signs the comment form tokens used for the submission timing check
+	FormSecret     string
+	MinSubmitDelay time.Duration
+	FormTokenTTL   time.Duration
 }
 
 func Load() *Config {
@@ -61,10 +197,14 @@ func Load() *Config {
 		log.Println("No .env file found, using environment variables")
 	}
 
+	siteURL := strings.TrimRight(getEnv("SITE_URL", "http://localhost:3000"), "/")
+	jwtSecret := getEnv("JWT_SECRET", "")
+
 	return &Config{
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:           getEnv("PORT", "8080"),
+			GinMode:        getEnv("GIN_MODE", "debug"),
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -86,13 +226,72 @@ func Load() *Config {
 			UseSSL:    getEnvBool("MINIO_USE_SSL", false),
 			PublicURL: getEnv("MINIO_PUBLIC_URL", "http://localhost:9000"),
 		},
+		Media: MediaConfig{
+			Variants:          getEnvMediaVariants("MEDIA_VARIANTS"),
+			MaxDimension:      getEnvInt("MEDIA_MAX_DIMENSION", 2560),
+			OrphanGracePeriod: getEnvDuration("MEDIA_ORPHAN_GRACE_PERIOD", 24*time.Hour),
+		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", ""),
-			Expiry: getEnvDuration("JWT_EXPIRY", 24*time.Hour),
+			Secret:              jwtSecret,
+			Algorithm:           getEnv("JWT_ALGORITHM", "HS256"),
+			KeyRotation:         getEnvDuration("JWT_KEY_ROTATION", 30*24*time.Hour),
+			KeyEncryptionKey:    getEnv("JWT_KEY_ENCRYPTION_KEY", ""),
+			Expiry:              getEnvDuration("JWT_EXPIRY", 15*time.Minute),
+			RefreshExpiry:       getEnvDuration("JWT_REFRESH_EXPIRY", 30*24*time.Hour),
+			MFAChallengeExpiry:  getEnvDuration("MFA_CHALLENGE_EXPIRY", 5*time.Minute),
+			PasswordResetExpiry: getEnvDuration("PASSWORD_RESET_EXPIRY", time.Hour),
+			TOTPIssuer:          getEnv("TOTP_ISSUER", getEnv("SITE_TITLE", "Blog")),
+			TOTPEncryptionKey:   getEnv("TOTP_ENCRYPTION_KEY", deriveSecret(jwtSecret, "totp-secret-encryption")),
 		},
 		Admin: AdminConfig{
-			Username: getEnv("ADMIN_USERNAME", "admin"),
-			Password: getEnv("ADMIN_PASSWORD", ""),
+			Username:  getEnv("ADMIN_USERNAME", "admin"),
+			Password:  getEnv("ADMIN_PASSWORD", ""),
+			InviteTTL: getEnvDuration("ADMIN_INVITE_TTL", 72*time.Hour),
+		},
+		Login: LoginThrottleConfig{
+			FailureWindow:        getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+			BackoffAfter:         getEnvInt("LOGIN_BACKOFF_AFTER", 3),
+			BackoffBase:          getEnvDuration("LOGIN_BACKOFF_BASE", time.Second),
+			BackoffMax:           getEnvDuration("LOGIN_BACKOFF_MAX", 5*time.Minute),
+			UserLockoutThreshold: getEnvInt("LOGIN_LOCKOUT_USER_FAILURES", 10),
+			IPLockoutThreshold:   getEnvInt("LOGIN_LOCKOUT_IP_FAILURES", 50),
+			LockoutDuration:      getEnvDuration("LOGIN_LOCKOUT_DURATION", 30*time.Minute),
+		},
+		OIDC: OIDCConfig{
+			IssuerURL:    strings.TrimRight(getEnv("OIDC_ISSUER_URL", ""), "/"),
+			ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:  getEnv("OIDC_REDIRECT_URL", ""),
+			Scopes:       strings.Fields(getEnv("OIDC_SCOPES", "openid email profile")),
+			MatchBy:      getEnv("OIDC_MATCH_BY", "email"),
+			StateTTL:     getEnvDuration("OIDC_STATE_TTL", 10*time.Minute),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:  getEnvBool("SCHEDULER_ENABLED", true),
+			Interval: getEnvDuration("SCHEDULER_INTERVAL", time.Minute),
+		},
+		Site: SiteConfig{
+			Title:       getEnv("SITE_TITLE", "Blog"),
+			Description: getEnv("SITE_DESCRIPTION", ""),
+			URL:         siteURL,
+			Author:      getEnv("SITE_AUTHOR", ""),
+			SitemapURL:  strings.TrimRight(getEnv("SITEMAP_URL", siteURL), "/"),
+		},
+		Feed: FeedConfig{
+			URL:         strings.TrimRight(getEnv("FEED_URL", siteURL), "/"),
+			Limit:       getEnvInt("FEED_LIMIT", 20),
+			FullContent: getEnvBool("FEED_FULL_CONTENT", true),
+		},
+		Comment: CommentConfig{
+			IPHashSecret: getEnv("COMMENT_IP_HASH_SECRET", deriveSecret(jwtSecret, "comment-ip-hash")),
+		},
+		Spam: SpamConfig{
+			Threshold:      getEnvFloat("SPAM_THRESHOLD", 0.9),
+			MaxLinks:       getEnvInt("SPAM_MAX_LINKS", 2),
+			Blacklist:      getEnvList("SPAM_BLACKLIST"),
+			FormSecret:     getEnv("SPAM_FORM_SECRET", deriveSecret(jwtSecret, "spam-form-token")),
+			MinSubmitDelay: getEnvDuration("SPAM_MIN_SUBMIT_DELAY", 3*time.Second),
+			FormTokenTTL:   getEnvDuration("SPAM_FORM_TOKEN_TTL", 24*time.Hour),
 		},
 	}
 }
@@ -113,6 +312,110 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// deriveSecret derives a key for one purpose from a shared secret with HKDF-SHA256,
+// so the label keeps keys for different purposes independent of each other
+func deriveSecret(secret, label string) string {
+	if secret == "" {
+		return ""
+	}
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, label, sha256.Size)
+	if err != nil {
+		log.Fatalf("Failed to derive %s secret: %v", label, err)
+	}
+	return hex.EncodeToString(key)
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return defaultValue
+		}
+		return i
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return defaultValue
+		}
+		return f
+	}
+	return defaultValue
+}
+
+// getEnvList splits a comma-separated variable, dropping empty entries
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvMediaVariants parses a comma-separated list of name:width pairs, falling
+// back to defaultMediaVariants when the variable is unset or malformed
+func getEnvMediaVariants(key string) []MediaVariantSize {
+	if value := os.Getenv(key); value != "" {
+		variants, err := parseMediaVariants(value)
+		if err == nil {
+			return variants
+		}
+		log.Printf("Invalid %s, using %q: %v", key, defaultMediaVariants, err)
+	}
+	variants, _ := parseMediaVariants(defaultMediaVariants)
+	return variants
+}
+
+// parseMediaVariants parses a list like "sm:320,md:640" into variant sizes sorted by width.
+// Names must be unique, lowercase alphanumeric and not "original".
+func parseMediaVariants(value string) ([]MediaVariantSize, error) {
+	var variants []MediaVariantSize
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, width, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("variant %q is not name:width", item)
+		}
+		name = strings.TrimSpace(name)
+		if !validVariantName(name) {
+			return nil, fmt.Errorf("invalid variant name %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate variant name %q", name)
+		}
+		w, err := strconv.Atoi(strings.TrimSpace(width))
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid width for variant %q", name)
+		}
+		seen[name] = true
+		variants = append(variants, MediaVariantSize{Name: name, Width: w})
+	}
+	sort.Slice(variants, func(i, j int) bool { return variants[i].Width < variants[j].Width })
+	return variants, nil
+}
+
+func validVariantName(name string) bool {
+	if name == "" || len(name) > 50 || name == "original" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		b, err := strconv.ParseBool(value)
@@ -12,13 +12,103 @@ SELECT * FROM admins WHERE username = $1;
 SELECT * FROM admins WHERE id = $1;
 
 -- name: CreateAdmin :one
-INSERT INTO admins (username, password)
-VALUES ($1, $2)
+INSERT INTO admins (username, password, role, email)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: UpdateAdminPassword :exec
 UPDATE admins SET password = $2, updated_at = NOW() WHERE id = $1;
 
+-- name: DeleteInvitedAdmin :exec
+DELETE FROM admins WHERE id = $1 AND password = '';
+
+-- name: ListAdmins :many
+SELECT * FROM admins ORDER BY id ASC;
+
+-- name: UpdateAdminRole :one
+UPDATE admins SET role = $2, updated_at = NOW() WHERE id = $1
+RETURNING *;
+
+-- name: SetAdminDisabled :one
+UPDATE admins
+SET disabled_at = CASE WHEN sqlc.arg(disabled)::bool THEN COALESCE(disabled_at, NOW()) ELSE NULL END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CountActiveOwners :one
+SELECT COUNT(*) FROM admins WHERE role = 'owner' AND disabled_at IS NULL;
+
+-- name: SetAdminTOTPSecret :exec
+UPDATE admins SET totp_secret = $2, totp_enabled_at = NULL, updated_at = NOW() WHERE id = $1;
+
+-- name: EnableAdminTOTP :exec
+UPDATE admins SET totp_enabled_at = NOW(), updated_at = NOW() WHERE id = $1;
+
+-- name: DisableAdminTOTP :exec
+UPDATE admins SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = NOW() WHERE id = $1;
+
+-- name: UseAdminTOTPStep :execrows
+UPDATE admins SET totp_last_step = $2
+WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2);
+
+-- name: GetAdminByEmail :one
+SELECT * FROM admins WHERE LOWER(email) = LOWER(sqlc.arg(email)::text);
+
+-- name: GetAdminByOIDCSubject :one
+SELECT * FROM admins WHERE oidc_subject = $1;
+
+-- name: UpdateAdminIdentity :one
+UPDATE admins SET email = $2, oidc_subject = $3, updated_at = NOW() WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAdminRecoveryCodes :exec
+DELETE FROM admin_recovery_codes WHERE admin_id = $1;
+
+-- name: CreateAdminRecoveryCodes :exec
+INSERT INTO admin_recovery_codes (admin_id, code_hash)
+SELECT sqlc.arg(admin_id)::int, unnest(sqlc.arg(code_hashes)::text[]);
+
+-- name: UseAdminRecoveryCode :execrows
+UPDATE admin_recovery_codes SET used_at = NOW()
+WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- ============================================================================
+-- API KEYS
+-- ============================================================================
+
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (admin_id, name, prefix, key_hash, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetAPIKeyByID :one
+SELECT * FROM api_keys WHERE id = $1;
+
+-- name: GetAPIKeyByHash :one
+SELECT k.*, a.username, a.role, a.disabled_at AS admin_disabled_at
+FROM api_keys k
+JOIN admins a ON a.id = k.admin_id
+WHERE k.key_hash = $1;
+
+-- name: ListAPIKeys :many
+SELECT * FROM api_keys ORDER BY created_at DESC, id DESC;
+
+-- name: ListAPIKeysByAdmin :many
+SELECT * FROM api_keys WHERE admin_id = $1 ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateAPIKey :one
+UPDATE api_keys SET name = $2, scopes = $3 WHERE id = $1
+RETURNING *;
+
+-- name: RevokeAPIKey :one
+UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1
+RETURNING *;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
+
 -- ============================================================================
 -- CATEGORIES
 -- ============================================================================
@@ -94,7 +184,8 @@ ORDER BY t.name ASC;
 -- ============================================================================
 
 -- name: ListPublishedPosts :many
-SELECT p.*, c.name as category_name, c.slug as category_slug
+SELECT p.*, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = 'published'
@@ -104,8 +195,17 @@ LIMIT $1 OFFSET $2;
 -- name: CountPublishedPosts :one
 SELECT COUNT(*) FROM posts WHERE status = 'published';
 
+-- name: ListPublishedPostSitemapEntries :many
+SELECT p.id, p.slug, p.category_id, p.updated_at, p.published_at,
+    ARRAY(SELECT pt.tag_id FROM post_tags pt WHERE pt.post_id = p.id)::int[] AS tag_ids
+FROM posts p
+WHERE p.status = 'published' AND p.id > $1
+ORDER BY p.id
+LIMIT $2;
+
 -- name: ListPublishedPostsByCategory :many
-SELECT p.*, c.name as category_name, c.slug as category_slug
+SELECT p.*, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = 'published' AND p.category_id = $1
@@ -116,7 +216,8 @@ LIMIT $2 OFFSET $3;
 SELECT COUNT(*) FROM posts WHERE status = 'published' AND category_id = $1;
 
 -- name: ListPublishedPostsByTag :many
-SELECT p.*, c.name as category_name, c.slug as category_slug
+SELECT p.*, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 JOIN post_tags pt ON p.id = pt.post_id
@@ -136,21 +237,146 @@ LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.slug = $1 AND p.status = 'published';
 
 -- name: SearchPublishedPosts :many
-SELECT p.*, c.name as category_name, c.slug as category_slug
+SELECT p.*, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count,
+    (
+        bigm_similarity(sqlc.arg(query)::text, p.title) * 4
+        + bigm_similarity(sqlc.arg(query)::text, COALESCE(p.excerpt, '')) * 2
+        + bigm_similarity(sqlc.arg(query)::text, COALESCE(c.name, '')) * 1.5
+        + COALESCE((
+            SELECT MAX(bigm_similarity(sqlc.arg(query)::text, t.name))
+            FROM tags t
+            INNER JOIN post_tags pt ON t.id = pt.tag_id
+            WHERE pt.post_id = p.id
+        ), 0) * 1.5
+        + bigm_similarity(sqlc.arg(query)::text, p.content)
+    )::float8 as score
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = 'published'
-  AND (p.title ILIKE '%' || $1 || '%' OR p.content ILIKE '%' || $1 || '%')
-ORDER BY p.published_at DESC
-LIMIT $2 OFFSET $3;
+  AND (
+    lower(p.title) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(p.content) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(c.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    )
+  )
+  AND (sqlc.arg(category_slug)::text = '' OR c.slug = sqlc.arg(category_slug)::text)
+  AND (sqlc.arg(tag_slug)::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = sqlc.arg(tag_slug)::text
+  ))
+  AND (sqlc.arg(year)::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = sqlc.arg(year)::int)
+ORDER BY score DESC, p.published_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountSearchPublishedPosts :one
-SELECT COUNT(*) FROM posts
-WHERE status = 'published'
-  AND (title ILIKE '%' || $1 || '%' OR content ILIKE '%' || $1 || '%');
+SELECT COUNT(*) FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(p.content) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(c.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    )
+  )
+  AND (sqlc.arg(category_slug)::text = '' OR c.slug = sqlc.arg(category_slug)::text)
+  AND (sqlc.arg(tag_slug)::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = sqlc.arg(tag_slug)::text
+  ))
+  AND (sqlc.arg(year)::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = sqlc.arg(year)::int);
+
+-- name: SearchFacetCategories :many
+SELECT c.id, c.name, c.slug, COUNT(*) as post_count
+FROM posts p
+INNER JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(p.content) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(c.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    )
+  )
+  AND (sqlc.arg(category_slug)::text = '' OR c.slug = sqlc.arg(category_slug)::text)
+  AND (sqlc.arg(tag_slug)::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = sqlc.arg(tag_slug)::text
+  ))
+  AND (sqlc.arg(year)::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = sqlc.arg(year)::int)
+GROUP BY c.id, c.name, c.slug
+ORDER BY post_count DESC, c.name;
+
+-- name: SearchFacetTags :many
+SELECT t.id, t.name, t.slug, COUNT(*) as post_count
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+INNER JOIN post_tags pt ON pt.post_id = p.id
+INNER JOIN tags t ON t.id = pt.tag_id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(p.content) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(c.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    )
+  )
+  AND (sqlc.arg(category_slug)::text = '' OR c.slug = sqlc.arg(category_slug)::text)
+  AND (sqlc.arg(tag_slug)::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = sqlc.arg(tag_slug)::text
+  ))
+  AND (sqlc.arg(year)::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = sqlc.arg(year)::int)
+GROUP BY t.id, t.name, t.slug
+ORDER BY post_count DESC, t.name;
+
+-- name: SearchFacetYears :many
+SELECT EXTRACT(YEAR FROM p.published_at)::int as year, COUNT(*) as post_count
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(p.content) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR lower(c.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower(sqlc.arg(query)::text))
+    )
+  )
+  AND (sqlc.arg(category_slug)::text = '' OR c.slug = sqlc.arg(category_slug)::text)
+  AND (sqlc.arg(tag_slug)::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = sqlc.arg(tag_slug)::text
+  ))
+  AND (sqlc.arg(year)::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = sqlc.arg(year)::int)
+  AND p.published_at IS NOT NULL
+GROUP BY year
+ORDER BY year DESC;
 
 -- name: ListAllPosts :many
-SELECT p.*, c.name as category_name, c.slug as category_slug
+SELECT p.*, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 ORDER BY p.created_at DESC
@@ -160,7 +386,8 @@ LIMIT $1 OFFSET $2;
 SELECT COUNT(*) FROM posts;
 
 -- name: ListPostsByStatus :many
-SELECT p.*, c.name as category_name, c.slug as category_slug
+SELECT p.*, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = $1
@@ -170,6 +397,14 @@ LIMIT $2 OFFSET $3;
 -- name: CountPostsByStatus :one
 SELECT COUNT(*) FROM posts WHERE status = $1;
 
+-- name: ListScheduledPosts :many
+SELECT p.*, c.name as category_name, c.slug as category_slug
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'scheduled'
+ORDER BY p.published_at ASC
+LIMIT $1;
+
 -- name: GetPostByID :one
 SELECT p.*, c.name as category_name, c.slug as category_slug
 FROM posts p
@@ -183,8 +418,8 @@ LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.slug = $1;
 
 -- name: CreatePost :one
-INSERT INTO posts (title, slug, content, excerpt, category_id, status, reading_time, thumbnail)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO posts (title, slug, content, excerpt, category_id, status, reading_time, thumbnail, author_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: UpdatePost :one
@@ -202,10 +437,24 @@ RETURNING *;
 
 -- name: UnpublishPost :one
 UPDATE posts
-SET status = 'draft', updated_at = NOW()
+SET status = 'draft',
+    published_at = CASE WHEN status = 'scheduled' THEN NULL ELSE published_at END,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: SchedulePost :one
+UPDATE posts
+SET status = 'scheduled', published_at = $2, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: PublishDuePosts :many
+UPDATE posts
+SET status = 'published', updated_at = NOW()
+WHERE status = 'scheduled' AND published_at <= $1
+RETURNING *;
+
 -- name: DeletePost :exec
 DELETE FROM posts WHERE id = $1;
 
@@ -242,6 +491,27 @@ DELETE FROM post_tags WHERE post_id = $1;
 -- name: SetPostTags :exec
 DELETE FROM post_tags WHERE post_id = $1;
 
+-- ============================================================================
+-- POST_REVISIONS
+-- ============================================================================
+
+-- name: CreatePostRevision :one
+INSERT INTO post_revisions (post_id, title, content, excerpt, tags)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListPostRevisions :many
+SELECT * FROM post_revisions
+WHERE post_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountPostRevisions :one
+SELECT COUNT(*) FROM post_revisions WHERE post_id = $1;
+
+-- name: GetPostRevision :one
+SELECT * FROM post_revisions WHERE id = $1 AND post_id = $2;
+
 -- ============================================================================
 -- PROJECTS
 -- ============================================================================
@@ -290,14 +560,75 @@ SELECT COUNT(*) FROM media;
 -- name: GetMediaByID :one
 SELECT * FROM media WHERE id = $1;
 
+-- name: GetMediaByContentHash :one
+SELECT * FROM media WHERE content_hash = $1;
+
 -- name: CreateMedia :one
-INSERT INTO media (filename, original_name, path, url, mime_type, size, width, height, thumbnail_sm, thumbnail_md)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+INSERT INTO media (filename, original_name, path, url, mime_type, size, width, height, blurhash, lqip, dominant_color, exif, content_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING *;
 
 -- name: DeleteMedia :exec
 DELETE FROM media WHERE id = $1;
 
+-- name: CreateMediaVariant :one
+INSERT INTO media_variants (media_id, name, mime_type, path, url, width, height, size)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: ListMediaVariantsByMediaIDs :many
+SELECT * FROM media_variants
+WHERE media_id = ANY(sqlc.arg(media_ids)::int[])
+ORDER BY media_id, width, mime_type;
+
+-- name: ListMediaPlaceholdersByURLs :many
+SELECT m.url, m.blurhash, m.lqip, m.dominant_color FROM media m
+WHERE m.url = ANY(sqlc.arg(urls)::text[]) AND m.blurhash IS NOT NULL
+UNION ALL
+SELECT v.url, m.blurhash, m.lqip, m.dominant_color FROM media_variants v
+JOIN media m ON m.id = v.media_id
+WHERE v.url = ANY(sqlc.arg(urls)::text[]) AND m.blurhash IS NOT NULL;
+
+-- name: ListMediaObjectPaths :many
+SELECT id AS media_id, path FROM media
+UNION ALL
+SELECT media_id, path FROM media_variants
+ORDER BY media_id, path;
+
+-- name: ListUnusedMedia :many
+SELECT * FROM media
+WHERE NOT EXISTS (SELECT 1 FROM media_usages u WHERE u.media_id = media.id)
+ORDER BY created_at DESC LIMIT $1 OFFSET $2;
+
+-- name: CountUnusedMedia :one
+SELECT COUNT(*) FROM media
+WHERE NOT EXISTS (SELECT 1 FROM media_usages u WHERE u.media_id = media.id);
+
+-- name: ListMediaUsages :many
+SELECT u.media_id, u.source_type, u.source_id, u.field,
+       COALESCE(p.title, pr.title, '')::text AS source_title,
+       COALESCE(p.slug, pr.slug, '')::text AS source_slug
+FROM media_usages u
+LEFT JOIN posts p ON u.source_type = 'post' AND p.id = u.source_id
+LEFT JOIN projects pr ON u.source_type = 'project' AND pr.id = u.source_id
+WHERE u.media_id = $1
+ORDER BY u.source_type, u.source_id, u.field;
+
+-- name: DeleteMediaUsagesBySource :exec
+DELETE FROM media_usages WHERE source_type = $1 AND source_id = $2;
+
+-- name: AddMediaUsages :exec
+-- Resolves each referenced URL (a media file or one of its variants) to its media
+INSERT INTO media_usages (media_id, source_type, source_id, field)
+SELECT DISTINCT m.media_id, sqlc.arg(source_type)::varchar, sqlc.arg(source_id)::int, r.field
+FROM unnest(sqlc.arg(urls)::text[], sqlc.arg(fields)::text[]) AS r(url, field)
+JOIN (
+    SELECT id AS media_id, url FROM media
+    UNION ALL
+    SELECT media_id, url FROM media_variants
+) m ON m.url = r.url
+ON CONFLICT DO NOTHING;
+
 -- ============================================================================
 -- DASHBOARD STATS
 -- ============================================================================
@@ -325,3 +656,113 @@ LIMIT $1;
 
 -- name: GetTotalViews :one
 SELECT COALESCE(SUM(view_count), 0) as total_views FROM posts;
+
+-- ============================================================================
+-- COMMENTS
+-- ============================================================================
+
+-- name: CreateComment :one
+INSERT INTO comments (post_id, parent_id, author_name, author_email, github_handle, content, status, ip_hash, spam_score)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetCommentByID :one
+SELECT * FROM comments WHERE id = $1;
+
+-- name: ListCommentsByIDs :many
+SELECT * FROM comments WHERE id = ANY(sqlc.arg(ids)::int[]);
+
+-- name: ListVisibleCommentsByPost :many
+SELECT * FROM comments
+WHERE post_id = $1 AND status <> 'pending'
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListCommentsByStatus :many
+SELECT cm.*, p.title as post_title, p.slug as post_slug
+FROM comments cm
+INNER JOIN posts p ON cm.post_id = p.id
+WHERE cm.status = $1
+ORDER BY cm.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountCommentsByStatus :one
+SELECT COUNT(*) FROM comments WHERE status = $1;
+
+-- name: UpdateCommentsStatus :execrows
+UPDATE comments SET status = sqlc.arg(status), updated_at = NOW()
+WHERE id = ANY(sqlc.arg(ids)::int[]);
+
+-- name: SetCommentSpamTrainedAs :exec
+UPDATE comments SET spam_trained_as = $2 WHERE id = $1;
+
+-- ============================================================================
+-- SPAM FILTER
+-- ============================================================================
+
+-- name: GetSpamTokens :many
+SELECT * FROM spam_tokens WHERE token = ANY(sqlc.arg(tokens)::text[]);
+
+-- name: ListSpamClasses :many
+SELECT * FROM spam_classes;
+
+-- name: AdjustSpamTokens :exec
+INSERT INTO spam_tokens (token, spam_count, ham_count)
+SELECT unnest(sqlc.arg(tokens)::text[]), GREATEST(sqlc.arg(spam_delta)::int, 0), GREATEST(sqlc.arg(ham_delta)::int, 0)
+ON CONFLICT (token) DO UPDATE SET
+    spam_count = GREATEST(spam_tokens.spam_count + sqlc.arg(spam_delta)::int, 0),
+    ham_count = GREATEST(spam_tokens.ham_count + sqlc.arg(ham_delta)::int, 0);
+
+-- name: AdjustSpamClasses :exec
+UPDATE spam_classes
+SET documents = GREATEST(documents + CASE label WHEN 'spam' THEN sqlc.arg(spam_delta)::int ELSE sqlc.arg(ham_delta)::int END, 0);
+
+-- ============================================================================
+-- AUDIT LOG
+-- ============================================================================
+
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (admin_id, username, api_key_id, action, target_type, target_id, before, after, ip, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ListAuditLogs :many
+SELECT * FROM audit_logs
+WHERE (sqlc.arg(admin_id)::int = 0 OR admin_id = sqlc.arg(admin_id)::int)
+  AND (sqlc.arg(action)::text = '' OR action = sqlc.arg(action)::text)
+  AND (sqlc.arg(target_type)::text = '' OR target_type = sqlc.arg(target_type)::text)
+  AND (sqlc.arg(target_id)::text = '' OR target_id = sqlc.arg(target_id)::text)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR created_at < sqlc.narg(until)::timestamptz)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountAuditLogs :one
+SELECT COUNT(*) FROM audit_logs
+WHERE (sqlc.arg(admin_id)::int = 0 OR admin_id = sqlc.arg(admin_id)::int)
+  AND (sqlc.arg(action)::text = '' OR action = sqlc.arg(action)::text)
+  AND (sqlc.arg(target_type)::text = '' OR target_type = sqlc.arg(target_type)::text)
+  AND (sqlc.arg(target_id)::text = '' OR target_id = sqlc.arg(target_id)::text)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR created_at < sqlc.narg(until)::timestamptz);
+
+-- ============================================================================
+-- JWT SIGNING KEYS
+-- ============================================================================
+
+-- name: CreateSigningKey :one
+INSERT INTO jwt_signing_keys (kid, algorithm, private_key)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListSigningKeys :many
+SELECT * FROM jwt_signing_keys
+WHERE retired_at IS NULL OR retired_at > sqlc.arg(retired_after)::timestamptz
+ORDER BY created_at DESC;
+
+-- name: RetireSigningKeys :exec
+UPDATE jwt_signing_keys
+SET retired_at = NOW()
+WHERE retired_at IS NULL AND kid <> $1;
+
+-- name: DeleteRetiredSigningKeys :exec
+DELETE FROM jwt_signing_keys
+WHERE retired_at < sqlc.arg(retired_before)::timestamptz;
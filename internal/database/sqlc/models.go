@@ -6,16 +6,60 @@ package sqlc
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/sqlc-dev/pqtype"
 )
 
 type Admin struct {
+	ID            int32          `json:"id"`
+	Username      string         `json:"username"`
+	Password      string         `json:"password"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	UpdatedAt     sql.NullTime   `json:"updated_at"`
+	Role          string         `json:"role"`
+	DisabledAt    sql.NullTime   `json:"disabled_at"`
+	TotpSecret    sql.NullString `json:"totp_secret"`
+	TotpEnabledAt sql.NullTime   `json:"totp_enabled_at"`
+	TotpLastStep  sql.NullInt64  `json:"totp_last_step"`
+	Email         sql.NullString `json:"email"`
+	OidcSubject   sql.NullString `json:"oidc_subject"`
+}
+
+type AdminRecoveryCode struct {
 	ID        int32        `json:"id"`
-	Username  string       `json:"username"`
-	Password  string       `json:"password"`
+	AdminID   int32        `json:"admin_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
 	CreatedAt sql.NullTime `json:"created_at"`
-	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+type ApiKey struct {
+	ID         int32        `json:"id"`
+	AdminID    int32        `json:"admin_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"key_hash"`
+	Scopes     []string     `json:"scopes"`
+	ExpiresAt  sql.NullTime `json:"expires_at"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	RevokedAt  sql.NullTime `json:"revoked_at"`
+	CreatedAt  sql.NullTime `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID         int64                 `json:"id"`
+	AdminID    sql.NullInt32         `json:"admin_id"`
+	Username   string                `json:"username"`
+	ApiKeyID   sql.NullInt32         `json:"api_key_id"`
+	Action     string                `json:"action"`
+	TargetType string                `json:"target_type"`
+	TargetID   string                `json:"target_id"`
+	Before     pqtype.NullRawMessage `json:"before"`
+	After      pqtype.NullRawMessage `json:"after"`
+	Ip         string                `json:"ip"`
+	RequestID  string                `json:"request_id"`
+	CreatedAt  sql.NullTime          `json:"created_at"`
 }
 
 type Category struct {
@@ -27,19 +71,67 @@ type Category struct {
 	CreatedAt   sql.NullTime   `json:"created_at"`
 }
 
+type Comment struct {
+	ID            int32          `json:"id"`
+	PostID        int32          `json:"post_id"`
+	ParentID      sql.NullInt32  `json:"parent_id"`
+	AuthorName    string         `json:"author_name"`
+	AuthorEmail   sql.NullString `json:"author_email"`
+	GithubHandle  sql.NullString `json:"github_handle"`
+	Content       string         `json:"content"`
+	Status        string         `json:"status"`
+	IpHash        sql.NullString `json:"ip_hash"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	UpdatedAt     sql.NullTime   `json:"updated_at"`
+	SpamScore     float64        `json:"spam_score"`
+	SpamTrainedAs sql.NullString `json:"spam_trained_as"`
+}
+
+type JwtSigningKey struct {
+	Kid        string       `json:"kid"`
+	Algorithm  string       `json:"algorithm"`
+	PrivateKey string       `json:"private_key"`
+	CreatedAt  time.Time    `json:"created_at"`
+	RetiredAt  sql.NullTime `json:"retired_at"`
+}
+
 type Medium struct {
-	ID           int32          `json:"id"`
-	Filename     string         `json:"filename"`
-	OriginalName string         `json:"original_name"`
-	Path         string         `json:"path"`
-	Url          string         `json:"url"`
-	MimeType     sql.NullString `json:"mime_type"`
-	Size         sql.NullInt64  `json:"size"`
-	Width        sql.NullInt32  `json:"width"`
-	Height       sql.NullInt32  `json:"height"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	ThumbnailSm  sql.NullString `json:"thumbnail_sm"`
-	ThumbnailMd  sql.NullString `json:"thumbnail_md"`
+	ID            int32                 `json:"id"`
+	Filename      string                `json:"filename"`
+	OriginalName  string                `json:"original_name"`
+	Path          string                `json:"path"`
+	Url           string                `json:"url"`
+	MimeType      sql.NullString        `json:"mime_type"`
+	Size          sql.NullInt64         `json:"size"`
+	Width         sql.NullInt32         `json:"width"`
+	Height        sql.NullInt32         `json:"height"`
+	CreatedAt     sql.NullTime          `json:"created_at"`
+	Blurhash      sql.NullString        `json:"blurhash"`
+	Lqip          sql.NullString        `json:"lqip"`
+	DominantColor sql.NullString        `json:"dominant_color"`
+	Exif          pqtype.NullRawMessage `json:"exif"`
+	ContentHash   sql.NullString        `json:"content_hash"`
+}
+
+type MediaUsage struct {
+	MediaID    int32        `json:"media_id"`
+	SourceType string       `json:"source_type"`
+	SourceID   int32        `json:"source_id"`
+	Field      string       `json:"field"`
+	CreatedAt  sql.NullTime `json:"created_at"`
+}
+
+type MediaVariant struct {
+	ID        int32        `json:"id"`
+	MediaID   int32        `json:"media_id"`
+	Name      string       `json:"name"`
+	MimeType  string       `json:"mime_type"`
+	Path      string       `json:"path"`
+	Url       string       `json:"url"`
+	Width     int32        `json:"width"`
+	Height    int32        `json:"height"`
+	Size      int64        `json:"size"`
+	CreatedAt sql.NullTime `json:"created_at"`
 }
 
 type Post struct {
@@ -56,6 +148,17 @@ type Post struct {
 	CreatedAt   sql.NullTime   `json:"created_at"`
 	UpdatedAt   sql.NullTime   `json:"updated_at"`
 	PublishedAt sql.NullTime   `json:"published_at"`
+	AuthorID    sql.NullInt32  `json:"author_id"`
+}
+
+type PostRevision struct {
+	ID        int32                 `json:"id"`
+	PostID    int32                 `json:"post_id"`
+	Title     string                `json:"title"`
+	Content   string                `json:"content"`
+	Excerpt   sql.NullString        `json:"excerpt"`
+	Tags      pqtype.NullRawMessage `json:"tags"`
+	CreatedAt sql.NullTime          `json:"created_at"`
 }
 
 type PostTag struct {
@@ -80,6 +183,17 @@ type Project struct {
 	UpdatedAt   sql.NullTime          `json:"updated_at"`
 }
 
+type SpamClass struct {
+	Label     string `json:"label"`
+	Documents int32  `json:"documents"`
+}
+
+type SpamToken struct {
+	Token     string `json:"token"`
+	SpamCount int32  `json:"spam_count"`
+	HamCount  int32  `json:"ham_count"`
+}
+
 type Tag struct {
 	ID        int32        `json:"id"`
 	Name      string       `json:"name"`
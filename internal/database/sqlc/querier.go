@@ -7,31 +7,61 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	// Resolves each referenced URL (a media file or one of its variants) to its media
+	AddMediaUsages(ctx context.Context, arg AddMediaUsagesParams) error
 	AddPostTag(ctx context.Context, arg AddPostTagParams) error
+	AdjustSpamClasses(ctx context.Context, arg AdjustSpamClassesParams) error
+	AdjustSpamTokens(ctx context.Context, arg AdjustSpamTokensParams) error
 	CheckSlugExists(ctx context.Context, slug string) (bool, error)
 	CheckSlugExistsExcept(ctx context.Context, arg CheckSlugExistsExceptParams) (bool, error)
+	CountActiveOwners(ctx context.Context) (int64, error)
 	CountAllPosts(ctx context.Context) (int64, error)
+	CountAuditLogs(ctx context.Context, arg CountAuditLogsParams) (int64, error)
+	CountCommentsByStatus(ctx context.Context, status string) (int64, error)
 	CountMedia(ctx context.Context) (int64, error)
+	CountPostRevisions(ctx context.Context, postID int32) (int64, error)
 	CountPostsByStatus(ctx context.Context, status sql.NullString) (int64, error)
 	CountPublishedPosts(ctx context.Context) (int64, error)
 	CountPublishedPostsByCategory(ctx context.Context, categoryID sql.NullInt32) (int64, error)
 	CountPublishedPostsByTag(ctx context.Context, tagID int32) (int64, error)
-	CountSearchPublishedPosts(ctx context.Context, dollar_1 sql.NullString) (int64, error)
+	CountSearchPublishedPosts(ctx context.Context, arg CountSearchPublishedPostsParams) (int64, error)
+	CountUnusedMedia(ctx context.Context) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin, error)
+	CreateAdminRecoveryCodes(ctx context.Context, arg CreateAdminRecoveryCodesParams) error
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error)
 	CreateMedia(ctx context.Context, arg CreateMediaParams) (Medium, error)
+	CreateMediaVariant(ctx context.Context, arg CreateMediaVariantParams) (MediaVariant, error)
 	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
+	// ============================================================================
+	// POST_REVISIONS
+	// ============================================================================
+	CreatePostRevision(ctx context.Context, arg CreatePostRevisionParams) (PostRevision, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
+	CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (JwtSigningKey, error)
 	CreateTag(ctx context.Context, arg CreateTagParams) (Tag, error)
+	DeleteAdminRecoveryCodes(ctx context.Context, adminID int32) error
 	DeleteCategory(ctx context.Context, id int32) error
+	DeleteInvitedAdmin(ctx context.Context, id int32) error
 	DeleteMedia(ctx context.Context, id int32) error
+	DeleteMediaUsagesBySource(ctx context.Context, arg DeleteMediaUsagesBySourceParams) error
 	DeletePost(ctx context.Context, id int32) error
 	DeleteProject(ctx context.Context, id int32) error
+	DeleteRetiredSigningKeys(ctx context.Context, retiredBefore time.Time) error
 	DeleteTag(ctx context.Context, id int32) error
+	DisableAdminTOTP(ctx context.Context, id int32) error
+	EnableAdminTOTP(ctx context.Context, id int32) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (GetAPIKeyByHashRow, error)
+	GetAPIKeyByID(ctx context.Context, id int32) (ApiKey, error)
+	GetAdminByEmail(ctx context.Context, email string) (Admin, error)
 	GetAdminByID(ctx context.Context, id int32) (Admin, error)
+	GetAdminByOIDCSubject(ctx context.Context, oidcSubject sql.NullString) (Admin, error)
 	// Blog API SQL Queries
 	// This file contains all SQL queries for sqlc code generation
 	// ============================================================================
@@ -42,9 +72,12 @@ type Querier interface {
 	GetCategoryBySlug(ctx context.Context, slug string) (Category, error)
 	GetCategoryPostCount(ctx context.Context, categoryID sql.NullInt32) (int64, error)
 	GetCategoryStats(ctx context.Context) ([]GetCategoryStatsRow, error)
+	GetCommentByID(ctx context.Context, id int32) (Comment, error)
+	GetMediaByContentHash(ctx context.Context, contentHash sql.NullString) (Medium, error)
 	GetMediaByID(ctx context.Context, id int32) (Medium, error)
 	GetPostByID(ctx context.Context, id int32) (GetPostByIDRow, error)
 	GetPostBySlug(ctx context.Context, slug string) (GetPostBySlugRow, error)
+	GetPostRevision(ctx context.Context, arg GetPostRevisionParams) (PostRevision, error)
 	// ============================================================================
 	// DASHBOARD STATS
 	// ============================================================================
@@ -57,49 +90,84 @@ type Querier interface {
 	GetProjectBySlug(ctx context.Context, slug string) (Project, error)
 	GetPublishedPostBySlug(ctx context.Context, slug string) (GetPublishedPostBySlugRow, error)
 	GetRecentPosts(ctx context.Context, limit int32) ([]GetRecentPostsRow, error)
+	GetSpamTokens(ctx context.Context, tokens []string) ([]SpamToken, error)
 	GetTagByID(ctx context.Context, id int32) (Tag, error)
 	GetTagBySlug(ctx context.Context, slug string) (Tag, error)
 	GetTagPostCount(ctx context.Context, tagID int32) (int64, error)
 	GetTotalViews(ctx context.Context) (interface{}, error)
 	IncrementViewCount(ctx context.Context, id int32) error
+	ListAPIKeys(ctx context.Context) ([]ApiKey, error)
+	ListAPIKeysByAdmin(ctx context.Context, adminID int32) ([]ApiKey, error)
+	ListAdmins(ctx context.Context) ([]Admin, error)
 	ListAllPosts(ctx context.Context, arg ListAllPostsParams) ([]ListAllPostsRow, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
 	// ============================================================================
 	// CATEGORIES
 	// ============================================================================
 	ListCategories(ctx context.Context) ([]Category, error)
+	ListCommentsByIDs(ctx context.Context, ids []int32) ([]Comment, error)
+	ListCommentsByStatus(ctx context.Context, arg ListCommentsByStatusParams) ([]ListCommentsByStatusRow, error)
 	ListFeaturedProjects(ctx context.Context) ([]Project, error)
 	// ============================================================================
 	// MEDIA
 	// ============================================================================
 	ListMedia(ctx context.Context, arg ListMediaParams) ([]Medium, error)
+	ListMediaObjectPaths(ctx context.Context) ([]ListMediaObjectPathsRow, error)
+	ListMediaPlaceholdersByURLs(ctx context.Context, urls []string) ([]ListMediaPlaceholdersByURLsRow, error)
+	ListMediaUsages(ctx context.Context, mediaID int32) ([]ListMediaUsagesRow, error)
+	ListMediaVariantsByMediaIDs(ctx context.Context, mediaIds []int32) ([]MediaVariant, error)
+	ListPostRevisions(ctx context.Context, arg ListPostRevisionsParams) ([]PostRevision, error)
 	ListPostsByStatus(ctx context.Context, arg ListPostsByStatusParams) ([]ListPostsByStatusRow, error)
 	// ============================================================================
 	// PROJECTS
 	// ============================================================================
 	ListProjects(ctx context.Context) ([]Project, error)
+	ListPublishedPostSitemapEntries(ctx context.Context, arg ListPublishedPostSitemapEntriesParams) ([]ListPublishedPostSitemapEntriesRow, error)
 	// ============================================================================
 	// POSTS
 	// ============================================================================
 	ListPublishedPosts(ctx context.Context, arg ListPublishedPostsParams) ([]ListPublishedPostsRow, error)
 	ListPublishedPostsByCategory(ctx context.Context, arg ListPublishedPostsByCategoryParams) ([]ListPublishedPostsByCategoryRow, error)
 	ListPublishedPostsByTag(ctx context.Context, arg ListPublishedPostsByTagParams) ([]ListPublishedPostsByTagRow, error)
+	ListScheduledPosts(ctx context.Context, limit int32) ([]ListScheduledPostsRow, error)
+	ListSigningKeys(ctx context.Context, retiredAfter time.Time) ([]JwtSigningKey, error)
+	ListSpamClasses(ctx context.Context) ([]SpamClass, error)
 	// ============================================================================
 	// TAGS
 	// ============================================================================
 	ListTags(ctx context.Context) ([]Tag, error)
 	ListTagsWithPostCount(ctx context.Context) ([]ListTagsWithPostCountRow, error)
+	ListUnusedMedia(ctx context.Context, arg ListUnusedMediaParams) ([]Medium, error)
+	ListVisibleCommentsByPost(ctx context.Context, postID int32) ([]Comment, error)
+	PublishDuePosts(ctx context.Context, publishedAt sql.NullTime) ([]Post, error)
 	PublishPost(ctx context.Context, id int32) (Post, error)
 	RemoveAllPostTags(ctx context.Context, postID int32) error
 	RemovePostTag(ctx context.Context, arg RemovePostTagParams) error
+	RetireSigningKeys(ctx context.Context, kid string) error
+	RevokeAPIKey(ctx context.Context, id int32) (ApiKey, error)
+	SchedulePost(ctx context.Context, arg SchedulePostParams) (Post, error)
+	SearchFacetCategories(ctx context.Context, arg SearchFacetCategoriesParams) ([]SearchFacetCategoriesRow, error)
+	SearchFacetTags(ctx context.Context, arg SearchFacetTagsParams) ([]SearchFacetTagsRow, error)
+	SearchFacetYears(ctx context.Context, arg SearchFacetYearsParams) ([]SearchFacetYearsRow, error)
 	SearchPublishedPosts(ctx context.Context, arg SearchPublishedPostsParams) ([]SearchPublishedPostsRow, error)
+	SetAdminDisabled(ctx context.Context, arg SetAdminDisabledParams) (Admin, error)
+	SetAdminTOTPSecret(ctx context.Context, arg SetAdminTOTPSecretParams) error
+	SetCommentSpamTrainedAs(ctx context.Context, arg SetCommentSpamTrainedAsParams) error
 	SetPostTags(ctx context.Context, postID int32) error
+	TouchAPIKey(ctx context.Context, id int32) error
 	UnpublishPost(ctx context.Context, id int32) (Post, error)
+	UpdateAPIKey(ctx context.Context, arg UpdateAPIKeyParams) (ApiKey, error)
+	UpdateAdminIdentity(ctx context.Context, arg UpdateAdminIdentityParams) (Admin, error)
 	UpdateAdminPassword(ctx context.Context, arg UpdateAdminPasswordParams) error
+	UpdateAdminRole(ctx context.Context, arg UpdateAdminRoleParams) (Admin, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateCommentsStatus(ctx context.Context, arg UpdateCommentsStatusParams) (int64, error)
 	UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
 	UpdateProjectOrder(ctx context.Context, arg UpdateProjectOrderParams) error
 	UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, error)
+	UseAdminRecoveryCode(ctx context.Context, arg UseAdminRecoveryCodeParams) (int64, error)
+	UseAdminTOTPStep(ctx context.Context, arg UseAdminTOTPStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -8,10 +8,43 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
+const addMediaUsages = `-- name: AddMediaUsages :exec
+
+INSERT INTO media_usages (media_id, source_type, source_id, field)
+SELECT DISTINCT m.media_id, $1::varchar, $2::int, r.field
+FROM unnest($3::text[], $4::text[]) AS r(url, field)
+JOIN (
+    SELECT id AS media_id, url FROM media
+    UNION ALL
+    SELECT media_id, url FROM media_variants
+) m ON m.url = r.url
+ON CONFLICT DO NOTHING
+`
+
+type AddMediaUsagesParams struct {
+	SourceType string   `json:"source_type"`
+	SourceID   int32    `json:"source_id"`
+	Urls       []string `json:"urls"`
+	Fields     []string `json:"fields"`
+}
+
+// Resolves each referenced URL (a media file or one of its variants) to its media
+func (q *Queries) AddMediaUsages(ctx context.Context, arg AddMediaUsagesParams) error {
+	_, err := q.db.ExecContext(ctx, addMediaUsages,
+		arg.SourceType,
+		arg.SourceID,
+		pq.Array(arg.Urls),
+		pq.Array(arg.Fields),
+	)
+	return err
+}
+
 const addPostTag = `-- name: AddPostTag :exec
 INSERT INTO post_tags (post_id, tag_id)
 VALUES ($1, $2)
@@ -28,6 +61,40 @@ func (q *Queries) AddPostTag(ctx context.Context, arg AddPostTagParams) error {
 	return err
 }
 
+const adjustSpamClasses = `-- name: AdjustSpamClasses :exec
+UPDATE spam_classes
+SET documents = GREATEST(documents + CASE label WHEN 'spam' THEN $1::int ELSE $2::int END, 0)
+`
+
+type AdjustSpamClassesParams struct {
+	SpamDelta int32 `json:"spam_delta"`
+	HamDelta  int32 `json:"ham_delta"`
+}
+
+func (q *Queries) AdjustSpamClasses(ctx context.Context, arg AdjustSpamClassesParams) error {
+	_, err := q.db.ExecContext(ctx, adjustSpamClasses, arg.SpamDelta, arg.HamDelta)
+	return err
+}
+
+const adjustSpamTokens = `-- name: AdjustSpamTokens :exec
+INSERT INTO spam_tokens (token, spam_count, ham_count)
+SELECT unnest($1::text[]), GREATEST($2::int, 0), GREATEST($3::int, 0)
+ON CONFLICT (token) DO UPDATE SET
+    spam_count = GREATEST(spam_tokens.spam_count + $2::int, 0),
+    ham_count = GREATEST(spam_tokens.ham_count + $3::int, 0)
+`
+
+type AdjustSpamTokensParams struct {
+	Tokens    []string `json:"tokens"`
+	SpamDelta int32    `json:"spam_delta"`
+	HamDelta  int32    `json:"ham_delta"`
+}
+
+func (q *Queries) AdjustSpamTokens(ctx context.Context, arg AdjustSpamTokensParams) error {
+	_, err := q.db.ExecContext(ctx, adjustSpamTokens, pq.Array(arg.Tokens), arg.SpamDelta, arg.HamDelta)
+	return err
+}
+
 const checkSlugExists = `-- name: CheckSlugExists :one
 SELECT EXISTS(SELECT 1 FROM posts WHERE slug = $1)
 `
@@ -55,6 +122,17 @@ func (q *Queries) CheckSlugExistsExcept(ctx context.Context, arg CheckSlugExists
 	return exists, err
 }
 
+const countActiveOwners = `-- name: CountActiveOwners :one
+SELECT COUNT(*) FROM admins WHERE role = 'owner' AND disabled_at IS NULL
+`
+
+func (q *Queries) CountActiveOwners(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveOwners)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countAllPosts = `-- name: CountAllPosts :one
 SELECT COUNT(*) FROM posts
 `
@@ -66,6 +144,50 @@ func (q *Queries) CountAllPosts(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countAuditLogs = `-- name: CountAuditLogs :one
+SELECT COUNT(*) FROM audit_logs
+WHERE ($1::int = 0 OR admin_id = $1::int)
+  AND ($2::text = '' OR action = $2::text)
+  AND ($3::text = '' OR target_type = $3::text)
+  AND ($4::text = '' OR target_id = $4::text)
+  AND ($5::timestamptz IS NULL OR created_at >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR created_at < $6::timestamptz)
+`
+
+type CountAuditLogsParams struct {
+	AdminID    int32        `json:"admin_id"`
+	Action     string       `json:"action"`
+	TargetType string       `json:"target_type"`
+	TargetID   string       `json:"target_id"`
+	Since      sql.NullTime `json:"since"`
+	Until      sql.NullTime `json:"until"`
+}
+
+func (q *Queries) CountAuditLogs(ctx context.Context, arg CountAuditLogsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuditLogs,
+		arg.AdminID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Since,
+		arg.Until,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCommentsByStatus = `-- name: CountCommentsByStatus :one
+SELECT COUNT(*) FROM comments WHERE status = $1
+`
+
+func (q *Queries) CountCommentsByStatus(ctx context.Context, status string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCommentsByStatus, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countMedia = `-- name: CountMedia :one
 SELECT COUNT(*) FROM media
 `
@@ -77,6 +199,17 @@ func (q *Queries) CountMedia(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countPostRevisions = `-- name: CountPostRevisions :one
+SELECT COUNT(*) FROM post_revisions WHERE post_id = $1
+`
+
+func (q *Queries) CountPostRevisions(ctx context.Context, postID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPostRevisions, postID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countPostsByStatus = `-- name: CountPostsByStatus :one
 SELECT COUNT(*) FROM posts WHERE status = $1
 `
@@ -124,31 +257,119 @@ func (q *Queries) CountPublishedPostsByTag(ctx context.Context, tagID int32) (in
 }
 
 const countSearchPublishedPosts = `-- name: CountSearchPublishedPosts :one
-SELECT COUNT(*) FROM posts
-WHERE status = 'published'
-  AND (title ILIKE '%' || $1 || '%' OR content ILIKE '%' || $1 || '%')
+SELECT COUNT(*) FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower($1::text))
+    OR lower(p.content) LIKE likequery(lower($1::text))
+    OR lower(c.name) LIKE likequery(lower($1::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower($1::text))
+    )
+  )
+  AND ($2::text = '' OR c.slug = $2::text)
+  AND ($3::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = $3::text
+  ))
+  AND ($4::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = $4::int)
+`
+
+type CountSearchPublishedPostsParams struct {
+	Query        string `json:"query"`
+	CategorySlug string `json:"category_slug"`
+	TagSlug      string `json:"tag_slug"`
+	Year         int32  `json:"year"`
+}
+
+func (q *Queries) CountSearchPublishedPosts(ctx context.Context, arg CountSearchPublishedPostsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchPublishedPosts,
+		arg.Query,
+		arg.CategorySlug,
+		arg.TagSlug,
+		arg.Year,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUnusedMedia = `-- name: CountUnusedMedia :one
+SELECT COUNT(*) FROM media
+WHERE NOT EXISTS (SELECT 1 FROM media_usages u WHERE u.media_id = media.id)
 `
 
-func (q *Queries) CountSearchPublishedPosts(ctx context.Context, dollar_1 sql.NullString) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countSearchPublishedPosts, dollar_1)
+func (q *Queries) CountUnusedMedia(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnusedMedia)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
+const createAPIKey = `-- name: CreateAPIKey :one
+INSERT INTO api_keys (admin_id, name, prefix, key_hash, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, admin_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+type CreateAPIKeyParams struct {
+	AdminID   int32        `json:"admin_id"`
+	Name      string       `json:"name"`
+	Prefix    string       `json:"prefix"`
+	KeyHash   string       `json:"key_hash"`
+	Scopes    []string     `json:"scopes"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+}
+
+func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, createAPIKey,
+		arg.AdminID,
+		arg.Name,
+		arg.Prefix,
+		arg.KeyHash,
+		pq.Array(arg.Scopes),
+		arg.ExpiresAt,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createAdmin = `-- name: CreateAdmin :one
-INSERT INTO admins (username, password)
-VALUES ($1, $2)
-RETURNING id, username, password, created_at, updated_at
+INSERT INTO admins (username, password, role, email)
+VALUES ($1, $2, $3, $4)
+RETURNING id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject
 `
 
 type CreateAdminParams struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string         `json:"username"`
+	Password string         `json:"password"`
+	Role     string         `json:"role"`
+	Email    sql.NullString `json:"email"`
 }
 
 func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin, error) {
-	row := q.db.QueryRowContext(ctx, createAdmin, arg.Username, arg.Password)
+	row := q.db.QueryRowContext(ctx, createAdmin,
+		arg.Username,
+		arg.Password,
+		arg.Role,
+		arg.Email,
+	)
 	var i Admin
 	err := row.Scan(
 		&i.ID,
@@ -156,10 +377,66 @@ func (q *Queries) CreateAdmin(ctx context.Context, arg CreateAdminParams) (Admin
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
 	)
 	return i, err
 }
 
+const createAdminRecoveryCodes = `-- name: CreateAdminRecoveryCodes :exec
+INSERT INTO admin_recovery_codes (admin_id, code_hash)
+SELECT $1::int, unnest($2::text[])
+`
+
+type CreateAdminRecoveryCodesParams struct {
+	AdminID    int32    `json:"admin_id"`
+	CodeHashes []string `json:"code_hashes"`
+}
+
+func (q *Queries) CreateAdminRecoveryCodes(ctx context.Context, arg CreateAdminRecoveryCodesParams) error {
+	_, err := q.db.ExecContext(ctx, createAdminRecoveryCodes, arg.AdminID, pq.Array(arg.CodeHashes))
+	return err
+}
+
+const createAuditLog = `-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (admin_id, username, api_key_id, action, target_type, target_id, before, after, ip, request_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+type CreateAuditLogParams struct {
+	AdminID    sql.NullInt32         `json:"admin_id"`
+	Username   string                `json:"username"`
+	ApiKeyID   sql.NullInt32         `json:"api_key_id"`
+	Action     string                `json:"action"`
+	TargetType string                `json:"target_type"`
+	TargetID   string                `json:"target_id"`
+	Before     pqtype.NullRawMessage `json:"before"`
+	After      pqtype.NullRawMessage `json:"after"`
+	Ip         string                `json:"ip"`
+	RequestID  string                `json:"request_id"`
+}
+
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditLog,
+		arg.AdminID,
+		arg.Username,
+		arg.ApiKeyID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Before,
+		arg.After,
+		arg.Ip,
+		arg.RequestID,
+	)
+	return err
+}
+
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (name, slug, description, sort_order)
 VALUES ($1, $2, $3, $4)
@@ -192,23 +469,75 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 	return i, err
 }
 
+const createComment = `-- name: CreateComment :one
+INSERT INTO comments (post_id, parent_id, author_name, author_email, github_handle, content, status, ip_hash, spam_score)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, post_id, parent_id, author_name, author_email, github_handle, content, status, ip_hash, created_at, updated_at, spam_score, spam_trained_as
+`
+
+type CreateCommentParams struct {
+	PostID       int32          `json:"post_id"`
+	ParentID     sql.NullInt32  `json:"parent_id"`
+	AuthorName   string         `json:"author_name"`
+	AuthorEmail  sql.NullString `json:"author_email"`
+	GithubHandle sql.NullString `json:"github_handle"`
+	Content      string         `json:"content"`
+	Status       string         `json:"status"`
+	IpHash       sql.NullString `json:"ip_hash"`
+	SpamScore    float64        `json:"spam_score"`
+}
+
+func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, createComment,
+		arg.PostID,
+		arg.ParentID,
+		arg.AuthorName,
+		arg.AuthorEmail,
+		arg.GithubHandle,
+		arg.Content,
+		arg.Status,
+		arg.IpHash,
+		arg.SpamScore,
+	)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.ParentID,
+		&i.AuthorName,
+		&i.AuthorEmail,
+		&i.GithubHandle,
+		&i.Content,
+		&i.Status,
+		&i.IpHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SpamScore,
+		&i.SpamTrainedAs,
+	)
+	return i, err
+}
+
 const createMedia = `-- name: CreateMedia :one
-INSERT INTO media (filename, original_name, path, url, mime_type, size, width, height, thumbnail_sm, thumbnail_md)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, filename, original_name, path, url, mime_type, size, width, height, created_at, thumbnail_sm, thumbnail_md
+INSERT INTO media (filename, original_name, path, url, mime_type, size, width, height, blurhash, lqip, dominant_color, exif, content_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, filename, original_name, path, url, mime_type, size, width, height, created_at, blurhash, lqip, dominant_color, exif, content_hash
 `
 
 type CreateMediaParams struct {
-	Filename     string         `json:"filename"`
-	OriginalName string         `json:"original_name"`
-	Path         string         `json:"path"`
-	Url          string         `json:"url"`
-	MimeType     sql.NullString `json:"mime_type"`
-	Size         sql.NullInt64  `json:"size"`
-	Width        sql.NullInt32  `json:"width"`
-	Height       sql.NullInt32  `json:"height"`
-	ThumbnailSm  sql.NullString `json:"thumbnail_sm"`
-	ThumbnailMd  sql.NullString `json:"thumbnail_md"`
+	Filename      string                `json:"filename"`
+	OriginalName  string                `json:"original_name"`
+	Path          string                `json:"path"`
+	Url           string                `json:"url"`
+	MimeType      sql.NullString        `json:"mime_type"`
+	Size          sql.NullInt64         `json:"size"`
+	Width         sql.NullInt32         `json:"width"`
+	Height        sql.NullInt32         `json:"height"`
+	Blurhash      sql.NullString        `json:"blurhash"`
+	Lqip          sql.NullString        `json:"lqip"`
+	DominantColor sql.NullString        `json:"dominant_color"`
+	Exif          pqtype.NullRawMessage `json:"exif"`
+	ContentHash   sql.NullString        `json:"content_hash"`
 }
 
 func (q *Queries) CreateMedia(ctx context.Context, arg CreateMediaParams) (Medium, error) {
@@ -221,8 +550,11 @@ func (q *Queries) CreateMedia(ctx context.Context, arg CreateMediaParams) (Mediu
 		arg.Size,
 		arg.Width,
 		arg.Height,
-		arg.ThumbnailSm,
-		arg.ThumbnailMd,
+		arg.Blurhash,
+		arg.Lqip,
+		arg.DominantColor,
+		arg.Exif,
+		arg.ContentHash,
 	)
 	var i Medium
 	err := row.Scan(
@@ -236,16 +568,63 @@ func (q *Queries) CreateMedia(ctx context.Context, arg CreateMediaParams) (Mediu
 		&i.Width,
 		&i.Height,
 		&i.CreatedAt,
-		&i.ThumbnailSm,
-		&i.ThumbnailMd,
+		&i.Blurhash,
+		&i.Lqip,
+		&i.DominantColor,
+		&i.Exif,
+		&i.ContentHash,
 	)
 	return i, err
 }
 
-const createPost = `-- name: CreatePost :one
-INSERT INTO posts (title, slug, content, excerpt, category_id, status, reading_time, thumbnail)
+const createMediaVariant = `-- name: CreateMediaVariant :one
+INSERT INTO media_variants (media_id, name, mime_type, path, url, width, height, size)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at
+RETURNING id, media_id, name, mime_type, path, url, width, height, size, created_at
+`
+
+type CreateMediaVariantParams struct {
+	MediaID  int32  `json:"media_id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Path     string `json:"path"`
+	Url      string `json:"url"`
+	Width    int32  `json:"width"`
+	Height   int32  `json:"height"`
+	Size     int64  `json:"size"`
+}
+
+func (q *Queries) CreateMediaVariant(ctx context.Context, arg CreateMediaVariantParams) (MediaVariant, error) {
+	row := q.db.QueryRowContext(ctx, createMediaVariant,
+		arg.MediaID,
+		arg.Name,
+		arg.MimeType,
+		arg.Path,
+		arg.Url,
+		arg.Width,
+		arg.Height,
+		arg.Size,
+	)
+	var i MediaVariant
+	err := row.Scan(
+		&i.ID,
+		&i.MediaID,
+		&i.Name,
+		&i.MimeType,
+		&i.Path,
+		&i.Url,
+		&i.Width,
+		&i.Height,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPost = `-- name: CreatePost :one
+INSERT INTO posts (title, slug, content, excerpt, category_id, status, reading_time, thumbnail, author_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at, author_id
 `
 
 type CreatePostParams struct {
@@ -257,6 +636,7 @@ type CreatePostParams struct {
 	Status      sql.NullString `json:"status"`
 	ReadingTime sql.NullInt32  `json:"reading_time"`
 	Thumbnail   sql.NullString `json:"thumbnail"`
+	AuthorID    sql.NullInt32  `json:"author_id"`
 }
 
 func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, error) {
@@ -269,6 +649,7 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.Status,
 		arg.ReadingTime,
 		arg.Thumbnail,
+		arg.AuthorID,
 	)
 	var i Post
 	err := row.Scan(
@@ -285,6 +666,46 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
+	)
+	return i, err
+}
+
+const createPostRevision = `-- name: CreatePostRevision :one
+
+INSERT INTO post_revisions (post_id, title, content, excerpt, tags)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, post_id, title, content, excerpt, tags, created_at
+`
+
+type CreatePostRevisionParams struct {
+	PostID  int32                 `json:"post_id"`
+	Title   string                `json:"title"`
+	Content string                `json:"content"`
+	Excerpt sql.NullString        `json:"excerpt"`
+	Tags    pqtype.NullRawMessage `json:"tags"`
+}
+
+// ============================================================================
+// POST_REVISIONS
+// ============================================================================
+func (q *Queries) CreatePostRevision(ctx context.Context, arg CreatePostRevisionParams) (PostRevision, error) {
+	row := q.db.QueryRowContext(ctx, createPostRevision,
+		arg.PostID,
+		arg.Title,
+		arg.Content,
+		arg.Excerpt,
+		arg.Tags,
+	)
+	var i PostRevision
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.Title,
+		&i.Content,
+		&i.Excerpt,
+		&i.Tags,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -343,6 +764,31 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 	return i, err
 }
 
+const createSigningKey = `-- name: CreateSigningKey :one
+INSERT INTO jwt_signing_keys (kid, algorithm, private_key)
+VALUES ($1, $2, $3)
+RETURNING kid, algorithm, private_key, created_at, retired_at
+`
+
+type CreateSigningKeyParams struct {
+	Kid        string `json:"kid"`
+	Algorithm  string `json:"algorithm"`
+	PrivateKey string `json:"private_key"`
+}
+
+func (q *Queries) CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) (JwtSigningKey, error) {
+	row := q.db.QueryRowContext(ctx, createSigningKey, arg.Kid, arg.Algorithm, arg.PrivateKey)
+	var i JwtSigningKey
+	err := row.Scan(
+		&i.Kid,
+		&i.Algorithm,
+		&i.PrivateKey,
+		&i.CreatedAt,
+		&i.RetiredAt,
+	)
+	return i, err
+}
+
 const createTag = `-- name: CreateTag :one
 INSERT INTO tags (name, slug)
 VALUES ($1, $2)
@@ -366,6 +812,15 @@ func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) (Tag, erro
 	return i, err
 }
 
+const deleteAdminRecoveryCodes = `-- name: DeleteAdminRecoveryCodes :exec
+DELETE FROM admin_recovery_codes WHERE admin_id = $1
+`
+
+func (q *Queries) DeleteAdminRecoveryCodes(ctx context.Context, adminID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteAdminRecoveryCodes, adminID)
+	return err
+}
+
 const deleteCategory = `-- name: DeleteCategory :exec
 DELETE FROM categories WHERE id = $1
 `
@@ -375,6 +830,15 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteInvitedAdmin = `-- name: DeleteInvitedAdmin :exec
+DELETE FROM admins WHERE id = $1 AND password = ''
+`
+
+func (q *Queries) DeleteInvitedAdmin(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteInvitedAdmin, id)
+	return err
+}
+
 const deleteMedia = `-- name: DeleteMedia :exec
 DELETE FROM media WHERE id = $1
 `
@@ -384,6 +848,20 @@ func (q *Queries) DeleteMedia(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteMediaUsagesBySource = `-- name: DeleteMediaUsagesBySource :exec
+DELETE FROM media_usages WHERE source_type = $1 AND source_id = $2
+`
+
+type DeleteMediaUsagesBySourceParams struct {
+	SourceType string `json:"source_type"`
+	SourceID   int32  `json:"source_id"`
+}
+
+func (q *Queries) DeleteMediaUsagesBySource(ctx context.Context, arg DeleteMediaUsagesBySourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMediaUsagesBySource, arg.SourceType, arg.SourceID)
+	return err
+}
+
 const deletePost = `-- name: DeletePost :exec
 DELETE FROM posts WHERE id = $1
 `
@@ -402,6 +880,16 @@ func (q *Queries) DeleteProject(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteRetiredSigningKeys = `-- name: DeleteRetiredSigningKeys :exec
+DELETE FROM jwt_signing_keys
+WHERE retired_at < $1::timestamptz
+`
+
+func (q *Queries) DeleteRetiredSigningKeys(ctx context.Context, retiredBefore time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteRetiredSigningKeys, retiredBefore)
+	return err
+}
+
 const deleteTag = `-- name: DeleteTag :exec
 DELETE FROM tags WHERE id = $1
 `
@@ -411,8 +899,116 @@ func (q *Queries) DeleteTag(ctx context.Context, id int32) error {
 	return err
 }
 
+const disableAdminTOTP = `-- name: DisableAdminTOTP :exec
+UPDATE admins SET totp_secret = NULL, totp_enabled_at = NULL, updated_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) DisableAdminTOTP(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, disableAdminTOTP, id)
+	return err
+}
+
+const enableAdminTOTP = `-- name: EnableAdminTOTP :exec
+UPDATE admins SET totp_enabled_at = NOW(), updated_at = NOW() WHERE id = $1
+`
+
+func (q *Queries) EnableAdminTOTP(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, enableAdminTOTP, id)
+	return err
+}
+
+const getAPIKeyByHash = `-- name: GetAPIKeyByHash :one
+SELECT k.id, k.admin_id, k.name, k.prefix, k.key_hash, k.scopes, k.expires_at, k.last_used_at, k.revoked_at, k.created_at, a.username, a.role, a.disabled_at AS admin_disabled_at
+FROM api_keys k
+JOIN admins a ON a.id = k.admin_id
+WHERE k.key_hash = $1
+`
+
+type GetAPIKeyByHashRow struct {
+	ID              int32        `json:"id"`
+	AdminID         int32        `json:"admin_id"`
+	Name            string       `json:"name"`
+	Prefix          string       `json:"prefix"`
+	KeyHash         string       `json:"key_hash"`
+	Scopes          []string     `json:"scopes"`
+	ExpiresAt       sql.NullTime `json:"expires_at"`
+	LastUsedAt      sql.NullTime `json:"last_used_at"`
+	RevokedAt       sql.NullTime `json:"revoked_at"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	Username        string       `json:"username"`
+	Role            string       `json:"role"`
+	AdminDisabledAt sql.NullTime `json:"admin_disabled_at"`
+}
+
+func (q *Queries) GetAPIKeyByHash(ctx context.Context, keyHash string) (GetAPIKeyByHashRow, error) {
+	row := q.db.QueryRowContext(ctx, getAPIKeyByHash, keyHash)
+	var i GetAPIKeyByHashRow
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+		&i.Username,
+		&i.Role,
+		&i.AdminDisabledAt,
+	)
+	return i, err
+}
+
+const getAPIKeyByID = `-- name: GetAPIKeyByID :one
+SELECT id, admin_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys WHERE id = $1
+`
+
+func (q *Queries) GetAPIKeyByID(ctx context.Context, id int32) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getAPIKeyByID, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAdminByEmail = `-- name: GetAdminByEmail :one
+SELECT id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject FROM admins WHERE LOWER(email) = LOWER($1::text)
+`
+
+func (q *Queries) GetAdminByEmail(ctx context.Context, email string) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, getAdminByEmail, email)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
+	)
+	return i, err
+}
+
 const getAdminByID = `-- name: GetAdminByID :one
-SELECT id, username, password, created_at, updated_at FROM admins WHERE id = $1
+SELECT id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject FROM admins WHERE id = $1
 `
 
 func (q *Queries) GetAdminByID(ctx context.Context, id int32) (Admin, error) {
@@ -424,6 +1020,37 @@ func (q *Queries) GetAdminByID(ctx context.Context, id int32) (Admin, error) {
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
+	)
+	return i, err
+}
+
+const getAdminByOIDCSubject = `-- name: GetAdminByOIDCSubject :one
+SELECT id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject FROM admins WHERE oidc_subject = $1
+`
+
+func (q *Queries) GetAdminByOIDCSubject(ctx context.Context, oidcSubject sql.NullString) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, getAdminByOIDCSubject, oidcSubject)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
 	)
 	return i, err
 }
@@ -431,7 +1058,7 @@ func (q *Queries) GetAdminByID(ctx context.Context, id int32) (Admin, error) {
 const getAdminByUsername = `-- name: GetAdminByUsername :one
 
 
-SELECT id, username, password, created_at, updated_at FROM admins WHERE username = $1
+SELECT id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject FROM admins WHERE username = $1
 `
 
 // Blog API SQL Queries
@@ -448,6 +1075,13 @@ func (q *Queries) GetAdminByUsername(ctx context.Context, username string) (Admi
 		&i.Password,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
 	)
 	return i, err
 }
@@ -542,32 +1176,87 @@ func (q *Queries) GetCategoryStats(ctx context.Context) ([]GetCategoryStatsRow,
 	return items, nil
 }
 
-const getMediaByID = `-- name: GetMediaByID :one
-SELECT id, filename, original_name, path, url, mime_type, size, width, height, created_at, thumbnail_sm, thumbnail_md FROM media WHERE id = $1
+const getCommentByID = `-- name: GetCommentByID :one
+SELECT id, post_id, parent_id, author_name, author_email, github_handle, content, status, ip_hash, created_at, updated_at, spam_score, spam_trained_as FROM comments WHERE id = $1
 `
 
-func (q *Queries) GetMediaByID(ctx context.Context, id int32) (Medium, error) {
-	row := q.db.QueryRowContext(ctx, getMediaByID, id)
-	var i Medium
+func (q *Queries) GetCommentByID(ctx context.Context, id int32) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, getCommentByID, id)
+	var i Comment
 	err := row.Scan(
 		&i.ID,
-		&i.Filename,
-		&i.OriginalName,
-		&i.Path,
-		&i.Url,
+		&i.PostID,
+		&i.ParentID,
+		&i.AuthorName,
+		&i.AuthorEmail,
+		&i.GithubHandle,
+		&i.Content,
+		&i.Status,
+		&i.IpHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SpamScore,
+		&i.SpamTrainedAs,
+	)
+	return i, err
+}
+
+const getMediaByContentHash = `-- name: GetMediaByContentHash :one
+SELECT id, filename, original_name, path, url, mime_type, size, width, height, created_at, blurhash, lqip, dominant_color, exif, content_hash FROM media WHERE content_hash = $1
+`
+
+func (q *Queries) GetMediaByContentHash(ctx context.Context, contentHash sql.NullString) (Medium, error) {
+	row := q.db.QueryRowContext(ctx, getMediaByContentHash, contentHash)
+	var i Medium
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.OriginalName,
+		&i.Path,
+		&i.Url,
+		&i.MimeType,
+		&i.Size,
+		&i.Width,
+		&i.Height,
+		&i.CreatedAt,
+		&i.Blurhash,
+		&i.Lqip,
+		&i.DominantColor,
+		&i.Exif,
+		&i.ContentHash,
+	)
+	return i, err
+}
+
+const getMediaByID = `-- name: GetMediaByID :one
+SELECT id, filename, original_name, path, url, mime_type, size, width, height, created_at, blurhash, lqip, dominant_color, exif, content_hash FROM media WHERE id = $1
+`
+
+func (q *Queries) GetMediaByID(ctx context.Context, id int32) (Medium, error) {
+	row := q.db.QueryRowContext(ctx, getMediaByID, id)
+	var i Medium
+	err := row.Scan(
+		&i.ID,
+		&i.Filename,
+		&i.OriginalName,
+		&i.Path,
+		&i.Url,
 		&i.MimeType,
 		&i.Size,
 		&i.Width,
 		&i.Height,
 		&i.CreatedAt,
-		&i.ThumbnailSm,
-		&i.ThumbnailMd,
+		&i.Blurhash,
+		&i.Lqip,
+		&i.DominantColor,
+		&i.Exif,
+		&i.ContentHash,
 	)
 	return i, err
 }
 
 const getPostByID = `-- name: GetPostByID :one
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.id = $1
@@ -587,6 +1276,7 @@ type GetPostByIDRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
 }
@@ -608,6 +1298,7 @@ func (q *Queries) GetPostByID(ctx context.Context, id int32) (GetPostByIDRow, er
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
 		&i.CategoryName,
 		&i.CategorySlug,
 	)
@@ -615,7 +1306,7 @@ func (q *Queries) GetPostByID(ctx context.Context, id int32) (GetPostByIDRow, er
 }
 
 const getPostBySlug = `-- name: GetPostBySlug :one
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.slug = $1
@@ -635,6 +1326,7 @@ type GetPostBySlugRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
 }
@@ -656,12 +1348,37 @@ func (q *Queries) GetPostBySlug(ctx context.Context, slug string) (GetPostBySlug
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
 		&i.CategoryName,
 		&i.CategorySlug,
 	)
 	return i, err
 }
 
+const getPostRevision = `-- name: GetPostRevision :one
+SELECT id, post_id, title, content, excerpt, tags, created_at FROM post_revisions WHERE id = $1 AND post_id = $2
+`
+
+type GetPostRevisionParams struct {
+	ID     int32 `json:"id"`
+	PostID int32 `json:"post_id"`
+}
+
+func (q *Queries) GetPostRevision(ctx context.Context, arg GetPostRevisionParams) (PostRevision, error) {
+	row := q.db.QueryRowContext(ctx, getPostRevision, arg.ID, arg.PostID)
+	var i PostRevision
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.Title,
+		&i.Content,
+		&i.Excerpt,
+		&i.Tags,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getPostStats = `-- name: GetPostStats :one
 
 SELECT
@@ -779,7 +1496,7 @@ func (q *Queries) GetProjectBySlug(ctx context.Context, slug string) (Project, e
 }
 
 const getPublishedPostBySlug = `-- name: GetPublishedPostBySlug :one
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.slug = $1 AND p.status = 'published'
@@ -799,6 +1516,7 @@ type GetPublishedPostBySlugRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
 }
@@ -820,6 +1538,7 @@ func (q *Queries) GetPublishedPostBySlug(ctx context.Context, slug string) (GetP
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
 		&i.CategoryName,
 		&i.CategorySlug,
 	)
@@ -827,7 +1546,7 @@ func (q *Queries) GetPublishedPostBySlug(ctx context.Context, slug string) (GetP
 }
 
 const getRecentPosts = `-- name: GetRecentPosts :many
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 ORDER BY p.created_at DESC
@@ -848,6 +1567,7 @@ type GetRecentPostsRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
 }
@@ -875,6 +1595,7 @@ func (q *Queries) GetRecentPosts(ctx context.Context, limit int32) ([]GetRecentP
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
 		); err != nil {
@@ -891,6 +1612,33 @@ func (q *Queries) GetRecentPosts(ctx context.Context, limit int32) ([]GetRecentP
 	return items, nil
 }
 
+const getSpamTokens = `-- name: GetSpamTokens :many
+SELECT token, spam_count, ham_count FROM spam_tokens WHERE token = ANY($1::text[])
+`
+
+func (q *Queries) GetSpamTokens(ctx context.Context, tokens []string) ([]SpamToken, error) {
+	rows, err := q.db.QueryContext(ctx, getSpamTokens, pq.Array(tokens))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SpamToken{}
+	for rows.Next() {
+		var i SpamToken
+		if err := rows.Scan(&i.Token, &i.SpamCount, &i.HamCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTagByID = `-- name: GetTagByID :one
 SELECT id, name, slug, created_at FROM tags WHERE id = $1
 `
@@ -956,8 +1704,125 @@ func (q *Queries) IncrementViewCount(ctx context.Context, id int32) error {
 	return err
 }
 
+const listAPIKeys = `-- name: ListAPIKeys :many
+SELECT id, admin_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListAPIKeys(ctx context.Context) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdminID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			pq.Array(&i.Scopes),
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAPIKeysByAdmin = `-- name: ListAPIKeysByAdmin :many
+SELECT id, admin_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at FROM api_keys WHERE admin_id = $1 ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListAPIKeysByAdmin(ctx context.Context, adminID int32) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listAPIKeysByAdmin, adminID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdminID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			pq.Array(&i.Scopes),
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAdmins = `-- name: ListAdmins :many
+SELECT id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject FROM admins ORDER BY id ASC
+`
+
+func (q *Queries) ListAdmins(ctx context.Context) ([]Admin, error) {
+	rows, err := q.db.QueryContext(ctx, listAdmins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Admin{}
+	for rows.Next() {
+		var i Admin
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Password,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Role,
+			&i.DisabledAt,
+			&i.TotpSecret,
+			&i.TotpEnabledAt,
+			&i.TotpLastStep,
+			&i.Email,
+			&i.OidcSubject,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAllPosts = `-- name: ListAllPosts :many
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 ORDER BY p.created_at DESC
@@ -983,8 +1848,10 @@ type ListAllPostsRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
+	CommentCount int64          `json:"comment_count"`
 }
 
 func (q *Queries) ListAllPosts(ctx context.Context, arg ListAllPostsParams) ([]ListAllPostsRow, error) {
@@ -1010,8 +1877,78 @@ func (q *Queries) ListAllPosts(ctx context.Context, arg ListAllPostsParams) ([]L
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
+			&i.CommentCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditLogs = `-- name: ListAuditLogs :many
+SELECT id, admin_id, username, api_key_id, action, target_type, target_id, before, after, ip, request_id, created_at FROM audit_logs
+WHERE ($1::int = 0 OR admin_id = $1::int)
+  AND ($2::text = '' OR action = $2::text)
+  AND ($3::text = '' OR target_type = $3::text)
+  AND ($4::text = '' OR target_id = $4::text)
+  AND ($5::timestamptz IS NULL OR created_at >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR created_at < $6::timestamptz)
+ORDER BY created_at DESC, id DESC
+LIMIT $7 OFFSET $8
+`
+
+type ListAuditLogsParams struct {
+	AdminID    int32        `json:"admin_id"`
+	Action     string       `json:"action"`
+	TargetType string       `json:"target_type"`
+	TargetID   string       `json:"target_id"`
+	Since      sql.NullTime `json:"since"`
+	Until      sql.NullTime `json:"until"`
+	Limit      int32        `json:"limit"`
+	Offset     int32        `json:"offset"`
+}
+
+func (q *Queries) ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditLogs,
+		arg.AdminID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Since,
+		arg.Until,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditLog{}
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdminID,
+			&i.Username,
+			&i.ApiKeyID,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Before,
+			&i.After,
+			&i.Ip,
+			&i.RequestID,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1064,6 +2001,119 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 	return items, nil
 }
 
+const listCommentsByIDs = `-- name: ListCommentsByIDs :many
+SELECT id, post_id, parent_id, author_name, author_email, github_handle, content, status, ip_hash, created_at, updated_at, spam_score, spam_trained_as FROM comments WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) ListCommentsByIDs(ctx context.Context, ids []int32) ([]Comment, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentsByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Comment{}
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.ParentID,
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.GithubHandle,
+			&i.Content,
+			&i.Status,
+			&i.IpHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SpamScore,
+			&i.SpamTrainedAs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommentsByStatus = `-- name: ListCommentsByStatus :many
+SELECT cm.id, cm.post_id, cm.parent_id, cm.author_name, cm.author_email, cm.github_handle, cm.content, cm.status, cm.ip_hash, cm.created_at, cm.updated_at, cm.spam_score, cm.spam_trained_as, p.title as post_title, p.slug as post_slug
+FROM comments cm
+INNER JOIN posts p ON cm.post_id = p.id
+WHERE cm.status = $1
+ORDER BY cm.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListCommentsByStatusParams struct {
+	Status string `json:"status"`
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+type ListCommentsByStatusRow struct {
+	ID            int32          `json:"id"`
+	PostID        int32          `json:"post_id"`
+	ParentID      sql.NullInt32  `json:"parent_id"`
+	AuthorName    string         `json:"author_name"`
+	AuthorEmail   sql.NullString `json:"author_email"`
+	GithubHandle  sql.NullString `json:"github_handle"`
+	Content       string         `json:"content"`
+	Status        string         `json:"status"`
+	IpHash        sql.NullString `json:"ip_hash"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	UpdatedAt     sql.NullTime   `json:"updated_at"`
+	SpamScore     float64        `json:"spam_score"`
+	SpamTrainedAs sql.NullString `json:"spam_trained_as"`
+	PostTitle     string         `json:"post_title"`
+	PostSlug      string         `json:"post_slug"`
+}
+
+func (q *Queries) ListCommentsByStatus(ctx context.Context, arg ListCommentsByStatusParams) ([]ListCommentsByStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentsByStatus, arg.Status, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCommentsByStatusRow{}
+	for rows.Next() {
+		var i ListCommentsByStatusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.ParentID,
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.GithubHandle,
+			&i.Content,
+			&i.Status,
+			&i.IpHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SpamScore,
+			&i.SpamTrainedAs,
+			&i.PostTitle,
+			&i.PostSlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listFeaturedProjects = `-- name: ListFeaturedProjects :many
 SELECT id, title, slug, description, content, tech_stack, demo_url, github_url, thumbnail, images, is_featured, sort_order, created_at, updated_at FROM projects WHERE is_featured = true ORDER BY sort_order ASC, id ASC
 `
@@ -1108,7 +2158,7 @@ func (q *Queries) ListFeaturedProjects(ctx context.Context) ([]Project, error) {
 
 const listMedia = `-- name: ListMedia :many
 
-SELECT id, filename, original_name, path, url, mime_type, size, width, height, created_at, thumbnail_sm, thumbnail_md FROM media ORDER BY created_at DESC LIMIT $1 OFFSET $2
+SELECT id, filename, original_name, path, url, mime_type, size, width, height, created_at, blurhash, lqip, dominant_color, exif, content_hash FROM media ORDER BY created_at DESC LIMIT $1 OFFSET $2
 `
 
 type ListMediaParams struct {
@@ -1139,8 +2189,11 @@ func (q *Queries) ListMedia(ctx context.Context, arg ListMediaParams) ([]Medium,
 			&i.Width,
 			&i.Height,
 			&i.CreatedAt,
-			&i.ThumbnailSm,
-			&i.ThumbnailMd,
+			&i.Blurhash,
+			&i.Lqip,
+			&i.DominantColor,
+			&i.Exif,
+			&i.ContentHash,
 		); err != nil {
 			return nil, err
 		}
@@ -1155,41 +2208,257 @@ func (q *Queries) ListMedia(ctx context.Context, arg ListMediaParams) ([]Medium,
 	return items, nil
 }
 
-const listPostsByStatus = `-- name: ListPostsByStatus :many
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
-FROM posts p
-LEFT JOIN categories c ON p.category_id = c.id
-WHERE p.status = $1
-ORDER BY p.created_at DESC
-LIMIT $2 OFFSET $3
+const listMediaObjectPaths = `-- name: ListMediaObjectPaths :many
+SELECT id AS media_id, path FROM media
+UNION ALL
+SELECT media_id, path FROM media_variants
+ORDER BY media_id, path
 `
 
-type ListPostsByStatusParams struct {
-	Status sql.NullString `json:"status"`
-	Limit  int32          `json:"limit"`
-	Offset int32          `json:"offset"`
-}
-
-type ListPostsByStatusRow struct {
-	ID           int32          `json:"id"`
-	Title        string         `json:"title"`
-	Slug         string         `json:"slug"`
-	Content      string         `json:"content"`
-	Excerpt      sql.NullString `json:"excerpt"`
-	CategoryID   sql.NullInt32  `json:"category_id"`
-	Status       sql.NullString `json:"status"`
-	ViewCount    sql.NullInt32  `json:"view_count"`
-	ReadingTime  sql.NullInt32  `json:"reading_time"`
-	Thumbnail    sql.NullString `json:"thumbnail"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	UpdatedAt    sql.NullTime   `json:"updated_at"`
-	PublishedAt  sql.NullTime   `json:"published_at"`
-	CategoryName sql.NullString `json:"category_name"`
-	CategorySlug sql.NullString `json:"category_slug"`
+type ListMediaObjectPathsRow struct {
+	MediaID int32  `json:"media_id"`
+	Path    string `json:"path"`
 }
 
-func (q *Queries) ListPostsByStatus(ctx context.Context, arg ListPostsByStatusParams) ([]ListPostsByStatusRow, error) {
-	rows, err := q.db.QueryContext(ctx, listPostsByStatus, arg.Status, arg.Limit, arg.Offset)
+func (q *Queries) ListMediaObjectPaths(ctx context.Context) ([]ListMediaObjectPathsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMediaObjectPaths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListMediaObjectPathsRow{}
+	for rows.Next() {
+		var i ListMediaObjectPathsRow
+		if err := rows.Scan(&i.MediaID, &i.Path); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMediaPlaceholdersByURLs = `-- name: ListMediaPlaceholdersByURLs :many
+SELECT m.url, m.blurhash, m.lqip, m.dominant_color FROM media m
+WHERE m.url = ANY($1::text[]) AND m.blurhash IS NOT NULL
+UNION ALL
+SELECT v.url, m.blurhash, m.lqip, m.dominant_color FROM media_variants v
+JOIN media m ON m.id = v.media_id
+WHERE v.url = ANY($1::text[]) AND m.blurhash IS NOT NULL
+`
+
+type ListMediaPlaceholdersByURLsRow struct {
+	Url           string         `json:"url"`
+	Blurhash      sql.NullString `json:"blurhash"`
+	Lqip          sql.NullString `json:"lqip"`
+	DominantColor sql.NullString `json:"dominant_color"`
+}
+
+func (q *Queries) ListMediaPlaceholdersByURLs(ctx context.Context, urls []string) ([]ListMediaPlaceholdersByURLsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMediaPlaceholdersByURLs, pq.Array(urls))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListMediaPlaceholdersByURLsRow{}
+	for rows.Next() {
+		var i ListMediaPlaceholdersByURLsRow
+		if err := rows.Scan(
+			&i.Url,
+			&i.Blurhash,
+			&i.Lqip,
+			&i.DominantColor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMediaUsages = `-- name: ListMediaUsages :many
+SELECT u.media_id, u.source_type, u.source_id, u.field,
+       COALESCE(p.title, pr.title, '')::text AS source_title,
+       COALESCE(p.slug, pr.slug, '')::text AS source_slug
+FROM media_usages u
+LEFT JOIN posts p ON u.source_type = 'post' AND p.id = u.source_id
+LEFT JOIN projects pr ON u.source_type = 'project' AND pr.id = u.source_id
+WHERE u.media_id = $1
+ORDER BY u.source_type, u.source_id, u.field
+`
+
+type ListMediaUsagesRow struct {
+	MediaID     int32  `json:"media_id"`
+	SourceType  string `json:"source_type"`
+	SourceID    int32  `json:"source_id"`
+	Field       string `json:"field"`
+	SourceTitle string `json:"source_title"`
+	SourceSlug  string `json:"source_slug"`
+}
+
+func (q *Queries) ListMediaUsages(ctx context.Context, mediaID int32) ([]ListMediaUsagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMediaUsages, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListMediaUsagesRow{}
+	for rows.Next() {
+		var i ListMediaUsagesRow
+		if err := rows.Scan(
+			&i.MediaID,
+			&i.SourceType,
+			&i.SourceID,
+			&i.Field,
+			&i.SourceTitle,
+			&i.SourceSlug,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMediaVariantsByMediaIDs = `-- name: ListMediaVariantsByMediaIDs :many
+SELECT id, media_id, name, mime_type, path, url, width, height, size, created_at FROM media_variants
+WHERE media_id = ANY($1::int[])
+ORDER BY media_id, width, mime_type
+`
+
+func (q *Queries) ListMediaVariantsByMediaIDs(ctx context.Context, mediaIds []int32) ([]MediaVariant, error) {
+	rows, err := q.db.QueryContext(ctx, listMediaVariantsByMediaIDs, pq.Array(mediaIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MediaVariant{}
+	for rows.Next() {
+		var i MediaVariant
+		if err := rows.Scan(
+			&i.ID,
+			&i.MediaID,
+			&i.Name,
+			&i.MimeType,
+			&i.Path,
+			&i.Url,
+			&i.Width,
+			&i.Height,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPostRevisions = `-- name: ListPostRevisions :many
+SELECT id, post_id, title, content, excerpt, tags, created_at FROM post_revisions
+WHERE post_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListPostRevisionsParams struct {
+	PostID int32 `json:"post_id"`
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListPostRevisions(ctx context.Context, arg ListPostRevisionsParams) ([]PostRevision, error) {
+	rows, err := q.db.QueryContext(ctx, listPostRevisions, arg.PostID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PostRevision{}
+	for rows.Next() {
+		var i PostRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.Title,
+			&i.Content,
+			&i.Excerpt,
+			&i.Tags,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPostsByStatus = `-- name: ListPostsByStatus :many
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = $1
+ORDER BY p.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListPostsByStatusParams struct {
+	Status sql.NullString `json:"status"`
+	Limit  int32          `json:"limit"`
+	Offset int32          `json:"offset"`
+}
+
+type ListPostsByStatusRow struct {
+	ID           int32          `json:"id"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	Content      string         `json:"content"`
+	Excerpt      sql.NullString `json:"excerpt"`
+	CategoryID   sql.NullInt32  `json:"category_id"`
+	Status       sql.NullString `json:"status"`
+	ViewCount    sql.NullInt32  `json:"view_count"`
+	ReadingTime  sql.NullInt32  `json:"reading_time"`
+	Thumbnail    sql.NullString `json:"thumbnail"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	UpdatedAt    sql.NullTime   `json:"updated_at"`
+	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
+	CategoryName sql.NullString `json:"category_name"`
+	CategorySlug sql.NullString `json:"category_slug"`
+	CommentCount int64          `json:"comment_count"`
+}
+
+func (q *Queries) ListPostsByStatus(ctx context.Context, arg ListPostsByStatusParams) ([]ListPostsByStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPostsByStatus, arg.Status, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -1211,8 +2480,10 @@ func (q *Queries) ListPostsByStatus(ctx context.Context, arg ListPostsByStatusPa
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
+			&i.CommentCount,
 		); err != nil {
 			return nil, err
 		}
@@ -1273,9 +2544,63 @@ func (q *Queries) ListProjects(ctx context.Context) ([]Project, error) {
 	return items, nil
 }
 
-const listPublishedPosts = `-- name: ListPublishedPosts :many
+const listPublishedPostSitemapEntries = `-- name: ListPublishedPostSitemapEntries :many
+SELECT p.id, p.slug, p.category_id, p.updated_at, p.published_at,
+    ARRAY(SELECT pt.tag_id FROM post_tags pt WHERE pt.post_id = p.id)::int[] AS tag_ids
+FROM posts p
+WHERE p.status = 'published' AND p.id > $1
+ORDER BY p.id
+LIMIT $2
+`
+
+type ListPublishedPostSitemapEntriesParams struct {
+	ID    int32 `json:"id"`
+	Limit int32 `json:"limit"`
+}
+
+type ListPublishedPostSitemapEntriesRow struct {
+	ID          int32         `json:"id"`
+	Slug        string        `json:"slug"`
+	CategoryID  sql.NullInt32 `json:"category_id"`
+	UpdatedAt   sql.NullTime  `json:"updated_at"`
+	PublishedAt sql.NullTime  `json:"published_at"`
+	TagIds      []int32       `json:"tag_ids"`
+}
+
+func (q *Queries) ListPublishedPostSitemapEntries(ctx context.Context, arg ListPublishedPostSitemapEntriesParams) ([]ListPublishedPostSitemapEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedPostSitemapEntries, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPublishedPostSitemapEntriesRow{}
+	for rows.Next() {
+		var i ListPublishedPostSitemapEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.CategoryID,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			pq.Array(&i.TagIds),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+const listPublishedPosts = `-- name: ListPublishedPosts :many
+,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = 'published'
@@ -1302,8 +2627,10 @@ type ListPublishedPostsRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
+	CommentCount int64          `json:"comment_count"`
 }
 
 // ============================================================================
@@ -1332,8 +2659,10 @@ func (q *Queries) ListPublishedPosts(ctx context.Context, arg ListPublishedPosts
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
+			&i.CommentCount,
 		); err != nil {
 			return nil, err
 		}
@@ -1349,7 +2678,8 @@ func (q *Queries) ListPublishedPosts(ctx context.Context, arg ListPublishedPosts
 }
 
 const listPublishedPostsByCategory = `-- name: ListPublishedPostsByCategory :many
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = 'published' AND p.category_id = $1
@@ -1377,8 +2707,10 @@ type ListPublishedPostsByCategoryRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
+	CommentCount int64          `json:"comment_count"`
 }
 
 func (q *Queries) ListPublishedPostsByCategory(ctx context.Context, arg ListPublishedPostsByCategoryParams) ([]ListPublishedPostsByCategoryRow, error) {
@@ -1404,8 +2736,10 @@ func (q *Queries) ListPublishedPostsByCategory(ctx context.Context, arg ListPubl
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
+			&i.CommentCount,
 		); err != nil {
 			return nil, err
 		}
@@ -1421,7 +2755,8 @@ func (q *Queries) ListPublishedPostsByCategory(ctx context.Context, arg ListPubl
 }
 
 const listPublishedPostsByTag = `-- name: ListPublishedPostsByTag :many
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 JOIN post_tags pt ON p.id = pt.post_id
@@ -1450,8 +2785,10 @@ type ListPublishedPostsByTagRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
+	CommentCount int64          `json:"comment_count"`
 }
 
 func (q *Queries) ListPublishedPostsByTag(ctx context.Context, arg ListPublishedPostsByTagParams) ([]ListPublishedPostsByTagRow, error) {
@@ -1477,6 +2814,76 @@ func (q *Queries) ListPublishedPostsByTag(ctx context.Context, arg ListPublished
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
+			&i.CategoryName,
+			&i.CategorySlug,
+			&i.CommentCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledPosts = `-- name: ListScheduledPosts :many
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'scheduled'
+ORDER BY p.published_at ASC
+LIMIT $1
+`
+
+type ListScheduledPostsRow struct {
+	ID           int32          `json:"id"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	Content      string         `json:"content"`
+	Excerpt      sql.NullString `json:"excerpt"`
+	CategoryID   sql.NullInt32  `json:"category_id"`
+	Status       sql.NullString `json:"status"`
+	ViewCount    sql.NullInt32  `json:"view_count"`
+	ReadingTime  sql.NullInt32  `json:"reading_time"`
+	Thumbnail    sql.NullString `json:"thumbnail"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	UpdatedAt    sql.NullTime   `json:"updated_at"`
+	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
+	CategoryName sql.NullString `json:"category_name"`
+	CategorySlug sql.NullString `json:"category_slug"`
+}
+
+func (q *Queries) ListScheduledPosts(ctx context.Context, limit int32) ([]ListScheduledPostsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listScheduledPosts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListScheduledPostsRow{}
+	for rows.Next() {
+		var i ListScheduledPostsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Content,
+			&i.Excerpt,
+			&i.CategoryID,
+			&i.Status,
+			&i.ViewCount,
+			&i.ReadingTime,
+			&i.Thumbnail,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
 		); err != nil {
@@ -1493,6 +2900,68 @@ func (q *Queries) ListPublishedPostsByTag(ctx context.Context, arg ListPublished
 	return items, nil
 }
 
+const listSigningKeys = `-- name: ListSigningKeys :many
+SELECT kid, algorithm, private_key, created_at, retired_at FROM jwt_signing_keys
+WHERE retired_at IS NULL OR retired_at > $1::timestamptz
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListSigningKeys(ctx context.Context, retiredAfter time.Time) ([]JwtSigningKey, error) {
+	rows, err := q.db.QueryContext(ctx, listSigningKeys, retiredAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []JwtSigningKey{}
+	for rows.Next() {
+		var i JwtSigningKey
+		if err := rows.Scan(
+			&i.Kid,
+			&i.Algorithm,
+			&i.PrivateKey,
+			&i.CreatedAt,
+			&i.RetiredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSpamClasses = `-- name: ListSpamClasses :many
+SELECT label, documents FROM spam_classes
+`
+
+func (q *Queries) ListSpamClasses(ctx context.Context) ([]SpamClass, error) {
+	rows, err := q.db.QueryContext(ctx, listSpamClasses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SpamClass{}
+	for rows.Next() {
+		var i SpamClass
+		if err := rows.Scan(&i.Label, &i.Documents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTags = `-- name: ListTags :many
 
 SELECT id, name, slug, created_at FROM tags ORDER BY name ASC
@@ -1546,21 +3015,159 @@ type ListTagsWithPostCountRow struct {
 	PostCount int64        `json:"post_count"`
 }
 
-func (q *Queries) ListTagsWithPostCount(ctx context.Context) ([]ListTagsWithPostCountRow, error) {
-	rows, err := q.db.QueryContext(ctx, listTagsWithPostCount)
+func (q *Queries) ListTagsWithPostCount(ctx context.Context) ([]ListTagsWithPostCountRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTagsWithPostCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTagsWithPostCountRow{}
+	for rows.Next() {
+		var i ListTagsWithPostCountRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.CreatedAt,
+			&i.PostCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnusedMedia = `-- name: ListUnusedMedia :many
+SELECT id, filename, original_name, path, url, mime_type, size, width, height, created_at, blurhash, lqip, dominant_color, exif, content_hash FROM media
+WHERE NOT EXISTS (SELECT 1 FROM media_usages u WHERE u.media_id = media.id)
+ORDER BY created_at DESC LIMIT $1 OFFSET $2
+`
+
+type ListUnusedMediaParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListUnusedMedia(ctx context.Context, arg ListUnusedMediaParams) ([]Medium, error) {
+	rows, err := q.db.QueryContext(ctx, listUnusedMedia, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Medium{}
+	for rows.Next() {
+		var i Medium
+		if err := rows.Scan(
+			&i.ID,
+			&i.Filename,
+			&i.OriginalName,
+			&i.Path,
+			&i.Url,
+			&i.MimeType,
+			&i.Size,
+			&i.Width,
+			&i.Height,
+			&i.CreatedAt,
+			&i.Blurhash,
+			&i.Lqip,
+			&i.DominantColor,
+			&i.Exif,
+			&i.ContentHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVisibleCommentsByPost = `-- name: ListVisibleCommentsByPost :many
+SELECT id, post_id, parent_id, author_name, author_email, github_handle, content, status, ip_hash, created_at, updated_at, spam_score, spam_trained_as FROM comments
+WHERE post_id = $1 AND status <> 'pending'
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListVisibleCommentsByPost(ctx context.Context, postID int32) ([]Comment, error) {
+	rows, err := q.db.QueryContext(ctx, listVisibleCommentsByPost, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Comment{}
+	for rows.Next() {
+		var i Comment
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.ParentID,
+			&i.AuthorName,
+			&i.AuthorEmail,
+			&i.GithubHandle,
+			&i.Content,
+			&i.Status,
+			&i.IpHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.SpamScore,
+			&i.SpamTrainedAs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishDuePosts = `-- name: PublishDuePosts :many
+UPDATE posts
+SET status = 'published', updated_at = NOW()
+WHERE status = 'scheduled' AND published_at <= $1
+RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at, author_id
+`
+
+func (q *Queries) PublishDuePosts(ctx context.Context, publishedAt sql.NullTime) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, publishDuePosts, publishedAt)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ListTagsWithPostCountRow{}
+	items := []Post{}
 	for rows.Next() {
-		var i ListTagsWithPostCountRow
+		var i Post
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
+			&i.Title,
 			&i.Slug,
+			&i.Content,
+			&i.Excerpt,
+			&i.CategoryID,
+			&i.Status,
+			&i.ViewCount,
+			&i.ReadingTime,
+			&i.Thumbnail,
 			&i.CreatedAt,
-			&i.PostCount,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.AuthorID,
 		); err != nil {
 			return nil, err
 		}
@@ -1579,7 +3186,7 @@ const publishPost = `-- name: PublishPost :one
 UPDATE posts
 SET status = 'published', published_at = NOW(), updated_at = NOW()
 WHERE id = $1
-RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at
+RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at, author_id
 `
 
 func (q *Queries) PublishPost(ctx context.Context, id int32) (Post, error) {
@@ -1599,6 +3206,7 @@ func (q *Queries) PublishPost(ctx context.Context, id int32) (Post, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
 	)
 	return i, err
 }
@@ -1626,20 +3234,339 @@ func (q *Queries) RemovePostTag(ctx context.Context, arg RemovePostTagParams) er
 	return err
 }
 
+const retireSigningKeys = `-- name: RetireSigningKeys :exec
+UPDATE jwt_signing_keys
+SET retired_at = NOW()
+WHERE retired_at IS NULL AND kid <> $1
+`
+
+func (q *Queries) RetireSigningKeys(ctx context.Context, kid string) error {
+	_, err := q.db.ExecContext(ctx, retireSigningKeys, kid)
+	return err
+}
+
+const revokeAPIKey = `-- name: RevokeAPIKey :one
+UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1
+RETURNING id, admin_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, id int32) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, revokeAPIKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const schedulePost = `-- name: SchedulePost :one
+UPDATE posts
+SET status = 'scheduled', published_at = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at, author_id
+`
+
+type SchedulePostParams struct {
+	ID          int32         `json:"id"`
+	PublishedAt sql.NullTime  `json:"published_at"`
+	AuthorID    sql.NullInt32 `json:"author_id"`
+}
+
+func (q *Queries) SchedulePost(ctx context.Context, arg SchedulePostParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, schedulePost, arg.ID, arg.PublishedAt)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.Content,
+		&i.Excerpt,
+		&i.CategoryID,
+		&i.Status,
+		&i.ViewCount,
+		&i.ReadingTime,
+		&i.Thumbnail,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishedAt,
+		&i.AuthorID,
+	)
+	return i, err
+}
+
+const searchFacetCategories = `-- name: SearchFacetCategories :many
+SELECT c.id, c.name, c.slug, COUNT(*) as post_count
+FROM posts p
+INNER JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower($1::text))
+    OR lower(p.content) LIKE likequery(lower($1::text))
+    OR lower(c.name) LIKE likequery(lower($1::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower($1::text))
+    )
+  )
+  AND ($2::text = '' OR c.slug = $2::text)
+  AND ($3::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = $3::text
+  ))
+  AND ($4::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = $4::int)
+GROUP BY c.id, c.name, c.slug
+ORDER BY post_count DESC, c.name
+`
+
+type SearchFacetCategoriesParams struct {
+	Query        string `json:"query"`
+	CategorySlug string `json:"category_slug"`
+	TagSlug      string `json:"tag_slug"`
+	Year         int32  `json:"year"`
+}
+
+type SearchFacetCategoriesRow struct {
+	ID        int32  `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+}
+
+func (q *Queries) SearchFacetCategories(ctx context.Context, arg SearchFacetCategoriesParams) ([]SearchFacetCategoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchFacetCategories,
+		arg.Query,
+		arg.CategorySlug,
+		arg.TagSlug,
+		arg.Year,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFacetCategoriesRow{}
+	for rows.Next() {
+		var i SearchFacetCategoriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.PostCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchFacetTags = `-- name: SearchFacetTags :many
+SELECT t.id, t.name, t.slug, COUNT(*) as post_count
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+INNER JOIN post_tags pt ON pt.post_id = p.id
+INNER JOIN tags t ON t.id = pt.tag_id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower($1::text))
+    OR lower(p.content) LIKE likequery(lower($1::text))
+    OR lower(c.name) LIKE likequery(lower($1::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower($1::text))
+    )
+  )
+  AND ($2::text = '' OR c.slug = $2::text)
+  AND ($3::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = $3::text
+  ))
+  AND ($4::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = $4::int)
+GROUP BY t.id, t.name, t.slug
+ORDER BY post_count DESC, t.name
+`
+
+type SearchFacetTagsParams struct {
+	Query        string `json:"query"`
+	CategorySlug string `json:"category_slug"`
+	TagSlug      string `json:"tag_slug"`
+	Year         int32  `json:"year"`
+}
+
+type SearchFacetTagsRow struct {
+	ID        int32  `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	PostCount int64  `json:"post_count"`
+}
+
+func (q *Queries) SearchFacetTags(ctx context.Context, arg SearchFacetTagsParams) ([]SearchFacetTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchFacetTags,
+		arg.Query,
+		arg.CategorySlug,
+		arg.TagSlug,
+		arg.Year,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFacetTagsRow{}
+	for rows.Next() {
+		var i SearchFacetTagsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Slug,
+			&i.PostCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchFacetYears = `-- name: SearchFacetYears :many
+SELECT EXTRACT(YEAR FROM p.published_at)::int as year, COUNT(*) as post_count
+FROM posts p
+LEFT JOIN categories c ON p.category_id = c.id
+WHERE p.status = 'published'
+  AND (
+    lower(p.title) LIKE likequery(lower($1::text))
+    OR lower(p.content) LIKE likequery(lower($1::text))
+    OR lower(c.name) LIKE likequery(lower($1::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower($1::text))
+    )
+  )
+  AND ($2::text = '' OR c.slug = $2::text)
+  AND ($3::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = $3::text
+  ))
+  AND ($4::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = $4::int)
+  AND p.published_at IS NOT NULL
+GROUP BY year
+ORDER BY year DESC
+`
+
+type SearchFacetYearsParams struct {
+	Query        string `json:"query"`
+	CategorySlug string `json:"category_slug"`
+	TagSlug      string `json:"tag_slug"`
+	Year         int32  `json:"year"`
+}
+
+type SearchFacetYearsRow struct {
+	Year      int32 `json:"year"`
+	PostCount int64 `json:"post_count"`
+}
+
+func (q *Queries) SearchFacetYears(ctx context.Context, arg SearchFacetYearsParams) ([]SearchFacetYearsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchFacetYears,
+		arg.Query,
+		arg.CategorySlug,
+		arg.TagSlug,
+		arg.Year,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFacetYearsRow{}
+	for rows.Next() {
+		var i SearchFacetYearsRow
+		if err := rows.Scan(
+			&i.Year,
+			&i.PostCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchPublishedPosts = `-- name: SearchPublishedPosts :many
-SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, c.name as category_name, c.slug as category_slug
+SELECT p.id, p.title, p.slug, p.content, p.excerpt, p.category_id, p.status, p.view_count, p.reading_time, p.thumbnail, p.created_at, p.updated_at, p.published_at, p.author_id, c.name as category_name, c.slug as category_slug,
+    (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = p.id AND cm.status = 'approved') as comment_count,
+    (
+        bigm_similarity($1::text, p.title) * 4
+        + bigm_similarity($1::text, COALESCE(p.excerpt, '')) * 2
+        + bigm_similarity($1::text, COALESCE(c.name, '')) * 1.5
+        + COALESCE((
+            SELECT MAX(bigm_similarity($1::text, t.name))
+            FROM tags t
+            INNER JOIN post_tags pt ON t.id = pt.tag_id
+            WHERE pt.post_id = p.id
+        ), 0) * 1.5
+        + bigm_similarity($1::text, p.content)
+    )::float8 as score
 FROM posts p
 LEFT JOIN categories c ON p.category_id = c.id
 WHERE p.status = 'published'
-  AND (p.title ILIKE '%' || $1 || '%' OR p.content ILIKE '%' || $1 || '%')
-ORDER BY p.published_at DESC
-LIMIT $2 OFFSET $3
+  AND (
+    lower(p.title) LIKE likequery(lower($1::text))
+    OR lower(p.content) LIKE likequery(lower($1::text))
+    OR lower(c.name) LIKE likequery(lower($1::text))
+    OR EXISTS (
+        SELECT 1 FROM tags st
+        INNER JOIN post_tags spt ON st.id = spt.tag_id
+        WHERE spt.post_id = p.id AND lower(st.name) LIKE likequery(lower($1::text))
+    )
+  )
+  AND ($2::text = '' OR c.slug = $2::text)
+  AND ($3::text = '' OR EXISTS (
+        SELECT 1 FROM tags ft
+        INNER JOIN post_tags fpt ON ft.id = fpt.tag_id
+        WHERE fpt.post_id = p.id AND ft.slug = $3::text
+  ))
+  AND ($4::int = 0 OR EXTRACT(YEAR FROM p.published_at)::int = $4::int)
+ORDER BY score DESC, p.published_at DESC
+LIMIT $5 OFFSET $6
 `
 
 type SearchPublishedPostsParams struct {
-	Column1 sql.NullString `json:"column_1"`
-	Limit   int32          `json:"limit"`
-	Offset  int32          `json:"offset"`
+	Query        string `json:"query"`
+	CategorySlug string `json:"category_slug"`
+	TagSlug      string `json:"tag_slug"`
+	Year         int32  `json:"year"`
+	Limit        int32  `json:"limit"`
+	Offset       int32  `json:"offset"`
 }
 
 type SearchPublishedPostsRow struct {
@@ -1656,12 +3583,22 @@ type SearchPublishedPostsRow struct {
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	UpdatedAt    sql.NullTime   `json:"updated_at"`
 	PublishedAt  sql.NullTime   `json:"published_at"`
+	AuthorID     sql.NullInt32  `json:"author_id"`
 	CategoryName sql.NullString `json:"category_name"`
 	CategorySlug sql.NullString `json:"category_slug"`
+	CommentCount int64          `json:"comment_count"`
+	Score        float64        `json:"score"`
 }
 
 func (q *Queries) SearchPublishedPosts(ctx context.Context, arg SearchPublishedPostsParams) ([]SearchPublishedPostsRow, error) {
-	rows, err := q.db.QueryContext(ctx, searchPublishedPosts, arg.Column1, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, searchPublishedPosts,
+		arg.Query,
+		arg.CategorySlug,
+		arg.TagSlug,
+		arg.Year,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1683,8 +3620,11 @@ func (q *Queries) SearchPublishedPosts(ctx context.Context, arg SearchPublishedP
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.PublishedAt,
+			&i.AuthorID,
 			&i.CategoryName,
 			&i.CategorySlug,
+			&i.CommentCount,
+			&i.Score,
 		); err != nil {
 			return nil, err
 		}
@@ -1699,6 +3639,67 @@ func (q *Queries) SearchPublishedPosts(ctx context.Context, arg SearchPublishedP
 	return items, nil
 }
 
+const setAdminDisabled = `-- name: SetAdminDisabled :one
+UPDATE admins
+SET disabled_at = CASE WHEN $1::bool THEN COALESCE(disabled_at, NOW()) ELSE NULL END,
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject
+`
+
+type SetAdminDisabledParams struct {
+	Disabled bool  `json:"disabled"`
+	ID       int32 `json:"id"`
+}
+
+func (q *Queries) SetAdminDisabled(ctx context.Context, arg SetAdminDisabledParams) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, setAdminDisabled, arg.Disabled, arg.ID)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
+	)
+	return i, err
+}
+
+const setAdminTOTPSecret = `-- name: SetAdminTOTPSecret :exec
+UPDATE admins SET totp_secret = $2, totp_enabled_at = NULL, updated_at = NOW() WHERE id = $1
+`
+
+type SetAdminTOTPSecretParams struct {
+	ID         int32          `json:"id"`
+	TotpSecret sql.NullString `json:"totp_secret"`
+}
+
+func (q *Queries) SetAdminTOTPSecret(ctx context.Context, arg SetAdminTOTPSecretParams) error {
+	_, err := q.db.ExecContext(ctx, setAdminTOTPSecret, arg.ID, arg.TotpSecret)
+	return err
+}
+
+const setCommentSpamTrainedAs = `-- name: SetCommentSpamTrainedAs :exec
+UPDATE comments SET spam_trained_as = $2 WHERE id = $1
+`
+
+type SetCommentSpamTrainedAsParams struct {
+	ID            int32          `json:"id"`
+	SpamTrainedAs sql.NullString `json:"spam_trained_as"`
+}
+
+func (q *Queries) SetCommentSpamTrainedAs(ctx context.Context, arg SetCommentSpamTrainedAsParams) error {
+	_, err := q.db.ExecContext(ctx, setCommentSpamTrainedAs, arg.ID, arg.SpamTrainedAs)
+	return err
+}
+
 const setPostTags = `-- name: SetPostTags :exec
 DELETE FROM post_tags WHERE post_id = $1
 `
@@ -1708,11 +3709,23 @@ func (q *Queries) SetPostTags(ctx context.Context, postID int32) error {
 	return err
 }
 
+const touchAPIKey = `-- name: TouchAPIKey :exec
+UPDATE api_keys SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+`
+
+func (q *Queries) TouchAPIKey(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, touchAPIKey, id)
+	return err
+}
+
 const unpublishPost = `-- name: UnpublishPost :one
 UPDATE posts
-SET status = 'draft', updated_at = NOW()
+SET status = 'draft',
+    published_at = CASE WHEN status = 'scheduled' THEN NULL ELSE published_at END,
+    updated_at = NOW()
 WHERE id = $1
-RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at
+RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at, author_id
 `
 
 func (q *Queries) UnpublishPost(ctx context.Context, id int32) (Post, error) {
@@ -1732,6 +3745,67 @@ func (q *Queries) UnpublishPost(ctx context.Context, id int32) (Post, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
+	)
+	return i, err
+}
+
+const updateAPIKey = `-- name: UpdateAPIKey :one
+UPDATE api_keys SET name = $2, scopes = $3 WHERE id = $1
+RETURNING id, admin_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+`
+
+type UpdateAPIKeyParams struct {
+	ID     int32    `json:"id"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func (q *Queries) UpdateAPIKey(ctx context.Context, arg UpdateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, updateAPIKey, arg.ID, arg.Name, pq.Array(arg.Scopes))
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateAdminIdentity = `-- name: UpdateAdminIdentity :one
+UPDATE admins SET email = $2, oidc_subject = $3, updated_at = NOW() WHERE id = $1
+RETURNING id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject
+`
+
+type UpdateAdminIdentityParams struct {
+	ID          int32          `json:"id"`
+	Email       sql.NullString `json:"email"`
+	OidcSubject sql.NullString `json:"oidc_subject"`
+}
+
+func (q *Queries) UpdateAdminIdentity(ctx context.Context, arg UpdateAdminIdentityParams) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, updateAdminIdentity, arg.ID, arg.Email, arg.OidcSubject)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
 	)
 	return i, err
 }
@@ -1750,6 +3824,36 @@ func (q *Queries) UpdateAdminPassword(ctx context.Context, arg UpdateAdminPasswo
 	return err
 }
 
+const updateAdminRole = `-- name: UpdateAdminRole :one
+UPDATE admins SET role = $2, updated_at = NOW() WHERE id = $1
+RETURNING id, username, password, created_at, updated_at, role, disabled_at, totp_secret, totp_enabled_at, totp_last_step, email, oidc_subject
+`
+
+type UpdateAdminRoleParams struct {
+	ID   int32  `json:"id"`
+	Role string `json:"role"`
+}
+
+func (q *Queries) UpdateAdminRole(ctx context.Context, arg UpdateAdminRoleParams) (Admin, error) {
+	row := q.db.QueryRowContext(ctx, updateAdminRole, arg.ID, arg.Role)
+	var i Admin
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Password,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Role,
+		&i.DisabledAt,
+		&i.TotpSecret,
+		&i.TotpEnabledAt,
+		&i.TotpLastStep,
+		&i.Email,
+		&i.OidcSubject,
+	)
+	return i, err
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories
 SET name = $2, slug = $3, description = $4, sort_order = $5
@@ -1785,12 +3889,30 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 	return i, err
 }
 
+const updateCommentsStatus = `-- name: UpdateCommentsStatus :execrows
+UPDATE comments SET status = $1, updated_at = NOW()
+WHERE id = ANY($2::int[])
+`
+
+type UpdateCommentsStatusParams struct {
+	Status string  `json:"status"`
+	Ids    []int32 `json:"ids"`
+}
+
+func (q *Queries) UpdateCommentsStatus(ctx context.Context, arg UpdateCommentsStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateCommentsStatus, arg.Status, pq.Array(arg.Ids))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updatePost = `-- name: UpdatePost :one
 UPDATE posts
 SET title = $2, slug = $3, content = $4, excerpt = $5, category_id = $6,
     reading_time = $7, thumbnail = $8, updated_at = NOW()
 WHERE id = $1
-RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at
+RETURNING id, title, slug, content, excerpt, category_id, status, view_count, reading_time, thumbnail, created_at, updated_at, published_at, author_id
 `
 
 type UpdatePostParams struct {
@@ -1830,6 +3952,7 @@ func (q *Queries) UpdatePost(ctx context.Context, arg UpdatePostParams) (Post, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.PublishedAt,
+		&i.AuthorID,
 	)
 	return i, err
 }
@@ -1931,3 +4054,39 @@ func (q *Queries) UpdateTag(ctx context.Context, arg UpdateTagParams) (Tag, erro
 	)
 	return i, err
 }
+
+const useAdminRecoveryCode = `-- name: UseAdminRecoveryCode :execrows
+UPDATE admin_recovery_codes SET used_at = NOW()
+WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL
+`
+
+type UseAdminRecoveryCodeParams struct {
+	AdminID  int32  `json:"admin_id"`
+	CodeHash string `json:"code_hash"`
+}
+
+func (q *Queries) UseAdminRecoveryCode(ctx context.Context, arg UseAdminRecoveryCodeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useAdminRecoveryCode, arg.AdminID, arg.CodeHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const useAdminTOTPStep = `-- name: UseAdminTOTPStep :execrows
+UPDATE admins SET totp_last_step = $2
+WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2)
+`
+
+type UseAdminTOTPStepParams struct {
+	ID           int32         `json:"id"`
+	TotpLastStep sql.NullInt64 `json:"totp_last_step"`
+}
+
+func (q *Queries) UseAdminTOTPStep(ctx context.Context, arg UseAdminTOTPStepParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useAdminTOTPStep, arg.ID, arg.TotpLastStep)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
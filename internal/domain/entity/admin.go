@@ -1,24 +1,213 @@
 package entity
 
-import "time"
+import (
+	"slices"
+	"time"
+)
+
+// AdminRole represents the role of an admin account
+type AdminRole string
+
+const (
+	// AdminRoleOwner can do everything, including managing other admins
+	AdminRoleOwner AdminRole = "owner"
+	// AdminRoleEditor manages all content but not admins
+	AdminRoleEditor AdminRole = "editor"
+	// AdminRoleAuthor writes and publishes their own posts and uploads media
+	AdminRoleAuthor AdminRole = "author"
+	// AdminRoleViewer has read-only access to the admin API
+	AdminRoleViewer AdminRole = "viewer"
+)
+
+// Permission represents an action guarded by role-based access control
+type Permission string
+
+const (
+	PermissionContentRead      Permission = "content:read"
+	PermissionPostWrite        Permission = "posts:write"
+	PermissionPostEditAny      Permission = "posts:edit_any"
+	PermissionTaxonomyWrite    Permission = "taxonomy:write"
+	PermissionProjectWrite     Permission = "projects:write"
+	PermissionMediaUpload      Permission = "media:upload"
+	PermissionMediaDelete      Permission = "media:delete"
+	PermissionCommentsModerate Permission = "comments:moderate"
+	PermissionAdminsManage     Permission = "admins:manage"
+)
+
+var rolePermissions = map[AdminRole][]Permission{
+	AdminRoleOwner: {
+		PermissionContentRead, PermissionPostWrite, PermissionPostEditAny, PermissionTaxonomyWrite,
+		PermissionProjectWrite, PermissionMediaUpload, PermissionMediaDelete, PermissionCommentsModerate,
+		PermissionAdminsManage,
+	},
+	AdminRoleEditor: {
+		PermissionContentRead, PermissionPostWrite, PermissionPostEditAny, PermissionTaxonomyWrite,
+		PermissionProjectWrite, PermissionMediaUpload, PermissionMediaDelete, PermissionCommentsModerate,
+	},
+	AdminRoleAuthor: {
+		PermissionContentRead, PermissionPostWrite, PermissionMediaUpload,
+	},
+	AdminRoleViewer: {
+		PermissionContentRead,
+	},
+}
+
+// IsValid returns true if the role is a known admin role
+func (r AdminRole) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// IsValid returns true if the permission is a known permission
+func (p Permission) IsValid() bool {
+	return AdminRoleOwner.Can(p)
+}
+
+// Can returns true if the role grants the permission
+func (r AdminRole) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
 
 // Admin represents an admin user entity
 type Admin struct {
-	ID        int32
-	Username  string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	ID         int32
+	Username   string
+	Password   string
+	Role       AdminRole
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+	DisabledAt *time.Time
+	// TOTPSecret is set when enrollment starts; TOTPEnabledAt once a code has confirmed it
+	TOTPSecret    string
+	TOTPEnabledAt *time.Time
+	// Email and OIDCSubject map an OpenID Connect identity to the admin
+	Email       string
+	OIDCSubject string
+}
+
+// IsDisabled returns true if the admin can no longer sign in
+func (a *Admin) IsDisabled() bool {
+	return a.DisabledAt != nil
+}
+
+// IsInvited returns true if the admin was invited but has not set a password yet
+func (a *Admin) IsInvited() bool {
+	return a.Password == ""
+}
+
+// TOTPEnabled returns true if logging in requires a second factor
+func (a *Admin) TOTPEnabled() bool {
+	return a.TOTPEnabledAt != nil
+}
+
+// Actor identifies the authenticated admin performing an operation
+type Actor struct {
+	AdminID int32
+	Role    AdminRole
+	// Scopes limits the role's permissions when the request was made with an API key; nil otherwise
+	Scopes []Permission
+}
+
+// Can returns true if the actor's role, and scopes if any, grant the permission
+func (a Actor) Can(p Permission) bool {
+	if a.Scopes != nil && !slices.Contains(a.Scopes, p) {
+		return false
+	}
+	return a.Role.Can(p)
+}
+
+// AdminInvitation represents a newly invited admin and the one-time token to set their password
+type AdminInvitation struct {
+	Admin     *Admin
+	Token     string
+	ExpiresAt time.Time
+}
+
+// PasswordReset represents a one-time token to set a new password without the current one
+type PasswordReset struct {
+	Admin     *Admin
+	Token     string
+	ExpiresAt time.Time
+}
+
+// OIDCIdentity is the verified identity from an OpenID Connect ID token
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
 }
 
-// TokenInfo represents JWT token information
+// OIDCLoginState is kept between redirecting to the identity provider and its callback.
+// Verifier is the PKCE code verifier; Nonce must come back in the ID token.
+type OIDCLoginState struct {
+	Verifier string
+	Nonce    string
+}
+
+// OIDCAuthorization is where to send the browser to sign in with the identity provider.
+// State has to be passed back together with the authorization code.
+type OIDCAuthorization struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
+
+// TokenInfo represents the tokens issued at login or refresh
 type TokenInfo struct {
+	Token            string
+	ExpiresAt        time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// LoginResult is the outcome of the password step of a login.
+// Either Tokens is set, or MFAChallenge is when the admin has two-factor authentication enabled.
+type LoginResult struct {
+	Tokens       *TokenInfo
+	MFAChallenge *MFAChallenge
+}
+
+// MFAChallenge is a short-lived token proving the password step succeeded,
+// exchanged for tokens together with a TOTP or recovery code
+type MFAChallenge struct {
 	Token     string
 	ExpiresAt time.Time
 }
 
-// Claims represents JWT claims
+// TOTPEnrollment is a pending TOTP secret to be added to an authenticator app
+type TOTPEnrollment struct {
+	Secret string
+	// URI is the otpauth:// key URI, usually rendered as a QR code
+	URI string
+}
+
+// Claims represents the authenticated admin of a request, from a JWT access token or an API key
 type Claims struct {
 	UserID   int32
 	Username string
+	Role     AdminRole
+	// TokenID is the jti claim, used to revoke a single access token
+	TokenID string
+	// SessionID is the refresh token family the access token was issued for
+	SessionID string
+	ExpiresAt time.Time
+	// APIKeyID and Scopes are set when the request was made with an API key
+	APIKeyID int32
+	Scopes   []Permission
+}
+
+// RefreshToken represents a stored refresh token.
+// Tokens are rotated on every use and belong to a family (one login session);
+// presenting a token that was already rotated revokes the whole family.
+type RefreshToken struct {
+	TokenHash string
+	FamilyID  string
+	AdminID   int32
+	Used      bool
 }
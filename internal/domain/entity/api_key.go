@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKey represents a long-lived key for automation clients such as CI.
+// Requests made with it act as the admin who created it, limited to its scopes.
+type APIKey struct {
+	ID      int32
+	AdminID int32
+	Name    string
+	// Prefix is the start of the key, shown to tell keys apart; the key itself is only stored hashed
+	Prefix     string
+	Scopes     []Permission
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// IsRevoked returns true if the key was revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// IsExpired returns true if the key has an expiry that has passed
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// HasScope returns true if the key was granted the permission
+func (k *APIKey) HasScope(p Permission) bool {
+	return slices.Contains(k.Scopes, p)
+}
+
+// APIKeyWithAdmin is an API key together with the admin it acts as
+type APIKeyWithAdmin struct {
+	APIKey
+	Admin Admin
+}
+
+// CreatedAPIKey is a new API key and its secret, which is only shown once
+type CreatedAPIKey struct {
+	Key    *APIKey
+	Secret string
+}
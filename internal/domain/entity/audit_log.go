@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// AuditAction identifies a recorded admin action as "<target>.<verb>"
+type AuditAction string
+
+const (
+	AuditActionPostCreate      AuditAction = "post.create"
+	AuditActionPostUpdate      AuditAction = "post.update"
+	AuditActionPostDelete      AuditAction = "post.delete"
+	AuditActionPostPublish     AuditAction = "post.publish"
+	AuditActionPostUnpublish   AuditAction = "post.unpublish"
+	AuditActionPostSchedule    AuditAction = "post.schedule"
+	AuditActionCategoryCreate  AuditAction = "category.create"
+	AuditActionCategoryUpdate  AuditAction = "category.update"
+	AuditActionCategoryDelete  AuditAction = "category.delete"
+	AuditActionTagCreate       AuditAction = "tag.create"
+	AuditActionTagUpdate       AuditAction = "tag.update"
+	AuditActionTagDelete       AuditAction = "tag.delete"
+	AuditActionProjectCreate   AuditAction = "project.create"
+	AuditActionProjectUpdate   AuditAction = "project.update"
+	AuditActionProjectDelete   AuditAction = "project.delete"
+	AuditActionProjectReorder  AuditAction = "project.reorder"
+	AuditActionMediaUpload     AuditAction = "media.upload"
+	AuditActionMediaDelete     AuditAction = "media.delete"
+	AuditActionCommentModerate AuditAction = "comment.moderate"
+	AuditActionLogin           AuditAction = "auth.login"
+	AuditActionLoginFailed     AuditAction = "auth.login_failed"
+	AuditActionPasswordChange  AuditAction = "auth.password_change"
+	AuditActionPasswordIssue   AuditAction = "auth.password_reset_issue"
+	AuditActionPasswordReset   AuditAction = "auth.password_reset"
+	AuditActionOIDCLink        AuditAction = "auth.oidc_link"
+	AuditActionAdminInvite     AuditAction = "admin.invite"
+	AuditActionAdminRole       AuditAction = "admin.update_role"
+	AuditActionAdminStatus     AuditAction = "admin.update_status"
+	AuditActionAdminIdentity   AuditAction = "admin.update_identity"
+	AuditActionLockoutClear    AuditAction = "admin.clear_lockout"
+	AuditActionAPIKeyCreate    AuditAction = "api_key.create"
+	AuditActionAPIKeyUpdate    AuditAction = "api_key.update"
+	AuditActionAPIKeyRevoke    AuditAction = "api_key.revoke"
+)
+
+// AuditLog records who changed what, with a summary of the target before and after the change
+type AuditLog struct {
+	ID int64
+	// AdminID is nil for failed logins with an unknown username and once the admin is deleted
+	AdminID    *int32
+	Username   string
+	APIKeyID   *int32
+	Action     AuditAction
+	TargetType string
+	TargetID   string
+	// Before and After hold the main fields of the target; Before is nil for creations, After for deletions
+	Before    map[string]any
+	After     map[string]any
+	IP        string
+	RequestID string
+	CreatedAt time.Time
+}
+
+// AuditLogFilter narrows the audit log; zero values match everything
+type AuditLogFilter struct {
+	AdminID    int32
+	Action     AuditAction
+	TargetType string
+	TargetID   string
+	Since      *time.Time
+	Until      *time.Time
+}
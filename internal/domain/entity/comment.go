@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+// CommentStatus represents the moderation state of a comment
+type CommentStatus string
+
+const (
+	CommentStatusPending  CommentStatus = "pending"
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusSpam     CommentStatus = "spam"
+	CommentStatusDeleted  CommentStatus = "deleted"
+)
+
+// IsValid returns true if the status is a known comment status
+func (s CommentStatus) IsValid() bool {
+	switch s {
+	case CommentStatusPending, CommentStatusApproved, CommentStatusSpam, CommentStatusDeleted:
+		return true
+	}
+	return false
+}
+
+// Comment represents a reader comment on a post
+// A comment is identified either by name (and optional email) or by a GitHub handle
+type Comment struct {
+	ID           int32
+	PostID       int32
+	ParentID     *int32
+	AuthorName   string
+	AuthorEmail  string
+	GitHubHandle string
+	Content      string
+	Status       CommentStatus
+	IPHash       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// SpamScore is the combined spam filter score at submission (0 = clean, 1 = spam)
+	SpamScore float64
+	// SpamTrainedAs is the class the comment was last used to train the spam classifier with
+	SpamTrainedAs SpamLabel
+}
+
+// IsApproved returns true if the comment is publicly visible
+func (c *Comment) IsApproved() bool {
+	return c.Status == CommentStatusApproved
+}
+
+// CommentWithPost represents a comment with the post it belongs to, for moderation
+type CommentWithPost struct {
+	Comment
+	PostTitle string
+	PostSlug  string
+}
+
+// CommentThread represents a comment and its replies
+// Removed comments that still have visible replies are kept as placeholders
+type CommentThread struct {
+	Comment
+	Replies []*CommentThread
+}
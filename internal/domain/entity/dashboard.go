@@ -4,9 +4,10 @@ import "time"
 
 // DashboardStats represents the dashboard statistics
 type DashboardStats struct {
-	Posts       PostStats
-	Categories  []CategoryStats
-	RecentPosts []RecentPost
+	Posts          PostStats
+	Categories     []CategoryStats
+	RecentPosts    []RecentPost
+	ScheduledPosts []RecentPost
 }
 
 // PostStats represents post statistics
@@ -14,6 +15,7 @@ type PostStats struct {
 	Total     int64
 	Published int64
 	Draft     int64
+	Scheduled int64
 }
 
 // CategoryStats represents category with post count
@@ -24,7 +26,7 @@ type CategoryStats struct {
 	PostCount int64
 }
 
-// RecentPost represents a recent post for dashboard
+// RecentPost represents a recent or scheduled post for dashboard
 type RecentPost struct {
 	ID          int32
 	Title       string
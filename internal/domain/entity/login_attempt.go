@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// LoginSubjectKind is what failed logins are counted against
+type LoginSubjectKind string
+
+const (
+	LoginSubjectIP       LoginSubjectKind = "ip"
+	LoginSubjectUsername LoginSubjectKind = "username"
+)
+
+// IsValid returns true if the kind is a known login subject kind
+func (k LoginSubjectKind) IsValid() bool {
+	return k == LoginSubjectIP || k == LoginSubjectUsername
+}
+
+// LoginSubject is a client IP or a username that failed logins are counted against
+type LoginSubject struct {
+	Kind  LoginSubjectKind
+	Value string
+}
+
+// Key returns the subject as "kind:value"
+func (s LoginSubject) Key() string {
+	return string(s.Kind) + ":" + s.Value
+}
+
+// ParseLoginSubject parses a "kind:value" key. IPv6 values keep their colons.
+func ParseLoginSubject(key string) (LoginSubject, bool) {
+	kind, value, ok := strings.Cut(key, ":")
+	subject := LoginSubject{Kind: LoginSubjectKind(kind), Value: value}
+	if !ok || value == "" || !subject.Kind.IsValid() {
+		return LoginSubject{}, false
+	}
+	return subject, true
+}
+
+// LoginLockout is the throttling state of a login subject
+type LoginLockout struct {
+	Subject LoginSubject
+	// Failures counts failed logins in the current window
+	Failures int64
+	// Locked is true when the failure threshold was reached, as opposed to a backoff delay
+	Locked bool
+	// RetryAfter is how long logins stay blocked; zero if not blocked
+	RetryAfter time.Duration
+}
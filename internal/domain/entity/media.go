@@ -13,9 +13,95 @@ type Media struct {
 	Size         int64
 	Width        int32
 	Height       int32
-	ThumbnailSM  string
-	ThumbnailMD  string
-	CreatedAt    time.Time
+	// Variants are the resized copies and alternative formats generated for
+	// processed images, narrowest first; empty for files stored as-is (GIF, SVG)
+	Variants []MediaVariant
+	// Placeholder is nil for files stored as-is
+	Placeholder *MediaPlaceholder
+	// EXIF is nil unless the upload carried camera information
+	EXIF *MediaEXIF
+	// ContentHash is the hex SHA-256 of the uploaded bytes, used to detect
+	// re-uploads; empty for files uploaded before it was recorded
+	ContentHash string
+	CreatedAt   time.Time
+}
+
+// MediaVariantOriginal names the variants that re-encode the full-size image
+// in another format
+const MediaVariantOriginal = "original"
+
+// MediaVariant is one stored rendition of a media file
+type MediaVariant struct {
+	// Name is the configured size name (e.g. "md"), or MediaVariantOriginal
+	Name     string
+	MimeType string
+	Path     string
+	URL      string
+	Width    int32
+	Height   int32
+	Size     int64
+}
+
+// MediaPlaceholder holds the previews a client can show while an image loads
+type MediaPlaceholder struct {
+	BlurHash      string
+	LQIP          string // tiny JPEG as a data: URI
+	DominantColor string // #rrggbb
+}
+
+// MediaEXIF is the camera information read from a photo's EXIF data.
+// The location and identifying tags (serial numbers, owner) are never kept.
+type MediaEXIF struct {
+	CameraMake   string
+	CameraModel  string
+	LensModel    string
+	FocalLength  float64 // millimetres
+	FNumber      float64
+	ExposureTime string // seconds, e.g. "1/250"
+	ISO          int
+	CapturedAt   string // camera-local time as "2006-01-02T15:04:05"; EXIF has no time zone
+}
+
+// MediaUsageSource is the kind of record a media file is used by
+type MediaUsageSource string
+
+const (
+	MediaUsageSourcePost    MediaUsageSource = "post"
+	MediaUsageSourceProject MediaUsageSource = "project"
+)
+
+// Fields of a post or project that can reference media
+const (
+	MediaUsageFieldContent   = "content"
+	MediaUsageFieldThumbnail = "thumbnail"
+	MediaUsageFieldImages    = "images"
+)
+
+// MediaReference is a URL found in one field of a post or project
+type MediaReference struct {
+	Field string
+	URL   string
+}
+
+// MediaUsage is a post or project field that references a media file,
+// directly or through one of its variants
+type MediaUsage struct {
+	MediaID     int32
+	SourceType  MediaUsageSource
+	SourceID    int32
+	SourceTitle string
+	SourceSlug  string
+	Field       string
+}
+
+// Variant returns the rendition with the given name and MIME type, or nil
+func (m *Media) Variant(name, mimeType string) *MediaVariant {
+	for i := range m.Variants {
+		if m.Variants[i].Name == name && m.Variants[i].MimeType == mimeType {
+			return &m.Variants[i]
+		}
+	}
+	return nil
 }
 
 // UploadedFile represents the result of a file upload
@@ -26,6 +112,41 @@ type UploadedFile struct {
 	URL          string
 	MimeType     string
 	Size         int64
-	ThumbnailSM  string
-	ThumbnailMD  string
+	Width        int32
+	Height       int32
+	Variants     []MediaVariant
+	Placeholder  *MediaPlaceholder
+	EXIF         *MediaEXIF
+	// MetadataRemoved lists the kinds of embedded metadata (e.g. "gps",
+	// "exif", "xmp") stripped from the stored files
+	MetadataRemoved []string
+	// Deduplicated is set when the same file was uploaded before and the
+	// existing media is returned instead of a new one
+	Deduplicated bool
+}
+
+// StoredObject is a file in object storage
+type StoredObject struct {
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+// MediaObject is a storage path recorded for a media file or one of its variants
+type MediaObject struct {
+	MediaID int32
+	Path    string
+}
+
+// StorageReport is the result of comparing object storage with the media records
+type StorageReport struct {
+	// Orphans are uploaded objects no media file or variant refers to
+	Orphans []StoredObject
+	// Dangling are recorded paths whose object is missing from storage
+	Dangling []MediaObject
+	// Deleted are the paths of the orphans that were removed
+	Deleted []string
+	// DeleteRefused is set when orphans were kept because dangling records
+	// suggest recorded paths are wrong and the orphans may be files in use
+	DeleteRefused bool
 }
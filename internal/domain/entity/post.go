@@ -8,6 +8,7 @@ type PostStatus string
 const (
 	PostStatusDraft     PostStatus = "draft"
 	PostStatusPublished PostStatus = "published"
+	PostStatusScheduled PostStatus = "scheduled"
 )
 
 // Post represents a blog post entity
@@ -25,6 +26,8 @@ type Post struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	PublishedAt *time.Time
+	// AuthorID is the admin who created the post, nil for posts created before roles existed
+	AuthorID *int32
 }
 
 // IsPublished returns true if the post is published
@@ -37,6 +40,11 @@ func (p *Post) IsDraft() bool {
 	return p.Status == PostStatusDraft
 }
 
+// IsScheduled returns true if the post is waiting to be published at PublishedAt
+func (p *Post) IsScheduled() bool {
+	return p.Status == PostStatusScheduled
+}
+
 // TagBrief represents minimal tag information for post responses
 type TagBrief struct {
 	ID   int32
@@ -50,4 +58,68 @@ type PostWithDetails struct {
 	CategoryName string
 	CategorySlug string
 	Tags         []TagBrief
+	CommentCount int64 // approved comments, only filled in list queries
+	// ThumbnailPlaceholder is set when Thumbnail is the URL of an uploaded image
+	ThumbnailPlaceholder *MediaPlaceholder
+}
+
+// PostSearchResult represents a post matched by search with its relevance
+type PostSearchResult struct {
+	PostWithDetails
+	Score      float64
+	Highlights map[string][]string // matched fragments keyed by field (title, excerpt, content)
+}
+
+// PostSearchFilter narrows a search to a category, tag or publication year
+// Zero values do not filter
+type PostSearchFilter struct {
+	CategorySlug string
+	TagSlug      string
+	Year         int32
+}
+
+// SearchFacet represents a filter value with the number of matching posts
+type SearchFacet struct {
+	Value string // the value to pass back as a filter (slug or year)
+	Label string
+	Count int64
+}
+
+// PostSearchFacets represents the drill-down counts of a search
+type PostSearchFacets struct {
+	Categories []SearchFacet
+	Tags       []SearchFacet
+	Years      []SearchFacet
+}
+
+// PostRevision represents a snapshot of a post taken before it was overwritten
+type PostRevision struct {
+	ID        int32
+	PostID    int32
+	Title     string
+	Content   string
+	Excerpt   string
+	Tags      []TagBrief
+	CreatedAt time.Time
+}
+
+// TagIDs returns the IDs of the tags captured in the revision
+func (r *PostRevision) TagIDs() []int32 {
+	ids := make([]int32, len(r.Tags))
+	for i, t := range r.Tags {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// PostRevisionDiff represents unified diffs between two versions of a post
+// Each field is empty when that part of the post did not change
+type PostRevisionDiff struct {
+	PostID         int32
+	FromRevisionID int32
+	ToRevisionID   *int32 // nil means the current post
+	Title          string
+	Excerpt        string
+	Tags           string
+	Content        string
 }
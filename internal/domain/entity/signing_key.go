@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"crypto"
+	"time"
+)
+
+// SigningAlgorithm is the JWS algorithm access tokens are signed with
+type SigningAlgorithm string
+
+const (
+	// SigningAlgorithmHS256 signs with JWT_SECRET; only this server can verify tokens
+	SigningAlgorithmHS256 SigningAlgorithm = "HS256"
+	// SigningAlgorithmRS256 signs with rotating RSA keys published as a JWKS
+	SigningAlgorithmRS256 SigningAlgorithm = "RS256"
+	// SigningAlgorithmEdDSA signs with rotating Ed25519 keys published as a JWKS
+	SigningAlgorithmEdDSA SigningAlgorithm = "EdDSA"
+)
+
+// IsValid returns true if the algorithm is supported
+func (a SigningAlgorithm) IsValid() bool {
+	switch a {
+	case SigningAlgorithmHS256, SigningAlgorithmRS256, SigningAlgorithmEdDSA:
+		return true
+	}
+	return false
+}
+
+// IsAsymmetric returns true if tokens are signed with a private key and verified with a public one
+func (a SigningAlgorithm) IsAsymmetric() bool {
+	return a == SigningAlgorithmRS256 || a == SigningAlgorithmEdDSA
+}
+
+// SigningKey is an asymmetric key access tokens are signed with, named in the token header by its ID.
+// Only the newest key signs; retired keys verify tokens until those have expired.
+type SigningKey struct {
+	ID        string
+	Algorithm SigningAlgorithm
+	// PrivateKey is an *rsa.PrivateKey for RS256 and an ed25519.PrivateKey for EdDSA
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	RetiredAt  *time.Time
+}
+
+// PublicKey returns the key that verifies tokens signed with the key
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
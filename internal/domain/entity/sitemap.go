@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// SitemapURL represents a single page entry in an XML sitemap
+type SitemapURL struct {
+	Loc     string
+	LastMod *time.Time
+}
+
+// PostSitemapEntry is the slice of a published post the sitemap needs
+type PostSitemapEntry struct {
+	ID          int32
+	Slug        string
+	CategoryID  *int32
+	TagIDs      []int32
+	UpdatedAt   *time.Time
+	PublishedAt *time.Time
+}
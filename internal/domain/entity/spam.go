@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// SpamLabel is a training class of the spam classifier
+type SpamLabel string
+
+const (
+	SpamLabelSpam SpamLabel = "spam"
+	SpamLabelHam  SpamLabel = "ham"
+)
+
+// SpamCandidate is a submitted comment as seen by the spam filters
+type SpamCandidate struct {
+	PostID       int32
+	AuthorName   string
+	AuthorEmail  string
+	GitHubHandle string
+	Content      string
+	// Honeypot is a form field hidden from humans; bots tend to fill it in
+	Honeypot string
+	// FormToken is the signed token handed out when the comment form was rendered
+	FormToken   string
+	SubmittedAt time.Time
+}
+
+// SpamVerdict is the outcome of running a candidate through the spam filters
+// Scores holds the individual score of each filter by name
+type SpamVerdict struct {
+	Score  float64
+	Spam   bool
+	Scores map[string]float64
+}
+
+// SpamTokenCount is the number of training documents of each class that contained a token
+type SpamTokenCount struct {
+	Token string
+	Spam  int64
+	Ham   int64
+}
+
+// SpamCorpus is the number of training documents per class
+type SpamCorpus struct {
+	Spam int64
+	Ham  int64
+}
@@ -0,0 +1,24 @@
+package entity
+
+// SuggestionType identifies what a search suggestion points to
+type SuggestionType string
+
+const (
+	SuggestionTypePost     SuggestionType = "post"
+	SuggestionTypeTag      SuggestionType = "tag"
+	SuggestionTypeCategory SuggestionType = "category"
+)
+
+// SearchSuggestion represents an autocomplete entry for the search box
+type SearchSuggestion struct {
+	Type  SuggestionType
+	ID    int32
+	Title string
+	Slug  string
+}
+
+// SuggestionEntry is a suggestion together with the terms it is indexed under
+type SuggestionEntry struct {
+	Suggestion SearchSuggestion
+	Terms      []string
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Category errors
 var (
@@ -18,8 +22,25 @@ var (
 
 // Post errors
 var (
-	ErrPostNotFound = errors.New("post not found")
-	ErrSlugExists   = errors.New("slug already exists")
+	ErrPostNotFound     = errors.New("post not found")
+	ErrSlugExists       = errors.New("slug already exists")
+	ErrRevisionNotFound = errors.New("post revision not found")
+	ErrScheduleInPast   = errors.New("scheduled publish time must be in the future")
+)
+
+// Comment errors
+var (
+	ErrCommentNotFound        = errors.New("comment not found")
+	ErrCommentContentRequired = errors.New("comment content is required")
+	ErrCommentAuthorRequired  = errors.New("comment author name or github handle is required")
+	ErrInvalidGitHubHandle    = errors.New("invalid github handle")
+	ErrInvalidCommentParent   = errors.New("comment parent must be an approved comment on the same post")
+	ErrInvalidCommentStatus   = errors.New("invalid comment status")
+)
+
+// Sitemap errors
+var (
+	ErrSitemapNotFound = errors.New("sitemap not found")
 )
 
 // Project errors
@@ -34,10 +55,90 @@ var (
 	ErrInvalidFileType = errors.New("invalid file type")
 	ErrFileTooLarge    = errors.New("file too large")
 	ErrUploadFailed    = errors.New("upload failed")
+	ErrMediaDuplicate  = errors.New("media with the same content already exists")
+	ErrMediaInUse      = errors.New("media is used by posts or projects")
 )
 
 // Auth errors
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrAdminNotFound      = errors.New("admin not found")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrAdminNotFound        = errors.New("admin not found")
+	ErrInvalidToken         = errors.New("invalid or expired token")
+	ErrTokenRevoked         = errors.New("token has been revoked")
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrInvalidRefreshToken  = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected")
+	ErrAdminDisabled        = errors.New("admin account is disabled")
+	ErrSigningKeyNotFound   = errors.New("signing key not found")
+)
+
+// Single sign-on errors
+var (
+	ErrOIDCDisabled        = errors.New("single sign-on is not configured")
+	ErrOIDCStateNotFound   = errors.New("single sign-on state not found")
+	ErrInvalidOIDCState    = errors.New("invalid or expired single sign-on state")
+	ErrOIDCExchangeFailed  = errors.New("single sign-on code exchange failed")
+	ErrOIDCAccountNotFound = errors.New("no admin is linked to this single sign-on identity")
+)
+
+// Password errors
+var (
+	ErrWrongPassword             = errors.New("current password is incorrect")
+	ErrPasswordResetNotFound     = errors.New("password reset not found")
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+)
+
+// Login throttling errors
+var (
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+	ErrAccountLocked        = errors.New("login locked after too many failed attempts")
+	ErrInvalidLoginSubject  = errors.New("invalid login lockout subject")
+)
+
+// LoginThrottledError wraps ErrTooManyLoginAttempts or ErrAccountLocked with how long
+// the client has to wait before trying again
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", e.Err, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// Two-factor authentication errors
+var (
+	ErrTOTPAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled      = errors.New("two-factor enrollment has not been started")
+	ErrTOTPNotEnabled       = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTOTPCode      = errors.New("invalid two-factor code")
+	ErrMFAChallengeNotFound = errors.New("two-factor challenge not found")
+	ErrInvalidMFAChallenge  = errors.New("invalid or expired two-factor challenge")
+)
+
+// API key errors
+var (
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrInvalidAPIKey       = errors.New("invalid, expired or revoked api key")
+	ErrInvalidAPIKeyScope  = errors.New("invalid api key scope")
+	ErrInvalidAPIKeyExpiry = errors.New("api key expiry must be in the future")
+)
+
+// Admin management errors
+var (
+	ErrAdminUsernameExists = errors.New("admin username already exists")
+	ErrAdminIdentityExists = errors.New("admin email or single sign-on subject already in use")
+	ErrInvalidAdminRole    = errors.New("invalid admin role")
+	ErrLastOwner           = errors.New("cannot demote or disable the last active owner")
+	ErrInviteNotFound      = errors.New("invitation not found")
+	ErrInvalidInviteToken  = errors.New("invalid or expired invitation token")
+)
+
+// Permission errors
+var (
+	ErrForbidden = errors.New("permission denied")
 )
@@ -14,9 +14,49 @@ type AdminRepository interface {
 	// FindByUsername returns an admin by username
 	FindByUsername(ctx context.Context, username string) (*entity.Admin, error)
 
+	// FindByEmail returns an admin by email, ignoring case
+	FindByEmail(ctx context.Context, email string) (*entity.Admin, error)
+
+	// FindByOIDCSubject returns the admin linked to an OpenID Connect subject
+	FindByOIDCSubject(ctx context.Context, subject string) (*entity.Admin, error)
+
+	// UpdateIdentity sets the email and OpenID Connect subject used for single sign-on
+	UpdateIdentity(ctx context.Context, id int32, email, subject string) (*entity.Admin, error)
+
 	// Create creates a new admin
 	Create(ctx context.Context, admin *entity.Admin) (*entity.Admin, error)
 
 	// UpdatePassword updates an admin's password
 	UpdatePassword(ctx context.Context, id int32, hashedPassword string) error
+
+	// DeleteInvited deletes an admin that has not accepted its invitation yet
+	DeleteInvited(ctx context.Context, id int32) error
+
+	// List returns all admins ordered by ID
+	List(ctx context.Context) ([]entity.Admin, error)
+
+	// UpdateRole changes an admin's role
+	UpdateRole(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error)
+
+	// SetDisabled disables or re-enables an admin
+	SetDisabled(ctx context.Context, id int32, disabled bool) (*entity.Admin, error)
+
+	// CountActiveOwners returns the number of owners that are not disabled
+	CountActiveOwners(ctx context.Context) (int64, error)
+
+	// SetTOTPSecret stores a pending TOTP secret, leaving two-factor authentication disabled
+	SetTOTPSecret(ctx context.Context, id int32, secret string) error
+
+	// EnableTOTP turns on two-factor authentication and replaces the admin's recovery codes
+	EnableTOTP(ctx context.Context, id int32, recoveryCodeHashes []string) error
+
+	// DisableTOTP clears the TOTP secret and deletes the admin's recovery codes
+	DisableTOTP(ctx context.Context, id int32) error
+
+	// UseRecoveryCode marks an unused recovery code as used, returning false if there was none
+	UseRecoveryCode(ctx context.Context, id int32, codeHash string) (bool, error)
+
+	// UseTOTPStep records the time step of an accepted TOTP code, returning false if that
+	// step or a later one was already used
+	UseTOTPStep(ctx context.Context, id int32, step int64) (bool, error)
 }
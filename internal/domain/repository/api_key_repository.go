@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// APIKeyRepository defines the interface for API key storage operations
+type APIKeyRepository interface {
+	// Create stores a new API key by the hash of its secret
+	Create(ctx context.Context, key *entity.APIKey, keyHash string) (*entity.APIKey, error)
+
+	// FindByID returns an API key by ID
+	FindByID(ctx context.Context, id int32) (*entity.APIKey, error)
+
+	// FindByHash returns the API key with the secret hash and the admin it acts as
+	FindByHash(ctx context.Context, keyHash string) (*entity.APIKeyWithAdmin, error)
+
+	// List returns all API keys, newest first
+	List(ctx context.Context) ([]entity.APIKey, error)
+
+	// ListByAdmin returns the API keys created by an admin, newest first
+	ListByAdmin(ctx context.Context, adminID int32) ([]entity.APIKey, error)
+
+	// Update changes the name and scopes of an API key
+	Update(ctx context.Context, id int32, name string, scopes []entity.Permission) (*entity.APIKey, error)
+
+	// Revoke marks an API key as revoked; revoking twice keeps the first time
+	Revoke(ctx context.Context, id int32) (*entity.APIKey, error)
+
+	// Touch records that an API key was used, at most once a minute
+	Touch(ctx context.Context, id int32) error
+}
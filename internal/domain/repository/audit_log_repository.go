@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// AuditLogRepository defines the interface for audit log storage operations
+type AuditLogRepository interface {
+	// Create appends an entry to the audit log
+	Create(ctx context.Context, log *entity.AuditLog) error
+
+	// List returns the entries matching the filter, newest first
+	List(ctx context.Context, filter entity.AuditLogFilter, limit, offset int32) ([]entity.AuditLog, error)
+
+	// Count returns the number of entries matching the filter
+	Count(ctx context.Context, filter entity.AuditLogFilter) (int64, error)
+}
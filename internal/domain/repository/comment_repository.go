@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// CommentRepository defines the interface for comment data access
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
+	FindByID(ctx context.Context, id int32) (*entity.Comment, error)
+	FindByIDs(ctx context.Context, ids []int32) ([]entity.Comment, error)
+
+	// ListVisibleByPost returns all moderated (non-pending) comments of a post, oldest first
+	ListVisibleByPost(ctx context.Context, postID int32) ([]entity.Comment, error)
+
+	// Moderation
+	ListByStatus(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, error)
+	CountByStatus(ctx context.Context, status entity.CommentStatus) (int64, error)
+	UpdateStatus(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error)
+
+	// SetSpamTrainedAs records which class the comment was used to train the spam classifier with
+	SetSpamTrainedAs(ctx context.Context, id int32, label entity.SpamLabel) error
+}
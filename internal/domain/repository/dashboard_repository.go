@@ -16,4 +16,7 @@ type DashboardRepository interface {
 
 	// GetRecentPosts returns the most recent posts
 	GetRecentPosts(ctx context.Context, limit int32) ([]entity.RecentPost, error)
+
+	// GetScheduledPosts returns upcoming scheduled posts, soonest first
+	GetScheduledPosts(ctx context.Context, limit int32) ([]entity.RecentPost, error)
 }
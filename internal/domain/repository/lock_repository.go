@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// LockRepository defines the interface for distributed locks shared between replicas (Redis-based)
+type LockRepository interface {
+	// Acquire takes the lock for owner if it is free, returns true if acquired
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+
+	// Release frees the lock only if it is still held by owner
+	Release(ctx context.Context, key, owner string) error
+}
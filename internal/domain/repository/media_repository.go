@@ -10,10 +10,29 @@ import (
 type MediaRepository interface {
 	// CRUD operations
 	FindByID(ctx context.Context, id int32) (*entity.Media, error)
+	FindByContentHash(ctx context.Context, hash string) (*entity.Media, error)
 	Create(ctx context.Context, media *entity.Media) (*entity.Media, error)
 	Delete(ctx context.Context, id int32) error
 
 	// List operations
 	List(ctx context.Context, limit, offset int32) ([]entity.Media, error)
 	Count(ctx context.Context) (int64, error)
+
+	// Unused media is referenced by no post or project
+	ListUnused(ctx context.Context, limit, offset int32) ([]entity.Media, error)
+	CountUnused(ctx context.Context) (int64, error)
+
+	// ListObjectPaths returns the storage path of every media file and variant
+	ListObjectPaths(ctx context.Context) ([]entity.MediaObject, error)
+
+	// Usage tracking
+	FindUsages(ctx context.Context, mediaID int32) ([]entity.MediaUsage, error)
+	// ReplaceUsages records the media referenced by a post or project,
+	// replacing what was recorded for it before; references to URLs that
+	// aren't media files or variants are ignored
+	ReplaceUsages(ctx context.Context, sourceType entity.MediaUsageSource, sourceID int32, refs []entity.MediaReference) error
+
+	// FindPlaceholdersByURLs maps each URL that belongs to a media file or
+	// one of its variants to that file's placeholder; unknown URLs are left out
+	FindPlaceholdersByURLs(ctx context.Context, urls []string) (map[string]entity.MediaPlaceholder, error)
 }
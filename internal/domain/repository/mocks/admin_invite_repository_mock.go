@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+)
+
+// MockAdminInviteRepository is a mock implementation of AdminInviteRepository
+type MockAdminInviteRepository struct {
+	SaveFunc    func(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error
+	ConsumeFunc func(ctx context.Context, tokenHash string) (int32, error)
+}
+
+func (m *MockAdminInviteRepository) Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, tokenHash, adminID, ttl)
+	}
+	return nil
+}
+
+func (m *MockAdminInviteRepository) Consume(ctx context.Context, tokenHash string) (int32, error) {
+	if m.ConsumeFunc != nil {
+		return m.ConsumeFunc(ctx, tokenHash)
+	}
+	return 0, nil
+}
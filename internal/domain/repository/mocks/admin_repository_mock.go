@@ -0,0 +1,147 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockAdminRepository is a mock implementation of AdminRepository
+type MockAdminRepository struct {
+	FindByIDFunc          func(ctx context.Context, id int32) (*entity.Admin, error)
+	FindByUsernameFunc    func(ctx context.Context, username string) (*entity.Admin, error)
+	FindByEmailFunc       func(ctx context.Context, email string) (*entity.Admin, error)
+	FindByOIDCSubjectFunc func(ctx context.Context, subject string) (*entity.Admin, error)
+	UpdateIdentityFunc    func(ctx context.Context, id int32, email, subject string) (*entity.Admin, error)
+	CreateFunc            func(ctx context.Context, admin *entity.Admin) (*entity.Admin, error)
+	UpdatePasswordFunc    func(ctx context.Context, id int32, hashedPassword string) error
+	DeleteInvitedFunc     func(ctx context.Context, id int32) error
+	ListFunc              func(ctx context.Context) ([]entity.Admin, error)
+	UpdateRoleFunc        func(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error)
+	SetDisabledFunc       func(ctx context.Context, id int32, disabled bool) (*entity.Admin, error)
+	CountActiveOwnersFunc func(ctx context.Context) (int64, error)
+	SetTOTPSecretFunc     func(ctx context.Context, id int32, secret string) error
+	EnableTOTPFunc        func(ctx context.Context, id int32, recoveryCodeHashes []string) error
+	DisableTOTPFunc       func(ctx context.Context, id int32) error
+	UseRecoveryCodeFunc   func(ctx context.Context, id int32, codeHash string) (bool, error)
+	UseTOTPStepFunc       func(ctx context.Context, id int32, step int64) (bool, error)
+}
+
+func (m *MockAdminRepository) FindByID(ctx context.Context, id int32) (*entity.Admin, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) FindByUsername(ctx context.Context, username string) (*entity.Admin, error) {
+	if m.FindByUsernameFunc != nil {
+		return m.FindByUsernameFunc(ctx, username)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) FindByEmail(ctx context.Context, email string) (*entity.Admin, error) {
+	if m.FindByEmailFunc != nil {
+		return m.FindByEmailFunc(ctx, email)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) FindByOIDCSubject(ctx context.Context, subject string) (*entity.Admin, error) {
+	if m.FindByOIDCSubjectFunc != nil {
+		return m.FindByOIDCSubjectFunc(ctx, subject)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) UpdateIdentity(ctx context.Context, id int32, email, subject string) (*entity.Admin, error) {
+	if m.UpdateIdentityFunc != nil {
+		return m.UpdateIdentityFunc(ctx, id, email, subject)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) Create(ctx context.Context, admin *entity.Admin) (*entity.Admin, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, admin)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) UpdatePassword(ctx context.Context, id int32, hashedPassword string) error {
+	if m.UpdatePasswordFunc != nil {
+		return m.UpdatePasswordFunc(ctx, id, hashedPassword)
+	}
+	return nil
+}
+
+func (m *MockAdminRepository) DeleteInvited(ctx context.Context, id int32) error {
+	if m.DeleteInvitedFunc != nil {
+		return m.DeleteInvitedFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockAdminRepository) List(ctx context.Context) ([]entity.Admin, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) UpdateRole(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error) {
+	if m.UpdateRoleFunc != nil {
+		return m.UpdateRoleFunc(ctx, id, role)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) SetDisabled(ctx context.Context, id int32, disabled bool) (*entity.Admin, error) {
+	if m.SetDisabledFunc != nil {
+		return m.SetDisabledFunc(ctx, id, disabled)
+	}
+	return nil, nil
+}
+
+func (m *MockAdminRepository) CountActiveOwners(ctx context.Context) (int64, error) {
+	if m.CountActiveOwnersFunc != nil {
+		return m.CountActiveOwnersFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockAdminRepository) SetTOTPSecret(ctx context.Context, id int32, secret string) error {
+	if m.SetTOTPSecretFunc != nil {
+		return m.SetTOTPSecretFunc(ctx, id, secret)
+	}
+	return nil
+}
+
+func (m *MockAdminRepository) EnableTOTP(ctx context.Context, id int32, recoveryCodeHashes []string) error {
+	if m.EnableTOTPFunc != nil {
+		return m.EnableTOTPFunc(ctx, id, recoveryCodeHashes)
+	}
+	return nil
+}
+
+func (m *MockAdminRepository) DisableTOTP(ctx context.Context, id int32) error {
+	if m.DisableTOTPFunc != nil {
+		return m.DisableTOTPFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockAdminRepository) UseRecoveryCode(ctx context.Context, id int32, codeHash string) (bool, error) {
+	if m.UseRecoveryCodeFunc != nil {
+		return m.UseRecoveryCodeFunc(ctx, id, codeHash)
+	}
+	return false, nil
+}
+
+func (m *MockAdminRepository) UseTOTPStep(ctx context.Context, id int32, step int64) (bool, error) {
+	if m.UseTOTPStepFunc != nil {
+		return m.UseTOTPStepFunc(ctx, id, step)
+	}
+	return false, nil
+}
@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	CreateFunc      func(ctx context.Context, key *entity.APIKey, keyHash string) (*entity.APIKey, error)
+	FindByIDFunc    func(ctx context.Context, id int32) (*entity.APIKey, error)
+	FindByHashFunc  func(ctx context.Context, keyHash string) (*entity.APIKeyWithAdmin, error)
+	ListFunc        func(ctx context.Context) ([]entity.APIKey, error)
+	ListByAdminFunc func(ctx context.Context, adminID int32) ([]entity.APIKey, error)
+	UpdateFunc      func(ctx context.Context, id int32, name string, scopes []entity.Permission) (*entity.APIKey, error)
+	RevokeFunc      func(ctx context.Context, id int32) (*entity.APIKey, error)
+	TouchFunc       func(ctx context.Context, id int32) error
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey, keyHash string) (*entity.APIKey, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, key, keyHash)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id int32) (*entity.APIKey, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKeyWithAdmin, error) {
+	if m.FindByHashFunc != nil {
+		return m.FindByHashFunc(ctx, keyHash)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) List(ctx context.Context) ([]entity.APIKey, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) ListByAdmin(ctx context.Context, adminID int32) ([]entity.APIKey, error) {
+	if m.ListByAdminFunc != nil {
+		return m.ListByAdminFunc(ctx, adminID)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) Update(ctx context.Context, id int32, name string, scopes []entity.Permission) (*entity.APIKey, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, name, scopes)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id int32) (*entity.APIKey, error) {
+	if m.RevokeFunc != nil {
+		return m.RevokeFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockAPIKeyRepository) Touch(ctx context.Context, id int32) error {
+	if m.TouchFunc != nil {
+		return m.TouchFunc(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockAuditLogRepository is a mock implementation of AuditLogRepository
+type MockAuditLogRepository struct {
+	CreateFunc func(ctx context.Context, log *entity.AuditLog) error
+	ListFunc   func(ctx context.Context, filter entity.AuditLogFilter, limit, offset int32) ([]entity.AuditLog, error)
+	CountFunc  func(ctx context.Context, filter entity.AuditLogFilter) (int64, error)
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, log)
+	}
+	return nil
+}
+
+func (m *MockAuditLogRepository) List(ctx context.Context, filter entity.AuditLogFilter, limit, offset int32) ([]entity.AuditLog, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockAuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int64, error) {
+	if m.CountFunc != nil {
+		return m.CountFunc(ctx, filter)
+	}
+	return 0, nil
+}
@@ -0,0 +1,75 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockCommentRepository is a mock implementation of CommentRepository
+type MockCommentRepository struct {
+	CreateFunc            func(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
+	FindByIDFunc          func(ctx context.Context, id int32) (*entity.Comment, error)
+	FindByIDsFunc         func(ctx context.Context, ids []int32) ([]entity.Comment, error)
+	ListVisibleByPostFunc func(ctx context.Context, postID int32) ([]entity.Comment, error)
+	ListByStatusFunc      func(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, error)
+	CountByStatusFunc     func(ctx context.Context, status entity.CommentStatus) (int64, error)
+	UpdateStatusFunc      func(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error)
+	SetSpamTrainedAsFunc  func(ctx context.Context, id int32, label entity.SpamLabel) error
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, comment)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentRepository) FindByID(ctx context.Context, id int32) (*entity.Comment, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentRepository) FindByIDs(ctx context.Context, ids []int32) ([]entity.Comment, error) {
+	if m.FindByIDsFunc != nil {
+		return m.FindByIDsFunc(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentRepository) ListVisibleByPost(ctx context.Context, postID int32) ([]entity.Comment, error) {
+	if m.ListVisibleByPostFunc != nil {
+		return m.ListVisibleByPostFunc(ctx, postID)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentRepository) ListByStatus(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, error) {
+	if m.ListByStatusFunc != nil {
+		return m.ListByStatusFunc(ctx, status, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockCommentRepository) CountByStatus(ctx context.Context, status entity.CommentStatus) (int64, error) {
+	if m.CountByStatusFunc != nil {
+		return m.CountByStatusFunc(ctx, status)
+	}
+	return 0, nil
+}
+
+func (m *MockCommentRepository) UpdateStatus(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error) {
+	if m.UpdateStatusFunc != nil {
+		return m.UpdateStatusFunc(ctx, ids, status)
+	}
+	return 0, nil
+}
+
+func (m *MockCommentRepository) SetSpamTrainedAs(ctx context.Context, id int32, label entity.SpamLabel) error {
+	if m.SetSpamTrainedAsFunc != nil {
+		return m.SetSpamTrainedAsFunc(ctx, id, label)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"time"
+)
+
+// MockLockRepository is a mock implementation of LockRepository
+type MockLockRepository struct {
+	AcquireFunc func(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	ReleaseFunc func(ctx context.Context, key, owner string) error
+}
+
+func (m *MockLockRepository) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	if m.AcquireFunc != nil {
+		return m.AcquireFunc(ctx, key, owner, ttl)
+	}
+	return false, nil
+}
+
+func (m *MockLockRepository) Release(ctx context.Context, key, owner string) error {
+	if m.ReleaseFunc != nil {
+		return m.ReleaseFunc(ctx, key, owner)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockLoginAttemptRepository is a mock implementation of LoginAttemptRepository
+type MockLoginAttemptRepository struct {
+	RecordFailureFunc func(ctx context.Context, subject entity.LoginSubject, window time.Duration) (int64, error)
+	BlockFunc         func(ctx context.Context, subject entity.LoginSubject, d time.Duration, locked bool) error
+	BlockedFunc       func(ctx context.Context, subject entity.LoginSubject) (time.Duration, bool, error)
+	ResetFunc         func(ctx context.Context, subject entity.LoginSubject) error
+	ListFunc          func(ctx context.Context) ([]entity.LoginLockout, error)
+}
+
+func (m *MockLoginAttemptRepository) RecordFailure(ctx context.Context, subject entity.LoginSubject, window time.Duration) (int64, error) {
+	if m.RecordFailureFunc != nil {
+		return m.RecordFailureFunc(ctx, subject, window)
+	}
+	return 0, nil
+}
+
+func (m *MockLoginAttemptRepository) Block(ctx context.Context, subject entity.LoginSubject, d time.Duration, locked bool) error {
+	if m.BlockFunc != nil {
+		return m.BlockFunc(ctx, subject, d, locked)
+	}
+	return nil
+}
+
+func (m *MockLoginAttemptRepository) Blocked(ctx context.Context, subject entity.LoginSubject) (time.Duration, bool, error) {
+	if m.BlockedFunc != nil {
+		return m.BlockedFunc(ctx, subject)
+	}
+	return 0, false, nil
+}
+
+func (m *MockLoginAttemptRepository) Reset(ctx context.Context, subject entity.LoginSubject) error {
+	if m.ResetFunc != nil {
+		return m.ResetFunc(ctx, subject)
+	}
+	return nil
+}
+
+func (m *MockLoginAttemptRepository) List(ctx context.Context) ([]entity.LoginLockout, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx)
+	}
+	return nil, nil
+}
@@ -0,0 +1,107 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockMediaRepository is a mock implementation of MediaRepository
+type MockMediaRepository struct {
+	FindByIDFunc               func(ctx context.Context, id int32) (*entity.Media, error)
+	FindByContentHashFunc      func(ctx context.Context, hash string) (*entity.Media, error)
+	CreateFunc                 func(ctx context.Context, media *entity.Media) (*entity.Media, error)
+	DeleteFunc                 func(ctx context.Context, id int32) error
+	ListFunc                   func(ctx context.Context, limit, offset int32) ([]entity.Media, error)
+	CountFunc                  func(ctx context.Context) (int64, error)
+	ListUnusedFunc             func(ctx context.Context, limit, offset int32) ([]entity.Media, error)
+	CountUnusedFunc            func(ctx context.Context) (int64, error)
+	ListObjectPathsFunc        func(ctx context.Context) ([]entity.MediaObject, error)
+	FindUsagesFunc             func(ctx context.Context, mediaID int32) ([]entity.MediaUsage, error)
+	ReplaceUsagesFunc          func(ctx context.Context, sourceType entity.MediaUsageSource, sourceID int32, refs []entity.MediaReference) error
+	FindPlaceholdersByURLsFunc func(ctx context.Context, urls []string) (map[string]entity.MediaPlaceholder, error)
+}
+
+func (m *MockMediaRepository) FindByID(ctx context.Context, id int32) (*entity.Media, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) FindByContentHash(ctx context.Context, hash string) (*entity.Media, error) {
+	if m.FindByContentHashFunc != nil {
+		return m.FindByContentHashFunc(ctx, hash)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) Create(ctx context.Context, media *entity.Media) (*entity.Media, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, media)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) Delete(ctx context.Context, id int32) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockMediaRepository) List(ctx context.Context, limit, offset int32) ([]entity.Media, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) Count(ctx context.Context) (int64, error) {
+	if m.CountFunc != nil {
+		return m.CountFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockMediaRepository) ListUnused(ctx context.Context, limit, offset int32) ([]entity.Media, error) {
+	if m.ListUnusedFunc != nil {
+		return m.ListUnusedFunc(ctx, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) CountUnused(ctx context.Context) (int64, error) {
+	if m.CountUnusedFunc != nil {
+		return m.CountUnusedFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockMediaRepository) ListObjectPaths(ctx context.Context) ([]entity.MediaObject, error) {
+	if m.ListObjectPathsFunc != nil {
+		return m.ListObjectPathsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) FindUsages(ctx context.Context, mediaID int32) ([]entity.MediaUsage, error) {
+	if m.FindUsagesFunc != nil {
+		return m.FindUsagesFunc(ctx, mediaID)
+	}
+	return nil, nil
+}
+
+func (m *MockMediaRepository) ReplaceUsages(ctx context.Context, sourceType entity.MediaUsageSource, sourceID int32, refs []entity.MediaReference) error {
+	if m.ReplaceUsagesFunc != nil {
+		return m.ReplaceUsagesFunc(ctx, sourceType, sourceID, refs)
+	}
+	return nil
+}
+
+func (m *MockMediaRepository) FindPlaceholdersByURLs(ctx context.Context, urls []string) (map[string]entity.MediaPlaceholder, error) {
+	if m.FindPlaceholdersByURLsFunc != nil {
+		return m.FindPlaceholdersByURLsFunc(ctx, urls)
+	}
+	return nil, nil
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+	"time"
+)
+
+// MockMFAChallengeRepository is a mock implementation of MFAChallengeRepository
+type MockMFAChallengeRepository struct {
+	SaveFunc    func(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error
+	AttemptFunc func(ctx context.Context, tokenHash string) (adminID int32, attempts int64, err error)
+	DeleteFunc  func(ctx context.Context, tokenHash string) error
+}
+
+func (m *MockMFAChallengeRepository) Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, tokenHash, adminID, ttl)
+	}
+	return nil
+}
+
+func (m *MockMFAChallengeRepository) Attempt(ctx context.Context, tokenHash string) (adminID int32, attempts int64, err error) {
+	if m.AttemptFunc != nil {
+		return m.AttemptFunc(ctx, tokenHash)
+	}
+	return 0, 0, nil
+}
+
+func (m *MockMFAChallengeRepository) Delete(ctx context.Context, tokenHash string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tokenHash)
+	}
+	return nil
+}
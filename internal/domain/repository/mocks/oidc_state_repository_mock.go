@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockOIDCStateRepository is a mock implementation of OIDCStateRepository
+type MockOIDCStateRepository struct {
+	SaveFunc    func(ctx context.Context, stateHash string, state *entity.OIDCLoginState, ttl time.Duration) error
+	ConsumeFunc func(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error)
+}
+
+func (m *MockOIDCStateRepository) Save(ctx context.Context, stateHash string, state *entity.OIDCLoginState, ttl time.Duration) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, stateHash, state, ttl)
+	}
+	return nil
+}
+
+func (m *MockOIDCStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	if m.ConsumeFunc != nil {
+		return m.ConsumeFunc(ctx, stateHash)
+	}
+	return nil, nil
+}
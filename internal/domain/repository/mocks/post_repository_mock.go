@@ -0,0 +1,284 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockPostRepository is a mock implementation of PostRepository
+type MockPostRepository struct {
+	FindByIDFunc                    func(ctx context.Context, id int32) (*entity.PostWithDetails, error)
+	FindBySlugFunc                  func(ctx context.Context, slug string) (*entity.PostWithDetails, error)
+	CreateFunc                      func(ctx context.Context, post *entity.Post) (*entity.Post, error)
+	UpdateFunc                      func(ctx context.Context, post *entity.Post) (*entity.Post, error)
+	DeleteFunc                      func(ctx context.Context, id int32) error
+	SlugExistsFunc                  func(ctx context.Context, slug string) (bool, error)
+	SlugExistsExceptFunc            func(ctx context.Context, slug string, excludeID int32) (bool, error)
+	FindPublishedBySlugFunc         func(ctx context.Context, slug string) (*entity.PostWithDetails, error)
+	ListPublishedFunc               func(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error)
+	CountPublishedFunc              func(ctx context.Context) (int64, error)
+	ListPublishedSitemapEntriesFunc func(ctx context.Context, afterID int32, limit int32) ([]entity.PostSitemapEntry, error)
+	ListPublishedByCategoryFunc     func(ctx context.Context, categoryID int32, limit, offset int32) ([]entity.PostWithDetails, error)
+	CountPublishedByCategoryFunc    func(ctx context.Context, categoryID int32) (int64, error)
+	ListPublishedByTagFunc          func(ctx context.Context, tagID int32, limit, offset int32) ([]entity.PostWithDetails, error)
+	CountPublishedByTagFunc         func(ctx context.Context, tagID int32) (int64, error)
+	SearchPublishedFunc             func(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, error)
+	CountSearchPublishedFunc        func(ctx context.Context, query string, filter entity.PostSearchFilter) (int64, error)
+	SearchFacetsFunc                func(ctx context.Context, query string, filter entity.PostSearchFilter) (*entity.PostSearchFacets, error)
+	ListAllFunc                     func(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error)
+	CountAllFunc                    func(ctx context.Context) (int64, error)
+	ListByStatusFunc                func(ctx context.Context, status entity.PostStatus, limit, offset int32) ([]entity.PostWithDetails, error)
+	CountByStatusFunc               func(ctx context.Context, status entity.PostStatus) (int64, error)
+	PublishFunc                     func(ctx context.Context, id int32) (*entity.Post, error)
+	UnpublishFunc                   func(ctx context.Context, id int32) (*entity.Post, error)
+	ScheduleFunc                    func(ctx context.Context, id int32, publishAt time.Time) (*entity.Post, error)
+	PublishDueFunc                  func(ctx context.Context, now time.Time) ([]entity.Post, error)
+	GetTagsFunc                     func(ctx context.Context, postID int32) ([]entity.TagBrief, error)
+	SetTagsFunc                     func(ctx context.Context, postID int32, tagIDs []int32) error
+	RemoveAllTagsFunc               func(ctx context.Context, postID int32) error
+	IncrementViewCountFunc          func(ctx context.Context, id int32) error
+	UpdateWithRevisionFunc          func(ctx context.Context, post *entity.Post, revision *entity.PostRevision) (*entity.Post, error)
+	ListRevisionsFunc               func(ctx context.Context, postID int32, limit, offset int32) ([]entity.PostRevision, error)
+	CountRevisionsFunc              func(ctx context.Context, postID int32) (int64, error)
+	FindRevisionFunc                func(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error)
+}
+
+func (m *MockPostRepository) FindByID(ctx context.Context, id int32) (*entity.PostWithDetails, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) FindBySlug(ctx context.Context, slug string) (*entity.PostWithDetails, error) {
+	if m.FindBySlugFunc != nil {
+		return m.FindBySlugFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) Create(ctx context.Context, post *entity.Post) (*entity.Post, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, post)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) Update(ctx context.Context, post *entity.Post) (*entity.Post, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, post)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) Delete(ctx context.Context, id int32) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPostRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	if m.SlugExistsFunc != nil {
+		return m.SlugExistsFunc(ctx, slug)
+	}
+	return false, nil
+}
+
+func (m *MockPostRepository) SlugExistsExcept(ctx context.Context, slug string, excludeID int32) (bool, error) {
+	if m.SlugExistsExceptFunc != nil {
+		return m.SlugExistsExceptFunc(ctx, slug, excludeID)
+	}
+	return false, nil
+}
+
+func (m *MockPostRepository) FindPublishedBySlug(ctx context.Context, slug string) (*entity.PostWithDetails, error) {
+	if m.FindPublishedBySlugFunc != nil {
+		return m.FindPublishedBySlugFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) ListPublished(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error) {
+	if m.ListPublishedFunc != nil {
+		return m.ListPublishedFunc(ctx, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountPublished(ctx context.Context) (int64, error) {
+	if m.CountPublishedFunc != nil {
+		return m.CountPublishedFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) ListPublishedSitemapEntries(ctx context.Context, afterID int32, limit int32) ([]entity.PostSitemapEntry, error) {
+	if m.ListPublishedSitemapEntriesFunc != nil {
+		return m.ListPublishedSitemapEntriesFunc(ctx, afterID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) ListPublishedByCategory(ctx context.Context, categoryID int32, limit, offset int32) ([]entity.PostWithDetails, error) {
+	if m.ListPublishedByCategoryFunc != nil {
+		return m.ListPublishedByCategoryFunc(ctx, categoryID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountPublishedByCategory(ctx context.Context, categoryID int32) (int64, error) {
+	if m.CountPublishedByCategoryFunc != nil {
+		return m.CountPublishedByCategoryFunc(ctx, categoryID)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) ListPublishedByTag(ctx context.Context, tagID int32, limit, offset int32) ([]entity.PostWithDetails, error) {
+	if m.ListPublishedByTagFunc != nil {
+		return m.ListPublishedByTagFunc(ctx, tagID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountPublishedByTag(ctx context.Context, tagID int32) (int64, error) {
+	if m.CountPublishedByTagFunc != nil {
+		return m.CountPublishedByTagFunc(ctx, tagID)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) SearchPublished(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, error) {
+	if m.SearchPublishedFunc != nil {
+		return m.SearchPublishedFunc(ctx, query, filter, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountSearchPublished(ctx context.Context, query string, filter entity.PostSearchFilter) (int64, error) {
+	if m.CountSearchPublishedFunc != nil {
+		return m.CountSearchPublishedFunc(ctx, query, filter)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) SearchFacets(ctx context.Context, query string, filter entity.PostSearchFilter) (*entity.PostSearchFacets, error) {
+	if m.SearchFacetsFunc != nil {
+		return m.SearchFacetsFunc(ctx, query, filter)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) ListAll(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error) {
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountAll(ctx context.Context) (int64, error) {
+	if m.CountAllFunc != nil {
+		return m.CountAllFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) ListByStatus(ctx context.Context, status entity.PostStatus, limit, offset int32) ([]entity.PostWithDetails, error) {
+	if m.ListByStatusFunc != nil {
+		return m.ListByStatusFunc(ctx, status, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountByStatus(ctx context.Context, status entity.PostStatus) (int64, error) {
+	if m.CountByStatusFunc != nil {
+		return m.CountByStatusFunc(ctx, status)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) Publish(ctx context.Context, id int32) (*entity.Post, error) {
+	if m.PublishFunc != nil {
+		return m.PublishFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) Unpublish(ctx context.Context, id int32) (*entity.Post, error) {
+	if m.UnpublishFunc != nil {
+		return m.UnpublishFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) Schedule(ctx context.Context, id int32, publishAt time.Time) (*entity.Post, error) {
+	if m.ScheduleFunc != nil {
+		return m.ScheduleFunc(ctx, id, publishAt)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) PublishDue(ctx context.Context, now time.Time) ([]entity.Post, error) {
+	if m.PublishDueFunc != nil {
+		return m.PublishDueFunc(ctx, now)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) GetTags(ctx context.Context, postID int32) ([]entity.TagBrief, error) {
+	if m.GetTagsFunc != nil {
+		return m.GetTagsFunc(ctx, postID)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) SetTags(ctx context.Context, postID int32, tagIDs []int32) error {
+	if m.SetTagsFunc != nil {
+		return m.SetTagsFunc(ctx, postID, tagIDs)
+	}
+	return nil
+}
+
+func (m *MockPostRepository) RemoveAllTags(ctx context.Context, postID int32) error {
+	if m.RemoveAllTagsFunc != nil {
+		return m.RemoveAllTagsFunc(ctx, postID)
+	}
+	return nil
+}
+
+func (m *MockPostRepository) IncrementViewCount(ctx context.Context, id int32) error {
+	if m.IncrementViewCountFunc != nil {
+		return m.IncrementViewCountFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockPostRepository) UpdateWithRevision(ctx context.Context, post *entity.Post, revision *entity.PostRevision) (*entity.Post, error) {
+	if m.UpdateWithRevisionFunc != nil {
+		return m.UpdateWithRevisionFunc(ctx, post, revision)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) ListRevisions(ctx context.Context, postID int32, limit, offset int32) ([]entity.PostRevision, error) {
+	if m.ListRevisionsFunc != nil {
+		return m.ListRevisionsFunc(ctx, postID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *MockPostRepository) CountRevisions(ctx context.Context, postID int32) (int64, error) {
+	if m.CountRevisionsFunc != nil {
+		return m.CountRevisionsFunc(ctx, postID)
+	}
+	return 0, nil
+}
+
+func (m *MockPostRepository) FindRevision(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error) {
+	if m.FindRevisionFunc != nil {
+		return m.FindRevisionFunc(ctx, postID, revisionID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,91 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockProjectRepository is a mock implementation of ProjectRepository
+type MockProjectRepository struct {
+	FindByIDFunc         func(ctx context.Context, id int32) (*entity.Project, error)
+	FindBySlugFunc       func(ctx context.Context, slug string) (*entity.Project, error)
+	CreateFunc           func(ctx context.Context, project *entity.Project) (*entity.Project, error)
+	UpdateFunc           func(ctx context.Context, project *entity.Project) (*entity.Project, error)
+	DeleteFunc           func(ctx context.Context, id int32) error
+	ListAllFunc          func(ctx context.Context) ([]entity.Project, error)
+	ListFeaturedFunc     func(ctx context.Context) ([]entity.Project, error)
+	SlugExistsFunc       func(ctx context.Context, slug string) (bool, error)
+	SlugExistsExceptFunc func(ctx context.Context, slug string, excludeID int32) (bool, error)
+	UpdateOrderFunc      func(ctx context.Context, id int32, sortOrder int32) error
+}
+
+func (m *MockProjectRepository) FindByID(ctx context.Context, id int32) (*entity.Project, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) FindBySlug(ctx context.Context, slug string) (*entity.Project, error) {
+	if m.FindBySlugFunc != nil {
+		return m.FindBySlugFunc(ctx, slug)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, project)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, project *entity.Project) (*entity.Project, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, project)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id int32) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *MockProjectRepository) ListAll(ctx context.Context) ([]entity.Project, error) {
+	if m.ListAllFunc != nil {
+		return m.ListAllFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) ListFeatured(ctx context.Context) ([]entity.Project, error) {
+	if m.ListFeaturedFunc != nil {
+		return m.ListFeaturedFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockProjectRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	if m.SlugExistsFunc != nil {
+		return m.SlugExistsFunc(ctx, slug)
+	}
+	return false, nil
+}
+
+func (m *MockProjectRepository) SlugExistsExcept(ctx context.Context, slug string, excludeID int32) (bool, error) {
+	if m.SlugExistsExceptFunc != nil {
+		return m.SlugExistsExceptFunc(ctx, slug, excludeID)
+	}
+	return false, nil
+}
+
+func (m *MockProjectRepository) UpdateOrder(ctx context.Context, id int32, sortOrder int32) error {
+	if m.UpdateOrderFunc != nil {
+		return m.UpdateOrderFunc(ctx, id, sortOrder)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockRefreshTokenRepository is a mock implementation of RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	SaveFunc                func(ctx context.Context, token *entity.RefreshToken, ttl time.Duration) error
+	FindFunc                func(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	ConsumeFunc             func(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	IsFamilyActiveFunc      func(ctx context.Context, familyID string) (bool, error)
+	RevokeFamilyFunc        func(ctx context.Context, familyID string) error
+	RevokeAdminFamiliesFunc func(ctx context.Context, adminID int32) error
+}
+
+func (m *MockRefreshTokenRepository) Save(ctx context.Context, token *entity.RefreshToken, ttl time.Duration) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, token, ttl)
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) Find(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	if m.FindFunc != nil {
+		return m.FindFunc(ctx, tokenHash)
+	}
+	return nil, nil
+}
+
+func (m *MockRefreshTokenRepository) Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	if m.ConsumeFunc != nil {
+		return m.ConsumeFunc(ctx, tokenHash)
+	}
+	return nil, nil
+}
+
+func (m *MockRefreshTokenRepository) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	if m.IsFamilyActiveFunc != nil {
+		return m.IsFamilyActiveFunc(ctx, familyID)
+	}
+	return false, nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if m.RevokeFamilyFunc != nil {
+		return m.RevokeFamilyFunc(ctx, familyID)
+	}
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeAdminFamilies(ctx context.Context, adminID int32) error {
+	if m.RevokeAdminFamiliesFunc != nil {
+		return m.RevokeAdminFamiliesFunc(ctx, adminID)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockSigningKeyRepository is a mock implementation of SigningKeyRepository
+type MockSigningKeyRepository struct {
+	CreateFunc        func(ctx context.Context, key *entity.SigningKey) (*entity.SigningKey, error)
+	ListFunc          func(ctx context.Context, retiredAfter time.Time) ([]entity.SigningKey, error)
+	RetireOthersFunc  func(ctx context.Context, kid string) error
+	DeleteRetiredFunc func(ctx context.Context, retiredBefore time.Time) error
+}
+
+func (m *MockSigningKeyRepository) Create(ctx context.Context, key *entity.SigningKey) (*entity.SigningKey, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, key)
+	}
+	return nil, nil
+}
+
+func (m *MockSigningKeyRepository) List(ctx context.Context, retiredAfter time.Time) ([]entity.SigningKey, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, retiredAfter)
+	}
+	return nil, nil
+}
+
+func (m *MockSigningKeyRepository) RetireOthers(ctx context.Context, kid string) error {
+	if m.RetireOthersFunc != nil {
+		return m.RetireOthersFunc(ctx, kid)
+	}
+	return nil
+}
+
+func (m *MockSigningKeyRepository) DeleteRetired(ctx context.Context, retiredBefore time.Time) error {
+	if m.DeleteRetiredFunc != nil {
+		return m.DeleteRetiredFunc(ctx, retiredBefore)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+	"time"
+)
+
+// MockSitemapCacheRepository is a mock implementation of SitemapCacheRepository
+type MockSitemapCacheRepository struct {
+	GetFunc        func(ctx context.Context, name string) (data []byte, found bool, err error)
+	SetAllFunc     func(ctx context.Context, docs map[string][]byte, ttl time.Duration) error
+	InvalidateFunc func(ctx context.Context) error
+}
+
+func (m *MockSitemapCacheRepository) Get(ctx context.Context, name string) (data []byte, found bool, err error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, name)
+	}
+	return nil, false, nil
+}
+
+func (m *MockSitemapCacheRepository) SetAll(ctx context.Context, docs map[string][]byte, ttl time.Duration) error {
+	if m.SetAllFunc != nil {
+		return m.SetAllFunc(ctx, docs, ttl)
+	}
+	return nil
+}
+
+func (m *MockSitemapCacheRepository) Invalidate(ctx context.Context) error {
+	if m.InvalidateFunc != nil {
+		return m.InvalidateFunc(ctx)
+	}
+	return nil
+}
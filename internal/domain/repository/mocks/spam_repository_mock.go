@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockSpamRepository is a mock implementation of SpamRepository
+type MockSpamRepository struct {
+	GetTokenCountsFunc func(ctx context.Context, tokens []string) ([]entity.SpamTokenCount, error)
+	GetCorpusFunc      func(ctx context.Context) (*entity.SpamCorpus, error)
+	AdjustTokensFunc   func(ctx context.Context, tokens []string, spamDelta, hamDelta int32) error
+	AdjustCorpusFunc   func(ctx context.Context, spamDelta, hamDelta int32) error
+}
+
+func (m *MockSpamRepository) GetTokenCounts(ctx context.Context, tokens []string) ([]entity.SpamTokenCount, error) {
+	if m.GetTokenCountsFunc != nil {
+		return m.GetTokenCountsFunc(ctx, tokens)
+	}
+	return nil, nil
+}
+
+func (m *MockSpamRepository) GetCorpus(ctx context.Context) (*entity.SpamCorpus, error) {
+	if m.GetCorpusFunc != nil {
+		return m.GetCorpusFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockSpamRepository) AdjustTokens(ctx context.Context, tokens []string, spamDelta, hamDelta int32) error {
+	if m.AdjustTokensFunc != nil {
+		return m.AdjustTokensFunc(ctx, tokens, spamDelta, hamDelta)
+	}
+	return nil
+}
+
+func (m *MockSpamRepository) AdjustCorpus(ctx context.Context, spamDelta, hamDelta int32) error {
+	if m.AdjustCorpusFunc != nil {
+		return m.AdjustCorpusFunc(ctx, spamDelta, hamDelta)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockStorageRepository is a mock implementation of StorageRepository
+type MockStorageRepository struct {
+	UploadFunc      func(ctx context.Context, path string, file io.Reader, size int64, contentType string) error
+	DeleteFunc      func(ctx context.Context, path string) error
+	ListFunc        func(ctx context.Context, prefix string) ([]entity.StoredObject, error)
+	GenerateURLFunc func(path string) string
+}
+
+func (m *MockStorageRepository) Upload(ctx context.Context, path string, file io.Reader, size int64, contentType string) error {
+	if m.UploadFunc != nil {
+		return m.UploadFunc(ctx, path, file, size, contentType)
+	}
+	return nil
+}
+
+func (m *MockStorageRepository) Delete(ctx context.Context, path string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, path)
+	}
+	return nil
+}
+
+func (m *MockStorageRepository) List(ctx context.Context, prefix string) ([]entity.StoredObject, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, prefix)
+	}
+	return nil, nil
+}
+
+func (m *MockStorageRepository) GenerateURL(path string) string {
+	if m.GenerateURLFunc != nil {
+		return m.GenerateURLFunc(path)
+	}
+	return ""
+}
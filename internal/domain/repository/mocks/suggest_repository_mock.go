@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// MockSuggestRepository is a mock implementation of SuggestRepository
+type MockSuggestRepository struct {
+	PutFunc            func(ctx context.Context, suggestion *entity.SearchSuggestion, terms []string) error
+	DeleteFunc         func(ctx context.Context, suggestionType entity.SuggestionType, id int32) error
+	FindByPrefixesFunc func(ctx context.Context, prefixes []string, limit int) ([]entity.SearchSuggestion, error)
+	ReplaceFunc        func(ctx context.Context, entries []entity.SuggestionEntry) error
+}
+
+func (m *MockSuggestRepository) Put(ctx context.Context, suggestion *entity.SearchSuggestion, terms []string) error {
+	if m.PutFunc != nil {
+		return m.PutFunc(ctx, suggestion, terms)
+	}
+	return nil
+}
+
+func (m *MockSuggestRepository) Delete(ctx context.Context, suggestionType entity.SuggestionType, id int32) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, suggestionType, id)
+	}
+	return nil
+}
+
+func (m *MockSuggestRepository) FindByPrefixes(ctx context.Context, prefixes []string, limit int) ([]entity.SearchSuggestion, error) {
+	if m.FindByPrefixesFunc != nil {
+		return m.FindByPrefixesFunc(ctx, prefixes, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockSuggestRepository) Replace(ctx context.Context, entries []entity.SuggestionEntry) error {
+	if m.ReplaceFunc != nil {
+		return m.ReplaceFunc(ctx, entries)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+	"time"
+)
+
+// MockTokenDenylistRepository is a mock implementation of TokenDenylistRepository
+type MockTokenDenylistRepository struct {
+	AddFunc                func(ctx context.Context, tokenID string, ttl time.Duration) error
+	ContainsFunc           func(ctx context.Context, tokenID string) (bool, error)
+	RevokeIssuedBeforeFunc func(ctx context.Context, adminID int32, before time.Time, ttl time.Duration) error
+	RevokedBeforeFunc      func(ctx context.Context, adminID int32) (time.Time, error)
+}
+
+func (m *MockTokenDenylistRepository) Add(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if m.AddFunc != nil {
+		return m.AddFunc(ctx, tokenID, ttl)
+	}
+	return nil
+}
+
+func (m *MockTokenDenylistRepository) Contains(ctx context.Context, tokenID string) (bool, error) {
+	if m.ContainsFunc != nil {
+		return m.ContainsFunc(ctx, tokenID)
+	}
+	return false, nil
+}
+
+func (m *MockTokenDenylistRepository) RevokeIssuedBefore(ctx context.Context, adminID int32, before time.Time, ttl time.Duration) error {
+	if m.RevokeIssuedBeforeFunc != nil {
+		return m.RevokeIssuedBeforeFunc(ctx, adminID, before, ttl)
+	}
+	return nil
+}
+
+func (m *MockTokenDenylistRepository) RevokedBefore(ctx context.Context, adminID int32) (time.Time, error) {
+	if m.RevokedBeforeFunc != nil {
+		return m.RevokedBeforeFunc(ctx, adminID)
+	}
+	return time.Time{}, nil
+}
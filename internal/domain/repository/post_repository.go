@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 )
@@ -23,6 +24,8 @@ type PostRepository interface {
 	FindPublishedBySlug(ctx context.Context, slug string) (*entity.PostWithDetails, error)
 	ListPublished(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error)
 	CountPublished(ctx context.Context) (int64, error)
+	// ListPublishedSitemapEntries pages published posts by ID, returning those after afterID
+	ListPublishedSitemapEntries(ctx context.Context, afterID int32, limit int32) ([]entity.PostSitemapEntry, error)
 
 	// Filter by category
 	ListPublishedByCategory(ctx context.Context, categoryID int32, limit, offset int32) ([]entity.PostWithDetails, error)
@@ -33,8 +36,9 @@ type PostRepository interface {
 	CountPublishedByTag(ctx context.Context, tagID int32) (int64, error)
 
 	// Search
-	SearchPublished(ctx context.Context, query string, limit, offset int32) ([]entity.PostWithDetails, error)
-	CountSearchPublished(ctx context.Context, query string) (int64, error)
+	SearchPublished(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, error)
+	CountSearchPublished(ctx context.Context, query string, filter entity.PostSearchFilter) (int64, error)
+	SearchFacets(ctx context.Context, query string, filter entity.PostSearchFilter) (*entity.PostSearchFacets, error)
 
 	// Admin operations
 	ListAll(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error)
@@ -46,6 +50,10 @@ type PostRepository interface {
 	Publish(ctx context.Context, id int32) (*entity.Post, error)
 	Unpublish(ctx context.Context, id int32) (*entity.Post, error)
 
+	// Scheduled publishing
+	Schedule(ctx context.Context, id int32, publishAt time.Time) (*entity.Post, error)
+	PublishDue(ctx context.Context, now time.Time) ([]entity.Post, error)
+
 	// Tag management
 	GetTags(ctx context.Context, postID int32) ([]entity.TagBrief, error)
 	SetTags(ctx context.Context, postID int32, tagIDs []int32) error
@@ -53,4 +61,11 @@ type PostRepository interface {
 
 	// View count
 	IncrementViewCount(ctx context.Context, id int32) error
+
+	// Revisions
+	// UpdateWithRevision saves revision and applies the post update in one transaction
+	UpdateWithRevision(ctx context.Context, post *entity.Post, revision *entity.PostRevision) (*entity.Post, error)
+	ListRevisions(ctx context.Context, postID int32, limit, offset int32) ([]entity.PostRevision, error)
+	CountRevisions(ctx context.Context, postID int32) (int64, error)
+	FindRevision(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error)
 }
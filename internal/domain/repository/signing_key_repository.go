@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// SigningKeyRepository defines the interface for JWT signing key storage operations
+type SigningKeyRepository interface {
+	// Create stores a new signing key
+	Create(ctx context.Context, key *entity.SigningKey) (*entity.SigningKey, error)
+
+	// List returns the active keys and the keys retired after the given time, newest first
+	List(ctx context.Context, retiredAfter time.Time) ([]entity.SigningKey, error)
+
+	// RetireOthers retires every active key except the one with the given ID
+	RetireOthers(ctx context.Context, kid string) error
+
+	// DeleteRetired deletes keys retired before the given time
+	DeleteRetired(ctx context.Context, retiredBefore time.Time) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// SitemapCacheRepository defines the interface for caching rendered sitemap documents (Redis-based)
+type SitemapCacheRepository interface {
+	// Get returns a cached document by name, found is false on a cache miss
+	Get(ctx context.Context, name string) (data []byte, found bool, err error)
+
+	// SetAll replaces all cached documents at once
+	SetAll(ctx context.Context, docs map[string][]byte, ttl time.Duration) error
+
+	// Invalidate removes all cached documents
+	Invalidate(ctx context.Context) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// SpamRepository defines the interface for the spam classifier training data
+type SpamRepository interface {
+	// GetTokenCounts returns the counts of the given tokens; unknown tokens are omitted
+	GetTokenCounts(ctx context.Context, tokens []string) ([]entity.SpamTokenCount, error)
+	GetCorpus(ctx context.Context) (*entity.SpamCorpus, error)
+
+	// AdjustTokens adds the deltas to the counts of each token (counts never go below zero)
+	AdjustTokens(ctx context.Context, tokens []string, spamDelta, hamDelta int32) error
+	// AdjustCorpus adds the deltas to the number of training documents per class
+	AdjustCorpus(ctx context.Context, spamDelta, hamDelta int32) error
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"io"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
 )
 
 // StorageRepository defines the interface for file storage operations
@@ -13,6 +15,9 @@ type StorageRepository interface {
 	// Delete removes a file from storage
 	Delete(ctx context.Context, path string) error
 
+	// List returns the files whose path starts with prefix
+	List(ctx context.Context, prefix string) ([]entity.StoredObject, error)
+
 	// GenerateURL generates a public URL for the file
 	GenerateURL(path string) string
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// SuggestRepository defines the interface for the search autocomplete index (Redis-based)
+type SuggestRepository interface {
+	// Put indexes a suggestion under the given terms, replacing any terms it had before
+	Put(ctx context.Context, suggestion *entity.SearchSuggestion, terms []string) error
+
+	// Delete removes a suggestion from the index
+	Delete(ctx context.Context, suggestionType entity.SuggestionType, id int32) error
+
+	// FindByPrefixes returns suggestions with a term starting with one of the prefixes,
+	// ordered by the first prefix they match, without duplicates and up to limit
+	FindByPrefixes(ctx context.Context, prefixes []string, limit int) ([]entity.SearchSuggestion, error)
+
+	// Replace swaps the whole index for entries in one step, so lookups see
+	// either the old or the new index and never a partial one
+	Replace(ctx context.Context, entries []entity.SuggestionEntry) error
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// RefreshTokenRepository defines the interface for refresh token storage (Redis-based)
+type RefreshTokenRepository interface {
+	// Save stores a refresh token and keeps its family active for ttl
+	Save(ctx context.Context, token *entity.RefreshToken, ttl time.Duration) error
+
+	// Find returns a refresh token by hash without using it up
+	Find(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+
+	// Consume atomically marks a refresh token as used and returns it as it was before,
+	// so Used is true when the token had already been rotated
+	Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+
+	// IsFamilyActive checks whether a token family has not expired or been revoked
+	IsFamilyActive(ctx context.Context, familyID string) (bool, error)
+
+	// RevokeFamily invalidates every refresh token of a family
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeAdminFamilies invalidates every refresh token family of an admin
+	RevokeAdminFamilies(ctx context.Context, adminID int32) error
+}
+
+// TokenDenylistRepository defines the interface for revoked access tokens (Redis-based)
+type TokenDenylistRepository interface {
+	// Add denylists a token ID for ttl, after which the token has expired anyway
+	Add(ctx context.Context, tokenID string, ttl time.Duration) error
+
+	// Contains checks if a token ID is denylisted
+	Contains(ctx context.Context, tokenID string) (bool, error)
+
+	// RevokeIssuedBefore rejects every access token of an admin issued before the given time.
+	// It is kept for ttl, after which those tokens have expired anyway.
+	RevokeIssuedBefore(ctx context.Context, adminID int32, before time.Time, ttl time.Duration) error
+
+	// RevokedBefore returns the cutoff set by RevokeIssuedBefore, or the zero time if there is none
+	RevokedBefore(ctx context.Context, adminID int32) (time.Time, error)
+}
+
+// AdminInviteRepository defines the interface for one-time admin invitation tokens (Redis-based)
+type AdminInviteRepository interface {
+	// Save stores an invitation token hash for the invited admin
+	Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error
+
+	// Consume deletes an invitation token and returns the admin it was issued for
+	Consume(ctx context.Context, tokenHash string) (int32, error)
+}
+
+// PasswordResetRepository defines the interface for one-time password reset tokens (Redis-based)
+type PasswordResetRepository interface {
+	// Save stores a reset token hash for the admin whose password it sets
+	Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error
+
+	// Consume deletes a reset token and returns the admin it was issued for
+	Consume(ctx context.Context, tokenHash string) (int32, error)
+}
+
+// OIDCStateRepository defines the interface for pending single sign-on logins (Redis-based)
+type OIDCStateRepository interface {
+	// Save stores the PKCE verifier and nonce of a login under the hash of its state parameter
+	Save(ctx context.Context, stateHash string, state *entity.OIDCLoginState, ttl time.Duration) error
+
+	// Consume deletes a pending login and returns it, so each state can only be used once
+	Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error)
+}
+
+// MFAChallengeRepository defines the interface for two-factor login challenges (Redis-based)
+type MFAChallengeRepository interface {
+	// Save stores a challenge token hash for the admin that passed the password step
+	Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error
+
+	// Attempt counts a code attempt against a challenge and returns its admin and the attempts so far
+	Attempt(ctx context.Context, tokenHash string) (adminID int32, attempts int64, err error)
+
+	// Delete removes a challenge, returning ErrMFAChallengeNotFound if it was already gone
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+// LoginAttemptRepository defines the interface for failed login counters and blocks (Redis-based)
+type LoginAttemptRepository interface {
+	// RecordFailure counts a failed login in a window starting at the first failure
+	// and returns the failures so far
+	RecordFailure(ctx context.Context, subject entity.LoginSubject, window time.Duration) (int64, error)
+
+	// Block rejects logins for the subject for d. Locked marks a lockout rather than a backoff delay.
+	Block(ctx context.Context, subject entity.LoginSubject, d time.Duration, locked bool) error
+
+	// Blocked returns how long logins for the subject remain blocked, and whether it is a lockout
+	Blocked(ctx context.Context, subject entity.LoginSubject) (time.Duration, bool, error)
+
+	// Reset clears the failures and any block of the subject
+	Reset(ctx context.Context, subject entity.LoginSubject) error
+
+	// List returns every subject with failures or a block
+	List(ctx context.Context) ([]entity.LoginLockout, error)
+}
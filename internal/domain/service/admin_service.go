@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// InviteAdminCommand represents the input for inviting an admin
+type InviteAdminCommand struct {
+	Username string
+	Role     entity.AdminRole
+	// Email is optional and lets the admin sign in with single sign-on
+	Email string
+}
+
+// AdminService defines the interface for managing admin accounts
+type AdminService interface {
+	// ListAdmins returns all admins
+	ListAdmins(ctx context.Context) ([]entity.Admin, error)
+
+	// InviteAdmin creates an admin without a password and returns a one-time token
+	// the invitee uses to set it. Inviting a username whose invitation was never
+	// accepted issues a new token for that account
+	InviteAdmin(ctx context.Context, cmd InviteAdminCommand) (*entity.AdminInvitation, error)
+
+	// AcceptInvite sets the password of an invited admin
+	AcceptInvite(ctx context.Context, token, password string) (*entity.Admin, error)
+
+	// UpdateRole changes an admin's role. The last active owner cannot be demoted.
+	UpdateRole(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error)
+
+	// SetDisabled disables or re-enables an admin. The last active owner cannot be disabled.
+	SetDisabled(ctx context.Context, id int32, disabled bool) (*entity.Admin, error)
+
+	// UpdateIdentity sets the email and OpenID Connect subject an admin signs in with
+	// through single sign-on. Either may be empty to unset it.
+	UpdateIdentity(ctx context.Context, id int32, email, subject string) (*entity.Admin, error)
+
+	// ListLoginLockouts returns the client IPs and usernames with failed logins or a login block
+	ListLoginLockouts(ctx context.Context) ([]entity.LoginLockout, error)
+
+	// ClearLoginLockout forgets the failed logins of a client IP or username and lifts its block
+	ClearLoginLockout(ctx context.Context, subject entity.LoginSubject) error
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// CreateAPIKeyCommand represents the input for creating an API key
+type CreateAPIKeyCommand struct {
+	Name   string
+	Scopes []entity.Permission
+	// ExpiresAt is optional; keys without it stay valid until revoked
+	ExpiresAt *time.Time
+}
+
+// UpdateAPIKeyCommand represents the input for updating an API key
+type UpdateAPIKeyCommand struct {
+	Name   string
+	Scopes []entity.Permission
+}
+
+// APIKeyService defines the interface for API key operations
+type APIKeyService interface {
+	// ListAPIKeys returns the actor's API keys, or every key if the actor manages admins
+	ListAPIKeys(ctx context.Context, actor entity.Actor) ([]entity.APIKey, error)
+
+	// CreateAPIKey creates a key acting as the actor. Scopes must be granted by the actor's role.
+	CreateAPIKey(ctx context.Context, actor entity.Actor, cmd CreateAPIKeyCommand) (*entity.CreatedAPIKey, error)
+
+	// UpdateAPIKey changes the name and scopes of one of the actor's keys
+	UpdateAPIKey(ctx context.Context, actor entity.Actor, id int32, cmd UpdateAPIKeyCommand) (*entity.APIKey, error)
+
+	// RevokeAPIKey revokes one of the actor's keys; admin managers can revoke any key
+	RevokeAPIKey(ctx context.Context, actor entity.Actor, id int32) (*entity.APIKey, error)
+
+	// Authenticate validates an API key, records its use and returns the claims of the admin it acts as
+	Authenticate(ctx context.Context, key string) (*entity.Claims, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// AuditService defines the interface for reading the admin audit log.
+// Entries are recorded by the services performing the actions.
+type AuditService interface {
+	ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, limit, offset int32) ([]entity.AuditLog, int64, error)
+}
@@ -10,18 +10,64 @@ import (
 type LoginCommand struct {
 	Username string
 	Password string
+	// ClientIP is used to throttle failed logins per client as well as per username
+	ClientIP string
 }
 
 // AuthService defines the interface for authentication operations
 type AuthService interface {
-	// Login authenticates an admin and returns token info
-	Login(ctx context.Context, cmd LoginCommand) (*entity.TokenInfo, error)
+	// Login authenticates an admin and returns an access token and a refresh token.
+	// Admins with two-factor authentication get an MFA challenge instead, to pass to VerifyMFA.
+	// Repeated failures return a LoginThrottledError until the backoff or lockout expires.
+	Login(ctx context.Context, cmd LoginCommand) (*entity.LoginResult, error)
+
+	// StartOIDCLogin begins a single sign-on login with the configured OpenID Connect provider
+	// and returns where to send the browser. Returns ErrOIDCDisabled if none is configured.
+	StartOIDCLogin(ctx context.Context) (*entity.OIDCAuthorization, error)
+
+	// CompleteOIDCLogin exchanges the authorization code from the provider's redirect for
+	// the admin's tokens, or an MFA challenge like Login. The admin is found by the ID token's
+	// subject, or by its verified email on first login when matching by email.
+	CompleteOIDCLogin(ctx context.Context, code, state string) (*entity.LoginResult, error)
+
+	// VerifyMFA exchanges a login challenge and a TOTP or recovery code for tokens
+	VerifyMFA(ctx context.Context, challengeToken, code string) (*entity.TokenInfo, error)
+
+	// Refresh rotates a refresh token and issues a new access token.
+	// Presenting a refresh token that was already rotated revokes its whole family.
+	Refresh(ctx context.Context, refreshToken string) (*entity.TokenInfo, error)
+
+	// Logout denylists the access token and revokes the refresh token family of the session.
+	// Either token may be empty.
+	Logout(ctx context.Context, accessToken, refreshToken string) error
 
 	// GetAdminByID returns an admin by ID
 	GetAdminByID(ctx context.Context, id int32) (*entity.Admin, error)
 
-	// ValidateToken validates a JWT token and returns claims
-	ValidateToken(tokenString string) (*entity.Claims, error)
+	// ValidateToken validates a JWT access token, rejecting denylisted ones, and returns claims
+	ValidateToken(ctx context.Context, tokenString string) (*entity.Claims, error)
+
+	// EnrollTOTP generates a new TOTP secret for the admin. It takes effect once activated.
+	EnrollTOTP(ctx context.Context, adminID int32) (*entity.TOTPEnrollment, error)
+
+	// ActivateTOTP enables two-factor authentication after checking a code from the enrolled
+	// secret and returns one-time recovery codes, which are only shown this once
+	ActivateTOTP(ctx context.Context, adminID int32, code string) ([]string, error)
+
+	// DisableTOTP turns off two-factor authentication after checking a TOTP or recovery code
+	DisableTOTP(ctx context.Context, adminID int32, code string) error
+
+	// ChangePassword sets a new password after checking the current one and ends every session
+	// of the admin, including the current one
+	ChangePassword(ctx context.Context, adminID int32, currentPassword, newPassword string) error
+
+	// IssuePasswordReset creates a one-time token to set the password of an admin who cannot log in.
+	// It is meant for the server operator and is only exposed on the command line.
+	IssuePasswordReset(ctx context.Context, username string) (*entity.PasswordReset, error)
+
+	// ResetPassword sets a new password with a reset token, ends every session of the admin
+	// and clears the login lockout of their username
+	ResetPassword(ctx context.Context, token, newPassword string) error
 
 	// EnsureAdminExists creates the initial admin if it doesn't exist
 	EnsureAdminExists(ctx context.Context, username, password string) error
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// CreateCommentCommand represents the data needed to post a comment
+type CreateCommentCommand struct {
+	ParentID     *int32
+	AuthorName   string
+	AuthorEmail  string
+	GitHubHandle string
+	Content      string
+	ClientIP     string
+
+	// Spam checks: the honeypot field and the token from IssueFormToken
+	Honeypot  string
+	FormToken string
+}
+
+// CommentService defines the interface for comment business logic
+type CommentService interface {
+	// Public API
+	ListPostComments(ctx context.Context, postSlug string) ([]*entity.CommentThread, error)
+	CreateComment(ctx context.Context, postSlug string, cmd CreateCommentCommand) (*entity.Comment, error)
+	IssueFormToken(ctx context.Context, postSlug string) (string, error)
+
+	// Admin API
+	// ModerateComments also trains the spam classifier with approve (ham) and spam decisions
+	ListComments(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, int64, error)
+	ModerateComments(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error)
+}
@@ -20,12 +20,24 @@ type MediaService interface {
 	// ListMedia returns a paginated list of media files
 	ListMedia(ctx context.Context, limit, offset int32) ([]entity.Media, int64, error)
 
+	// ListUnusedMedia returns a paginated list of media files no post or project references
+	ListUnusedMedia(ctx context.Context, limit, offset int32) ([]entity.Media, int64, error)
+
 	// GetMediaByID returns a media file by ID
 	GetMediaByID(ctx context.Context, id int32) (*entity.Media, error)
 
 	// UploadMedia uploads a file and saves metadata
 	UploadMedia(ctx context.Context, cmd UploadMediaCommand) (*entity.UploadedFile, error)
 
-	// DeleteMedia removes a media file
-	DeleteMedia(ctx context.Context, id int32) error
+	// GetMediaUsages returns the posts and projects that reference a media file
+	GetMediaUsages(ctx context.Context, id int32) ([]entity.MediaUsage, error)
+
+	// ReconcileStorage compares the uploaded objects in storage with the media
+	// records. With deleteOrphans set, orphans older than the configured grace
+	// period are removed unless there are dangling records, which are only reported.
+	ReconcileStorage(ctx context.Context, deleteOrphans bool) (*entity.StorageReport, error)
+
+	// DeleteMedia removes a media file. Media still referenced by a post or
+	// project is only removed when force is set.
+	DeleteMedia(ctx context.Context, id int32, force bool) error
 }
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// OIDCProvider is an OpenID Connect identity provider admins can sign in with
+type OIDCProvider interface {
+	// AuthCodeURL returns the authorization endpoint URL for an authorization code login
+	// with PKCE, derived from the code verifier
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+
+	// Exchange redeems an authorization code and returns the identity from the verified ID token,
+	// which must carry the nonce of the login
+	Exchange(ctx context.Context, code, verifier, nonce string) (*entity.OIDCIdentity, error)
+}
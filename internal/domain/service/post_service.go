@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 )
@@ -36,18 +37,31 @@ type PostService interface {
 	ListPublishedPosts(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, int64, error)
 	ListPublishedPostsByCategory(ctx context.Context, categoryID int32, limit, offset int32) ([]entity.PostWithDetails, int64, error)
 	ListPublishedPostsByTag(ctx context.Context, tagID int32, limit, offset int32) ([]entity.PostWithDetails, int64, error)
-	SearchPublishedPosts(ctx context.Context, query string, limit, offset int32) ([]entity.PostWithDetails, int64, error)
+	SearchPublishedPosts(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, int64, error)
+	GetSearchFacets(ctx context.Context, query string, filter entity.PostSearchFilter) (*entity.PostSearchFacets, error)
 
 	// Admin API
+	// Write operations take the acting admin: authors may only change posts they created,
+	// other actors need PermissionPostEditAny (domain.ErrForbidden otherwise)
 	GetPost(ctx context.Context, id int32) (*entity.PostWithDetails, error)
 	ListAllPosts(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, int64, error)
 	ListPostsByStatus(ctx context.Context, status entity.PostStatus, limit, offset int32) ([]entity.PostWithDetails, int64, error)
-	CreatePost(ctx context.Context, cmd CreatePostCommand) (*entity.PostWithDetails, error)
-	UpdatePost(ctx context.Context, id int32, cmd UpdatePostCommand) (*entity.PostWithDetails, error)
-	DeletePost(ctx context.Context, id int32) error
-	PublishPost(ctx context.Context, id int32, publish bool) (*entity.PostWithDetails, error)
+	CreatePost(ctx context.Context, actor entity.Actor, cmd CreatePostCommand) (*entity.PostWithDetails, error)
+	UpdatePost(ctx context.Context, actor entity.Actor, id int32, cmd UpdatePostCommand) (*entity.PostWithDetails, error)
+	DeletePost(ctx context.Context, actor entity.Actor, id int32) error
+	PublishPost(ctx context.Context, actor entity.Actor, id int32, publish bool) (*entity.PostWithDetails, error)
+
+	// Scheduled publishing
+	SchedulePost(ctx context.Context, actor entity.Actor, id int32, publishAt time.Time) (*entity.PostWithDetails, error)
+	PublishDuePosts(ctx context.Context, now time.Time) ([]entity.Post, error)
 
 	// View tracking
 	IncrementViewCount(ctx context.Context, id int32) error
 	GetPostIDBySlug(ctx context.Context, slug string) (int32, error)
+
+	// Revisions
+	ListRevisions(ctx context.Context, postID int32, limit, offset int32) ([]entity.PostRevision, int64, error)
+	GetRevision(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error)
+	DiffRevisions(ctx context.Context, postID, fromRevisionID int32, toRevisionID *int32) (*entity.PostRevisionDiff, error)
+	RestoreRevision(ctx context.Context, actor entity.Actor, postID, revisionID int32) (*entity.PostWithDetails, error)
 }
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// SigningKeyService defines the interface for the rotating keys of RS256 and EdDSA access tokens
+type SigningKeyService interface {
+	// SigningKey returns the newest active key for the configured algorithm,
+	// creating one if there is none yet
+	SigningKey(ctx context.Context) (*entity.SigningKey, error)
+
+	// VerificationKey returns the key with the given ID, including retired keys until
+	// the tokens they signed have expired
+	VerificationKey(ctx context.Context, kid string) (*entity.SigningKey, error)
+
+	// PublicKeys returns the keys to publish as a JWKS; there are none with HS256
+	PublicKeys(ctx context.Context) ([]entity.SigningKey, error)
+
+	// Rotate creates a new signing key once the current one is older than the rotation
+	// interval, retires the others and deletes keys that cannot verify a live token anymore
+	Rotate(ctx context.Context) error
+}
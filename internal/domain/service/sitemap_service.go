@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+)
+
+// SitemapService defines the interface for XML sitemap generation
+type SitemapService interface {
+	// GetSitemap returns the sitemap document for a page.
+	// Page 0 is the root sitemap, which is a sitemap index when the URLs are split into pages.
+	GetSitemap(ctx context.Context, page int) ([]byte, error)
+
+	// Invalidate drops the cached sitemap so it is rebuilt on the next request
+	Invalidate(ctx context.Context) error
+}
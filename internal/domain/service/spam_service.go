@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// SpamFilter scores one aspect of a submitted comment
+// Scores range from 0 (clean) to 1 (certainly spam)
+type SpamFilter interface {
+	Name() string
+	Score(ctx context.Context, candidate *entity.SpamCandidate) (float64, error)
+}
+
+// SpamService runs comments through a pipeline of spam filters and learns from moderation decisions
+type SpamService interface {
+	Check(ctx context.Context, candidate *entity.SpamCandidate) (*entity.SpamVerdict, error)
+	// Train teaches the classifier with a moderated comment, undoing any earlier training of it
+	Train(ctx context.Context, comment *entity.Comment, label entity.SpamLabel) error
+	// IssueFormToken returns a signed token for the comment form of a post, used by the timing check
+	IssueFormToken(postID int32) string
+}
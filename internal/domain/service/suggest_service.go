@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// SuggestService defines the interface for search autocomplete
+type SuggestService interface {
+	// Suggest returns post titles, tag names and category names matching the query
+	// by prefix, falling back to typo-tolerant matches
+	Suggest(ctx context.Context, query string, limit int) ([]entity.SearchSuggestion, error)
+
+	// Rebuild reindexes all published posts, tags and categories, swapping the
+	// new index in at once. It is a no-op while another instance is rebuilding.
+	Rebuild(ctx context.Context) error
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// GetActor returns the authenticated admin set by middleware.Auth
+func GetActor(c *gin.Context) entity.Actor {
+	id, _ := c.Get("user_id")
+	role, _ := c.Get("role")
+	scopes, _ := c.Get("scopes")
+
+	actor := entity.Actor{}
+	actor.AdminID, _ = id.(int32)
+	actor.Role, _ = role.(entity.AdminRole)
+	actor.Scopes, _ = scopes.([]entity.Permission)
+	return actor
+}
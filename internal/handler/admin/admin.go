@@ -0,0 +1,304 @@
+package admin
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type AdminHandler struct {
+	adminService domainService.AdminService
+}
+
+// NewAdminHandlerWithCleanArch creates a new AdminHandler with clean architecture service
+func NewAdminHandlerWithCleanArch(adminService domainService.AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// ListAdmins godoc
+// @Summary List admins
+// @Description Get all admin accounts with their roles (owner only)
+// @Tags admin/admins
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} handler.Response
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/admins [get]
+func (h *AdminHandler) ListAdmins(c *gin.Context) {
+	admins, err := h.adminService.ListAdmins(c.Request.Context())
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch admins", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToAdminResponses(admins))
+}
+
+// InviteAdmin godoc
+// @Summary Invite an admin
+// @Description Create an admin account with a role (owner, editor, author, viewer) and get a one-time
+// @Description invite token. The invitee sets a password with POST /api/admin/auth/accept-invite, or signs in
+// @Description with single sign-on when an email is given (owner only). Inviting a username that has not
+// @Description accepted its invitation yet issues a new token.
+// @Tags admin/admins
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.InviteAdminRequest true "Username, role and optional email"
+// @Success 201 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/admins/invite [post]
+func (h *AdminHandler) InviteAdmin(c *gin.Context) {
+	var req dto.InviteAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	invitation, err := h.adminService.InviteAdmin(c.Request.Context(), domainService.InviteAdminCommand{
+		Username: req.Username,
+		Role:     entity.AdminRole(req.Role),
+		Email:    req.Email,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidAdminRole):
+			handler.BadRequest(c, "Invalid admin role")
+		case errors.Is(err, domain.ErrAdminUsernameExists):
+			handler.Conflict(c, "Username already exists")
+		case errors.Is(err, domain.ErrAdminIdentityExists):
+			handler.Conflict(c, "Email is already used by another admin")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to invite admin", err)
+		}
+		return
+	}
+
+	handler.Created(c, mapper.ToInviteAdminResponse(invitation))
+}
+
+// AcceptInvite godoc
+// @Summary Accept an admin invitation
+// @Description Set the password of an invited admin with the one-time invite token, then log in as usual
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptInviteRequest true "Invite token and new password"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Router /api/admin/auth/accept-invite [post]
+func (h *AdminHandler) AcceptInvite(c *gin.Context) {
+	var req dto.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	admin, err := h.adminService.AcceptInvite(c.Request.Context(), req.Token, req.Password)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidInviteToken) {
+			handler.BadRequest(c, "Invalid or expired invitation token")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to accept invitation", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToAdminResponse(admin))
+}
+
+// UpdateRole godoc
+// @Summary Change an admin's role
+// @Description Change the role of an admin. Takes effect when their access token is next refreshed (owner only).
+// @Tags admin/admins
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Param request body dto.UpdateAdminRoleRequest true "New role"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/admins/{id}/role [patch]
+func (h *AdminHandler) UpdateRole(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid admin ID")
+		return
+	}
+
+	var req dto.UpdateAdminRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	admin, err := h.adminService.UpdateRole(c.Request.Context(), int32(id), entity.AdminRole(req.Role))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdminNotFound):
+			handler.NotFound(c, "Admin not found")
+		case errors.Is(err, domain.ErrInvalidAdminRole):
+			handler.BadRequest(c, "Invalid admin role")
+		case errors.Is(err, domain.ErrLastOwner):
+			handler.Conflict(c, "Cannot demote or disable the last active owner")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to update admin role", err)
+		}
+		return
+	}
+
+	handler.Success(c, mapper.ToAdminResponse(admin))
+}
+
+// UpdateIdentity godoc
+// @Summary Set an admin's single sign-on identity
+// @Description Set the email and OpenID Connect subject an admin signs in with through single sign-on.
+// @Description Leave the subject empty to link it on the admin's next login with a verified email (owner only).
+// @Tags admin/admins
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Param request body dto.UpdateAdminIdentityRequest true "Email and OpenID Connect subject"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/admins/{id}/identity [patch]
+func (h *AdminHandler) UpdateIdentity(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid admin ID")
+		return
+	}
+
+	var req dto.UpdateAdminIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	admin, err := h.adminService.UpdateIdentity(c.Request.Context(), int32(id), req.Email, req.OIDCSubject)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdminNotFound):
+			handler.NotFound(c, "Admin not found")
+		case errors.Is(err, domain.ErrAdminIdentityExists):
+			handler.Conflict(c, "Email or subject is already used by another admin")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to update admin identity", err)
+		}
+		return
+	}
+
+	handler.Success(c, mapper.ToAdminResponse(admin))
+}
+
+// UpdateStatus godoc
+// @Summary Disable or re-enable an admin
+// @Description Disabled admins cannot log in or refresh tokens; access tokens already issued expire
+// @Description within JWT_EXPIRY (owner only).
+// @Tags admin/admins
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Admin ID"
+// @Param request body dto.UpdateAdminStatusRequest true "Disabled flag"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/admins/{id}/status [patch]
+func (h *AdminHandler) UpdateStatus(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid admin ID")
+		return
+	}
+
+	var req dto.UpdateAdminStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	admin, err := h.adminService.SetDisabled(c.Request.Context(), int32(id), *req.Disabled)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAdminNotFound):
+			handler.NotFound(c, "Admin not found")
+		case errors.Is(err, domain.ErrLastOwner):
+			handler.Conflict(c, "Cannot demote or disable the last active owner")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to update admin status", err)
+		}
+		return
+	}
+
+	handler.Success(c, mapper.ToAdminResponse(admin))
+}
+
+// ListLoginLockouts godoc
+// @Summary List login lockouts
+// @Description Get the client IPs and usernames with recent failed logins, and whether logins
+// @Description are currently blocked by a backoff delay or a lockout (owner only)
+// @Tags admin/admins
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} handler.Response
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/admins/lockouts [get]
+func (h *AdminHandler) ListLoginLockouts(c *gin.Context) {
+	lockouts, err := h.adminService.ListLoginLockouts(c.Request.Context())
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch login lockouts", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToLoginLockoutResponses(lockouts))
+}
+
+// ClearLoginLockout godoc
+// @Summary Clear a login lockout
+// @Description Forget the failed logins of a client IP or username and lift its block (owner only)
+// @Tags admin/admins
+// @Security BearerAuth
+// @Param kind path string true "Subject kind (ip or username)"
+// @Param value path string true "Client IP or username"
+// @Success 204 "No Content"
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/admins/lockouts/{kind}/{value} [delete]
+func (h *AdminHandler) ClearLoginLockout(c *gin.Context) {
+	subject := entity.LoginSubject{
+		Kind:  entity.LoginSubjectKind(c.Param("kind")),
+		Value: c.Param("value"),
+	}
+
+	if err := h.adminService.ClearLoginLockout(c.Request.Context(), subject); err != nil {
+		if errors.Is(err, domain.ErrInvalidLoginSubject) {
+			handler.BadRequest(c, "Kind must be ip or username")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to clear login lockout", err)
+		return
+	}
+
+	handler.NoContent(c)
+}
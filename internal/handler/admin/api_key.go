@@ -0,0 +1,169 @@
+package admin
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type APIKeyHandler struct {
+	apiKeyService domainService.APIKeyService
+}
+
+// NewAPIKeyHandlerWithCleanArch creates a new APIKeyHandler with clean architecture service
+func NewAPIKeyHandlerWithCleanArch(apiKeyService domainService.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// ListAPIKeys godoc
+// @Summary List API keys
+// @Description Get your API keys, or every admin's keys for owners. Keys themselves are never returned.
+// @Tags admin/api-keys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} handler.Response
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/admin/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), handler.GetActor(c))
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch API keys", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToAPIKeyResponses(keys))
+}
+
+// CreateAPIKey godoc
+// @Summary Create an API key
+// @Description Create a key for automation clients, sent in the X-API-Key header instead of a Bearer token.
+// @Description Requests act as you, limited to the scopes, which must be permissions of your role
+// @Description (e.g. posts:write, media:upload). The key is only shown in this response.
+// @Tags admin/api-keys
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Name, scopes and optional expiry"
+// @Success 201 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	created, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), handler.GetActor(c), domainService.CreateAPIKeyCommand{
+		Name:      req.Name,
+		Scopes:    toPermissions(req.Scopes),
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidAPIKeyScope):
+			handler.BadRequest(c, err.Error())
+		case errors.Is(err, domain.ErrInvalidAPIKeyExpiry):
+			handler.BadRequest(c, "Expiry must be in the future")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to create API key", err)
+		}
+		return
+	}
+
+	handler.Created(c, mapper.ToCreateAPIKeyResponse(created))
+}
+
+// UpdateAPIKey godoc
+// @Summary Update an API key
+// @Description Rename an API key or change its scopes
+// @Tags admin/api-keys
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "API key ID"
+// @Param request body dto.UpdateAPIKeyRequest true "Name and scopes"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/api-keys/{id} [patch]
+func (h *APIKeyHandler) UpdateAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid API key ID")
+		return
+	}
+
+	var req dto.UpdateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	key, err := h.apiKeyService.UpdateAPIKey(c.Request.Context(), handler.GetActor(c), int32(id), domainService.UpdateAPIKeyCommand{
+		Name:   req.Name,
+		Scopes: toPermissions(req.Scopes),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAPIKeyNotFound):
+			handler.NotFound(c, "API key not found")
+		case errors.Is(err, domain.ErrInvalidAPIKeyScope):
+			handler.BadRequest(c, err.Error())
+		default:
+			handler.InternalErrorWithLog(c, "Failed to update API key", err)
+		}
+		return
+	}
+
+	handler.Success(c, mapper.ToAPIKeyResponse(key))
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke an API key
+// @Description Revoke an API key immediately. Revoked keys stay listed for reference.
+// @Tags admin/api-keys
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid API key ID")
+		return
+	}
+
+	key, err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), handler.GetActor(c), int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			handler.NotFound(c, "API key not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to revoke API key", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToAPIKeyResponse(key))
+}
+
+func toPermissions(scopes []string) []entity.Permission {
+	result := make([]entity.Permission, len(scopes))
+	for i, scope := range scopes {
+		result[i] = entity.Permission(scope)
+	}
+	return result
+}
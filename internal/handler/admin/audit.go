@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type AuditHandler struct {
+	auditService domainService.AuditService
+}
+
+// NewAuditHandlerWithCleanArch creates a new AuditHandler with clean architecture service
+func NewAuditHandlerWithCleanArch(auditService domainService.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListAuditLogs godoc
+// @Summary List audit log
+// @Description Get a paginated log of admin actions (content changes, logins, admin and API key management),
+// @Description newest first, with a summary of the target before and after each change (owner only)
+// @Tags admin/audit
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Param admin_id query int false "Filter by acting admin"
+// @Param action query string false "Filter by action (e.g. post.update, auth.login_failed)"
+// @Param target_type query string false "Filter by target type (e.g. post, category, media)"
+// @Param target_id query string false "Filter by target ID, together with target_type"
+// @Param since query string false "Only entries at or after this time (RFC 3339)"
+// @Param until query string false "Only entries before this time (RFC 3339)"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/audit [get]
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	pagination := handler.GetPagination(c)
+
+	filter := entity.AuditLogFilter{
+		Action:     entity.AuditAction(c.Query("action")),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+	}
+	if adminID := c.Query("admin_id"); adminID != "" {
+		id, err := strconv.ParseInt(adminID, 10, 32)
+		if err != nil {
+			handler.BadRequest(c, "Invalid admin ID")
+			return
+		}
+		filter.AdminID = int32(id)
+	}
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{
+		{"since", &filter.Since},
+		{"until", &filter.Until},
+	} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			handler.BadRequest(c, "Invalid "+param.name+" time, expected RFC 3339")
+			return
+		}
+		*param.target = &t
+	}
+
+	logs, total, err := h.auditService.ListAuditLogs(
+		c.Request.Context(),
+		filter,
+		int32(pagination.PerPage),
+		int32(pagination.Offset),
+	)
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch audit log", err)
+		return
+	}
+
+	handler.SuccessWithMeta(c, mapper.ToAuditLogResponses(logs), pagination.ToMeta(total))
+}
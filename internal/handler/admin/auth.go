@@ -2,7 +2,10 @@ package admin
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ydonggwui/blog-api/internal/domain"
@@ -25,7 +28,9 @@ func NewAuthHandlerWithCleanArch(authService domainService.AuthService) *AuthHan
 
 // Login godoc
 // @Summary Admin login
-// @Description Authenticate admin and get JWT token
+// @Description Authenticate admin and get JWT token. Admins with two-factor authentication get a
+// @Description dto.MFAChallengeResponse instead, to exchange at POST /api/admin/auth/login/mfa.
+// @Description Repeated failures per IP and per username are throttled with 429 and a Retry-After header.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -33,6 +38,8 @@ func NewAuthHandlerWithCleanArch(authService domainService.AuthService) *AuthHan
 // @Success 200 {object} dto.LoginResponse
 // @Failure 400 {object} handler.ErrorResponse
 // @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 429 {object} handler.ErrorResponse
 // @Router /api/admin/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -41,16 +48,176 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	tokenInfo, err := h.authService.Login(c.Request.Context(), domainService.LoginCommand{
+	result, err := h.authService.Login(c.Request.Context(), domainService.LoginCommand{
 		Username: req.Username,
 		Password: req.Password,
+		ClientIP: c.ClientIP(),
 	})
 	if err != nil {
-		if errors.Is(err, domain.ErrInvalidCredentials) {
+		var throttled *domain.LoginThrottledError
+		switch {
+		case errors.As(err, &throttled):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			if errors.Is(err, domain.ErrAccountLocked) {
+				handler.TooManyRequests(c, "Login is locked after too many failed attempts, try again later")
+			} else {
+				handler.TooManyRequests(c, "Too many failed login attempts, try again later")
+			}
+		case errors.Is(err, domain.ErrInvalidCredentials):
 			handler.Unauthorized(c, "Invalid username or password")
+		case errors.Is(err, domain.ErrAdminDisabled):
+			handler.Forbidden(c, "Account is disabled")
+		default:
+			handler.InternalErrorWithLog(c, "Login failed", err)
+		}
+		return
+	}
+
+	if result.MFAChallenge != nil {
+		handler.Success(c, mapper.ToMFAChallengeResponse(result.MFAChallenge))
+		return
+	}
+	handler.Success(c, mapper.ToLoginResponse(result.Tokens))
+}
+
+// VerifyMFA godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the challenge token from login and a TOTP or recovery code for tokens.
+// @Description A challenge allows a few attempts before the password step has to be repeated.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyMFARequest true "Challenge token and code"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/auth/login/mfa [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	tokenInfo, err := h.authService.VerifyMFA(c.Request.Context(), req.ChallengeToken, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidMFAChallenge):
+			handler.Unauthorized(c, "Invalid or expired login challenge, please log in again")
+		case errors.Is(err, domain.ErrInvalidTOTPCode):
+			handler.Unauthorized(c, "Invalid two-factor code")
+		case errors.Is(err, domain.ErrAdminDisabled):
+			handler.Forbidden(c, "Account is disabled")
+		default:
+			handler.InternalErrorWithLog(c, "Login failed", err)
+		}
+		return
+	}
+
+	handler.Success(c, mapper.ToLoginResponse(tokenInfo))
+}
+
+// StartOIDCLogin godoc
+// @Summary Start a single sign-on login
+// @Description Get the identity provider URL to send the browser to. After signing in, the provider redirects
+// @Description to OIDC_REDIRECT_URL with a code and the state, to pass to POST /api/admin/auth/oidc/callback.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.OIDCAuthorizationResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/auth/oidc/authorize [get]
+func (h *AuthHandler) StartOIDCLogin(c *gin.Context) {
+	authorization, err := h.authService.StartOIDCLogin(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCDisabled) {
+			handler.NotFound(c, "Single sign-on is not configured")
 			return
 		}
-		handler.InternalErrorWithLog(c, "Login failed", err)
+		handler.InternalErrorWithLog(c, "Failed to start single sign-on", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToOIDCAuthorizationResponse(authorization))
+}
+
+// CompleteOIDCLogin godoc
+// @Summary Complete a single sign-on login
+// @Description Exchange the authorization code and state from the identity provider's redirect for tokens,
+// @Description or a dto.MFAChallengeResponse when the admin has two-factor authentication enabled.
+// @Description The admin is found by the linked subject, or by verified email when OIDC_MATCH_BY is email.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.OIDCCallbackRequest true "Authorization code and state"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/auth/oidc/callback [post]
+func (h *AuthHandler) CompleteOIDCLogin(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	result, err := h.authService.CompleteOIDCLogin(c.Request.Context(), req.Code, req.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOIDCDisabled):
+			handler.NotFound(c, "Single sign-on is not configured")
+		case errors.Is(err, domain.ErrInvalidOIDCState):
+			handler.BadRequest(c, "Invalid or expired single sign-on state, please start again")
+		case errors.Is(err, domain.ErrOIDCExchangeFailed):
+			handler.Unauthorized(c, "Single sign-on failed")
+		case errors.Is(err, domain.ErrOIDCAccountNotFound):
+			handler.Unauthorized(c, "No admin account is linked to this identity")
+		case errors.Is(err, domain.ErrAdminDisabled):
+			handler.Forbidden(c, "Account is disabled")
+		default:
+			handler.InternalErrorWithLog(c, "Login failed", err)
+		}
+		return
+	}
+
+	if result.MFAChallenge != nil {
+		handler.Success(c, mapper.ToMFAChallengeResponse(result.MFAChallenge))
+		return
+	}
+	handler.Success(c, mapper.ToLoginResponse(result.Tokens))
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a new refresh token.
+// @Description Each refresh token can be used once; reusing one revokes the whole session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/admin/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	tokenInfo, err := h.authService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRefreshTokenReused):
+			handler.Unauthorized(c, "Refresh token reuse detected, please log in again")
+		case errors.Is(err, domain.ErrInvalidRefreshToken):
+			handler.Unauthorized(c, "Invalid or expired refresh token")
+		default:
+			handler.InternalErrorWithLog(c, "Token refresh failed", err)
+		}
 		return
 	}
 
@@ -59,14 +226,30 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 // Logout godoc
 // @Summary Admin logout
-// @Description Logout admin (client should discard token)
+// @Description Revoke the access token from the Authorization header and the refresh token session.
+// @Description Both are optional, so an expired access token can still be logged out with its refresh token.
 // @Tags auth
+// @Accept json
 // @Produce json
+// @Param request body dto.LogoutRequest false "Refresh token"
 // @Success 200 {object} map[string]string
 // @Router /api/admin/auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// JWT is stateless, so we just return success
-	// Client should discard the token
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			handler.BadRequest(c, "Invalid request body")
+			return
+		}
+	}
+
+	accessToken, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	if err := h.authService.Logout(c.Request.Context(), accessToken, req.RefreshToken); err != nil {
+		handler.InternalErrorWithLog(c, "Logout failed", err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": gin.H{
 			"message": "Logged out successfully",
@@ -90,7 +273,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 		return
 	}
 
-	// Convert to int32 (middleware stores int64, but our DB uses int32)
+	// Convert to int32 (middleware stores int32, older tokens used int64)
 	var id int32
 	switch v := userID.(type) {
 	case int64:
@@ -116,3 +299,177 @@ func (h *AuthHandler) Me(c *gin.Context) {
 
 	handler.Success(c, mapper.ToAdminResponse(admin))
 }
+
+// EnrollTOTP godoc
+// @Summary Start two-factor enrollment
+// @Description Generate a new TOTP secret for the current admin. Add it to an authenticator app
+// @Description (the otpauth URI can be shown as a QR code), then confirm it with POST /api/admin/auth/totp/activate.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.TOTPEnrollmentResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/auth/totp/enroll [post]
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	enrollment, err := h.authService.EnrollTOTP(c.Request.Context(), handler.GetActor(c).AdminID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTOTPAlreadyEnabled) {
+			handler.Conflict(c, "Two-factor authentication is already enabled")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to start two-factor enrollment", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToTOTPEnrollmentResponse(enrollment))
+}
+
+// ActivateTOTP godoc
+// @Summary Activate two-factor authentication
+// @Description Confirm the enrolled secret with a code from the authenticator app. Returns one-time
+// @Description recovery codes that can be used instead of a code; they are only shown once.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TOTPCodeRequest true "TOTP code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
+// @Router /api/admin/auth/totp/activate [post]
+func (h *AuthHandler) ActivateTOTP(c *gin.Context) {
+	var req dto.TOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	codes, err := h.authService.ActivateTOTP(c.Request.Context(), handler.GetActor(c).AdminID, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTOTPAlreadyEnabled):
+			handler.Conflict(c, "Two-factor authentication is already enabled")
+		case errors.Is(err, domain.ErrTOTPNotEnrolled):
+			handler.BadRequest(c, "Start two-factor enrollment first")
+		case errors.Is(err, domain.ErrInvalidTOTPCode):
+			handler.BadRequest(c, "Invalid two-factor code")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to activate two-factor authentication", err)
+		}
+		return
+	}
+
+	handler.Success(c, dto.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP godoc
+// @Summary Disable two-factor authentication
+// @Description Turn off two-factor authentication for the current admin with a TOTP or recovery code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TOTPCodeRequest true "TOTP or recovery code"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/admin/auth/totp/disable [post]
+func (h *AuthHandler) DisableTOTP(c *gin.Context) {
+	var req dto.TOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	if err := h.authService.DisableTOTP(c.Request.Context(), handler.GetActor(c).AdminID, req.Code); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTOTPNotEnabled):
+			handler.BadRequest(c, "Two-factor authentication is not enabled")
+		case errors.Is(err, domain.ErrInvalidTOTPCode):
+			handler.BadRequest(c, "Invalid two-factor code")
+		default:
+			handler.InternalErrorWithLog(c, "Failed to disable two-factor authentication", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "Two-factor authentication disabled",
+		},
+	})
+}
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Set a new password for the current admin after checking the current one. Every session
+// @Description of the admin ends, including this one, so log in again with the new password.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 401 {object} handler.ErrorResponse
+// @Router /api/admin/auth/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	err := h.authService.ChangePassword(c.Request.Context(), handler.GetActor(c).AdminID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		if errors.Is(err, domain.ErrWrongPassword) {
+			handler.BadRequest(c, "Current password is incorrect")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to change password", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "Password changed, please log in again",
+		},
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with a one-time reset token issued on the server with
+// @Description "blog-api reset-password <username>". Every session of the admin ends and
+// @Description the login lockout of the username is cleared.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} handler.ErrorResponse
+// @Router /api/admin/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		if errors.Is(err, domain.ErrInvalidPasswordResetToken) {
+			handler.BadRequest(c, "Invalid or expired password reset token")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to reset password", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{
+			"message": "Password reset, please log in with the new password",
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type CommentHandler struct {
+	commentService domainService.CommentService
+}
+
+// NewCommentHandlerWithCleanArch creates a new CommentHandler with clean architecture service
+func NewCommentHandlerWithCleanArch(commentService domainService.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// ListComments godoc
+// @Summary List comments (admin)
+// @Description Get a paginated moderation queue of comments by status, newest first
+// @Tags admin/comments
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (pending, approved, spam, deleted)" default(pending)
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Router /api/admin/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	pagination := handler.GetPagination(c)
+	status := entity.CommentStatus(c.Query("status"))
+
+	comments, total, err := h.commentService.ListComments(
+		c.Request.Context(),
+		status,
+		int32(pagination.PerPage),
+		int32(pagination.Offset),
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCommentStatus) {
+			handler.BadRequest(c, "Invalid comment status")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch comments", err)
+		return
+	}
+
+	handler.SuccessWithMeta(c, mapper.ToAdminCommentResponses(comments), pagination.ToMeta(total))
+}
+
+// ModerateComments godoc
+// @Summary Moderate comments
+// @Description Set the status of several comments at once (approve, mark as spam, delete or send back to pending)
+// @Tags admin/comments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.ModerateCommentsRequest true "Comment IDs and new status"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Router /api/admin/comments/moderate [post]
+func (h *CommentHandler) ModerateComments(c *gin.Context) {
+	var req dto.ModerateCommentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	updated, err := h.commentService.ModerateComments(c.Request.Context(), req.IDs, entity.CommentStatus(req.Status))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCommentStatus) {
+			handler.BadRequest(c, "Invalid comment status")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to moderate comments", err)
+		return
+	}
+
+	handler.Success(c, dto.ModerateCommentsResponse{Updated: updated})
+}
@@ -30,12 +30,17 @@ func NewMediaHandlerWithCleanArch(mediaService domainService.MediaService) *Medi
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(20)
+// @Param unused query bool false "Only media no post or project references"
 // @Success 200 {object} handler.Response
 // @Router /api/admin/media [get]
 func (h *MediaHandler) ListMedia(c *gin.Context) {
 	pagination := handler.GetPagination(c)
 
-	media, total, err := h.mediaService.ListMedia(
+	list := h.mediaService.ListMedia
+	if c.Query("unused") == "true" {
+		list = h.mediaService.ListUnusedMedia
+	}
+	media, total, err := list(
 		c.Request.Context(),
 		int32(pagination.PerPage),
 		int32(pagination.Offset),
@@ -56,6 +61,7 @@ func (h *MediaHandler) ListMedia(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "Image file to upload"
+// @Success 200 {object} handler.Response "Same file uploaded before; the existing media is returned with deduplicated=true"
 // @Success 201 {object} handler.Response
 // @Failure 400 {object} handler.ErrorResponse
 // @Failure 413 {object} handler.ErrorResponse
@@ -105,17 +111,53 @@ func (h *MediaHandler) UploadMedia(c *gin.Context) {
 		return
 	}
 
+	if result.Deduplicated {
+		handler.Success(c, mapper.ToUploadMediaResponse(result))
+		return
+	}
 	handler.Created(c, mapper.ToUploadMediaResponse(result))
 }
 
+// GetMediaUsages godoc
+// @Summary List media usages
+// @Description Get the posts and projects that reference a media file or one of its variants
+// @Tags admin/media
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Media ID"
+// @Success 200 {object} handler.Response
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/media/{id}/usages [get]
+func (h *MediaHandler) GetMediaUsages(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid media ID")
+		return
+	}
+
+	usages, err := h.mediaService.GetMediaUsages(c.Request.Context(), int32(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrMediaNotFound) {
+			handler.NotFound(c, "Media not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch media usages", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToMediaUsageResponses(usages))
+}
+
 // DeleteMedia godoc
 // @Summary Delete a media file
-// @Description Delete a media file from storage and database
+// @Description Delete a media file from storage and database. Media still referenced by a post or project is only deleted with force=true.
 // @Tags admin/media
 // @Security BearerAuth
 // @Param id path int true "Media ID"
+// @Param force query bool false "Delete even if posts or projects still reference it"
 // @Success 204 "No Content"
 // @Failure 404 {object} handler.ErrorResponse
+// @Failure 409 {object} handler.ErrorResponse
 // @Router /api/admin/media/{id} [delete]
 func (h *MediaHandler) DeleteMedia(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
@@ -124,11 +166,16 @@ func (h *MediaHandler) DeleteMedia(c *gin.Context) {
 		return
 	}
 
-	if err := h.mediaService.DeleteMedia(c.Request.Context(), int32(id)); err != nil {
+	force := c.Query("force") == "true"
+	if err := h.mediaService.DeleteMedia(c.Request.Context(), int32(id), force); err != nil {
 		if errors.Is(err, domain.ErrMediaNotFound) {
 			handler.NotFound(c, "Media not found")
 			return
 		}
+		if errors.Is(err, domain.ErrMediaInUse) {
+			handler.Conflict(c, "Media is still used by posts or projects; see its usages or delete with force=true")
+			return
+		}
 		handler.InternalErrorWithLog(c, "Failed to delete media", err)
 		return
 	}
@@ -32,7 +32,7 @@ func NewPostHandlerWithCleanArch(postService domainService.PostService) *PostHan
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
-// @Param status query string false "Filter by status (draft, published)"
+// @Param status query string false "Filter by status (draft, published, scheduled)"
 // @Success 200 {object} handler.Response
 // @Router /api/admin/posts [get]
 func (h *PostHandler) ListPosts(c *gin.Context) {
@@ -107,6 +107,7 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 // @Success 201 {object} handler.Response
 // @Failure 400 {object} handler.ErrorResponse
 // @Failure 409 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
 // @Router /api/admin/posts [post]
 func (h *PostHandler) CreatePost(c *gin.Context) {
 	var req dto.CreatePostRequest
@@ -116,12 +117,16 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 	}
 
 	cmd := mapper.ToCreatePostCommand(&req)
-	post, err := h.postService.CreatePost(c.Request.Context(), cmd)
+	post, err := h.postService.CreatePost(c.Request.Context(), handler.GetActor(c), cmd)
 	if err != nil {
 		if errors.Is(err, domain.ErrSlugExists) {
 			handler.Conflict(c, "Slug already exists")
 			return
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			handler.Forbidden(c, "You can only change your own posts")
+			return
+		}
 		handler.InternalErrorWithLog(c, "Failed to create post", err)
 		return
 	}
@@ -142,6 +147,7 @@ func (h *PostHandler) CreatePost(c *gin.Context) {
 // @Failure 400 {object} handler.ErrorResponse
 // @Failure 404 {object} handler.ErrorResponse
 // @Failure 409 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
 // @Router /api/admin/posts/{id} [put]
 func (h *PostHandler) UpdatePost(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
@@ -157,7 +163,7 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 	}
 
 	cmd := mapper.ToUpdatePostCommand(&req)
-	post, err := h.postService.UpdatePost(c.Request.Context(), int32(id), cmd)
+	post, err := h.postService.UpdatePost(c.Request.Context(), handler.GetActor(c), int32(id), cmd)
 	if err != nil {
 		if errors.Is(err, domain.ErrPostNotFound) {
 			handler.NotFound(c, "Post not found")
@@ -167,6 +173,10 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 			handler.Conflict(c, "Slug already exists")
 			return
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			handler.Forbidden(c, "You can only change your own posts")
+			return
+		}
 		handler.InternalErrorWithLog(c, "Failed to update post", err)
 		return
 	}
@@ -182,6 +192,7 @@ func (h *PostHandler) UpdatePost(c *gin.Context) {
 // @Param id path int true "Post ID"
 // @Success 204 "No Content"
 // @Failure 404 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
 // @Router /api/admin/posts/{id} [delete]
 func (h *PostHandler) DeletePost(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
@@ -190,11 +201,15 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 		return
 	}
 
-	if err := h.postService.DeletePost(c.Request.Context(), int32(id)); err != nil {
+	if err := h.postService.DeletePost(c.Request.Context(), handler.GetActor(c), int32(id)); err != nil {
 		if errors.Is(err, domain.ErrPostNotFound) {
 			handler.NotFound(c, "Post not found")
 			return
 		}
+		if errors.Is(err, domain.ErrForbidden) {
+			handler.Forbidden(c, "You can only change your own posts")
+			return
+		}
 		handler.InternalErrorWithLog(c, "Failed to delete post", err)
 		return
 	}
@@ -203,8 +218,8 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 }
 
 // PublishPost godoc
-// @Summary Publish or unpublish a post
-// @Description Change the publish status of a post
+// @Summary Publish, schedule or unpublish a post
+// @Description Change the publish status of a post. Set publish_at to a future time to schedule it.
 // @Tags admin/posts
 // @Security BearerAuth
 // @Accept json
@@ -212,7 +227,9 @@ func (h *PostHandler) DeletePost(c *gin.Context) {
 // @Param id path int true "Post ID"
 // @Param request body dto.PublishRequest true "Publish status"
 // @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
 // @Failure 404 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
 // @Router /api/admin/posts/{id}/publish [patch]
 func (h *PostHandler) PublishPost(c *gin.Context) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
@@ -227,15 +244,203 @@ func (h *PostHandler) PublishPost(c *gin.Context) {
 		return
 	}
 
-	post, err := h.postService.PublishPost(c.Request.Context(), int32(id), req.Publish)
+	var post *entity.PostWithDetails
+	if req.Publish && req.PublishAt != nil {
+		post, err = h.postService.SchedulePost(c.Request.Context(), handler.GetActor(c), int32(id), *req.PublishAt)
+	} else {
+		post, err = h.postService.PublishPost(c.Request.Context(), handler.GetActor(c), int32(id), req.Publish)
+	}
 	if err != nil {
 		if errors.Is(err, domain.ErrPostNotFound) {
 			handler.NotFound(c, "Post not found")
 			return
 		}
+		if errors.Is(err, domain.ErrScheduleInPast) {
+			handler.BadRequest(c, "Scheduled publish time must be in the future")
+			return
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			handler.Forbidden(c, "You can only change your own posts")
+			return
+		}
 		handler.InternalErrorWithLog(c, "Failed to update publish status", err)
 		return
 	}
 
 	handler.Success(c, mapper.ToPostResponse(post))
 }
+
+// ListRevisions godoc
+// @Summary List post revisions
+// @Description Get a paginated list of saved revisions for a post, newest first
+// @Tags admin/posts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(10)
+// @Success 200 {object} handler.Response
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/posts/{id}/revisions [get]
+func (h *PostHandler) ListRevisions(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid post ID")
+		return
+	}
+
+	pagination := handler.GetPagination(c)
+	revisions, total, err := h.postService.ListRevisions(
+		c.Request.Context(),
+		int32(id),
+		int32(pagination.PerPage),
+		int32(pagination.Offset),
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			handler.NotFound(c, "Post not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch revisions", err)
+		return
+	}
+
+	handler.SuccessWithMeta(c, mapper.ToPostRevisionListResponses(revisions), pagination.ToMeta(total))
+}
+
+// GetRevision godoc
+// @Summary Get a post revision
+// @Description Get a single revision of a post including its content
+// @Tags admin/posts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param revisionId path int true "Revision ID"
+// @Success 200 {object} handler.Response
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/posts/{id}/revisions/{revisionId} [get]
+func (h *PostHandler) GetRevision(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid post ID")
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(c.Param("revisionId"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid revision ID")
+		return
+	}
+
+	revision, err := h.postService.GetRevision(c.Request.Context(), int32(id), int32(revisionID))
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			handler.NotFound(c, "Revision not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch revision", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToPostRevisionResponse(revision))
+}
+
+// DiffRevisions godoc
+// @Summary Diff post revisions
+// @Description Get unified diffs between a revision and another revision or the current post
+// @Tags admin/posts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param from query int true "Base revision ID"
+// @Param to query int false "Target revision ID (defaults to the current post)"
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/admin/posts/{id}/revisions/diff [get]
+func (h *PostHandler) DiffRevisions(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid post ID")
+		return
+	}
+
+	fromID, err := strconv.ParseInt(c.Query("from"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid from revision ID")
+		return
+	}
+
+	var toID *int32
+	if to := c.Query("to"); to != "" {
+		parsed, err := strconv.ParseInt(to, 10, 32)
+		if err != nil {
+			handler.BadRequest(c, "Invalid to revision ID")
+			return
+		}
+		v := int32(parsed)
+		toID = &v
+	}
+
+	diff, err := h.postService.DiffRevisions(c.Request.Context(), int32(id), int32(fromID), toID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			handler.NotFound(c, "Revision not found")
+			return
+		}
+		if errors.Is(err, domain.ErrPostNotFound) {
+			handler.NotFound(c, "Post not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to diff revisions", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToPostRevisionDiffResponse(diff))
+}
+
+// RestoreRevision godoc
+// @Summary Restore a post revision
+// @Description Restore a post's title, content, excerpt and tags from a revision. The replaced version is saved as a new revision.
+// @Tags admin/posts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Post ID"
+// @Param revisionId path int true "Revision ID"
+// @Success 200 {object} handler.Response
+// @Failure 404 {object} handler.ErrorResponse
+// @Failure 403 {object} handler.ErrorResponse
+// @Router /api/admin/posts/{id}/revisions/{revisionId}/restore [post]
+func (h *PostHandler) RestoreRevision(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid post ID")
+		return
+	}
+
+	revisionID, err := strconv.ParseInt(c.Param("revisionId"), 10, 32)
+	if err != nil {
+		handler.BadRequest(c, "Invalid revision ID")
+		return
+	}
+
+	post, err := h.postService.RestoreRevision(c.Request.Context(), handler.GetActor(c), int32(id), int32(revisionID))
+	if err != nil {
+		if errors.Is(err, domain.ErrRevisionNotFound) {
+			handler.NotFound(c, "Revision not found")
+			return
+		}
+		if errors.Is(err, domain.ErrPostNotFound) {
+			handler.NotFound(c, "Post not found")
+			return
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			handler.Forbidden(c, "You can only change your own posts")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to restore revision", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToPostResponse(post))
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestETagMatches(t *testing.T) {
+	const etag = `"abc"`
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"strong match", `"abc"`, true},
+		{"weak match", `W/"abc"`, true},
+		{"different tag", `"abd"`, false},
+		{"unquoted", `abc`, false},
+		{"list containing tag", `"x", W/"abc" ,"y"`, true},
+		{"list without tag", `"x", "y"`, false},
+		{"wildcard", `*`, true},
+		{"empty", ``, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etagMatches(tt.header, etag); got != tt.want {
+				t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const etag = `"abc"`
+	lastModified := time.Date(2024, 5, 1, 9, 30, 15, 999, time.UTC)
+	at := func(d time.Duration) string { return lastModified.Add(d).Format(http.TimeFormat) }
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		lastModified time.Time
+		want         bool
+	}{
+		{"no conditions", nil, lastModified, false},
+		{"matching etag", map[string]string{"If-None-Match": `W/"abc"`}, lastModified, true},
+		{"other etag", map[string]string{"If-None-Match": `"old"`}, lastModified, false},
+		{"not modified since", map[string]string{"If-Modified-Since": at(0)}, lastModified, true},
+		{"modified since", map[string]string{"If-Modified-Since": at(-time.Minute)}, lastModified, false},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, lastModified, false},
+		{"no last modified", map[string]string{"If-Modified-Since": at(time.Hour)}, time.Time{}, false},
+		// If-None-Match takes precedence: a stale date doesn't rescue a changed tag
+		{"etag mismatch wins over date", map[string]string{"If-None-Match": `"old"`, "If-Modified-Since": at(time.Hour)}, lastModified, false},
+		{"etag match wins over date", map[string]string{"If-None-Match": etag, "If-Modified-Since": at(-time.Hour)}, lastModified, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/feed.xml", nil)
+			for k, v := range tt.headers {
+				c.Request.Header.Set(k, v)
+			}
+
+			got := NotModified(c, etag, tt.lastModified)
+			c.Writer.WriteHeaderNow()
+			if got != tt.want {
+				t.Errorf("NotModified() = %v, want %v", got, tt.want)
+			}
+			if tt.want && w.Code != http.StatusNotModified {
+				t.Errorf("status = %d, want 304", w.Code)
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("ETag header = %q", w.Header().Get("ETag"))
+			}
+			if lm := w.Header().Get("Last-Modified"); (lm != "") != !tt.lastModified.IsZero() {
+				t.Errorf("Last-Modified header = %q", lm)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package public
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type CommentHandler struct {
+	commentService domainService.CommentService
+}
+
+// NewCommentHandlerWithCleanArch creates a new CommentHandler with clean architecture service
+func NewCommentHandlerWithCleanArch(commentService domainService.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// ListComments godoc
+// @Summary List comments of a post
+// @Description Get the approved comments of a published post as threads, oldest first.
+// @Description Removed comments that still have replies are kept as placeholders with status "deleted".
+// @Tags comments
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Success 200 {object} handler.Response
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/posts/{slug}/comments [get]
+func (h *CommentHandler) ListComments(c *gin.Context) {
+	slug := c.Param("slug")
+
+	threads, err := h.commentService.ListPostComments(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			handler.NotFound(c, "Post not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch comments", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToCommentThreadResponses(threads))
+}
+
+// GetFormToken godoc
+// @Summary Get a comment form token
+// @Description Get a signed token to send as form_token with a new comment. Comments submitted
+// @Description too quickly after the token was issued, or without a token, score higher as spam.
+// @Tags comments
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Success 200 {object} handler.Response
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/posts/{slug}/comments/form-token [get]
+func (h *CommentHandler) GetFormToken(c *gin.Context) {
+	slug := c.Param("slug")
+
+	token, err := h.commentService.IssueFormToken(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrPostNotFound) {
+			handler.NotFound(c, "Post not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to issue form token", err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	handler.Success(c, dto.CommentFormTokenResponse{FormToken: token})
+}
+
+// CreateComment godoc
+// @Summary Post a comment
+// @Description Post a comment or a reply on a published post. New comments are held for moderation
+// @Description and scored by the spam filters; high scores are routed to spam.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Param request body dto.CreateCommentRequest true "Comment data"
+// @Success 201 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/posts/{slug}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	slug := c.Param("slug")
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		handler.BadRequest(c, "Invalid request body")
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(c.Request.Context(), slug, domainService.CreateCommentCommand{
+		ParentID:     req.ParentID,
+		AuthorName:   req.AuthorName,
+		AuthorEmail:  req.AuthorEmail,
+		GitHubHandle: req.GitHubHandle,
+		Content:      req.Content,
+		ClientIP:     c.ClientIP(),
+		Honeypot:     req.Website,
+		FormToken:    req.FormToken,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPostNotFound):
+			handler.NotFound(c, "Post not found")
+		case errors.Is(err, domain.ErrCommentContentRequired),
+			errors.Is(err, domain.ErrCommentAuthorRequired),
+			errors.Is(err, domain.ErrInvalidGitHubHandle),
+			errors.Is(err, domain.ErrInvalidCommentParent):
+			handler.BadRequest(c, err.Error())
+		default:
+			handler.InternalErrorWithLog(c, "Failed to create comment", err)
+		}
+		return
+	}
+
+	handler.Created(c, mapper.ToCommentResponse(comment))
+}
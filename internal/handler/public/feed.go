@@ -0,0 +1,289 @@
+package public
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/feeds"
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type feedFormat int
+
+const (
+	feedFormatRSS feedFormat = iota
+	feedFormatAtom
+	feedFormatJSON
+)
+
+type FeedHandler struct {
+	postService     domainService.PostService
+	categoryService domainService.CategoryService
+	tagService      domainService.TagService
+	site            *config.SiteConfig
+	feed            *config.FeedConfig
+}
+
+// NewFeedHandlerWithCleanArch creates a new FeedHandler with clean architecture
+func NewFeedHandlerWithCleanArch(
+	postService domainService.PostService,
+	categoryService domainService.CategoryService,
+	tagService domainService.TagService,
+	site *config.SiteConfig,
+	feed *config.FeedConfig,
+) *FeedHandler {
+	return &FeedHandler{
+		postService:     postService,
+		categoryService: categoryService,
+		tagService:      tagService,
+		site:            site,
+		feed:            feed,
+	}
+}
+
+// RSS godoc
+// @Summary RSS 2.0 feed
+// @Description Get the latest published posts as an RSS 2.0 feed. Supports conditional GET.
+// @Tags feeds
+// @Produce xml
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not Modified"
+// @Router /api/public/feed.xml [get]
+func (h *FeedHandler) RSS(c *gin.Context) {
+	h.serveSiteFeed(c, feedFormatRSS)
+}
+
+// Atom godoc
+// @Summary Atom feed
+// @Description Get the latest published posts as an Atom feed. Supports conditional GET.
+// @Tags feeds
+// @Produce xml
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {string} string "Atom feed"
+// @Success 304 "Not Modified"
+// @Router /api/public/atom.xml [get]
+func (h *FeedHandler) Atom(c *gin.Context) {
+	h.serveSiteFeed(c, feedFormatAtom)
+}
+
+// JSONFeed godoc
+// @Summary JSON Feed
+// @Description Get the latest published posts as a JSON Feed. Supports conditional GET.
+// @Tags feeds
+// @Produce json
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {object} feeds.JSONFeed
+// @Success 304 "Not Modified"
+// @Router /api/public/feed.json [get]
+func (h *FeedHandler) JSONFeed(c *gin.Context) {
+	h.serveSiteFeed(c, feedFormatJSON)
+}
+
+// CategoryRSS godoc
+// @Summary Category RSS 2.0 feed
+// @Description Get the latest published posts in a category as an RSS 2.0 feed
+// @Tags feeds
+// @Produce xml
+// @Param slug path string true "Category slug"
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/categories/{slug}/feed.xml [get]
+func (h *FeedHandler) CategoryRSS(c *gin.Context) {
+	h.serveCategoryFeed(c, feedFormatRSS)
+}
+
+// CategoryAtom godoc
+// @Summary Category Atom feed
+// @Description Get the latest published posts in a category as an Atom feed
+// @Tags feeds
+// @Produce xml
+// @Param slug path string true "Category slug"
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {string} string "Atom feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/categories/{slug}/atom.xml [get]
+func (h *FeedHandler) CategoryAtom(c *gin.Context) {
+	h.serveCategoryFeed(c, feedFormatAtom)
+}
+
+// CategoryJSONFeed godoc
+// @Summary Category JSON Feed
+// @Description Get the latest published posts in a category as a JSON Feed
+// @Tags feeds
+// @Produce json
+// @Param slug path string true "Category slug"
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {object} feeds.JSONFeed
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/categories/{slug}/feed.json [get]
+func (h *FeedHandler) CategoryJSONFeed(c *gin.Context) {
+	h.serveCategoryFeed(c, feedFormatJSON)
+}
+
+// TagRSS godoc
+// @Summary Tag RSS 2.0 feed
+// @Description Get the latest published posts with a tag as an RSS 2.0 feed
+// @Tags feeds
+// @Produce xml
+// @Param slug path string true "Tag slug"
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/tags/{slug}/feed.xml [get]
+func (h *FeedHandler) TagRSS(c *gin.Context) {
+	h.serveTagFeed(c, feedFormatRSS)
+}
+
+// TagAtom godoc
+// @Summary Tag Atom feed
+// @Description Get the latest published posts with a tag as an Atom feed
+// @Tags feeds
+// @Produce xml
+// @Param slug path string true "Tag slug"
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {string} string "Atom feed"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/tags/{slug}/atom.xml [get]
+func (h *FeedHandler) TagAtom(c *gin.Context) {
+	h.serveTagFeed(c, feedFormatAtom)
+}
+
+// TagJSONFeed godoc
+// @Summary Tag JSON Feed
+// @Description Get the latest published posts with a tag as a JSON Feed
+// @Tags feeds
+// @Produce json
+// @Param slug path string true "Tag slug"
+// @Param mode query string false "Content mode (full, excerpt)"
+// @Success 200 {object} feeds.JSONFeed
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/tags/{slug}/feed.json [get]
+func (h *FeedHandler) TagJSONFeed(c *gin.Context) {
+	h.serveTagFeed(c, feedFormatJSON)
+}
+
+func (h *FeedHandler) serveSiteFeed(c *gin.Context, format feedFormat) {
+	posts, _, err := h.postService.ListPublishedPosts(c.Request.Context(), int32(h.feed.Limit), 0)
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch posts", err)
+		return
+	}
+
+	h.render(c, format, posts, h.site.Title, h.site.Description, h.site.URL, "/feed.json")
+}
+
+func (h *FeedHandler) serveCategoryFeed(c *gin.Context, format feedFormat) {
+	slug := c.Param("slug")
+
+	category, err := h.categoryService.GetCategoryBySlug(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			handler.NotFound(c, "Category not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch category", err)
+		return
+	}
+
+	posts, _, err := h.postService.ListPublishedPostsByCategory(c.Request.Context(), category.ID, int32(h.feed.Limit), 0)
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch posts", err)
+		return
+	}
+
+	title := h.site.Title + " - " + category.Name
+	h.render(c, format, posts, title, category.Description, h.site.URL+"/categories/"+category.Slug, "/categories/"+category.Slug+"/feed.json")
+}
+
+func (h *FeedHandler) serveTagFeed(c *gin.Context, format feedFormat) {
+	slug := c.Param("slug")
+
+	tag, err := h.tagService.GetTagBySlug(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrTagNotFound) {
+			handler.NotFound(c, "Tag not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to fetch tag", err)
+		return
+	}
+
+	posts, _, err := h.postService.ListPublishedPostsByTag(c.Request.Context(), tag.ID, int32(h.feed.Limit), 0)
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch posts", err)
+		return
+	}
+
+	title := h.site.Title + " - #" + tag.Name
+	h.render(c, format, posts, title, "", h.site.URL+"/tags/"+tag.Slug, "/tags/"+tag.Slug+"/feed.json")
+}
+
+// render encodes the feed in the requested format and answers conditional requests.
+// jsonPath is the JSON feed's path under the configured feed URL, used as its self link
+func (h *FeedHandler) render(c *gin.Context, format feedFormat, posts []entity.PostWithDetails, title, description, link, jsonPath string) {
+	feed := mapper.ToFeed(posts, mapper.FeedOptions{
+		Title:       title,
+		Description: description,
+		Link:        link,
+		SiteURL:     h.site.URL,
+		Author:      h.site.Author,
+		FullContent: h.fullContent(c),
+	})
+
+	var body []byte
+	var contentType string
+	var err error
+
+	switch format {
+	case feedFormatAtom:
+		var s string
+		s, err = feed.ToAtom()
+		body, contentType = []byte(s), "application/atom+xml; charset=utf-8"
+	case feedFormatJSON:
+		jsonFeed := (&feeds.JSON{Feed: feed}).JSONFeed()
+		jsonFeed.FeedUrl = h.feed.URL + jsonPath
+		body, err = json.Marshal(jsonFeed)
+		contentType = "application/feed+json; charset=utf-8"
+	default:
+		var s string
+		s, err = feed.ToRss()
+		body, contentType = []byte(s), "application/rss+xml; charset=utf-8"
+	}
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to render feed", err)
+		return
+	}
+
+	if handler.NotModified(c, handler.ETag(body), feed.Updated) {
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// fullContent resolves the content mode from the query, falling back to the configured default
+func (h *FeedHandler) fullContent(c *gin.Context) bool {
+	switch c.Query("mode") {
+	case "full":
+		return true
+	case "excerpt":
+		return false
+	default:
+		return h.feed.FullContent
+	}
+}
@@ -0,0 +1,191 @@
+package public
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/feeds"
+	appService "github.com/ydonggwui/blog-api/internal/application/service"
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository/mocks"
+)
+
+func newTestFeedRouter(fullContent bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	updated := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	post := func(id int32, slug string) entity.PostWithDetails {
+		return entity.PostWithDetails{Post: entity.Post{
+			ID: id, Title: slug, Slug: slug, Content: "<p>full " + slug + "</p>", Excerpt: "excerpt " + slug,
+			CreatedAt: updated, UpdatedAt: updated,
+		}}
+	}
+
+	postRepo := &mocks.MockPostRepository{
+		ListPublishedFunc: func(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error) {
+			return []entity.PostWithDetails{post(1, "site-post")}, nil
+		},
+		ListPublishedByCategoryFunc: func(ctx context.Context, categoryID int32, limit, offset int32) ([]entity.PostWithDetails, error) {
+			return []entity.PostWithDetails{post(2, "category-post")}, nil
+		},
+		ListPublishedByTagFunc: func(ctx context.Context, tagID int32, limit, offset int32) ([]entity.PostWithDetails, error) {
+			return []entity.PostWithDetails{post(3, "tag-post")}, nil
+		},
+	}
+	categoryRepo := &mocks.MockCategoryRepository{
+		FindBySlugFunc: func(ctx context.Context, slug string) (*entity.Category, error) {
+			if slug != "go" {
+				return nil, domain.ErrCategoryNotFound
+			}
+			return &entity.Category{ID: 1, Name: "Go", Slug: "go", Description: "Go posts"}, nil
+		},
+	}
+	tagRepo := &mocks.MockTagRepository{
+		FindBySlugFunc: func(ctx context.Context, slug string) (*entity.Tag, error) {
+			if slug != "generics" {
+				return nil, domain.ErrTagNotFound
+			}
+			return &entity.Tag{ID: 1, Name: "generics", Slug: "generics"}, nil
+		},
+	}
+
+	audit, suggest := &mocks.MockAuditLogRepository{}, &mocks.MockSuggestRepository{}
+	h := NewFeedHandlerWithCleanArch(
+		appService.NewPostService(postRepo, suggest, &mocks.MockMediaRepository{}, audit),
+		appService.NewCategoryService(categoryRepo, suggest, audit),
+		appService.NewTagService(tagRepo, suggest, audit),
+		&config.SiteConfig{Title: "Blog", URL: "https://blog.test"},
+		&config.FeedConfig{URL: "https://api.blog.test/api/public", Limit: 20, FullContent: fullContent},
+	)
+
+	r := gin.New()
+	r.GET("/feed.json", h.JSONFeed)
+	r.GET("/atom.xml", h.Atom)
+	r.GET("/categories/:slug/feed.json", h.CategoryJSONFeed)
+	r.GET("/tags/:slug/feed.xml", h.TagRSS)
+	return r
+}
+
+func serve(r *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestFeedHandler_Feeds(t *testing.T) {
+	tests := []struct {
+		name        string
+		fullContent bool
+		path        string
+		wantTitle   string
+		wantPost    string
+		wantFull    bool
+	}{
+		{"site feed, full by default", true, "/feed.json", "Blog", "site-post", true},
+		{"site feed, summary by default", false, "/feed.json", "Blog", "site-post", false},
+		{"summary requested", true, "/feed.json?mode=excerpt", "Blog", "site-post", false},
+		{"full requested", false, "/feed.json?mode=full", "Blog", "site-post", true},
+		{"category feed", true, "/categories/go/feed.json", "Blog - Go", "category-post", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(newTestFeedRouter(tt.fullContent), tt.path, nil)
+			if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/feed+json") {
+				t.Fatalf("status = %d, content type %q", w.Code, w.Header().Get("Content-Type"))
+			}
+			var feed feeds.JSONFeed
+			if err := json.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+				t.Fatalf("invalid JSON feed: %v", err)
+			}
+			if feed.Title != tt.wantTitle || len(feed.Items) != 1 {
+				t.Fatalf("feed = %q with %d items, want %q with 1", feed.Title, len(feed.Items), tt.wantTitle)
+			}
+			item := feed.Items[0]
+			if item.Url != "https://blog.test/posts/"+tt.wantPost {
+				t.Errorf("item url = %q, want the %s post", item.Url, tt.wantPost)
+			}
+			if (item.ContentHTML != "") != tt.wantFull {
+				t.Errorf("item content = %q, want full content: %v", item.ContentHTML, tt.wantFull)
+			}
+		})
+	}
+
+	t.Run("self link ignores request headers", func(t *testing.T) {
+		w := serve(newTestFeedRouter(true), "/categories/go/feed.json", map[string]string{
+			"Host":              "evil.test",
+			"X-Forwarded-Proto": "javascript",
+		})
+		var feed feeds.JSONFeed
+		if err := json.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+			t.Fatalf("invalid JSON feed: %v", err)
+		}
+		if feed.FeedUrl != "https://api.blog.test/api/public/categories/go/feed.json" {
+			t.Errorf("feed url = %q, want it built from the configured feed URL", feed.FeedUrl)
+		}
+	})
+
+	t.Run("tag feed", func(t *testing.T) {
+		w := serve(newTestFeedRouter(false), "/tags/generics/feed.xml", nil)
+		body := w.Body.String()
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/rss+xml") {
+			t.Fatalf("status = %d, content type %q", w.Code, w.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(body, "<title>Blog - #generics</title>") || !strings.Contains(body, "https://blog.test/tags/generics") ||
+			!strings.Contains(body, "https://blog.test/posts/tag-post") {
+			t.Errorf("tag feed missing channel or item:\n%s", body)
+		}
+	})
+
+	t.Run("unknown category and tag", func(t *testing.T) {
+		r := newTestFeedRouter(true)
+		for _, path := range []string{"/categories/rust/feed.json", "/tags/unknown/feed.xml"} {
+			if w := serve(r, path, nil); w.Code != http.StatusNotFound {
+				t.Errorf("%s: status = %d, want 404", path, w.Code)
+			}
+		}
+	})
+}
+
+func TestFeedHandler_ConditionalGet(t *testing.T) {
+	r := newTestFeedRouter(true)
+	first := serve(r, "/atom.xml", nil)
+	etag, lastModified := first.Header().Get("ETag"), first.Header().Get("Last-Modified")
+	if first.Code != http.StatusOK || etag == "" || lastModified != "Wed, 01 May 2024 09:00:00 GMT" {
+		t.Fatalf("status = %d, ETag %q, Last-Modified %q", first.Code, etag, lastModified)
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"same etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"weak etag", map[string]string{"If-None-Match": "W/" + etag}, http.StatusNotModified},
+		{"other mode has another etag", map[string]string{"If-None-Match": serve(r, "/atom.xml?mode=excerpt", nil).Header().Get("ETag")}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified},
+		{"stale etag overrides date", map[string]string{"If-None-Match": `"stale"`, "If-Modified-Since": lastModified}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(r, "/atom.xml", tt.headers)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("304 response has a body")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+type JWKSHandler struct {
+	signingKeyService domainService.SigningKeyService
+}
+
+// NewJWKSHandlerWithCleanArch creates a new JWKSHandler with clean architecture service
+func NewJWKSHandlerWithCleanArch(signingKeyService domainService.SigningKeyService) *JWKSHandler {
+	return &JWKSHandler{
+		signingKeyService: signingKeyService,
+	}
+}
+
+// GetJWKS godoc
+// @Summary JSON Web Key Set
+// @Description Get the public keys admin access tokens are signed with, to verify them in other services.
+// @Description Tokens name their key in the kid header. Keys rotate every JWT_KEY_ROTATION, so refetch
+// @Description the set when a token names an unknown key. Empty when JWT_ALGORITHM is HS256.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.JWKSResponse
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	keys, err := h.signingKeyService.PublicKeys(c.Request.Context())
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch signing keys", err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, mapper.ToJWKSResponse(keys))
+}
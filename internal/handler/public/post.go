@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
 	"github.com/ydonggwui/blog-api/internal/handler"
 	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
@@ -118,10 +119,15 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 
 // SearchPosts godoc
 // @Summary Search posts
-// @Description Search published posts by title and content
+// @Description Search published posts by title, content, category and tag names, ranked by relevance.
+// @Description Each result has a score and highlighted fragments with matches wrapped in <mark>.
+// @Description Facet counts per category, tag and year are returned next to the results for drill-down.
 // @Tags posts
 // @Produce json
 // @Param q query string true "Search query"
+// @Param category query string false "Filter by category slug"
+// @Param tag query string false "Filter by tag slug"
+// @Param year query int false "Filter by publication year"
 // @Param page query int false "Page number" default(1)
 // @Param per_page query int false "Items per page" default(10)
 // @Success 200 {object} handler.Response
@@ -134,11 +140,25 @@ func (h *PostHandler) SearchPosts(c *gin.Context) {
 		return
 	}
 
+	filter := entity.PostSearchFilter{
+		CategorySlug: c.Query("category"),
+		TagSlug:      c.Query("tag"),
+	}
+	if year := c.Query("year"); year != "" {
+		y, err := strconv.ParseInt(year, 10, 32)
+		if err != nil || y < 1 {
+			handler.BadRequest(c, "Invalid year")
+			return
+		}
+		filter.Year = int32(y)
+	}
+
 	pagination := handler.GetPagination(c)
 
 	posts, total, err := h.postService.SearchPublishedPosts(
 		c.Request.Context(),
 		query,
+		filter,
 		int32(pagination.PerPage),
 		int32(pagination.Offset),
 	)
@@ -147,7 +167,13 @@ func (h *PostHandler) SearchPosts(c *gin.Context) {
 		return
 	}
 
-	handler.SuccessWithMeta(c, mapper.ToPostListResponses(posts), pagination.ToMeta(total))
+	facets, err := h.postService.GetSearchFacets(c.Request.Context(), query, filter)
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch search facets", err)
+		return
+	}
+
+	handler.SuccessWithFacets(c, mapper.ToPostSearchResponses(posts), pagination.ToMeta(total), mapper.ToPostSearchFacetsResponse(facets))
 }
 
 // RecordView godoc
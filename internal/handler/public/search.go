@@ -0,0 +1,59 @@
+package public
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/mapper"
+)
+
+const (
+	defaultSuggestLimit = 8
+	maxSuggestLimit     = 20
+)
+
+type SearchHandler struct {
+	suggestService domainService.SuggestService
+}
+
+// NewSearchHandlerWithCleanArch creates a new SearchHandler with clean architecture service
+func NewSearchHandlerWithCleanArch(suggestService domainService.SuggestService) *SearchHandler {
+	return &SearchHandler{
+		suggestService: suggestService,
+	}
+}
+
+// Suggest godoc
+// @Summary Search suggestions
+// @Description Autocomplete post titles, tag names and category names by prefix, with typo-tolerant matches after the prefix matches
+// @Tags search
+// @Produce json
+// @Param q query string true "Partial search query"
+// @Param limit query int false "Maximum number of suggestions" default(8)
+// @Success 200 {object} handler.Response
+// @Failure 400 {object} handler.ErrorResponse
+// @Router /api/public/search/suggest [get]
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		handler.BadRequest(c, "Search query is required")
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultSuggestLimit)))
+	if err != nil || limit < 1 {
+		limit = defaultSuggestLimit
+	}
+	limit = min(limit, maxSuggestLimit)
+
+	suggestions, err := h.suggestService.Suggest(c.Request.Context(), query, limit)
+	if err != nil {
+		handler.InternalErrorWithLog(c, "Failed to fetch suggestions", err)
+		return
+	}
+
+	handler.Success(c, mapper.ToSearchSuggestionResponses(suggestions))
+}
@@ -0,0 +1,77 @@
+package public
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/handler"
+)
+
+type SitemapHandler struct {
+	sitemapService domainService.SitemapService
+}
+
+// NewSitemapHandlerWithCleanArch creates a new SitemapHandler with clean architecture service
+func NewSitemapHandlerWithCleanArch(sitemapService domainService.SitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapService: sitemapService,
+	}
+}
+
+// GetSitemap godoc
+// @Summary XML sitemap
+// @Description Get the root sitemap. Past 50,000 URLs this is a sitemap index pointing to sitemap-N.xml pages.
+// @Tags sitemap
+// @Produce xml
+// @Success 200 {string} string "Sitemap or sitemap index"
+// @Success 304 "Not Modified"
+// @Router /api/public/sitemap.xml [get]
+func (h *SitemapHandler) GetSitemap(c *gin.Context) {
+	h.serve(c, 0)
+}
+
+// GetSitemapPage godoc
+// @Summary XML sitemap page
+// @Description Get a page of a split sitemap listed in the sitemap index
+// @Tags sitemap
+// @Produce xml
+// @Param page path int true "Sitemap page number"
+// @Success 200 {string} string "Sitemap"
+// @Success 304 "Not Modified"
+// @Failure 404 {object} handler.ErrorResponse
+// @Router /api/public/sitemap-{page}.xml [get]
+func (h *SitemapHandler) GetSitemapPage(c *gin.Context) {
+	// The route is /sitemap-:page, so the param still carries the extension
+	param, ok := strings.CutSuffix(c.Param("page"), ".xml")
+	page, err := strconv.Atoi(param)
+	if !ok || err != nil || page < 1 {
+		handler.NotFound(c, "Sitemap not found")
+		return
+	}
+
+	h.serve(c, page)
+}
+
+func (h *SitemapHandler) serve(c *gin.Context, page int) {
+	data, err := h.sitemapService.GetSitemap(c.Request.Context(), page)
+	if err != nil {
+		if errors.Is(err, domain.ErrSitemapNotFound) {
+			handler.NotFound(c, "Sitemap not found")
+			return
+		}
+		handler.InternalErrorWithLog(c, "Failed to build sitemap", err)
+		return
+	}
+
+	if handler.NotModified(c, handler.ETag(data), time.Time{}) {
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", data)
+}
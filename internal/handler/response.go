@@ -10,8 +10,9 @@ import (
 // Response formats
 
 type Response struct {
-	Data interface{} `json:"data,omitempty"`
-	Meta *Meta       `json:"meta,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+	Meta   *Meta       `json:"meta,omitempty"`
+	Facets interface{} `json:"facets,omitempty"`
 }
 
 type Meta struct {
@@ -40,6 +41,11 @@ func SuccessWithMeta(c *gin.Context, data interface{}, meta *Meta) {
 	c.JSON(http.StatusOK, Response{Data: data, Meta: meta})
 }
 
+// SuccessWithFacets adds search facet counts next to the paginated data
+func SuccessWithFacets(c *gin.Context, data interface{}, meta *Meta, facets interface{}) {
+	c.JSON(http.StatusOK, Response{Data: data, Meta: meta, Facets: facets})
+}
+
 func Created(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusCreated, Response{Data: data})
 }
@@ -80,6 +86,12 @@ func Conflict(c *gin.Context, message string) {
 	})
 }
 
+func TooManyRequests(c *gin.Context, message string) {
+	c.JSON(http.StatusTooManyRequests, ErrorResponse{
+		Error: ErrorDetail{Code: "TOO_MANY_REQUESTS", Message: message},
+	})
+}
+
 func InternalError(c *gin.Context, message string) {
 	c.JSON(http.StatusInternalServerError, ErrorResponse{
 		Error: ErrorDetail{Code: "INTERNAL_ERROR", Message: message},
@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+)
+
+type provider struct {
+	cfg *config.OIDCConfig
+
+	// Discovery happens on first use so the server starts while the identity provider is down;
+	// a failed discovery is retried on the next login
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// NewProvider creates an OpenID Connect identity provider from the issuer's discovery document
+func NewProvider(cfg *config.OIDCConfig) domainService.OIDCProvider {
+	return &provider{cfg: cfg}
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	oauthConfig, _, err := p.discover(ctx)
+	if err != nil {
+		return "", fmt.Errorf("oidcProvider.AuthCodeURL: %w", err)
+	}
+	return oauthConfig.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code, verifier, nonce string) (*entity.OIDCIdentity, error) {
+	oauthConfig, idTokenVerifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("oidcProvider.Exchange: %w", err)
+	}
+
+	token, err := oauthConfig.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("oidcProvider.Exchange: %w: %v", domain.ErrOIDCExchangeFailed, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("oidcProvider.Exchange: %w: no id_token in token response", domain.ErrOIDCExchangeFailed)
+	}
+
+	idToken, err := idTokenVerifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("oidcProvider.Exchange: %w: %v", domain.ErrOIDCExchangeFailed, err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("oidcProvider.Exchange: %w: nonce mismatch", domain.ErrOIDCExchangeFailed)
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("oidcProvider.Exchange: %w: %v", domain.ErrOIDCExchangeFailed, err)
+	}
+
+	return &entity.OIDCIdentity{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+// discover fetches the issuer's endpoints and signing keys once and caches them
+func (p *provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+	if !p.cfg.Enabled() {
+		return nil, nil, domain.ErrOIDCDisabled
+	}
+
+	discovered, err := gooidc.NewProvider(ctx, p.cfg.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discovery failed: %w", err)
+	}
+
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     discovered.Endpoint(),
+		Scopes:       p.cfg.Scopes,
+	}
+	p.verifier = discovered.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth, p.verifier, nil
+}
@@ -3,8 +3,10 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/ydonggwui/blog-api/internal/database/sqlc"
 	"github.com/ydonggwui/blog-api/internal/domain"
@@ -14,10 +16,13 @@ import (
 
 type adminRepository struct {
 	queries *sqlc.Queries
+	box     secretBox
 }
 
-func NewAdminRepository(queries *sqlc.Queries) repository.AdminRepository {
-	return &adminRepository{queries: queries}
+// NewAdminRepository creates a new PostgreSQL admin repository.
+// TOTP secrets are encrypted with an AES-256 key derived from totpEncryptionKey before they are stored.
+func NewAdminRepository(queries *sqlc.Queries, totpEncryptionKey string) repository.AdminRepository {
+	return &adminRepository{queries: queries, box: newSecretBox(totpEncryptionKey)}
 }
 
 func (r *adminRepository) FindByID(ctx context.Context, id int32) (*entity.Admin, error) {
@@ -28,7 +33,11 @@ func (r *adminRepository) FindByID(ctx context.Context, id int32) (*entity.Admin
 		}
 		return nil, fmt.Errorf("adminRepository.FindByID: %w", err)
 	}
-	return toAdminEntity(admin), nil
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.FindByID: %w", err)
+	}
+	return result, nil
 }
 
 func (r *adminRepository) FindByUsername(ctx context.Context, username string) (*entity.Admin, error) {
@@ -39,18 +48,77 @@ func (r *adminRepository) FindByUsername(ctx context.Context, username string) (
 		}
 		return nil, fmt.Errorf("adminRepository.FindByUsername: %w", err)
 	}
-	return toAdminEntity(admin), nil
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.FindByUsername: %w", err)
+	}
+	return result, nil
+}
+
+func (r *adminRepository) FindByEmail(ctx context.Context, email string) (*entity.Admin, error) {
+	admin, err := r.queries.GetAdminByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAdminNotFound
+		}
+		return nil, fmt.Errorf("adminRepository.FindByEmail: %w", err)
+	}
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.FindByEmail: %w", err)
+	}
+	return result, nil
+}
+
+func (r *adminRepository) FindByOIDCSubject(ctx context.Context, subject string) (*entity.Admin, error) {
+	admin, err := r.queries.GetAdminByOIDCSubject(ctx, sql.NullString{String: subject, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAdminNotFound
+		}
+		return nil, fmt.Errorf("adminRepository.FindByOIDCSubject: %w", err)
+	}
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.FindByOIDCSubject: %w", err)
+	}
+	return result, nil
+}
+
+func (r *adminRepository) UpdateIdentity(ctx context.Context, id int32, email, subject string) (*entity.Admin, error) {
+	admin, err := r.queries.UpdateAdminIdentity(ctx, sqlc.UpdateAdminIdentityParams{
+		ID:          id,
+		Email:       sql.NullString{String: email, Valid: email != ""},
+		OidcSubject: sql.NullString{String: subject, Valid: subject != ""},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAdminNotFound
+		}
+		return nil, fmt.Errorf("adminRepository.UpdateIdentity: %w", err)
+	}
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.UpdateIdentity: %w", err)
+	}
+	return result, nil
 }
 
 func (r *adminRepository) Create(ctx context.Context, admin *entity.Admin) (*entity.Admin, error) {
 	created, err := r.queries.CreateAdmin(ctx, sqlc.CreateAdminParams{
 		Username: admin.Username,
 		Password: admin.Password,
+		Role:     string(admin.Role),
+		Email:    sql.NullString{String: admin.Email, Valid: admin.Email != ""},
 	})
 	if err != nil {
 		return nil, fmt.Errorf("adminRepository.Create: %w", err)
 	}
-	return toAdminEntity(created), nil
+	result, err := r.toEntity(created)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.Create: %w", err)
+	}
+	return result, nil
 }
 
 func (r *adminRepository) UpdatePassword(ctx context.Context, id int32, hashedPassword string) error {
@@ -62,3 +130,157 @@ func (r *adminRepository) UpdatePassword(ctx context.Context, id int32, hashedPa
 	}
 	return nil
 }
+
+func (r *adminRepository) DeleteInvited(ctx context.Context, id int32) error {
+	if err := r.queries.DeleteInvitedAdmin(ctx, id); err != nil {
+		return fmt.Errorf("adminRepository.DeleteInvited: %w", err)
+	}
+	return nil
+}
+
+func (r *adminRepository) List(ctx context.Context) ([]entity.Admin, error) {
+	admins, err := r.queries.ListAdmins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.List: %w", err)
+	}
+
+	result := make([]entity.Admin, len(admins))
+	for i, a := range admins {
+		admin, err := r.toEntity(a)
+		if err != nil {
+			return nil, fmt.Errorf("adminRepository.List: %w", err)
+		}
+		result[i] = *admin
+	}
+	return result, nil
+}
+
+func (r *adminRepository) UpdateRole(ctx context.Context, id int32, role entity.AdminRole) (*entity.Admin, error) {
+	admin, err := r.queries.UpdateAdminRole(ctx, sqlc.UpdateAdminRoleParams{
+		ID:   id,
+		Role: string(role),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAdminNotFound
+		}
+		return nil, fmt.Errorf("adminRepository.UpdateRole: %w", err)
+	}
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.UpdateRole: %w", err)
+	}
+	return result, nil
+}
+
+func (r *adminRepository) SetDisabled(ctx context.Context, id int32, disabled bool) (*entity.Admin, error) {
+	admin, err := r.queries.SetAdminDisabled(ctx, sqlc.SetAdminDisabledParams{
+		Disabled: disabled,
+		ID:       id,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAdminNotFound
+		}
+		return nil, fmt.Errorf("adminRepository.SetDisabled: %w", err)
+	}
+	result, err := r.toEntity(admin)
+	if err != nil {
+		return nil, fmt.Errorf("adminRepository.SetDisabled: %w", err)
+	}
+	return result, nil
+}
+
+func (r *adminRepository) CountActiveOwners(ctx context.Context) (int64, error) {
+	count, err := r.queries.CountActiveOwners(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("adminRepository.CountActiveOwners: %w", err)
+	}
+	return count, nil
+}
+
+func (r *adminRepository) SetTOTPSecret(ctx context.Context, id int32, secret string) error {
+	sealed, err := r.box.seal(totpSecretAAD(id), []byte(secret))
+	if err != nil {
+		return fmt.Errorf("adminRepository.SetTOTPSecret: encrypt secret failed: %w", err)
+	}
+	if err := r.queries.SetAdminTOTPSecret(ctx, sqlc.SetAdminTOTPSecretParams{
+		ID:         id,
+		TotpSecret: sql.NullString{String: base64.StdEncoding.EncodeToString(sealed), Valid: true},
+	}); err != nil {
+		return fmt.Errorf("adminRepository.SetTOTPSecret: %w", err)
+	}
+	return nil
+}
+
+func (r *adminRepository) EnableTOTP(ctx context.Context, id int32, recoveryCodeHashes []string) error {
+	if err := r.queries.DeleteAdminRecoveryCodes(ctx, id); err != nil {
+		return fmt.Errorf("adminRepository.EnableTOTP: delete recovery codes failed: %w", err)
+	}
+	if err := r.queries.CreateAdminRecoveryCodes(ctx, sqlc.CreateAdminRecoveryCodesParams{
+		AdminID:    id,
+		CodeHashes: recoveryCodeHashes,
+	}); err != nil {
+		return fmt.Errorf("adminRepository.EnableTOTP: create recovery codes failed: %w", err)
+	}
+	if err := r.queries.EnableAdminTOTP(ctx, id); err != nil {
+		return fmt.Errorf("adminRepository.EnableTOTP: %w", err)
+	}
+	return nil
+}
+
+func (r *adminRepository) DisableTOTP(ctx context.Context, id int32) error {
+	if err := r.queries.DisableAdminTOTP(ctx, id); err != nil {
+		return fmt.Errorf("adminRepository.DisableTOTP: %w", err)
+	}
+	if err := r.queries.DeleteAdminRecoveryCodes(ctx, id); err != nil {
+		return fmt.Errorf("adminRepository.DisableTOTP: delete recovery codes failed: %w", err)
+	}
+	return nil
+}
+
+func (r *adminRepository) UseRecoveryCode(ctx context.Context, id int32, codeHash string) (bool, error) {
+	rows, err := r.queries.UseAdminRecoveryCode(ctx, sqlc.UseAdminRecoveryCodeParams{
+		AdminID:  id,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, fmt.Errorf("adminRepository.UseRecoveryCode: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *adminRepository) UseTOTPStep(ctx context.Context, id int32, step int64) (bool, error) {
+	rows, err := r.queries.UseAdminTOTPStep(ctx, sqlc.UseAdminTOTPStepParams{
+		ID:           id,
+		TotpLastStep: sql.NullInt64{Int64: step, Valid: true},
+	})
+	if err != nil {
+		return false, fmt.Errorf("adminRepository.UseTOTPStep: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// toEntity maps a stored admin and decrypts its TOTP secret
+func (r *adminRepository) toEntity(a sqlc.Admin) (*entity.Admin, error) {
+	admin := toAdminEntity(a)
+	if admin.TOTPSecret == "" {
+		return admin, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(admin.TOTPSecret)
+	if err != nil {
+		return nil, fmt.Errorf("decode TOTP secret of admin %d failed: %w", a.ID, err)
+	}
+	secret, err := r.box.open(totpSecretAAD(a.ID), sealed)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt TOTP secret of admin %d failed: %w", a.ID, err)
+	}
+	admin.TOTPSecret = string(secret)
+	return admin, nil
+}
+
+// totpSecretAAD binds an encrypted TOTP secret to the admin it belongs to
+func totpSecretAAD(id int32) string {
+	return "admin:" + strconv.Itoa(int(id))
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+type apiKeyRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewAPIKeyRepository(queries *sqlc.Queries) repository.APIKeyRepository {
+	return &apiKeyRepository{queries: queries}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey, keyHash string) (*entity.APIKey, error) {
+	params := sqlc.CreateAPIKeyParams{
+		AdminID: key.AdminID,
+		Name:    key.Name,
+		Prefix:  key.Prefix,
+		KeyHash: keyHash,
+		Scopes:  fromPermissions(key.Scopes),
+	}
+	if key.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullTime{Time: *key.ExpiresAt, Valid: true}
+	}
+
+	created, err := r.queries.CreateAPIKey(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyRepository.Create: %w", err)
+	}
+	return toAPIKeyEntity(created), nil
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id int32) (*entity.APIKey, error) {
+	key, err := r.queries.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("apiKeyRepository.FindByID: %w", err)
+	}
+	return toAPIKeyEntity(key), nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKeyWithAdmin, error) {
+	row, err := r.queries.GetAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("apiKeyRepository.FindByHash: %w", err)
+	}
+	return toAPIKeyWithAdmin(row), nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]entity.APIKey, error) {
+	keys, err := r.queries.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyRepository.List: %w", err)
+	}
+	return toAPIKeyEntities(keys), nil
+}
+
+func (r *apiKeyRepository) ListByAdmin(ctx context.Context, adminID int32) ([]entity.APIKey, error) {
+	keys, err := r.queries.ListAPIKeysByAdmin(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("apiKeyRepository.ListByAdmin: %w", err)
+	}
+	return toAPIKeyEntities(keys), nil
+}
+
+func (r *apiKeyRepository) Update(ctx context.Context, id int32, name string, scopes []entity.Permission) (*entity.APIKey, error) {
+	key, err := r.queries.UpdateAPIKey(ctx, sqlc.UpdateAPIKeyParams{
+		ID:     id,
+		Name:   name,
+		Scopes: fromPermissions(scopes),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("apiKeyRepository.Update: %w", err)
+	}
+	return toAPIKeyEntity(key), nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id int32) (*entity.APIKey, error) {
+	key, err := r.queries.RevokeAPIKey(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("apiKeyRepository.Revoke: %w", err)
+	}
+	return toAPIKeyEntity(key), nil
+}
+
+func (r *apiKeyRepository) Touch(ctx context.Context, id int32) error {
+	if err := r.queries.TouchAPIKey(ctx, id); err != nil {
+		return fmt.Errorf("apiKeyRepository.Touch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+type auditLogRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewAuditLogRepository(queries *sqlc.Queries) repository.AuditLogRepository {
+	return &auditLogRepository{queries: queries}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	params := sqlc.CreateAuditLogParams{
+		Username:   log.Username,
+		Action:     string(log.Action),
+		TargetType: log.TargetType,
+		TargetID:   log.TargetID,
+		Ip:         log.IP,
+		RequestID:  log.RequestID,
+	}
+	if log.AdminID != nil {
+		params.AdminID = sql.NullInt32{Int32: *log.AdminID, Valid: true}
+	}
+	if log.APIKeyID != nil {
+		params.ApiKeyID = sql.NullInt32{Int32: *log.APIKeyID, Valid: true}
+	}
+
+	var err error
+	if params.Before, err = toAuditSummaryJSON(log.Before); err != nil {
+		return fmt.Errorf("auditLogRepository.Create: marshal before failed: %w", err)
+	}
+	if params.After, err = toAuditSummaryJSON(log.After); err != nil {
+		return fmt.Errorf("auditLogRepository.Create: marshal after failed: %w", err)
+	}
+
+	if err := r.queries.CreateAuditLog(ctx, params); err != nil {
+		return fmt.Errorf("auditLogRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter entity.AuditLogFilter, limit, offset int32) ([]entity.AuditLog, error) {
+	logs, err := r.queries.ListAuditLogs(ctx, sqlc.ListAuditLogsParams{
+		AdminID:    filter.AdminID,
+		Action:     string(filter.Action),
+		TargetType: filter.TargetType,
+		TargetID:   filter.TargetID,
+		Since:      toNullTime(filter.Since),
+		Until:      toNullTime(filter.Until),
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("auditLogRepository.List: %w", err)
+	}
+	return toAuditLogEntities(logs), nil
+}
+
+func (r *auditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int64, error) {
+	count, err := r.queries.CountAuditLogs(ctx, sqlc.CountAuditLogsParams{
+		AdminID:    filter.AdminID,
+		Action:     string(filter.Action),
+		TargetType: filter.TargetType,
+		TargetID:   filter.TargetID,
+		Since:      toNullTime(filter.Since),
+		Until:      toNullTime(filter.Until),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("auditLogRepository.Count: %w", err)
+	}
+	return count, nil
+}
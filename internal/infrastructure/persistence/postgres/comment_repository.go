@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+type commentRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewCommentRepository creates a new PostgreSQL comment repository
+func NewCommentRepository(queries *sqlc.Queries) repository.CommentRepository {
+	return &commentRepository{
+		queries: queries,
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	created, err := r.queries.CreateComment(ctx, toCreateCommentParams(comment))
+	if err != nil {
+		return nil, fmt.Errorf("commentRepository.Create: %w", err)
+	}
+	return toCommentEntity(created), nil
+}
+
+func (r *commentRepository) FindByID(ctx context.Context, id int32) (*entity.Comment, error) {
+	comment, err := r.queries.GetCommentByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("commentRepository.FindByID: %w", err)
+	}
+	return toCommentEntity(comment), nil
+}
+
+func (r *commentRepository) FindByIDs(ctx context.Context, ids []int32) ([]entity.Comment, error) {
+	comments, err := r.queries.ListCommentsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("commentRepository.FindByIDs: %w", err)
+	}
+	return toCommentEntities(comments), nil
+}
+
+func (r *commentRepository) ListVisibleByPost(ctx context.Context, postID int32) ([]entity.Comment, error) {
+	comments, err := r.queries.ListVisibleCommentsByPost(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("commentRepository.ListVisibleByPost: %w", err)
+	}
+	return toCommentEntities(comments), nil
+}
+
+// Moderation
+
+func (r *commentRepository) ListByStatus(ctx context.Context, status entity.CommentStatus, limit, offset int32) ([]entity.CommentWithPost, error) {
+	comments, err := r.queries.ListCommentsByStatus(ctx, sqlc.ListCommentsByStatusParams{
+		Status: string(status),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("commentRepository.ListByStatus: %w", err)
+	}
+
+	result := make([]entity.CommentWithPost, len(comments))
+	for i, c := range comments {
+		result[i] = toCommentWithPost(c)
+	}
+	return result, nil
+}
+
+func (r *commentRepository) CountByStatus(ctx context.Context, status entity.CommentStatus) (int64, error) {
+	count, err := r.queries.CountCommentsByStatus(ctx, string(status))
+	if err != nil {
+		return 0, fmt.Errorf("commentRepository.CountByStatus: %w", err)
+	}
+	return count, nil
+}
+
+func (r *commentRepository) UpdateStatus(ctx context.Context, ids []int32, status entity.CommentStatus) (int64, error) {
+	updated, err := r.queries.UpdateCommentsStatus(ctx, sqlc.UpdateCommentsStatusParams{
+		Status: string(status),
+		Ids:    ids,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("commentRepository.UpdateStatus: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *commentRepository) SetSpamTrainedAs(ctx context.Context, id int32, label entity.SpamLabel) error {
+	err := r.queries.SetCommentSpamTrainedAs(ctx, sqlc.SetCommentSpamTrainedAsParams{
+		ID:            id,
+		SpamTrainedAs: sql.NullString{String: string(label), Valid: label != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("commentRepository.SetSpamTrainedAs: %w", err)
+	}
+	return nil
+}
@@ -34,10 +34,16 @@ func (r *dashboardRepository) GetPostStats(ctx context.Context) (*entity.PostSta
 		return nil, fmt.Errorf("dashboardRepository.GetPostStats: count draft failed: %w", err)
 	}
 
+	scheduled, err := r.queries.CountPostsByStatus(ctx, sql.NullString{String: "scheduled", Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("dashboardRepository.GetPostStats: count scheduled failed: %w", err)
+	}
+
 	return &entity.PostStats{
 		Total:     total,
 		Published: published,
 		Draft:     draft,
+		Scheduled: scheduled,
 	}, nil
 }
 
@@ -87,3 +93,27 @@ func (r *dashboardRepository) GetRecentPosts(ctx context.Context, limit int32) (
 
 	return result, nil
 }
+
+func (r *dashboardRepository) GetScheduledPosts(ctx context.Context, limit int32) ([]entity.RecentPost, error) {
+	posts, err := r.queries.ListScheduledPosts(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("dashboardRepository.GetScheduledPosts: %w", err)
+	}
+
+	result := make([]entity.RecentPost, len(posts))
+	for i, p := range posts {
+		result[i] = entity.RecentPost{
+			ID:        p.ID,
+			Title:     p.Title,
+			Slug:      p.Slug,
+			Status:    p.Status.String,
+			ViewCount: p.ViewCount.Int32,
+			CreatedAt: p.CreatedAt.Time,
+		}
+		if p.PublishedAt.Valid {
+			result[i].PublishedAt = &p.PublishedAt.Time
+		}
+	}
+
+	return result, nil
+}
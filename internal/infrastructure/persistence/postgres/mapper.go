@@ -2,7 +2,10 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
+	"github.com/sqlc-dev/pqtype"
 	"github.com/ydonggwui/blog-api/internal/database/sqlc"
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 )
@@ -144,6 +147,9 @@ func toPostEntity(p sqlc.Post) *entity.Post {
 	if p.PublishedAt.Valid {
 		post.PublishedAt = &p.PublishedAt.Time
 	}
+	if p.AuthorID.Valid {
+		post.AuthorID = &p.AuthorID.Int32
+	}
 	return post
 }
 
@@ -188,6 +194,9 @@ func toPostWithDetails(p sqlc.GetPostByIDRow, tags []entity.TagBrief) *entity.Po
 	if p.PublishedAt.Valid {
 		post.PublishedAt = &p.PublishedAt.Time
 	}
+	if p.AuthorID.Valid {
+		post.AuthorID = &p.AuthorID.Int32
+	}
 	return post
 }
 
@@ -243,7 +252,8 @@ func toPostWithDetailsFromList(p sqlc.ListPublishedPostsRow, tags []entity.TagBr
 			Slug:   p.Slug,
 			Status: entity.PostStatus(p.Status.String),
 		},
-		Tags: tags,
+		Tags:         tags,
+		CommentCount: p.CommentCount,
 	}
 	if p.Excerpt.Valid {
 		post.Excerpt = p.Excerpt.String
@@ -283,7 +293,8 @@ func toPostWithDetailsFromCategory(p sqlc.ListPublishedPostsByCategoryRow, tags
 			Slug:   p.Slug,
 			Status: entity.PostStatus(p.Status.String),
 		},
-		Tags: tags,
+		Tags:         tags,
+		CommentCount: p.CommentCount,
 	}
 	if p.Excerpt.Valid {
 		post.Excerpt = p.Excerpt.String
@@ -323,7 +334,8 @@ func toPostWithDetailsFromTag(p sqlc.ListPublishedPostsByTagRow, tags []entity.T
 			Slug:   p.Slug,
 			Status: entity.PostStatus(p.Status.String),
 		},
-		Tags: tags,
+		Tags:         tags,
+		CommentCount: p.CommentCount,
 	}
 	if p.Excerpt.Valid {
 		post.Excerpt = p.Excerpt.String
@@ -358,12 +370,14 @@ func toPostWithDetailsFromTag(p sqlc.ListPublishedPostsByTagRow, tags []entity.T
 func toPostWithDetailsFromSearch(p sqlc.SearchPublishedPostsRow, tags []entity.TagBrief) entity.PostWithDetails {
 	post := entity.PostWithDetails{
 		Post: entity.Post{
-			ID:     p.ID,
-			Title:  p.Title,
-			Slug:   p.Slug,
-			Status: entity.PostStatus(p.Status.String),
+			ID:      p.ID,
+			Title:   p.Title,
+			Slug:    p.Slug,
+			Content: p.Content,
+			Status:  entity.PostStatus(p.Status.String),
 		},
-		Tags: tags,
+		Tags:         tags,
+		CommentCount: p.CommentCount,
 	}
 	if p.Excerpt.Valid {
 		post.Excerpt = p.Excerpt.String
@@ -403,7 +417,8 @@ func toPostWithDetailsFromAll(p sqlc.ListAllPostsRow, tags []entity.TagBrief) en
 			Slug:   p.Slug,
 			Status: entity.PostStatus(p.Status.String),
 		},
-		Tags: tags,
+		Tags:         tags,
+		CommentCount: p.CommentCount,
 	}
 	if p.Excerpt.Valid {
 		post.Excerpt = p.Excerpt.String
@@ -432,6 +447,9 @@ func toPostWithDetailsFromAll(p sqlc.ListAllPostsRow, tags []entity.TagBrief) en
 	if p.PublishedAt.Valid {
 		post.PublishedAt = &p.PublishedAt.Time
 	}
+	if p.AuthorID.Valid {
+		post.AuthorID = &p.AuthorID.Int32
+	}
 	return post
 }
 
@@ -443,7 +461,8 @@ func toPostWithDetailsFromStatus(p sqlc.ListPostsByStatusRow, tags []entity.TagB
 			Slug:   p.Slug,
 			Status: entity.PostStatus(p.Status.String),
 		},
-		Tags: tags,
+		Tags:         tags,
+		CommentCount: p.CommentCount,
 	}
 	if p.Excerpt.Valid {
 		post.Excerpt = p.Excerpt.String
@@ -472,6 +491,9 @@ func toPostWithDetailsFromStatus(p sqlc.ListPostsByStatusRow, tags []entity.TagB
 	if p.PublishedAt.Valid {
 		post.PublishedAt = &p.PublishedAt.Time
 	}
+	if p.AuthorID.Valid {
+		post.AuthorID = &p.AuthorID.Int32
+	}
 	return post
 }
 
@@ -497,6 +519,7 @@ func toCreatePostParams(p *entity.Post) sqlc.CreatePostParams {
 		Status:      sql.NullString{String: string(p.Status), Valid: true},
 		ReadingTime: sql.NullInt32{Int32: p.ReadingTime, Valid: true},
 		Thumbnail:   sql.NullString{String: p.Thumbnail, Valid: p.Thumbnail != ""},
+		AuthorID:    sql.NullInt32{Int32: ptrToInt32(p.AuthorID), Valid: p.AuthorID != nil},
 	}
 }
 
@@ -520,6 +543,159 @@ func ptrToInt32(p *int32) int32 {
 	return *p
 }
 
+func toPostSitemapEntries(rows []sqlc.ListPublishedPostSitemapEntriesRow) []entity.PostSitemapEntry {
+	entries := make([]entity.PostSitemapEntry, len(rows))
+	for i, r := range rows {
+		entries[i] = entity.PostSitemapEntry{
+			ID:     r.ID,
+			Slug:   r.Slug,
+			TagIDs: r.TagIds,
+		}
+		if r.CategoryID.Valid {
+			entries[i].CategoryID = &r.CategoryID.Int32
+		}
+		if r.UpdatedAt.Valid {
+			entries[i].UpdatedAt = &r.UpdatedAt.Time
+		}
+		if r.PublishedAt.Valid {
+			entries[i].PublishedAt = &r.PublishedAt.Time
+		}
+	}
+	return entries
+}
+
+// Post revision mappers
+
+func toPostRevisionEntity(r sqlc.PostRevision) *entity.PostRevision {
+	revision := &entity.PostRevision{
+		ID:      r.ID,
+		PostID:  r.PostID,
+		Title:   r.Title,
+		Content: r.Content,
+		Tags:    []entity.TagBrief{},
+	}
+	if r.Excerpt.Valid {
+		revision.Excerpt = r.Excerpt.String
+	}
+	if r.Tags.Valid && len(r.Tags.RawMessage) > 0 {
+		var tags []entity.TagBrief
+		if err := json.Unmarshal(r.Tags.RawMessage, &tags); err == nil {
+			revision.Tags = tags
+		}
+	}
+	if r.CreatedAt.Valid {
+		revision.CreatedAt = r.CreatedAt.Time
+	}
+	return revision
+}
+
+func toPostRevisionEntities(revisions []sqlc.PostRevision) []entity.PostRevision {
+	result := make([]entity.PostRevision, len(revisions))
+	for i, r := range revisions {
+		result[i] = *toPostRevisionEntity(r)
+	}
+	return result
+}
+
+func toCreatePostRevisionParams(r *entity.PostRevision) sqlc.CreatePostRevisionParams {
+	params := sqlc.CreatePostRevisionParams{
+		PostID:  r.PostID,
+		Title:   r.Title,
+		Content: r.Content,
+		Excerpt: sql.NullString{String: r.Excerpt, Valid: r.Excerpt != ""},
+	}
+
+	// Handle Tags JSON
+	if len(r.Tags) > 0 {
+		tagsJSON, err := json.Marshal(r.Tags)
+		if err == nil {
+			params.Tags = pqtype.NullRawMessage{RawMessage: tagsJSON, Valid: true}
+		}
+	}
+
+	return params
+}
+
+// Comment mappers
+
+func toCommentEntity(c sqlc.Comment) *entity.Comment {
+	comment := &entity.Comment{
+		ID:         c.ID,
+		PostID:     c.PostID,
+		AuthorName: c.AuthorName,
+		Content:    c.Content,
+		Status:     entity.CommentStatus(c.Status),
+		SpamScore:  c.SpamScore,
+	}
+	if c.ParentID.Valid {
+		comment.ParentID = &c.ParentID.Int32
+	}
+	if c.AuthorEmail.Valid {
+		comment.AuthorEmail = c.AuthorEmail.String
+	}
+	if c.GithubHandle.Valid {
+		comment.GitHubHandle = c.GithubHandle.String
+	}
+	if c.IpHash.Valid {
+		comment.IPHash = c.IpHash.String
+	}
+	if c.CreatedAt.Valid {
+		comment.CreatedAt = c.CreatedAt.Time
+	}
+	if c.UpdatedAt.Valid {
+		comment.UpdatedAt = c.UpdatedAt.Time
+	}
+	if c.SpamTrainedAs.Valid {
+		comment.SpamTrainedAs = entity.SpamLabel(c.SpamTrainedAs.String)
+	}
+	return comment
+}
+
+func toCommentEntities(comments []sqlc.Comment) []entity.Comment {
+	result := make([]entity.Comment, len(comments))
+	for i, c := range comments {
+		result[i] = *toCommentEntity(c)
+	}
+	return result
+}
+
+func toCommentWithPost(c sqlc.ListCommentsByStatusRow) entity.CommentWithPost {
+	comment := toCommentEntity(sqlc.Comment{
+		ID:            c.ID,
+		PostID:        c.PostID,
+		ParentID:      c.ParentID,
+		AuthorName:    c.AuthorName,
+		AuthorEmail:   c.AuthorEmail,
+		GithubHandle:  c.GithubHandle,
+		Content:       c.Content,
+		Status:        c.Status,
+		IpHash:        c.IpHash,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
+		SpamScore:     c.SpamScore,
+		SpamTrainedAs: c.SpamTrainedAs,
+	})
+	return entity.CommentWithPost{
+		Comment:   *comment,
+		PostTitle: c.PostTitle,
+		PostSlug:  c.PostSlug,
+	}
+}
+
+func toCreateCommentParams(c *entity.Comment) sqlc.CreateCommentParams {
+	return sqlc.CreateCommentParams{
+		PostID:       c.PostID,
+		ParentID:     sql.NullInt32{Int32: ptrToInt32(c.ParentID), Valid: c.ParentID != nil},
+		AuthorName:   c.AuthorName,
+		AuthorEmail:  sql.NullString{String: c.AuthorEmail, Valid: c.AuthorEmail != ""},
+		GithubHandle: sql.NullString{String: c.GitHubHandle, Valid: c.GitHubHandle != ""},
+		Content:      c.Content,
+		Status:       string(c.Status),
+		IpHash:       sql.NullString{String: c.IPHash, Valid: c.IPHash != ""},
+		SpamScore:    c.SpamScore,
+	}
+}
+
 // Media mappers
 
 func toMediaEntity(m sqlc.Medium) *entity.Media {
@@ -542,18 +718,49 @@ func toMediaEntity(m sqlc.Medium) *entity.Media {
 	if m.Height.Valid {
 		media.Height = m.Height.Int32
 	}
-	if m.ThumbnailSm.Valid {
-		media.ThumbnailSM = m.ThumbnailSm.String
-	}
-	if m.ThumbnailMd.Valid {
-		media.ThumbnailMD = m.ThumbnailMd.String
-	}
 	if m.CreatedAt.Valid {
 		media.CreatedAt = m.CreatedAt.Time
 	}
+	if m.Blurhash.Valid {
+		media.Placeholder = &entity.MediaPlaceholder{
+			BlurHash:      m.Blurhash.String,
+			LQIP:          m.Lqip.String,
+			DominantColor: m.DominantColor.String,
+		}
+	}
+	if m.ContentHash.Valid {
+		media.ContentHash = m.ContentHash.String
+	}
+	if m.Exif.Valid && len(m.Exif.RawMessage) > 0 {
+		var e mediaEXIFJSON
+		if err := json.Unmarshal(m.Exif.RawMessage, &e); err == nil {
+			media.EXIF = &entity.MediaEXIF{
+				CameraMake:   e.CameraMake,
+				CameraModel:  e.CameraModel,
+				LensModel:    e.LensModel,
+				FocalLength:  e.FocalLength,
+				FNumber:      e.FNumber,
+				ExposureTime: e.ExposureTime,
+				ISO:          e.ISO,
+				CapturedAt:   e.CapturedAt,
+			}
+		}
+	}
 	return media
 }
 
+// mediaEXIFJSON is the layout of media.exif
+type mediaEXIFJSON struct {
+	CameraMake   string  `json:"camera_make,omitempty"`
+	CameraModel  string  `json:"camera_model,omitempty"`
+	LensModel    string  `json:"lens_model,omitempty"`
+	FocalLength  float64 `json:"focal_length,omitempty"`
+	FNumber      float64 `json:"f_number,omitempty"`
+	ExposureTime string  `json:"exposure_time,omitempty"`
+	ISO          int     `json:"iso,omitempty"`
+	CapturedAt   string  `json:"captured_at,omitempty"`
+}
+
 func toMediaEntities(media []sqlc.Medium) []entity.Media {
 	result := make([]entity.Media, len(media))
 	for i, m := range media {
@@ -562,8 +769,20 @@ func toMediaEntities(media []sqlc.Medium) []entity.Media {
 	return result
 }
 
+func toMediaVariantEntity(v sqlc.MediaVariant) entity.MediaVariant {
+	return entity.MediaVariant{
+		Name:     v.Name,
+		MimeType: v.MimeType,
+		Path:     v.Path,
+		URL:      v.Url,
+		Width:    v.Width,
+		Height:   v.Height,
+		Size:     v.Size,
+	}
+}
+
 func toCreateMediaParams(m *entity.Media) sqlc.CreateMediaParams {
-	return sqlc.CreateMediaParams{
+	params := sqlc.CreateMediaParams{
 		Filename:     m.Filename,
 		OriginalName: m.OriginalName,
 		Path:         m.Path,
@@ -572,9 +791,29 @@ func toCreateMediaParams(m *entity.Media) sqlc.CreateMediaParams {
 		Size:         sql.NullInt64{Int64: m.Size, Valid: m.Size > 0},
 		Width:        sql.NullInt32{Int32: m.Width, Valid: m.Width > 0},
 		Height:       sql.NullInt32{Int32: m.Height, Valid: m.Height > 0},
-		ThumbnailSm:  sql.NullString{String: m.ThumbnailSM, Valid: m.ThumbnailSM != ""},
-		ThumbnailMd:  sql.NullString{String: m.ThumbnailMD, Valid: m.ThumbnailMD != ""},
+		ContentHash:  sql.NullString{String: m.ContentHash, Valid: m.ContentHash != ""},
+	}
+	if m.Placeholder != nil {
+		params.Blurhash = sql.NullString{String: m.Placeholder.BlurHash, Valid: true}
+		params.Lqip = sql.NullString{String: m.Placeholder.LQIP, Valid: m.Placeholder.LQIP != ""}
+		params.DominantColor = sql.NullString{String: m.Placeholder.DominantColor, Valid: m.Placeholder.DominantColor != ""}
+	}
+	if m.EXIF != nil {
+		exifJSON, err := json.Marshal(mediaEXIFJSON{
+			CameraMake:   m.EXIF.CameraMake,
+			CameraModel:  m.EXIF.CameraModel,
+			LensModel:    m.EXIF.LensModel,
+			FocalLength:  m.EXIF.FocalLength,
+			FNumber:      m.EXIF.FNumber,
+			ExposureTime: m.EXIF.ExposureTime,
+			ISO:          m.EXIF.ISO,
+			CapturedAt:   m.EXIF.CapturedAt,
+		})
+		if err == nil {
+			params.Exif = pqtype.NullRawMessage{RawMessage: exifJSON, Valid: true}
+		}
 	}
+	return params
 }
 
 // Admin mappers
@@ -584,6 +823,7 @@ func toAdminEntity(a sqlc.Admin) *entity.Admin {
 		ID:       a.ID,
 		Username: a.Username,
 		Password: a.Password,
+		Role:     entity.AdminRole(a.Role),
 	}
 	if a.CreatedAt.Valid {
 		admin.CreatedAt = a.CreatedAt.Time
@@ -591,5 +831,151 @@ func toAdminEntity(a sqlc.Admin) *entity.Admin {
 	if a.UpdatedAt.Valid {
 		admin.UpdatedAt = &a.UpdatedAt.Time
 	}
+	if a.DisabledAt.Valid {
+		admin.DisabledAt = &a.DisabledAt.Time
+	}
+	if a.TotpSecret.Valid {
+		admin.TOTPSecret = a.TotpSecret.String
+	}
+	if a.TotpEnabledAt.Valid {
+		admin.TOTPEnabledAt = &a.TotpEnabledAt.Time
+	}
+	if a.Email.Valid {
+		admin.Email = a.Email.String
+	}
+	if a.OidcSubject.Valid {
+		admin.OIDCSubject = a.OidcSubject.String
+	}
 	return admin
 }
+
+func toAPIKeyEntity(k sqlc.ApiKey) *entity.APIKey {
+	key := &entity.APIKey{
+		ID:      k.ID,
+		AdminID: k.AdminID,
+		Name:    k.Name,
+		Prefix:  k.Prefix,
+		Scopes:  toPermissions(k.Scopes),
+	}
+	if k.ExpiresAt.Valid {
+		key.ExpiresAt = &k.ExpiresAt.Time
+	}
+	if k.LastUsedAt.Valid {
+		key.LastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		key.RevokedAt = &k.RevokedAt.Time
+	}
+	if k.CreatedAt.Valid {
+		key.CreatedAt = k.CreatedAt.Time
+	}
+	return key
+}
+
+func toAPIKeyEntities(keys []sqlc.ApiKey) []entity.APIKey {
+	result := make([]entity.APIKey, len(keys))
+	for i, k := range keys {
+		result[i] = *toAPIKeyEntity(k)
+	}
+	return result
+}
+
+func toAPIKeyWithAdmin(row sqlc.GetAPIKeyByHashRow) *entity.APIKeyWithAdmin {
+	key := toAPIKeyEntity(sqlc.ApiKey{
+		ID:         row.ID,
+		AdminID:    row.AdminID,
+		Name:       row.Name,
+		Prefix:     row.Prefix,
+		KeyHash:    row.KeyHash,
+		Scopes:     row.Scopes,
+		ExpiresAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt,
+		RevokedAt:  row.RevokedAt,
+		CreatedAt:  row.CreatedAt,
+	})
+
+	result := &entity.APIKeyWithAdmin{
+		APIKey: *key,
+		Admin: entity.Admin{
+			ID:       row.AdminID,
+			Username: row.Username,
+			Role:     entity.AdminRole(row.Role),
+		},
+	}
+	if row.AdminDisabledAt.Valid {
+		result.Admin.DisabledAt = &row.AdminDisabledAt.Time
+	}
+	return result
+}
+
+func toPermissions(scopes []string) []entity.Permission {
+	result := make([]entity.Permission, len(scopes))
+	for i, s := range scopes {
+		result[i] = entity.Permission(s)
+	}
+	return result
+}
+
+func fromPermissions(scopes []entity.Permission) []string {
+	result := make([]string, len(scopes))
+	for i, p := range scopes {
+		result[i] = string(p)
+	}
+	return result
+}
+
+// Audit log mappers
+
+func toAuditLogEntity(l sqlc.AuditLog) *entity.AuditLog {
+	log := &entity.AuditLog{
+		ID:         l.ID,
+		Username:   l.Username,
+		Action:     entity.AuditAction(l.Action),
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		IP:         l.Ip,
+		RequestID:  l.RequestID,
+	}
+	if l.AdminID.Valid {
+		log.AdminID = &l.AdminID.Int32
+	}
+	if l.ApiKeyID.Valid {
+		log.APIKeyID = &l.ApiKeyID.Int32
+	}
+	if l.Before.Valid {
+		_ = json.Unmarshal(l.Before.RawMessage, &log.Before)
+	}
+	if l.After.Valid {
+		_ = json.Unmarshal(l.After.RawMessage, &log.After)
+	}
+	if l.CreatedAt.Valid {
+		log.CreatedAt = l.CreatedAt.Time
+	}
+	return log
+}
+
+func toAuditLogEntities(logs []sqlc.AuditLog) []entity.AuditLog {
+	result := make([]entity.AuditLog, len(logs))
+	for i, l := range logs {
+		result[i] = *toAuditLogEntity(l)
+	}
+	return result
+}
+
+func toAuditSummaryJSON(summary map[string]any) (pqtype.NullRawMessage, error) {
+	if summary == nil {
+		return pqtype.NullRawMessage{}, nil
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+	return pqtype.NullRawMessage{RawMessage: data, Valid: true}, nil
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
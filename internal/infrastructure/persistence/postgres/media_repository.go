@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/ydonggwui/blog-api/internal/database/sqlc"
 	"github.com/ydonggwui/blog-api/internal/domain"
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
@@ -13,11 +14,12 @@ import (
 )
 
 type mediaRepository struct {
+	db      *sql.DB
 	queries *sqlc.Queries
 }
 
-func NewMediaRepository(queries *sqlc.Queries) repository.MediaRepository {
-	return &mediaRepository{queries: queries}
+func NewMediaRepository(db *sql.DB, queries *sqlc.Queries) repository.MediaRepository {
+	return &mediaRepository{db: db, queries: queries}
 }
 
 func (r *mediaRepository) FindByID(ctx context.Context, id int32) (*entity.Media, error) {
@@ -28,15 +30,63 @@ func (r *mediaRepository) FindByID(ctx context.Context, id int32) (*entity.Media
 		}
 		return nil, fmt.Errorf("mediaRepository.FindByID: %w", err)
 	}
-	return toMediaEntity(media), nil
+	result := []entity.Media{*toMediaEntity(media)}
+	if err := r.attachVariants(ctx, result); err != nil {
+		return nil, fmt.Errorf("mediaRepository.FindByID: %w", err)
+	}
+	return &result[0], nil
+}
+
+func (r *mediaRepository) FindByContentHash(ctx context.Context, hash string) (*entity.Media, error) {
+	media, err := r.queries.GetMediaByContentHash(ctx, sql.NullString{String: hash, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("mediaRepository.FindByContentHash: %w", err)
+	}
+	result := []entity.Media{*toMediaEntity(media)}
+	if err := r.attachVariants(ctx, result); err != nil {
+		return nil, fmt.Errorf("mediaRepository.FindByContentHash: %w", err)
+	}
+	return &result[0], nil
 }
 
 func (r *mediaRepository) Create(ctx context.Context, media *entity.Media) (*entity.Media, error) {
-	created, err := r.queries.CreateMedia(ctx, toCreateMediaParams(media))
+	var result *entity.Media
+	err := withTx(ctx, r.db, r.queries, func(q *sqlc.Queries) error {
+		created, err := q.CreateMedia(ctx, toCreateMediaParams(media))
+		if err != nil {
+			return err
+		}
+		result = toMediaEntity(created)
+		for _, v := range media.Variants {
+			variant, err := q.CreateMediaVariant(ctx, sqlc.CreateMediaVariantParams{
+				MediaID:  created.ID,
+				Name:     v.Name,
+				MimeType: v.MimeType,
+				Path:     v.Path,
+				Url:      v.URL,
+				Width:    v.Width,
+				Height:   v.Height,
+				Size:     v.Size,
+			})
+			if err != nil {
+				return fmt.Errorf("create variant failed: %w", err)
+			}
+			result.Variants = append(result.Variants, toMediaVariantEntity(variant))
+		}
+		return nil
+	})
 	if err != nil {
+		// A concurrent upload of the same file won the race
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "idx_media_content_hash" {
+			return nil, domain.ErrMediaDuplicate
+		}
 		return nil, fmt.Errorf("mediaRepository.Create: %w", err)
 	}
-	return toMediaEntity(created), nil
+	return result, nil
 }
 
 func (r *mediaRepository) Delete(ctx context.Context, id int32) error {
@@ -54,7 +104,11 @@ func (r *mediaRepository) List(ctx context.Context, limit, offset int32) ([]enti
 	if err != nil {
 		return nil, fmt.Errorf("mediaRepository.List: %w", err)
 	}
-	return toMediaEntities(media), nil
+	result := toMediaEntities(media)
+	if err := r.attachVariants(ctx, result); err != nil {
+		return nil, fmt.Errorf("mediaRepository.List: %w", err)
+	}
+	return result, nil
 }
 
 func (r *mediaRepository) Count(ctx context.Context) (int64, error) {
@@ -64,3 +118,136 @@ func (r *mediaRepository) Count(ctx context.Context) (int64, error) {
 	}
 	return count, nil
 }
+
+func (r *mediaRepository) ListUnused(ctx context.Context, limit, offset int32) ([]entity.Media, error) {
+	media, err := r.queries.ListUnusedMedia(ctx, sqlc.ListUnusedMediaParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mediaRepository.ListUnused: %w", err)
+	}
+	result := toMediaEntities(media)
+	if err := r.attachVariants(ctx, result); err != nil {
+		return nil, fmt.Errorf("mediaRepository.ListUnused: %w", err)
+	}
+	return result, nil
+}
+
+func (r *mediaRepository) CountUnused(ctx context.Context) (int64, error) {
+	count, err := r.queries.CountUnusedMedia(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("mediaRepository.CountUnused: %w", err)
+	}
+	return count, nil
+}
+
+func (r *mediaRepository) ListObjectPaths(ctx context.Context) ([]entity.MediaObject, error) {
+	rows, err := r.queries.ListMediaObjectPaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("mediaRepository.ListObjectPaths: %w", err)
+	}
+	objects := make([]entity.MediaObject, len(rows))
+	for i, row := range rows {
+		objects[i] = entity.MediaObject{MediaID: row.MediaID, Path: row.Path}
+	}
+	return objects, nil
+}
+
+func (r *mediaRepository) FindUsages(ctx context.Context, mediaID int32) ([]entity.MediaUsage, error) {
+	rows, err := r.queries.ListMediaUsages(ctx, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("mediaRepository.FindUsages: %w", err)
+	}
+	usages := make([]entity.MediaUsage, len(rows))
+	for i, row := range rows {
+		usages[i] = entity.MediaUsage{
+			MediaID:     row.MediaID,
+			SourceType:  entity.MediaUsageSource(row.SourceType),
+			SourceID:    row.SourceID,
+			SourceTitle: row.SourceTitle,
+			SourceSlug:  row.SourceSlug,
+			Field:       row.Field,
+		}
+	}
+	return usages, nil
+}
+
+func (r *mediaRepository) ReplaceUsages(ctx context.Context, sourceType entity.MediaUsageSource, sourceID int32, refs []entity.MediaReference) error {
+	// Both statements share a transaction so a failure never leaves the source without usages
+	err := withTx(ctx, r.db, r.queries, func(q *sqlc.Queries) error {
+		// Remove existing usages
+		if err := q.DeleteMediaUsagesBySource(ctx, sqlc.DeleteMediaUsagesBySourceParams{
+			SourceType: string(sourceType),
+			SourceID:   sourceID,
+		}); err != nil {
+			return fmt.Errorf("remove existing usages failed: %w", err)
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+
+		// Add new usages
+		params := sqlc.AddMediaUsagesParams{
+			SourceType: string(sourceType),
+			SourceID:   sourceID,
+			Urls:       make([]string, len(refs)),
+			Fields:     make([]string, len(refs)),
+		}
+		for i, ref := range refs {
+			params.Urls[i] = ref.URL
+			params.Fields[i] = ref.Field
+		}
+		if err := q.AddMediaUsages(ctx, params); err != nil {
+			return fmt.Errorf("add usages failed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("mediaRepository.ReplaceUsages: %w", err)
+	}
+	return nil
+}
+
+func (r *mediaRepository) FindPlaceholdersByURLs(ctx context.Context, urls []string) (map[string]entity.MediaPlaceholder, error) {
+	result := make(map[string]entity.MediaPlaceholder)
+	if len(urls) == 0 {
+		return result, nil
+	}
+	rows, err := r.queries.ListMediaPlaceholdersByURLs(ctx, urls)
+	if err != nil {
+		return nil, fmt.Errorf("mediaRepository.FindPlaceholdersByURLs: %w", err)
+	}
+	for _, row := range rows {
+		result[row.Url] = entity.MediaPlaceholder{
+			BlurHash:      row.Blurhash.String,
+			LQIP:          row.Lqip.String,
+			DominantColor: row.DominantColor.String,
+		}
+	}
+	return result, nil
+}
+
+// attachVariants loads the variants of all given media with one query
+func (r *mediaRepository) attachVariants(ctx context.Context, media []entity.Media) error {
+	if len(media) == 0 {
+		return nil
+	}
+	ids := make([]int32, len(media))
+	index := make(map[int32]int, len(media))
+	for i, m := range media {
+		ids[i] = m.ID
+		index[m.ID] = i
+	}
+
+	variants, err := r.queries.ListMediaVariantsByMediaIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("list variants failed: %w", err)
+	}
+	for _, v := range variants {
+		if i, ok := index[v.MediaID]; ok {
+			media[i].Variants = append(media[i].Variants, toMediaVariantEntity(v))
+		}
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/ydonggwui/blog-api/internal/database/sqlc"
 	"github.com/ydonggwui/blog-api/internal/domain"
@@ -13,11 +15,12 @@ import (
 )
 
 type postRepository struct {
+	db      *sql.DB
 	queries *sqlc.Queries
 }
 
-func NewPostRepository(queries *sqlc.Queries) repository.PostRepository {
-	return &postRepository{queries: queries}
+func NewPostRepository(db *sql.DB, queries *sqlc.Queries) repository.PostRepository {
+	return &postRepository{db: db, queries: queries}
 }
 
 // Basic CRUD
@@ -69,6 +72,7 @@ func (r *postRepository) FindBySlug(ctx context.Context, slug string) (*entity.P
 			CreatedAt:   post.CreatedAt,
 			UpdatedAt:   post.UpdatedAt,
 			PublishedAt: post.PublishedAt,
+			AuthorID:    post.AuthorID,
 		}),
 		Tags: tags,
 	}
@@ -171,6 +175,17 @@ func (r *postRepository) CountPublished(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+func (r *postRepository) ListPublishedSitemapEntries(ctx context.Context, afterID int32, limit int32) ([]entity.PostSitemapEntry, error) {
+	rows, err := r.queries.ListPublishedPostSitemapEntries(ctx, sqlc.ListPublishedPostSitemapEntriesParams{
+		ID:    afterID,
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postRepository.ListPublishedSitemapEntries: %w", err)
+	}
+	return toPostSitemapEntries(rows), nil
+}
+
 // Filter by category
 
 func (r *postRepository) ListPublishedByCategory(ctx context.Context, categoryID int32, limit, offset int32) ([]entity.PostWithDetails, error) {
@@ -229,34 +244,92 @@ func (r *postRepository) CountPublishedByTag(ctx context.Context, tagID int32) (
 
 // Search
 
-func (r *postRepository) SearchPublished(ctx context.Context, query string, limit, offset int32) ([]entity.PostWithDetails, error) {
-	queryParam := sql.NullString{String: query, Valid: query != ""}
+func (r *postRepository) SearchPublished(ctx context.Context, query string, filter entity.PostSearchFilter, limit, offset int32) ([]entity.PostSearchResult, error) {
 	posts, err := r.queries.SearchPublishedPosts(ctx, sqlc.SearchPublishedPostsParams{
-		Column1: queryParam,
-		Limit:   limit,
-		Offset:  offset,
+		Query:        query,
+		CategorySlug: filter.CategorySlug,
+		TagSlug:      filter.TagSlug,
+		Year:         filter.Year,
+		Limit:        limit,
+		Offset:       offset,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("postRepository.SearchPublished: %w", err)
 	}
 
-	result := make([]entity.PostWithDetails, len(posts))
+	result := make([]entity.PostSearchResult, len(posts))
 	for i, p := range posts {
 		tags, _ := r.getTags(ctx, p.ID)
-		result[i] = toPostWithDetailsFromSearch(p, tags)
+		result[i] = entity.PostSearchResult{
+			PostWithDetails: toPostWithDetailsFromSearch(p, tags),
+			Score:           p.Score,
+		}
 	}
 	return result, nil
 }
 
-func (r *postRepository) CountSearchPublished(ctx context.Context, query string) (int64, error) {
-	queryParam := sql.NullString{String: query, Valid: query != ""}
-	count, err := r.queries.CountSearchPublishedPosts(ctx, queryParam)
+func (r *postRepository) CountSearchPublished(ctx context.Context, query string, filter entity.PostSearchFilter) (int64, error) {
+	count, err := r.queries.CountSearchPublishedPosts(ctx, sqlc.CountSearchPublishedPostsParams{
+		Query:        query,
+		CategorySlug: filter.CategorySlug,
+		TagSlug:      filter.TagSlug,
+		Year:         filter.Year,
+	})
 	if err != nil {
 		return 0, fmt.Errorf("postRepository.CountSearchPublished: %w", err)
 	}
 	return count, nil
 }
 
+func (r *postRepository) SearchFacets(ctx context.Context, query string, filter entity.PostSearchFilter) (*entity.PostSearchFacets, error) {
+	categories, err := r.queries.SearchFacetCategories(ctx, sqlc.SearchFacetCategoriesParams{
+		Query:        query,
+		CategorySlug: filter.CategorySlug,
+		TagSlug:      filter.TagSlug,
+		Year:         filter.Year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postRepository.SearchFacets: categories: %w", err)
+	}
+
+	tags, err := r.queries.SearchFacetTags(ctx, sqlc.SearchFacetTagsParams{
+		Query:        query,
+		CategorySlug: filter.CategorySlug,
+		TagSlug:      filter.TagSlug,
+		Year:         filter.Year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postRepository.SearchFacets: tags: %w", err)
+	}
+
+	years, err := r.queries.SearchFacetYears(ctx, sqlc.SearchFacetYearsParams{
+		Query:        query,
+		CategorySlug: filter.CategorySlug,
+		TagSlug:      filter.TagSlug,
+		Year:         filter.Year,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postRepository.SearchFacets: years: %w", err)
+	}
+
+	facets := &entity.PostSearchFacets{
+		Categories: make([]entity.SearchFacet, len(categories)),
+		Tags:       make([]entity.SearchFacet, len(tags)),
+		Years:      make([]entity.SearchFacet, len(years)),
+	}
+	for i, c := range categories {
+		facets.Categories[i] = entity.SearchFacet{Value: c.Slug, Label: c.Name, Count: c.PostCount}
+	}
+	for i, t := range tags {
+		facets.Tags[i] = entity.SearchFacet{Value: t.Slug, Label: t.Name, Count: t.PostCount}
+	}
+	for i, y := range years {
+		year := strconv.Itoa(int(y.Year))
+		facets.Years[i] = entity.SearchFacet{Value: year, Label: year, Count: y.PostCount}
+	}
+	return facets, nil
+}
+
 // Admin operations
 
 func (r *postRepository) ListAll(ctx context.Context, limit, offset int32) ([]entity.PostWithDetails, error) {
@@ -334,6 +407,35 @@ func (r *postRepository) Unpublish(ctx context.Context, id int32) (*entity.Post,
 	return toPostEntity(post), nil
 }
 
+// Scheduled publishing
+
+func (r *postRepository) Schedule(ctx context.Context, id int32, publishAt time.Time) (*entity.Post, error) {
+	post, err := r.queries.SchedulePost(ctx, sqlc.SchedulePostParams{
+		ID:          id,
+		PublishedAt: sql.NullTime{Time: publishAt, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("postRepository.Schedule: %w", err)
+	}
+	return toPostEntity(post), nil
+}
+
+func (r *postRepository) PublishDue(ctx context.Context, now time.Time) ([]entity.Post, error) {
+	posts, err := r.queries.PublishDuePosts(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("postRepository.PublishDue: %w", err)
+	}
+
+	result := make([]entity.Post, len(posts))
+	for i, p := range posts {
+		result[i] = *toPostEntity(p)
+	}
+	return result, nil
+}
+
 // Tag management
 
 func (r *postRepository) GetTags(ctx context.Context, postID int32) ([]entity.TagBrief, error) {
@@ -374,6 +476,61 @@ func (r *postRepository) IncrementViewCount(ctx context.Context, id int32) error
 	return nil
 }
 
+// Revisions
+
+func (r *postRepository) UpdateWithRevision(ctx context.Context, post *entity.Post, revision *entity.PostRevision) (*entity.Post, error) {
+	var updated sqlc.Post
+	err := withTx(ctx, r.db, r.queries, func(q *sqlc.Queries) error {
+		if _, err := q.CreatePostRevision(ctx, toCreatePostRevisionParams(revision)); err != nil {
+			return fmt.Errorf("create revision failed: %w", err)
+		}
+		var err error
+		updated, err = q.UpdatePost(ctx, toUpdatePostParams(post))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("postRepository.UpdateWithRevision: %w", err)
+	}
+	return toPostEntity(updated), nil
+}
+
+func (r *postRepository) ListRevisions(ctx context.Context, postID int32, limit, offset int32) ([]entity.PostRevision, error) {
+	revisions, err := r.queries.ListPostRevisions(ctx, sqlc.ListPostRevisionsParams{
+		PostID: postID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postRepository.ListRevisions: %w", err)
+	}
+	return toPostRevisionEntities(revisions), nil
+}
+
+func (r *postRepository) CountRevisions(ctx context.Context, postID int32) (int64, error) {
+	count, err := r.queries.CountPostRevisions(ctx, postID)
+	if err != nil {
+		return 0, fmt.Errorf("postRepository.CountRevisions: %w", err)
+	}
+	return count, nil
+}
+
+func (r *postRepository) FindRevision(ctx context.Context, postID, revisionID int32) (*entity.PostRevision, error) {
+	revision, err := r.queries.GetPostRevision(ctx, sqlc.GetPostRevisionParams{
+		ID:     revisionID,
+		PostID: postID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("postRepository.FindRevision: %w", err)
+	}
+	return toPostRevisionEntity(revision), nil
+}
+
 // Helper methods
 
 func (r *postRepository) getTags(ctx context.Context, postID int32) ([]entity.TagBrief, error) {
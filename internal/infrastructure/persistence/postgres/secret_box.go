@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// secretBox encrypts values stored in the database with AES-256-GCM under a key
+// derived from a configured secret. Sealed values are the nonce followed by the ciphertext.
+type secretBox struct {
+	key [32]byte
+}
+
+func newSecretBox(secret string) secretBox {
+	return secretBox{key: sha256.Sum256([]byte(secret))}
+}
+
+// seal encrypts plaintext, binding it to aad so a ciphertext cannot be moved to another row
+func (b secretBox) seal(aad string, plaintext []byte) ([]byte, error) {
+	gcm, err := b.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, []byte(aad)), nil
+}
+
+func (b secretBox) open(aad string, sealed []byte) ([]byte, error) {
+	gcm, err := b.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, []byte(aad))
+}
+
+func (b secretBox) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(b.key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+// encryptedKeyPEMType is a PKCS#8 private key sealed with AES-256-GCM: nonce followed by ciphertext
+const encryptedKeyPEMType = "AES-GCM ENCRYPTED PRIVATE KEY"
+
+type signingKeyRepository struct {
+	queries *sqlc.Queries
+	box     secretBox
+}
+
+// NewSigningKeyRepository creates a new PostgreSQL JWT signing key repository.
+// Private keys are encrypted with an AES-256 key derived from keyEncryptionKey before they are stored.
+func NewSigningKeyRepository(queries *sqlc.Queries, keyEncryptionKey string) repository.SigningKeyRepository {
+	return &signingKeyRepository{queries: queries, box: newSecretBox(keyEncryptionKey)}
+}
+
+func (r *signingKeyRepository) Create(ctx context.Context, key *entity.SigningKey) (*entity.SigningKey, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyRepository.Create: encode private key failed: %w", err)
+	}
+
+	sealed, err := r.box.seal(key.ID, der)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyRepository.Create: encrypt private key failed: %w", err)
+	}
+
+	created, err := r.queries.CreateSigningKey(ctx, sqlc.CreateSigningKeyParams{
+		Kid:        key.ID,
+		Algorithm:  string(key.Algorithm),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: encryptedKeyPEMType, Bytes: sealed})),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyRepository.Create: %w", err)
+	}
+
+	result, err := r.toSigningKeyEntity(created)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyRepository.Create: %w", err)
+	}
+	return result, nil
+}
+
+func (r *signingKeyRepository) List(ctx context.Context, retiredAfter time.Time) ([]entity.SigningKey, error) {
+	keys, err := r.queries.ListSigningKeys(ctx, retiredAfter)
+	if err != nil {
+		return nil, fmt.Errorf("signingKeyRepository.List: %w", err)
+	}
+
+	result := make([]entity.SigningKey, 0, len(keys))
+	for _, k := range keys {
+		key, err := r.toSigningKeyEntity(k)
+		if err != nil {
+			return nil, fmt.Errorf("signingKeyRepository.List: %w", err)
+		}
+		result = append(result, *key)
+	}
+	return result, nil
+}
+
+func (r *signingKeyRepository) RetireOthers(ctx context.Context, kid string) error {
+	if err := r.queries.RetireSigningKeys(ctx, kid); err != nil {
+		return fmt.Errorf("signingKeyRepository.RetireOthers: %w", err)
+	}
+	return nil
+}
+
+func (r *signingKeyRepository) DeleteRetired(ctx context.Context, retiredBefore time.Time) error {
+	if err := r.queries.DeleteRetiredSigningKeys(ctx, retiredBefore); err != nil {
+		return fmt.Errorf("signingKeyRepository.DeleteRetired: %w", err)
+	}
+	return nil
+}
+
+// toSigningKeyEntity decrypts and decodes the PKCS#8 private key of a stored signing key
+func (r *signingKeyRepository) toSigningKeyEntity(k sqlc.JwtSigningKey) (*entity.SigningKey, error) {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil || block.Type != encryptedKeyPEMType {
+		return nil, fmt.Errorf("decode key %s failed: no encrypted PEM block", k.Kid)
+	}
+	der, err := r.box.open(k.Kid, block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt key %s failed: %w", k.Kid, err)
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse key %s failed: %w", k.Kid, err)
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+
+	key := &entity.SigningKey{
+		ID:         k.Kid,
+		Algorithm:  entity.SigningAlgorithm(k.Algorithm),
+		PrivateKey: signer,
+		CreatedAt:  k.CreatedAt,
+	}
+	if k.RetiredAt.Valid {
+		key.RetiredAt = &k.RetiredAt.Time
+	}
+	return key, nil
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+type spamRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewSpamRepository creates a new PostgreSQL spam classifier repository
+func NewSpamRepository(queries *sqlc.Queries) repository.SpamRepository {
+	return &spamRepository{
+		queries: queries,
+	}
+}
+
+func (r *spamRepository) GetTokenCounts(ctx context.Context, tokens []string) ([]entity.SpamTokenCount, error) {
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.queries.GetSpamTokens(ctx, tokens)
+	if err != nil {
+		return nil, fmt.Errorf("spamRepository.GetTokenCounts: %w", err)
+	}
+
+	result := make([]entity.SpamTokenCount, len(rows))
+	for i, row := range rows {
+		result[i] = entity.SpamTokenCount{
+			Token: row.Token,
+			Spam:  int64(row.SpamCount),
+			Ham:   int64(row.HamCount),
+		}
+	}
+	return result, nil
+}
+
+func (r *spamRepository) GetCorpus(ctx context.Context) (*entity.SpamCorpus, error) {
+	classes, err := r.queries.ListSpamClasses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("spamRepository.GetCorpus: %w", err)
+	}
+
+	corpus := &entity.SpamCorpus{}
+	for _, c := range classes {
+		switch entity.SpamLabel(c.Label) {
+		case entity.SpamLabelSpam:
+			corpus.Spam = int64(c.Documents)
+		case entity.SpamLabelHam:
+			corpus.Ham = int64(c.Documents)
+		}
+	}
+	return corpus, nil
+}
+
+func (r *spamRepository) AdjustTokens(ctx context.Context, tokens []string, spamDelta, hamDelta int32) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	err := r.queries.AdjustSpamTokens(ctx, sqlc.AdjustSpamTokensParams{
+		Tokens:    tokens,
+		SpamDelta: spamDelta,
+		HamDelta:  hamDelta,
+	})
+	if err != nil {
+		return fmt.Errorf("spamRepository.AdjustTokens: %w", err)
+	}
+	return nil
+}
+
+func (r *spamRepository) AdjustCorpus(ctx context.Context, spamDelta, hamDelta int32) error {
+	err := r.queries.AdjustSpamClasses(ctx, sqlc.AdjustSpamClassesParams{
+		SpamDelta: spamDelta,
+		HamDelta:  hamDelta,
+	})
+	if err != nil {
+		return fmt.Errorf("spamRepository.AdjustCorpus: %w", err)
+	}
+	return nil
+}
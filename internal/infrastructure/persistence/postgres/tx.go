@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+)
+
+// withTx runs fn with queries bound to a single transaction, which is committed
+// if fn succeeds and rolled back otherwise
+func withTx(ctx context.Context, db *sql.DB, queries *sqlc.Queries, fn func(q *sqlc.Queries) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction failed: %w", err)
+	}
+	if err := fn(queries.WithTx(tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+// releaseScript deletes the lock only when the stored owner matches,
+// so an instance whose lock expired cannot release another instance's lock
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type lockRepository struct {
+	client *redis.Client
+}
+
+func NewLockRepository(client *redis.Client) repository.LockRepository {
+	return &lockRepository{client: client}
+}
+
+func (r *lockRepository) Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	result, err := r.client.SetNX(ctx, key, owner, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("lockRepository.Acquire: %w", err)
+	}
+	return result, nil
+}
+
+func (r *lockRepository) Release(ctx context.Context, key, owner string) error {
+	if err := releaseScript.Run(ctx, r.client, []string{key}, owner).Err(); err != nil {
+		return fmt.Errorf("lockRepository.Release: %w", err)
+	}
+	return nil
+}
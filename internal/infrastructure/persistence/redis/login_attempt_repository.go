@@ -0,0 +1,149 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+const (
+	loginFailuresKeyPrefix = "auth:login_failures:"
+	loginBlockKeyPrefix    = "auth:login_block:"
+)
+
+// Values of a login block key
+const (
+	loginBlockBackoff = "backoff"
+	loginBlockLocked  = "locked"
+)
+
+type loginAttemptRepository struct {
+	client *redis.Client
+}
+
+// NewLoginAttemptRepository creates a new Redis failed login repository
+func NewLoginAttemptRepository(client *redis.Client) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{client: client}
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, subject entity.LoginSubject, window time.Duration) (int64, error) {
+	key := loginFailuresKeyPrefix + subject.Key()
+
+	var failures *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.Incr(ctx, key)
+		// The window starts at the first failure and is not extended by later ones
+		pipe.ExpireNX(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("loginAttemptRepository.RecordFailure: %w", err)
+	}
+	return failures.Val(), nil
+}
+
+func (r *loginAttemptRepository) Block(ctx context.Context, subject entity.LoginSubject, d time.Duration, locked bool) error {
+	value := loginBlockBackoff
+	if locked {
+		value = loginBlockLocked
+	}
+	if err := r.client.Set(ctx, loginBlockKeyPrefix+subject.Key(), value, d).Err(); err != nil {
+		return fmt.Errorf("loginAttemptRepository.Block: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) Blocked(ctx context.Context, subject entity.LoginSubject) (time.Duration, bool, error) {
+	remaining, locked, err := r.block(ctx, loginBlockKeyPrefix+subject.Key())
+	if err != nil {
+		return 0, false, fmt.Errorf("loginAttemptRepository.Blocked: %w", err)
+	}
+	return remaining, locked, nil
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, subject entity.LoginSubject) error {
+	key := subject.Key()
+	if err := r.client.Del(ctx, loginFailuresKeyPrefix+key, loginBlockKeyPrefix+key).Err(); err != nil {
+		return fmt.Errorf("loginAttemptRepository.Reset: %w", err)
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) List(ctx context.Context) ([]entity.LoginLockout, error) {
+	lockouts := map[string]*entity.LoginLockout{}
+	lockout := func(key string) *entity.LoginLockout {
+		subject, ok := entity.ParseLoginSubject(key)
+		if !ok {
+			return nil
+		}
+		if _, exists := lockouts[key]; !exists {
+			lockouts[key] = &entity.LoginLockout{Subject: subject}
+		}
+		return lockouts[key]
+	}
+
+	iter := r.client.Scan(ctx, 0, loginFailuresKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		failures, err := r.client.Get(ctx, iter.Val()).Int64()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue // expired since the scan
+			}
+			return nil, fmt.Errorf("loginAttemptRepository.List: %w", err)
+		}
+		if l := lockout(strings.TrimPrefix(iter.Val(), loginFailuresKeyPrefix)); l != nil {
+			l.Failures = failures
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("loginAttemptRepository.List: %w", err)
+	}
+
+	iter = r.client.Scan(ctx, 0, loginBlockKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		remaining, locked, err := r.block(ctx, iter.Val())
+		if err != nil {
+			return nil, fmt.Errorf("loginAttemptRepository.List: %w", err)
+		}
+		if remaining <= 0 {
+			continue
+		}
+		if l := lockout(strings.TrimPrefix(iter.Val(), loginBlockKeyPrefix)); l != nil {
+			l.RetryAfter = remaining
+			l.Locked = locked
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("loginAttemptRepository.List: %w", err)
+	}
+
+	result := make([]entity.LoginLockout, 0, len(lockouts))
+	for _, l := range lockouts {
+		result = append(result, *l)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Subject.Key() < result[j].Subject.Key()
+	})
+	return result, nil
+}
+
+// block returns the remaining time of a block key and whether it is a lockout
+func (r *loginAttemptRepository) block(ctx context.Context, key string) (time.Duration, bool, error) {
+	pipe := r.client.Pipeline()
+	value := pipe.Get(ctx, key)
+	ttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, false, err
+	}
+	if value.Err() != nil || ttl.Val() <= 0 {
+		return 0, false, nil
+	}
+	return ttl.Val(), value.Val() == loginBlockLocked, nil
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+const sitemapCacheKey = "sitemap"
+
+type sitemapCacheRepository struct {
+	client *redis.Client
+}
+
+func NewSitemapCacheRepository(client *redis.Client) repository.SitemapCacheRepository {
+	return &sitemapCacheRepository{client: client}
+}
+
+func (r *sitemapCacheRepository) Get(ctx context.Context, name string) ([]byte, bool, error) {
+	data, err := r.client.HGet(ctx, sitemapCacheKey, name).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("sitemapCacheRepository.Get: %w", err)
+	}
+	return data, true, nil
+}
+
+func (r *sitemapCacheRepository) SetAll(ctx context.Context, docs map[string][]byte, ttl time.Duration) error {
+	values := make(map[string]any, len(docs))
+	for name, data := range docs {
+		values[name] = data
+	}
+
+	// Replace the whole hash so stale pages from a larger previous sitemap disappear
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, sitemapCacheKey)
+	pipe.HSet(ctx, sitemapCacheKey, values)
+	pipe.Expire(ctx, sitemapCacheKey, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("sitemapCacheRepository.SetAll: %w", err)
+	}
+	return nil
+}
+
+func (r *sitemapCacheRepository) Invalidate(ctx context.Context) error {
+	if err := r.client.Del(ctx, sitemapCacheKey).Err(); err != nil {
+		return fmt.Errorf("sitemapCacheRepository.Invalidate: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+const (
+	// suggestTermsKey is a sorted set with every member at score 0, so it is ordered
+	// lexicographically and ZRANGEBYLEX does prefix lookups. Members are "term\x00type:id".
+	suggestTermsKey = "suggest:terms"
+	// suggestDocsKey is a hash of type:id to the stored suggestion and its terms
+	suggestDocsKey = "suggest:docs"
+
+	suggestMemberSep = "\x00"
+	// suggestReplaceBatch is how many suggestions Replace writes per round trip
+	suggestReplaceBatch = 500
+	// suggestStagingTTL expires the staging keys of a Replace that died before swapping them in
+	suggestStagingTTL = 10 * time.Minute
+	// suggestFetchFactor over-fetches per prefix since one suggestion can match through several terms
+	suggestFetchFactor = 4
+)
+
+type suggestDoc struct {
+	Type  entity.SuggestionType `json:"type"`
+	ID    int32                 `json:"id"`
+	Title string                `json:"title"`
+	Slug  string                `json:"slug"`
+	Terms []string              `json:"terms"`
+}
+
+type suggestRepository struct {
+	client *redis.Client
+}
+
+func NewSuggestRepository(client *redis.Client) repository.SuggestRepository {
+	return &suggestRepository{client: client}
+}
+
+func (r *suggestRepository) Put(ctx context.Context, suggestion *entity.SearchSuggestion, terms []string) error {
+	key := suggestDocKey(suggestion.Type, suggestion.ID)
+
+	old, err := r.getDoc(ctx, key)
+	if err != nil {
+		return fmt.Errorf("suggestRepository.Put: %w", err)
+	}
+
+	data, err := json.Marshal(suggestDoc{
+		Type:  suggestion.Type,
+		ID:    suggestion.ID,
+		Title: suggestion.Title,
+		Slug:  suggestion.Slug,
+		Terms: terms,
+	})
+	if err != nil {
+		return fmt.Errorf("suggestRepository.Put: encode failed: %w", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	if old != nil && len(old.Terms) > 0 {
+		pipe.ZRem(ctx, suggestTermsKey, suggestMembers(old.Terms, key)...)
+	}
+	if len(terms) > 0 {
+		members := make([]redis.Z, len(terms))
+		for i, term := range terms {
+			members[i] = redis.Z{Member: term + suggestMemberSep + key}
+		}
+		pipe.ZAdd(ctx, suggestTermsKey, members...)
+	}
+	pipe.HSet(ctx, suggestDocsKey, key, data)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("suggestRepository.Put: %w", err)
+	}
+	return nil
+}
+
+func (r *suggestRepository) Delete(ctx context.Context, suggestionType entity.SuggestionType, id int32) error {
+	key := suggestDocKey(suggestionType, id)
+
+	old, err := r.getDoc(ctx, key)
+	if err != nil {
+		return fmt.Errorf("suggestRepository.Delete: %w", err)
+	}
+	if old == nil {
+		return nil
+	}
+
+	pipe := r.client.TxPipeline()
+	if len(old.Terms) > 0 {
+		pipe.ZRem(ctx, suggestTermsKey, suggestMembers(old.Terms, key)...)
+	}
+	pipe.HDel(ctx, suggestDocsKey, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("suggestRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+func (r *suggestRepository) FindByPrefixes(ctx context.Context, prefixes []string, limit int) ([]entity.SearchSuggestion, error) {
+	if len(prefixes) == 0 || limit <= 0 {
+		return []entity.SearchSuggestion{}, nil
+	}
+
+	// One round trip for all prefixes
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(prefixes))
+	for i, prefix := range prefixes {
+		cmds[i] = pipe.ZRangeByLex(ctx, suggestTermsKey, &redis.ZRangeBy{
+			Min:   "[" + prefix,
+			Max:   "[" + prefix + "\xff",
+			Count: int64(limit * suggestFetchFactor),
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("suggestRepository.FindByPrefixes: lookup failed: %w", err)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, cmd := range cmds {
+		for _, member := range cmd.Val() {
+			_, key, ok := strings.Cut(member, suggestMemberSep)
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	if len(keys) == 0 {
+		return []entity.SearchSuggestion{}, nil
+	}
+
+	values, err := r.client.HMGet(ctx, suggestDocsKey, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("suggestRepository.FindByPrefixes: fetch failed: %w", err)
+	}
+
+	result := make([]entity.SearchSuggestion, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue // term without a document, left over from an interrupted write
+		}
+		var doc suggestDoc
+		if err := json.Unmarshal([]byte(s), &doc); err != nil {
+			continue
+		}
+		result = append(result, entity.SearchSuggestion{
+			Type:  doc.Type,
+			ID:    doc.ID,
+			Title: doc.Title,
+			Slug:  doc.Slug,
+		})
+	}
+	return result, nil
+}
+
+func (r *suggestRepository) Replace(ctx context.Context, entries []entity.SuggestionEntry) error {
+	// Build the new index under staging keys, then rename them over the live keys
+	suffix := ":staging:" + uuid.NewString()
+	termsKey, docsKey := suggestTermsKey+suffix, suggestDocsKey+suffix
+
+	hasTerms := false
+	for start := 0; start < len(entries); start += suggestReplaceBatch {
+		batch := entries[start:min(start+suggestReplaceBatch, len(entries))]
+
+		pipe := r.client.Pipeline()
+		for _, e := range batch {
+			key := suggestDocKey(e.Suggestion.Type, e.Suggestion.ID)
+			data, err := json.Marshal(suggestDoc{
+				Type:  e.Suggestion.Type,
+				ID:    e.Suggestion.ID,
+				Title: e.Suggestion.Title,
+				Slug:  e.Suggestion.Slug,
+				Terms: e.Terms,
+			})
+			if err != nil {
+				r.client.Del(context.WithoutCancel(ctx), termsKey, docsKey)
+				return fmt.Errorf("suggestRepository.Replace: encode failed: %w", err)
+			}
+			if len(e.Terms) > 0 {
+				members := make([]redis.Z, len(e.Terms))
+				for i, term := range e.Terms {
+					members[i] = redis.Z{Member: term + suggestMemberSep + key}
+				}
+				pipe.ZAdd(ctx, termsKey, members...)
+				hasTerms = true
+			}
+			pipe.HSet(ctx, docsKey, key, data)
+		}
+		pipe.Expire(ctx, termsKey, suggestStagingTTL)
+		pipe.Expire(ctx, docsKey, suggestStagingTTL)
+		if _, err := pipe.Exec(ctx); err != nil {
+			r.client.Del(context.WithoutCancel(ctx), termsKey, docsKey)
+			return fmt.Errorf("suggestRepository.Replace: stage failed: %w", err)
+		}
+	}
+
+	// RENAME fails on a missing key, so an empty part of the index is deleted instead
+	pipe := r.client.TxPipeline()
+	if hasTerms {
+		pipe.Rename(ctx, termsKey, suggestTermsKey)
+		pipe.Persist(ctx, suggestTermsKey)
+	} else {
+		pipe.Del(ctx, suggestTermsKey)
+	}
+	if len(entries) > 0 {
+		pipe.Rename(ctx, docsKey, suggestDocsKey)
+		pipe.Persist(ctx, suggestDocsKey)
+	} else {
+		pipe.Del(ctx, suggestDocsKey)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.client.Del(context.WithoutCancel(ctx), termsKey, docsKey)
+		return fmt.Errorf("suggestRepository.Replace: swap failed: %w", err)
+	}
+	return nil
+}
+
+func (r *suggestRepository) getDoc(ctx context.Context, key string) (*suggestDoc, error) {
+	data, err := r.client.HGet(ctx, suggestDocsKey, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get document failed: %w", err)
+	}
+
+	var doc suggestDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode document failed: %w", err)
+	}
+	return &doc, nil
+}
+
+func suggestDocKey(suggestionType entity.SuggestionType, id int32) string {
+	return fmt.Sprintf("%s:%d", suggestionType, id)
+}
+
+func suggestMembers(terms []string, key string) []any {
+	members := make([]any, len(terms))
+	for i, term := range terms {
+		members[i] = term + suggestMemberSep + key
+	}
+	return members
+}
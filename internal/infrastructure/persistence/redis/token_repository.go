@@ -0,0 +1,387 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/domain/repository"
+)
+
+const (
+	refreshTokenKeyPrefix  = "auth:refresh:"
+	refreshFamilyKeyPrefix = "auth:refresh_family:"
+	adminFamiliesKeyPrefix = "auth:admin_families:"
+	denylistKeyPrefix      = "auth:denylist:"
+	revokedBeforeKeyPrefix = "auth:revoked_before:"
+	inviteKeyPrefix        = "auth:invite:"
+	passwordResetKeyPrefix = "auth:password_reset:"
+	mfaChallengeKeyPrefix  = "auth:mfa:"
+	oidcStateKeyPrefix     = "auth:oidc_state:"
+)
+
+// consumeScript marks a refresh token as used and returns its fields as they were before,
+// so two concurrent refreshes with the same token cannot both succeed
+var consumeScript = redis.NewScript(`
+local fields = redis.call("HMGET", KEYS[1], "family", "admin_id", "used")
+if not fields[1] then
+	return false
+end
+redis.call("HSET", KEYS[1], "used", "1")
+return fields
+`)
+
+// attemptScript counts a code attempt against an MFA challenge and returns its admin ID
+// and the attempts so far, or nil if the challenge does not exist
+var attemptScript = redis.NewScript(`
+local adminID = redis.call("HGET", KEYS[1], "admin_id")
+if not adminID then
+	return false
+end
+local attempts = redis.call("HINCRBY", KEYS[1], "attempts", 1)
+return {adminID, attempts}
+`)
+
+type refreshTokenRepository struct {
+	client *redis.Client
+}
+
+// NewRefreshTokenRepository creates a new Redis refresh token repository
+func NewRefreshTokenRepository(client *redis.Client) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{client: client}
+}
+
+func (r *refreshTokenRepository) Save(ctx context.Context, token *entity.RefreshToken, ttl time.Duration) error {
+	key := refreshTokenKeyPrefix + token.TokenHash
+	adminID := strconv.Itoa(int(token.AdminID))
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"family", token.FamilyID,
+			"admin_id", adminID,
+			"used", "0",
+		)
+		pipe.Expire(ctx, key, ttl)
+		pipe.Set(ctx, refreshFamilyKeyPrefix+token.FamilyID, adminID, ttl)
+		// Index the admin's families so they can all be revoked; the set lives as long as the newest one
+		pipe.SAdd(ctx, adminFamiliesKeyPrefix+adminID, token.FamilyID)
+		pipe.Expire(ctx, adminFamiliesKeyPrefix+adminID, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("refreshTokenRepository.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) Find(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	fields, err := r.client.HMGet(ctx, refreshTokenKeyPrefix+tokenHash, "family", "admin_id", "used").Result()
+	if err != nil {
+		return nil, fmt.Errorf("refreshTokenRepository.Find: %w", err)
+	}
+	if fields[0] == nil {
+		return nil, domain.ErrRefreshTokenNotFound
+	}
+
+	token, err := toRefreshToken(tokenHash, fields)
+	if err != nil {
+		return nil, fmt.Errorf("refreshTokenRepository.Find: %w", err)
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) Consume(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	fields, err := consumeScript.Run(ctx, r.client, []string{refreshTokenKeyPrefix + tokenHash}).Slice()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("refreshTokenRepository.Consume: %w", err)
+	}
+
+	token, err := toRefreshToken(tokenHash, fields)
+	if err != nil {
+		return nil, fmt.Errorf("refreshTokenRepository.Consume: %w", err)
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	n, err := r.client.Exists(ctx, refreshFamilyKeyPrefix+familyID).Result()
+	if err != nil {
+		return false, fmt.Errorf("refreshTokenRepository.IsFamilyActive: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	if err := r.client.Del(ctx, refreshFamilyKeyPrefix+familyID).Err(); err != nil {
+		return fmt.Errorf("refreshTokenRepository.RevokeFamily: %w", err)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeAdminFamilies(ctx context.Context, adminID int32) error {
+	setKey := adminFamiliesKeyPrefix + strconv.Itoa(int(adminID))
+
+	families, err := r.client.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return fmt.Errorf("refreshTokenRepository.RevokeAdminFamilies: %w", err)
+	}
+
+	keys := make([]string, 0, len(families)+1)
+	for _, family := range families {
+		keys = append(keys, refreshFamilyKeyPrefix+family)
+	}
+	keys = append(keys, setKey)
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("refreshTokenRepository.RevokeAdminFamilies: %w", err)
+	}
+	return nil
+}
+
+// toRefreshToken converts the family, admin_id and used hash fields
+func toRefreshToken(tokenHash string, fields []interface{}) (*entity.RefreshToken, error) {
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("unexpected refresh token fields: %v", fields)
+	}
+
+	family, _ := fields[0].(string)
+	adminID, _ := fields[1].(string)
+	used, _ := fields[2].(string)
+
+	id, err := strconv.ParseInt(adminID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse admin id failed: %w", err)
+	}
+
+	return &entity.RefreshToken{
+		TokenHash: tokenHash,
+		FamilyID:  family,
+		AdminID:   int32(id),
+		Used:      used == "1",
+	}, nil
+}
+
+type tokenDenylistRepository struct {
+	client *redis.Client
+}
+
+// NewTokenDenylistRepository creates a new Redis access token denylist repository
+func NewTokenDenylistRepository(client *redis.Client) repository.TokenDenylistRepository {
+	return &tokenDenylistRepository{client: client}
+}
+
+func (r *tokenDenylistRepository) Add(ctx context.Context, tokenID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil // already expired
+	}
+	if err := r.client.Set(ctx, denylistKeyPrefix+tokenID, "1", ttl).Err(); err != nil {
+		return fmt.Errorf("tokenDenylistRepository.Add: %w", err)
+	}
+	return nil
+}
+
+func (r *tokenDenylistRepository) Contains(ctx context.Context, tokenID string) (bool, error) {
+	n, err := r.client.Exists(ctx, denylistKeyPrefix+tokenID).Result()
+	if err != nil {
+		return false, fmt.Errorf("tokenDenylistRepository.Contains: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *tokenDenylistRepository) RevokeIssuedBefore(ctx context.Context, adminID int32, before time.Time, ttl time.Duration) error {
+	key := revokedBeforeKeyPrefix + strconv.Itoa(int(adminID))
+	if err := r.client.Set(ctx, key, strconv.FormatInt(before.Unix(), 10), ttl).Err(); err != nil {
+		return fmt.Errorf("tokenDenylistRepository.RevokeIssuedBefore: %w", err)
+	}
+	return nil
+}
+
+func (r *tokenDenylistRepository) RevokedBefore(ctx context.Context, adminID int32) (time.Time, error) {
+	value, err := r.client.Get(ctx, revokedBeforeKeyPrefix+strconv.Itoa(int(adminID))).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("tokenDenylistRepository.RevokedBefore: %w", err)
+	}
+
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tokenDenylistRepository.RevokedBefore: parse cutoff failed: %w", err)
+	}
+	return time.Unix(unix, 0), nil
+}
+
+type adminInviteRepository struct {
+	client *redis.Client
+}
+
+// NewAdminInviteRepository creates a new Redis admin invitation repository
+func NewAdminInviteRepository(client *redis.Client) repository.AdminInviteRepository {
+	return &adminInviteRepository{client: client}
+}
+
+func (r *adminInviteRepository) Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+	if err := r.client.Set(ctx, inviteKeyPrefix+tokenHash, strconv.Itoa(int(adminID)), ttl).Err(); err != nil {
+		return fmt.Errorf("adminInviteRepository.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *adminInviteRepository) Consume(ctx context.Context, tokenHash string) (int32, error) {
+	value, err := r.client.GetDel(ctx, inviteKeyPrefix+tokenHash).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, domain.ErrInviteNotFound
+		}
+		return 0, fmt.Errorf("adminInviteRepository.Consume: %w", err)
+	}
+
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("adminInviteRepository.Consume: parse admin id failed: %w", err)
+	}
+	return int32(id), nil
+}
+
+type passwordResetRepository struct {
+	client *redis.Client
+}
+
+// NewPasswordResetRepository creates a new Redis password reset token repository
+func NewPasswordResetRepository(client *redis.Client) repository.PasswordResetRepository {
+	return &passwordResetRepository{client: client}
+}
+
+func (r *passwordResetRepository) Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+	if err := r.client.Set(ctx, passwordResetKeyPrefix+tokenHash, strconv.Itoa(int(adminID)), ttl).Err(); err != nil {
+		return fmt.Errorf("passwordResetRepository.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *passwordResetRepository) Consume(ctx context.Context, tokenHash string) (int32, error) {
+	value, err := r.client.GetDel(ctx, passwordResetKeyPrefix+tokenHash).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, domain.ErrPasswordResetNotFound
+		}
+		return 0, fmt.Errorf("passwordResetRepository.Consume: %w", err)
+	}
+
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("passwordResetRepository.Consume: parse admin id failed: %w", err)
+	}
+	return int32(id), nil
+}
+
+type oidcStateRepository struct {
+	client *redis.Client
+}
+
+// NewOIDCStateRepository creates a new Redis single sign-on state repository
+func NewOIDCStateRepository(client *redis.Client) repository.OIDCStateRepository {
+	return &oidcStateRepository{client: client}
+}
+
+func (r *oidcStateRepository) Save(ctx context.Context, stateHash string, state *entity.OIDCLoginState, ttl time.Duration) error {
+	key := oidcStateKeyPrefix + stateHash
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "verifier", state.Verifier, "nonce", state.Nonce)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("oidcStateRepository.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *oidcStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	key := oidcStateKeyPrefix + stateHash
+
+	var fields *redis.MapStringStringCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		fields = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oidcStateRepository.Consume: %w", err)
+	}
+
+	values := fields.Val()
+	if values["verifier"] == "" {
+		return nil, domain.ErrOIDCStateNotFound
+	}
+	return &entity.OIDCLoginState{
+		Verifier: values["verifier"],
+		Nonce:    values["nonce"],
+	}, nil
+}
+
+type mfaChallengeRepository struct {
+	client *redis.Client
+}
+
+// NewMFAChallengeRepository creates a new Redis two-factor login challenge repository
+func NewMFAChallengeRepository(client *redis.Client) repository.MFAChallengeRepository {
+	return &mfaChallengeRepository{client: client}
+}
+
+func (r *mfaChallengeRepository) Save(ctx context.Context, tokenHash string, adminID int32, ttl time.Duration) error {
+	key := mfaChallengeKeyPrefix + tokenHash
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "admin_id", strconv.Itoa(int(adminID)), "attempts", "0")
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("mfaChallengeRepository.Save: %w", err)
+	}
+	return nil
+}
+
+func (r *mfaChallengeRepository) Attempt(ctx context.Context, tokenHash string) (int32, int64, error) {
+	fields, err := attemptScript.Run(ctx, r.client, []string{mfaChallengeKeyPrefix + tokenHash}).Slice()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, 0, domain.ErrMFAChallengeNotFound
+		}
+		return 0, 0, fmt.Errorf("mfaChallengeRepository.Attempt: %w", err)
+	}
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("mfaChallengeRepository.Attempt: unexpected challenge fields: %v", fields)
+	}
+
+	adminID, _ := fields[0].(string)
+	attempts, _ := fields[1].(int64)
+
+	id, err := strconv.ParseInt(adminID, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("mfaChallengeRepository.Attempt: parse admin id failed: %w", err)
+	}
+	return int32(id), attempts, nil
+}
+
+func (r *mfaChallengeRepository) Delete(ctx context.Context, tokenHash string) error {
+	n, err := r.client.Del(ctx, mfaChallengeKeyPrefix+tokenHash).Result()
+	if err != nil {
+		return fmt.Errorf("mfaChallengeRepository.Delete: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrMFAChallengeNotFound
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/minio/minio-go/v7"
 	"github.com/ydonggwui/blog-api/internal/config"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	"github.com/ydonggwui/blog-api/internal/domain/repository"
 )
 
@@ -40,6 +41,21 @@ func (r *storageRepository) Delete(ctx context.Context, path string) error {
 	return nil
 }
 
+func (r *storageRepository) List(ctx context.Context, prefix string) ([]entity.StoredObject, error) {
+	var objects []entity.StoredObject
+	for obj := range r.client.ListObjects(ctx, r.cfg.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, fmt.Errorf("storageRepository.List: %w", obj.Err)
+		}
+		objects = append(objects, entity.StoredObject{
+			Path:         obj.Key,
+			Size:         obj.Size,
+			LastModified: obj.LastModified,
+		})
+	}
+	return objects, nil
+}
+
 func (r *storageRepository) GenerateURL(path string) string {
 	publicURL := strings.TrimRight(r.cfg.PublicURL, "/")
 	return fmt.Sprintf("%s/%s/%s", publicURL, r.cfg.Bucket, path)
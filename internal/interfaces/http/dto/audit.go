@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// AuditLogResponse represents an audit log entry. AdminID is omitted for failed
+// logins with an unknown username.
+type AuditLogResponse struct {
+	ID         int64          `json:"id"`
+	AdminID    *int32         `json:"admin_id,omitempty"`
+	Username   string         `json:"username"`
+	APIKeyID   *int32         `json:"api_key_id,omitempty"`
+	Action     string         `json:"action"`
+	TargetType string         `json:"target_type,omitempty"`
+	TargetID   string         `json:"target_id,omitempty"`
+	Before     map[string]any `json:"before,omitempty"`
+	After      map[string]any `json:"after,omitempty"`
+	IP         string         `json:"ip"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -10,13 +10,137 @@ type LoginRequest struct {
 
 // LoginResponse represents the login response
 type LoginResponse struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// MFAChallengeResponse is returned by login instead of tokens when the admin has
+// two-factor authentication enabled
+type MFAChallengeResponse struct {
+	MFARequired    bool      `json:"mfa_required"`
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// VerifyMFARequest represents the second step of a two-factor login.
+// Code is a six-digit TOTP code or a recovery code.
+type VerifyMFARequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// OIDCAuthorizationResponse tells the admin frontend where to send the browser for single sign-on.
+// State has to be sent back to the callback endpoint together with the authorization code.
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// OIDCCallbackRequest represents the authorization code and state from the identity provider's redirect
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// TOTPEnrollmentResponse represents a pending TOTP secret to add to an authenticator app
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// TOTPCodeRequest represents a request confirmed with a two-factor code
+type TOTPCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResponse lists one-time recovery codes; they cannot be retrieved again
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// RefreshTokenRequest represents the token refresh request body
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest represents the optional logout request body
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
 }
 
 // AdminResponse represents the admin user info response
+// Status is "invited" until the invitation is accepted, then "active" or "disabled"
 type AdminResponse struct {
-	ID        int32     `json:"id"`
-	Username  string    `json:"username"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          int32      `json:"id"`
+	Username    string     `json:"username"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	TOTPEnabled bool       `json:"totp_enabled"`
+	Email       string     `json:"email,omitempty"`
+	OIDCSubject string     `json:"oidc_subject,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DisabledAt  *time.Time `json:"disabled_at,omitempty"`
+}
+
+// InviteAdminRequest represents the request for inviting an admin
+// Email lets the invitee sign in with single sign-on instead of setting a password
+type InviteAdminRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+	Role     string `json:"role" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email,max=255"`
+}
+
+// InviteAdminResponse represents a new admin and the one-time token to pass on to them
+type InviteAdminResponse struct {
+	Admin           AdminResponse `json:"admin"`
+	InviteToken     string        `json:"invite_token"`
+	InviteExpiresAt time.Time     `json:"invite_expires_at"`
+}
+
+// AcceptInviteRequest represents the request for setting the password of an invited admin
+type AcceptInviteRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// ChangePasswordRequest represents the request for changing the current admin's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+// ResetPasswordRequest represents the request for setting a password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// UpdateAdminRoleRequest represents the request for changing an admin's role
+type UpdateAdminRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// UpdateAdminIdentityRequest represents the request for setting the single sign-on identity of an admin.
+// An empty OIDCSubject is linked on the admin's next single sign-on login with a verified Email.
+type UpdateAdminIdentityRequest struct {
+	Email       string `json:"email" binding:"omitempty,email,max=255"`
+	OIDCSubject string `json:"oidc_subject" binding:"max=255"`
+}
+
+// UpdateAdminStatusRequest represents the request for disabling or re-enabling an admin
+type UpdateAdminStatusRequest struct {
+	Disabled *bool `json:"disabled" binding:"required"`
+}
+
+// LoginLockoutResponse represents the failed logins of a client IP or username.
+// BlockedUntil is set while logins are blocked by a backoff delay or a lockout.
+type LoginLockoutResponse struct {
+	Kind         string     `json:"kind"`
+	Value        string     `json:"value"`
+	Failures     int64      `json:"failures"`
+	Locked       bool       `json:"locked"`
+	BlockedUntil *time.Time `json:"blocked_until,omitempty"`
 }
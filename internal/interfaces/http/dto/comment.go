@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+// CreateCommentRequest represents the request for posting a comment
+// Either author_name or github_handle must be provided
+type CreateCommentRequest struct {
+	ParentID     *int32 `json:"parent_id,omitempty"`
+	AuthorName   string `json:"author_name,omitempty" binding:"max=50"`
+	AuthorEmail  string `json:"author_email,omitempty" binding:"omitempty,email,max=255"`
+	GitHubHandle string `json:"github_handle,omitempty" binding:"omitempty,max=40"`
+	Content      string `json:"content" binding:"required,max=5000"`
+
+	// Website is a honeypot: the form hides it from humans, so it must stay empty
+	Website string `json:"website,omitempty"`
+	// FormToken is the token from GET /posts/{slug}/comments/form-token
+	FormToken string `json:"form_token,omitempty"`
+}
+
+// CommentFormTokenResponse represents a signed token to send back with a new comment
+type CommentFormTokenResponse struct {
+	FormToken string `json:"form_token"`
+}
+
+// ModerateCommentsRequest represents a bulk moderation action
+type ModerateCommentsRequest struct {
+	IDs    []int32 `json:"ids" binding:"required,min=1,max=100"`
+	Status string  `json:"status" binding:"required"`
+}
+
+// ModerateCommentsResponse represents the result of a bulk moderation action
+type ModerateCommentsResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// CommentResponse represents a public comment with its replies
+// Removed comments that still have replies are returned with status "deleted" and no author or content
+type CommentResponse struct {
+	ID           int32             `json:"id"`
+	ParentID     *int32            `json:"parent_id,omitempty"`
+	AuthorName   string            `json:"author_name,omitempty"`
+	GitHubHandle string            `json:"github_handle,omitempty"`
+	AvatarURL    string            `json:"avatar_url,omitempty"`
+	Content      string            `json:"content,omitempty"`
+	Status       string            `json:"status"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Replies      []CommentResponse `json:"replies"`
+}
+
+// AdminCommentResponse represents a comment in the moderation queue
+type AdminCommentResponse struct {
+	ID           int32     `json:"id"`
+	PostID       int32     `json:"post_id"`
+	PostTitle    string    `json:"post_title,omitempty"`
+	PostSlug     string    `json:"post_slug,omitempty"`
+	ParentID     *int32    `json:"parent_id,omitempty"`
+	AuthorName   string    `json:"author_name"`
+	AuthorEmail  string    `json:"author_email,omitempty"`
+	GitHubHandle string    `json:"github_handle,omitempty"`
+	Content      string    `json:"content"`
+	Status       string    `json:"status"`
+	SpamScore    float64   `json:"spam_score"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -4,9 +4,10 @@ import "time"
 
 // DashboardStatsResponse represents the dashboard statistics response
 type DashboardStatsResponse struct {
-	Posts       PostStatsResponse       `json:"posts"`
-	Categories  []CategoryStatsResponse `json:"categories"`
-	RecentPosts []RecentPostResponse    `json:"recent_posts"`
+	Posts          PostStatsResponse       `json:"posts"`
+	Categories     []CategoryStatsResponse `json:"categories"`
+	RecentPosts    []RecentPostResponse    `json:"recent_posts"`
+	ScheduledPosts []RecentPostResponse    `json:"scheduled_posts"`
 }
 
 // PostStatsResponse represents post statistics
@@ -14,6 +15,7 @@ type PostStatsResponse struct {
 	Total     int64 `json:"total"`
 	Published int64 `json:"published"`
 	Draft     int64 `json:"draft"`
+	Scheduled int64 `json:"scheduled"`
 }
 
 // CategoryStatsResponse represents category with post count
@@ -24,7 +26,7 @@ type CategoryStatsResponse struct {
 	PostCount int64  `json:"post_count"`
 }
 
-// RecentPostResponse represents a recent post for dashboard
+// RecentPostResponse represents a recent or scheduled post for dashboard
 type RecentPostResponse struct {
 	ID          int32      `json:"id"`
 	Title       string     `json:"title"`
@@ -0,0 +1,18 @@
+package dto
+
+// JWKSResponse is a JSON Web Key Set (RFC 7517) with the public keys access tokens are verified with
+type JWKSResponse struct {
+	Keys []JWKResponse `json:"keys"`
+}
+
+// JWKResponse is a public signing key. RSA keys set N and E; Ed25519 keys set Crv and X.
+type JWKResponse struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
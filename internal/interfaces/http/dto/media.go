@@ -4,18 +4,60 @@ import "time"
 
 // MediaResponse represents the response for a media file
 type MediaResponse struct {
-	ID           int32     `json:"id"`
-	Filename     string    `json:"filename"`
-	OriginalName string    `json:"original_name"`
-	Path         string    `json:"path"`
-	URL          string    `json:"url"`
-	MimeType     string    `json:"mime_type,omitempty"`
-	Size         int64     `json:"size,omitempty"`
-	Width        int32     `json:"width,omitempty"`
-	Height       int32     `json:"height,omitempty"`
-	ThumbnailSM  string    `json:"thumbnail_sm,omitempty"`
-	ThumbnailMD  string    `json:"thumbnail_md,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           int32                  `json:"id"`
+	Filename     string                 `json:"filename"`
+	OriginalName string                 `json:"original_name"`
+	Path         string                 `json:"path"`
+	URL          string                 `json:"url"`
+	MimeType     string                 `json:"mime_type,omitempty"`
+	Size         int64                  `json:"size,omitempty"`
+	Width        int32                  `json:"width,omitempty"`
+	Height       int32                  `json:"height,omitempty"`
+	ThumbnailSM  string                 `json:"thumbnail_sm,omitempty"`
+	ThumbnailMD  string                 `json:"thumbnail_md,omitempty"`
+	SrcSet       string                 `json:"srcset,omitempty" example:"https://cdn.example.com/blog/2024/01/uuid_sm.jpg 320w, https://cdn.example.com/blog/2024/01/uuid.jpg 1200w"`
+	Sources      []MediaSourceResponse  `json:"sources"`
+	Variants     []MediaVariantResponse `json:"variants"`
+	MediaPlaceholderResponse
+	EXIF      *MediaEXIFResponse `json:"exif,omitempty"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// MediaSourceResponse is a srcset for one format, ordered by preference so the
+// list maps directly onto <picture><source type srcset> elements
+type MediaSourceResponse struct {
+	Type   string `json:"type" example:"image/webp"`
+	SrcSet string `json:"srcset"`
+}
+
+// MediaVariantResponse is one stored rendition of a media file
+type MediaVariantResponse struct {
+	Name   string `json:"name" example:"md"`
+	Type   string `json:"type" example:"image/webp"`
+	URL    string `json:"url"`
+	Width  int32  `json:"width,omitempty"`
+	Height int32  `json:"height,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+}
+
+// MediaPlaceholderResponse holds the previews to show while an image loads;
+// empty for files stored as-is (GIF, SVG)
+type MediaPlaceholderResponse struct {
+	BlurHash      string `json:"blurhash,omitempty" example:"LKO2?U%2Tw=w]~RBVZRi};RPxuwH"`
+	LQIP          string `json:"lqip,omitempty" example:"data:image/jpeg;base64,/9j/2wBDAA..."`
+	DominantColor string `json:"dominant_color,omitempty" example:"#4a6b8c"`
+}
+
+// MediaEXIFResponse is the camera information of a photo; location data is never stored
+type MediaEXIFResponse struct {
+	CameraMake   string  `json:"camera_make,omitempty" example:"Canon"`
+	CameraModel  string  `json:"camera_model,omitempty" example:"EOS R6"`
+	LensModel    string  `json:"lens_model,omitempty" example:"RF35mm F1.8 MACRO IS STM"`
+	FocalLength  float64 `json:"focal_length,omitempty" example:"35"`
+	FNumber      float64 `json:"f_number,omitempty" example:"1.8"`
+	ExposureTime string  `json:"exposure_time,omitempty" example:"1/250"`
+	ISO          int     `json:"iso,omitempty" example:"400"`
+	CapturedAt   string  `json:"captured_at,omitempty" example:"2024-05-01T09:30:15"`
 }
 
 // MediaListResponse represents a paginated list of media files
@@ -26,12 +68,33 @@ type MediaListResponse struct {
 
 // UploadMediaResponse represents the response after uploading a file
 type UploadMediaResponse struct {
-	ID           int32  `json:"id"`
-	Filename     string `json:"filename"`
-	OriginalName string `json:"original_name"`
-	URL          string `json:"url"`
-	MimeType     string `json:"mime_type,omitempty"`
-	Size         int64  `json:"size,omitempty"`
-	ThumbnailSM  string `json:"thumbnail_sm,omitempty"`
-	ThumbnailMD  string `json:"thumbnail_md,omitempty"`
+	ID           int32                  `json:"id"`
+	Filename     string                 `json:"filename"`
+	OriginalName string                 `json:"original_name"`
+	URL          string                 `json:"url"`
+	MimeType     string                 `json:"mime_type,omitempty"`
+	Size         int64                  `json:"size,omitempty"`
+	Width        int32                  `json:"width,omitempty"`
+	Height       int32                  `json:"height,omitempty"`
+	ThumbnailSM  string                 `json:"thumbnail_sm,omitempty"`
+	ThumbnailMD  string                 `json:"thumbnail_md,omitempty"`
+	SrcSet       string                 `json:"srcset,omitempty"`
+	Sources      []MediaSourceResponse  `json:"sources"`
+	Variants     []MediaVariantResponse `json:"variants"`
+	MediaPlaceholderResponse
+	EXIF *MediaEXIFResponse `json:"exif,omitempty"`
+	// MetadataRemoved lists the embedded metadata stripped from the stored files:
+	// gps, exif, xmp, comment (GIF) or metadata (SVG)
+	MetadataRemoved []string `json:"metadata_removed" example:"gps,exif"`
+	// Deduplicated is true when the file was uploaded before; nothing new is stored
+	Deduplicated bool `json:"deduplicated"`
+}
+
+// MediaUsageResponse represents a post or project field that references a media file
+type MediaUsageResponse struct {
+	SourceType  string `json:"source_type" example:"post"`
+	SourceID    int32  `json:"source_id"`
+	SourceTitle string `json:"source_title"`
+	SourceSlug  string `json:"source_slug"`
+	Field       string `json:"field" example:"content"`
 }
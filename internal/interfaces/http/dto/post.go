@@ -25,9 +25,11 @@ type UpdatePostRequest struct {
 	Thumbnail  string  `json:"thumbnail,omitempty"`
 }
 
-// PublishRequest represents the request for publishing/unpublishing a post
+// PublishRequest represents the request for publishing/unpublishing a post.
+// When publish is true and publish_at is set, the post is scheduled instead.
 type PublishRequest struct {
-	Publish bool `json:"publish"`
+	Publish   bool       `json:"publish"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
 }
 
 // PostResponse represents a post in API responses
@@ -48,6 +50,9 @@ type PostResponse struct {
 	CreatedAt    time.Time        `json:"created_at"`
 	UpdatedAt    time.Time        `json:"updated_at"`
 	PublishedAt  *time.Time       `json:"published_at,omitempty"`
+
+	// ThumbnailPlaceholder is set when the thumbnail is an uploaded image
+	ThumbnailPlaceholder *MediaPlaceholderResponse `json:"thumbnail_placeholder,omitempty"`
 }
 
 // PostListResponse represents a post in list responses (without content)
@@ -61,11 +66,23 @@ type PostListResponse struct {
 	CategorySlug string           `json:"category_slug,omitempty"`
 	Status       string           `json:"status"`
 	ViewCount    int32            `json:"view_count"`
+	CommentCount int64            `json:"comment_count"`
 	ReadingTime  int32            `json:"reading_time,omitempty"`
 	Thumbnail    string           `json:"thumbnail,omitempty"`
 	Tags         []TagBriefInPost `json:"tags,omitempty"`
 	CreatedAt    time.Time        `json:"created_at"`
 	PublishedAt  *time.Time       `json:"published_at,omitempty"`
+
+	// ThumbnailPlaceholder is set when the thumbnail is an uploaded image
+	ThumbnailPlaceholder *MediaPlaceholderResponse `json:"thumbnail_placeholder,omitempty"`
+}
+
+// PostSearchResponse represents a post in search results with its relevance
+// Highlights maps field names (title, excerpt, content) to fragments with matches wrapped in <mark>
+type PostSearchResponse struct {
+	PostListResponse
+	Score      float64             `json:"score"`
+	Highlights map[string][]string `json:"highlights,omitempty"`
 }
 
 // TagBriefInPost represents a tag in post responses
@@ -74,3 +91,36 @@ type TagBriefInPost struct {
 	Name string `json:"name"`
 	Slug string `json:"slug"`
 }
+
+// PostRevisionResponse represents a post revision in API responses
+type PostRevisionResponse struct {
+	ID        int32            `json:"id"`
+	PostID    int32            `json:"post_id"`
+	Title     string           `json:"title"`
+	Content   string           `json:"content,omitempty"`
+	Excerpt   string           `json:"excerpt,omitempty"`
+	Tags      []TagBriefInPost `json:"tags,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// PostRevisionListResponse represents a post revision in list responses (without content)
+type PostRevisionListResponse struct {
+	ID        int32            `json:"id"`
+	PostID    int32            `json:"post_id"`
+	Title     string           `json:"title"`
+	Excerpt   string           `json:"excerpt,omitempty"`
+	Tags      []TagBriefInPost `json:"tags,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// PostRevisionDiffResponse represents unified diffs between two post versions.
+// A nil to_revision_id means the diff is against the current post.
+type PostRevisionDiffResponse struct {
+	PostID         int32  `json:"post_id"`
+	FromRevisionID int32  `json:"from_revision_id"`
+	ToRevisionID   *int32 `json:"to_revision_id"`
+	Title          string `json:"title"`
+	Excerpt        string `json:"excerpt"`
+	Tags           string `json:"tags"`
+	Content        string `json:"content"`
+}
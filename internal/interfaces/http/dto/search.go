@@ -0,0 +1,25 @@
+package dto
+
+// SearchSuggestionResponse represents an autocomplete entry
+// Type is one of post, tag or category, and Slug addresses the matching page
+type SearchSuggestionResponse struct {
+	Type  string `json:"type"`
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// SearchFacetResponse represents a filter value with the number of matching posts
+// Value is what to send back as the filter (category/tag slug or year)
+type SearchFacetResponse struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// PostSearchFacetsResponse represents the drill-down counts of a search
+type PostSearchFacetsResponse struct {
+	Categories []SearchFacetResponse `json:"categories"`
+	Tags       []SearchFacetResponse `json:"tags"`
+	Years      []SearchFacetResponse `json:"years"`
+}
@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+)
+
+// ToAPIKeyResponse converts entity.APIKey to dto.APIKeyResponse
+func ToAPIKeyResponse(k *entity.APIKey) dto.APIKeyResponse {
+	status := "active"
+	switch {
+	case k.IsRevoked():
+		status = "revoked"
+	case k.IsExpired(time.Now()):
+		status = "expired"
+	}
+
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return dto.APIKeyResponse{
+		ID:         k.ID,
+		AdminID:    k.AdminID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		Status:     status,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// ToAPIKeyResponses converts API keys to APIKeyResponse DTOs
+func ToAPIKeyResponses(keys []entity.APIKey) []dto.APIKeyResponse {
+	result := make([]dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		result[i] = ToAPIKeyResponse(&keys[i])
+	}
+	return result
+}
+
+// ToCreateAPIKeyResponse converts entity.CreatedAPIKey to dto.CreateAPIKeyResponse
+func ToCreateAPIKeyResponse(created *entity.CreatedAPIKey) dto.CreateAPIKeyResponse {
+	return dto.CreateAPIKeyResponse{
+		APIKeyResponse: ToAPIKeyResponse(created.Key),
+		Key:            created.Secret,
+	}
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+)
+
+// ToAuditLogResponse converts entity.AuditLog to dto.AuditLogResponse
+func ToAuditLogResponse(l *entity.AuditLog) dto.AuditLogResponse {
+	return dto.AuditLogResponse{
+		ID:         l.ID,
+		AdminID:    l.AdminID,
+		Username:   l.Username,
+		APIKeyID:   l.APIKeyID,
+		Action:     string(l.Action),
+		TargetType: l.TargetType,
+		TargetID:   l.TargetID,
+		Before:     l.Before,
+		After:      l.After,
+		IP:         l.IP,
+		RequestID:  l.RequestID,
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+// ToAuditLogResponses converts audit log entries to AuditLogResponse DTOs
+func ToAuditLogResponses(logs []entity.AuditLog) []dto.AuditLogResponse {
+	result := make([]dto.AuditLogResponse, len(logs))
+	for i := range logs {
+		result[i] = ToAuditLogResponse(&logs[i])
+	}
+	return result
+}
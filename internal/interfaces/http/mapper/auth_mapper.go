@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
 )
@@ -8,16 +10,95 @@ import (
 // ToLoginResponse converts entity.TokenInfo to dto.LoginResponse
 func ToLoginResponse(t *entity.TokenInfo) dto.LoginResponse {
 	return dto.LoginResponse{
-		Token:     t.Token,
-		ExpiresAt: t.ExpiresAt,
+		Token:            t.Token,
+		ExpiresAt:        t.ExpiresAt,
+		RefreshToken:     t.RefreshToken,
+		RefreshExpiresAt: t.RefreshExpiresAt,
+	}
+}
+
+// ToMFAChallengeResponse converts entity.MFAChallenge to dto.MFAChallengeResponse
+func ToMFAChallengeResponse(ch *entity.MFAChallenge) dto.MFAChallengeResponse {
+	return dto.MFAChallengeResponse{
+		MFARequired:    true,
+		ChallengeToken: ch.Token,
+		ExpiresAt:      ch.ExpiresAt,
+	}
+}
+
+// ToOIDCAuthorizationResponse converts entity.OIDCAuthorization to dto.OIDCAuthorizationResponse
+func ToOIDCAuthorizationResponse(a *entity.OIDCAuthorization) dto.OIDCAuthorizationResponse {
+	return dto.OIDCAuthorizationResponse{
+		AuthorizationURL: a.URL,
+		State:            a.State,
+		ExpiresAt:        a.ExpiresAt,
+	}
+}
+
+// ToTOTPEnrollmentResponse converts entity.TOTPEnrollment to dto.TOTPEnrollmentResponse
+func ToTOTPEnrollmentResponse(e *entity.TOTPEnrollment) dto.TOTPEnrollmentResponse {
+	return dto.TOTPEnrollmentResponse{
+		Secret:     e.Secret,
+		OTPAuthURI: e.URI,
 	}
 }
 
 // ToAdminResponse converts entity.Admin to dto.AdminResponse
 func ToAdminResponse(a *entity.Admin) dto.AdminResponse {
+	status := "active"
+	switch {
+	case a.IsDisabled():
+		status = "disabled"
+	case a.IsInvited():
+		status = "invited"
+	}
+
 	return dto.AdminResponse{
-		ID:        a.ID,
-		Username:  a.Username,
-		CreatedAt: a.CreatedAt,
+		ID:          a.ID,
+		Username:    a.Username,
+		Role:        string(a.Role),
+		Status:      status,
+		TOTPEnabled: a.TOTPEnabled(),
+		Email:       a.Email,
+		OIDCSubject: a.OIDCSubject,
+		CreatedAt:   a.CreatedAt,
+		DisabledAt:  a.DisabledAt,
+	}
+}
+
+// ToAdminResponses converts admins to AdminResponse DTOs
+func ToAdminResponses(admins []entity.Admin) []dto.AdminResponse {
+	result := make([]dto.AdminResponse, len(admins))
+	for i := range admins {
+		result[i] = ToAdminResponse(&admins[i])
+	}
+	return result
+}
+
+// ToInviteAdminResponse converts an invitation to an InviteAdminResponse DTO
+func ToInviteAdminResponse(inv *entity.AdminInvitation) dto.InviteAdminResponse {
+	return dto.InviteAdminResponse{
+		Admin:           ToAdminResponse(inv.Admin),
+		InviteToken:     inv.Token,
+		InviteExpiresAt: inv.ExpiresAt,
+	}
+}
+
+// ToLoginLockoutResponses converts login lockouts to LoginLockoutResponse DTOs
+func ToLoginLockoutResponses(lockouts []entity.LoginLockout) []dto.LoginLockoutResponse {
+	now := time.Now()
+	result := make([]dto.LoginLockoutResponse, len(lockouts))
+	for i, l := range lockouts {
+		result[i] = dto.LoginLockoutResponse{
+			Kind:     string(l.Subject.Kind),
+			Value:    l.Subject.Value,
+			Failures: l.Failures,
+			Locked:   l.Locked,
+		}
+		if l.RetryAfter > 0 {
+			blockedUntil := now.Add(l.RetryAfter)
+			result[i].BlockedUntil = &blockedUntil
+		}
 	}
+	return result
 }
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+)
+
+// ToCommentResponse converts a Comment entity to a CommentResponse DTO without replies.
+// Spam is reported as pending so that spammers get no feedback from the filter.
+func ToCommentResponse(c *entity.Comment) dto.CommentResponse {
+	status := c.Status
+	if status == entity.CommentStatusSpam {
+		status = entity.CommentStatusPending
+	}
+
+	resp := dto.CommentResponse{
+		ID:           c.ID,
+		ParentID:     c.ParentID,
+		AuthorName:   c.AuthorName,
+		GitHubHandle: c.GitHubHandle,
+		Content:      c.Content,
+		Status:       string(status),
+		CreatedAt:    c.CreatedAt,
+		Replies:      []dto.CommentResponse{},
+	}
+	if c.GitHubHandle != "" {
+		resp.AvatarURL = "https://github.com/" + c.GitHubHandle + ".png"
+	}
+	return resp
+}
+
+// ToCommentThreadResponses converts comment threads to nested CommentResponse DTOs
+func ToCommentThreadResponses(threads []*entity.CommentThread) []dto.CommentResponse {
+	result := make([]dto.CommentResponse, len(threads))
+	for i, t := range threads {
+		result[i] = ToCommentResponse(&t.Comment)
+		result[i].Replies = ToCommentThreadResponses(t.Replies)
+	}
+	return result
+}
+
+// ToAdminCommentResponses converts comments in the moderation queue to AdminCommentResponse DTOs
+func ToAdminCommentResponses(comments []entity.CommentWithPost) []dto.AdminCommentResponse {
+	result := make([]dto.AdminCommentResponse, len(comments))
+	for i, c := range comments {
+		result[i] = dto.AdminCommentResponse{
+			ID:           c.ID,
+			PostID:       c.PostID,
+			PostTitle:    c.PostTitle,
+			PostSlug:     c.PostSlug,
+			ParentID:     c.ParentID,
+			AuthorName:   c.AuthorName,
+			AuthorEmail:  c.AuthorEmail,
+			GitHubHandle: c.GitHubHandle,
+			Content:      c.Content,
+			Status:       string(c.Status),
+			SpamScore:    c.SpamScore,
+			CreatedAt:    c.CreatedAt,
+			UpdatedAt:    c.UpdatedAt,
+		}
+	}
+	return result
+}
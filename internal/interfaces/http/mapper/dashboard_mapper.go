@@ -8,9 +8,10 @@ import (
 // ToDashboardStatsResponse converts entity.DashboardStats to dto.DashboardStatsResponse
 func ToDashboardStatsResponse(s *entity.DashboardStats) dto.DashboardStatsResponse {
 	return dto.DashboardStatsResponse{
-		Posts:       toPostStatsResponse(s.Posts),
-		Categories:  toCategoryStatsResponses(s.Categories),
-		RecentPosts: toRecentPostResponses(s.RecentPosts),
+		Posts:          toPostStatsResponse(s.Posts),
+		Categories:     toCategoryStatsResponses(s.Categories),
+		RecentPosts:    toRecentPostResponses(s.RecentPosts),
+		ScheduledPosts: toRecentPostResponses(s.ScheduledPosts),
 	}
 }
 
@@ -19,6 +20,7 @@ func toPostStatsResponse(p entity.PostStats) dto.PostStatsResponse {
 		Total:     p.Total,
 		Published: p.Published,
 		Draft:     p.Draft,
+		Scheduled: p.Scheduled,
 	}
 }
 
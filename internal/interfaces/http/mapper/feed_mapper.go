@@ -0,0 +1,73 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+// FeedOptions describes the channel a feed is built for
+type FeedOptions struct {
+	Title       string
+	Description string
+	Link        string // HTML page the feed corresponds to
+	SiteURL     string // base URL used to build post links
+	Author      string
+	FullContent bool // include post content, otherwise only the excerpt
+}
+
+// ToFeed converts published posts to a syndication feed.
+// The feed's Updated time is the latest posts.updated_at among the items.
+func ToFeed(posts []entity.PostWithDetails, opts FeedOptions) *feeds.Feed {
+	feed := &feeds.Feed{
+		Title:       opts.Title,
+		Description: opts.Description,
+		Link:        &feeds.Link{Href: opts.Link},
+		Id:          opts.Link,
+		Items:       make([]*feeds.Item, 0, len(posts)),
+	}
+	if opts.Author != "" {
+		feed.Author = &feeds.Author{Name: opts.Author}
+	}
+
+	for _, p := range posts {
+		item := toFeedItem(p, opts)
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed
+}
+
+func toFeedItem(p entity.PostWithDetails, opts FeedOptions) *feeds.Item {
+	link := opts.SiteURL + "/posts/" + p.Slug
+
+	created := p.CreatedAt
+	if p.PublishedAt != nil {
+		created = *p.PublishedAt
+	}
+	updated := p.UpdatedAt
+	if updated.Before(created) {
+		updated = created
+	}
+
+	item := &feeds.Item{
+		Title:       p.Title,
+		Link:        &feeds.Link{Href: link},
+		Description: p.Excerpt,
+		Id:          link,
+		IsPermaLink: "true",
+		Created:     created.UTC().Truncate(time.Second),
+		Updated:     updated.UTC().Truncate(time.Second),
+	}
+	if opts.FullContent {
+		item.Content = p.Content
+	}
+	if opts.Author != "" {
+		item.Author = &feeds.Author{Name: opts.Author}
+	}
+	return item
+}
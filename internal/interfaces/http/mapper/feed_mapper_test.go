@@ -0,0 +1,87 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+)
+
+func TestToFeed(t *testing.T) {
+	published := time.Date(2024, 5, 1, 9, 0, 0, 500, time.UTC)
+	posts := []entity.PostWithDetails{
+		{Post: entity.Post{
+			Title: "Newer", Slug: "newer", Content: "<p>body</p>", Excerpt: "summary",
+			CreatedAt: published.Add(-time.Hour), PublishedAt: &published, UpdatedAt: published.Add(2 * time.Hour),
+		}},
+		{Post: entity.Post{
+			// Edited before it was published: updated never precedes published
+			Title: "Older", Slug: "older", Content: "<p>old</p>", Excerpt: "old summary",
+			CreatedAt: published.Add(-48 * time.Hour), UpdatedAt: published.Add(-72 * time.Hour),
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		opts        FeedOptions
+		wantContent string
+		wantAuthor  bool
+	}{
+		{
+			name:        "full content",
+			opts:        FeedOptions{Title: "Blog", Link: "https://blog.test", SiteURL: "https://blog.test", Author: "Kim", FullContent: true},
+			wantContent: "<p>body</p>",
+			wantAuthor:  true,
+		},
+		{
+			name:        "summary only",
+			opts:        FeedOptions{Title: "Blog", Link: "https://blog.test", SiteURL: "https://blog.test"},
+			wantContent: "",
+		},
+		{
+			name:        "category feed",
+			opts:        FeedOptions{Title: "Blog - Go", Link: "https://blog.test/categories/go", SiteURL: "https://blog.test", FullContent: true},
+			wantContent: "<p>body</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := ToFeed(posts, tt.opts)
+
+			if feed.Title != tt.opts.Title || feed.Link.Href != tt.opts.Link || feed.Id != tt.opts.Link {
+				t.Errorf("channel = %q %q %q, want title and link from options", feed.Title, feed.Link.Href, feed.Id)
+			}
+			if (feed.Author != nil) != tt.wantAuthor {
+				t.Errorf("feed author = %+v, want set: %v", feed.Author, tt.wantAuthor)
+			}
+			if len(feed.Items) != 2 {
+				t.Fatalf("got %d items, want 2", len(feed.Items))
+			}
+
+			item := feed.Items[0]
+			if item.Link.Href != "https://blog.test/posts/newer" || item.Id != item.Link.Href {
+				t.Errorf("item link = %q, id = %q", item.Link.Href, item.Id)
+			}
+			if item.Description != "summary" || item.Content != tt.wantContent {
+				t.Errorf("item description = %q, content = %q, want summary and %q", item.Description, item.Content, tt.wantContent)
+			}
+			if !item.Created.Equal(published.Truncate(time.Second)) {
+				t.Errorf("item created = %v, want the publish time %v", item.Created, published)
+			}
+			if older := feed.Items[1]; !older.Updated.Equal(older.Created) {
+				t.Errorf("older item updated = %v, want its created time %v", older.Updated, older.Created)
+			}
+			if !feed.Updated.Equal(item.Updated) {
+				t.Errorf("feed updated = %v, want the latest item update %v", feed.Updated, item.Updated)
+			}
+		})
+	}
+
+	t.Run("no posts", func(t *testing.T) {
+		feed := ToFeed(nil, FeedOptions{Title: "Blog", Link: "https://blog.test"})
+		if len(feed.Items) != 0 || !feed.Updated.IsZero() {
+			t.Errorf("empty feed = %d items, updated %v", len(feed.Items), feed.Updated)
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+)
+
+// ToJWKSResponse converts signing keys to a JSON Web Key Set with their public halves
+func ToJWKSResponse(keys []entity.SigningKey) dto.JWKSResponse {
+	result := dto.JWKSResponse{Keys: make([]dto.JWKResponse, 0, len(keys))}
+	for i := range keys {
+		jwk := dto.JWKResponse{
+			Kid: keys[i].ID,
+			Use: "sig",
+			Alg: string(keys[i].Algorithm),
+		}
+
+		switch pub := keys[i].PublicKey().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		result.Keys = append(result.Keys, jwk)
+	}
+	return result
+}
@@ -1,6 +1,10 @@
 package mapper
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
 )
@@ -17,9 +21,15 @@ func ToMediaResponse(m *entity.Media) dto.MediaResponse {
 		Size:         m.Size,
 		Width:        m.Width,
 		Height:       m.Height,
-		ThumbnailSM:  m.ThumbnailSM,
-		ThumbnailMD:  m.ThumbnailMD,
+		ThumbnailSM:  thumbnailURL(m, "sm"),
+		ThumbnailMD:  thumbnailURL(m, "md"),
+		SrcSet:       buildSrcSet(m, m.MimeType),
+		Sources:      toMediaSources(m),
+		Variants:     toMediaVariantResponses(m.Variants),
 		CreatedAt:    m.CreatedAt,
+
+		MediaPlaceholderResponse: toMediaPlaceholderResponse(m.Placeholder),
+		EXIF:                     toMediaEXIFResponse(m.EXIF),
 	}
 }
 
@@ -42,6 +52,7 @@ func ToMediaListResponse(media []entity.Media, total int64) dto.MediaListRespons
 
 // ToUploadMediaResponse converts entity.UploadedFile to dto.UploadMediaResponse
 func ToUploadMediaResponse(f *entity.UploadedFile) dto.UploadMediaResponse {
+	m := &entity.Media{URL: f.URL, MimeType: f.MimeType, Width: f.Width, Variants: f.Variants}
 	return dto.UploadMediaResponse{
 		ID:           f.ID,
 		Filename:     f.Filename,
@@ -49,7 +60,131 @@ func ToUploadMediaResponse(f *entity.UploadedFile) dto.UploadMediaResponse {
 		URL:          f.URL,
 		MimeType:     f.MimeType,
 		Size:         f.Size,
-		ThumbnailSM:  f.ThumbnailSM,
-		ThumbnailMD:  f.ThumbnailMD,
+		Width:        f.Width,
+		Height:       f.Height,
+		ThumbnailSM:  thumbnailURL(m, "sm"),
+		ThumbnailMD:  thumbnailURL(m, "md"),
+		SrcSet:       buildSrcSet(m, f.MimeType),
+		Sources:      toMediaSources(m),
+		Variants:     toMediaVariantResponses(f.Variants),
+
+		MediaPlaceholderResponse: toMediaPlaceholderResponse(f.Placeholder),
+		EXIF:                     toMediaEXIFResponse(f.EXIF),
+		MetadataRemoved:          append([]string{}, f.MetadataRemoved...),
+		Deduplicated:             f.Deduplicated,
+	}
+}
+
+// ToMediaUsageResponses converts media usages to their responses
+func ToMediaUsageResponses(usages []entity.MediaUsage) []dto.MediaUsageResponse {
+	result := make([]dto.MediaUsageResponse, len(usages))
+	for i, u := range usages {
+		result[i] = dto.MediaUsageResponse{
+			SourceType:  string(u.SourceType),
+			SourceID:    u.SourceID,
+			SourceTitle: u.SourceTitle,
+			SourceSlug:  u.SourceSlug,
+			Field:       u.Field,
+		}
+	}
+	return result
+}
+
+// thumbnailURL keeps the legacy thumbnail_sm/thumbnail_md fields working: the
+// JPEG variant of that name, or the original when the image was too narrow
+// for it. Files stored as-is have no thumbnails.
+func thumbnailURL(m *entity.Media, name string) string {
+	if len(m.Variants) == 0 {
+		return ""
+	}
+	if v := m.Variant(name, "image/jpeg"); v != nil {
+		return v.URL
+	}
+	return m.URL
+}
+
+// toMediaSources returns a srcset per format, WebP before the original's format
+func toMediaSources(m *entity.Media) []dto.MediaSourceResponse {
+	sources := []dto.MediaSourceResponse{}
+	if m.MimeType != "image/webp" {
+		if srcset := buildSrcSet(m, "image/webp"); srcset != "" {
+			sources = append(sources, dto.MediaSourceResponse{Type: "image/webp", SrcSet: srcset})
+		}
+	}
+	if srcset := buildSrcSet(m, m.MimeType); srcset != "" {
+		sources = append(sources, dto.MediaSourceResponse{Type: m.MimeType, SrcSet: srcset})
+	}
+	return sources
+}
+
+// buildSrcSet joins the renditions of one format as "url 320w, url 640w, ...".
+// A lone rendition of unknown width (GIF, SVG) is returned as a bare URL.
+func buildSrcSet(m *entity.Media, mimeType string) string {
+	type candidate struct {
+		url   string
+		width int32
 	}
+	var candidates []candidate
+	if m.MimeType == mimeType {
+		candidates = append(candidates, candidate{m.URL, m.Width})
+	}
+	for _, v := range m.Variants {
+		if v.MimeType == mimeType {
+			candidates = append(candidates, candidate{v.URL, v.Width})
+		}
+	}
+	if len(candidates) == 1 && candidates[0].width == 0 {
+		return candidates[0].url
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].width < candidates[j].width })
+	parts := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if c.width > 0 {
+			parts = append(parts, c.url+" "+strconv.Itoa(int(c.width))+"w")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func toMediaPlaceholderResponse(p *entity.MediaPlaceholder) dto.MediaPlaceholderResponse {
+	if p == nil {
+		return dto.MediaPlaceholderResponse{}
+	}
+	return dto.MediaPlaceholderResponse{
+		BlurHash:      p.BlurHash,
+		LQIP:          p.LQIP,
+		DominantColor: p.DominantColor,
+	}
+}
+
+func toMediaEXIFResponse(e *entity.MediaEXIF) *dto.MediaEXIFResponse {
+	if e == nil {
+		return nil
+	}
+	return &dto.MediaEXIFResponse{
+		CameraMake:   e.CameraMake,
+		CameraModel:  e.CameraModel,
+		LensModel:    e.LensModel,
+		FocalLength:  e.FocalLength,
+		FNumber:      e.FNumber,
+		ExposureTime: e.ExposureTime,
+		ISO:          e.ISO,
+		CapturedAt:   e.CapturedAt,
+	}
+}
+
+func toMediaVariantResponses(variants []entity.MediaVariant) []dto.MediaVariantResponse {
+	result := make([]dto.MediaVariantResponse, len(variants))
+	for i, v := range variants {
+		result[i] = dto.MediaVariantResponse{
+			Name:   v.Name,
+			Type:   v.MimeType,
+			URL:    v.URL,
+			Width:  v.Width,
+			Height: v.Height,
+			Size:   v.Size,
+		}
+	}
+	return result
 }
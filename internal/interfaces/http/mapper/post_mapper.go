@@ -45,42 +45,45 @@ func ToPostResponse(p *entity.PostWithDetails) *dto.PostResponse {
 	}
 
 	return &dto.PostResponse{
-		ID:           p.ID,
-		Title:        p.Title,
-		Slug:         p.Slug,
-		Content:      p.Content,
-		Excerpt:      p.Excerpt,
-		CategoryID:   p.CategoryID,
-		CategoryName: p.CategoryName,
-		CategorySlug: p.CategorySlug,
-		Status:       string(p.Status),
-		ViewCount:    p.ViewCount,
-		ReadingTime:  p.ReadingTime,
-		Thumbnail:    p.Thumbnail,
-		Tags:         toTagBriefsInPost(p.Tags),
-		CreatedAt:    p.CreatedAt,
-		UpdatedAt:    p.UpdatedAt,
-		PublishedAt:  p.PublishedAt,
+		ID:                   p.ID,
+		Title:                p.Title,
+		Slug:                 p.Slug,
+		Content:              p.Content,
+		Excerpt:              p.Excerpt,
+		CategoryID:           p.CategoryID,
+		CategoryName:         p.CategoryName,
+		CategorySlug:         p.CategorySlug,
+		Status:               string(p.Status),
+		ViewCount:            p.ViewCount,
+		ReadingTime:          p.ReadingTime,
+		Thumbnail:            p.Thumbnail,
+		ThumbnailPlaceholder: toThumbnailPlaceholder(p.ThumbnailPlaceholder),
+		Tags:                 toTagBriefsInPost(p.Tags),
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt,
+		PublishedAt:          p.PublishedAt,
 	}
 }
 
 // ToPostListResponse converts PostWithDetails entity to PostListResponse DTO
 func ToPostListResponse(p entity.PostWithDetails) dto.PostListResponse {
 	return dto.PostListResponse{
-		ID:           p.ID,
-		Title:        p.Title,
-		Slug:         p.Slug,
-		Excerpt:      p.Excerpt,
-		CategoryID:   p.CategoryID,
-		CategoryName: p.CategoryName,
-		CategorySlug: p.CategorySlug,
-		Status:       string(p.Status),
-		ViewCount:    p.ViewCount,
-		ReadingTime:  p.ReadingTime,
-		Thumbnail:    p.Thumbnail,
-		Tags:         toTagBriefsInPost(p.Tags),
-		CreatedAt:    p.CreatedAt,
-		PublishedAt:  p.PublishedAt,
+		ID:                   p.ID,
+		Title:                p.Title,
+		Slug:                 p.Slug,
+		Excerpt:              p.Excerpt,
+		CategoryID:           p.CategoryID,
+		CategoryName:         p.CategoryName,
+		CategorySlug:         p.CategorySlug,
+		Status:               string(p.Status),
+		ViewCount:            p.ViewCount,
+		CommentCount:         p.CommentCount,
+		ReadingTime:          p.ReadingTime,
+		Thumbnail:            p.Thumbnail,
+		ThumbnailPlaceholder: toThumbnailPlaceholder(p.ThumbnailPlaceholder),
+		Tags:                 toTagBriefsInPost(p.Tags),
+		CreatedAt:            p.CreatedAt,
+		PublishedAt:          p.PublishedAt,
 	}
 }
 
@@ -93,6 +96,28 @@ func ToPostListResponses(posts []entity.PostWithDetails) []dto.PostListResponse
 	return result
 }
 
+// ToPostSearchResponses converts search results to PostSearchResponse DTOs
+func ToPostSearchResponses(results []entity.PostSearchResult) []dto.PostSearchResponse {
+	responses := make([]dto.PostSearchResponse, len(results))
+	for i, r := range results {
+		responses[i] = dto.PostSearchResponse{
+			PostListResponse: ToPostListResponse(r.PostWithDetails),
+			Score:            r.Score,
+			Highlights:       r.Highlights,
+		}
+	}
+	return responses
+}
+
+// toThumbnailPlaceholder converts a post's thumbnail placeholder, if it has one
+func toThumbnailPlaceholder(p *entity.MediaPlaceholder) *dto.MediaPlaceholderResponse {
+	if p == nil {
+		return nil
+	}
+	placeholder := toMediaPlaceholderResponse(p)
+	return &placeholder
+}
+
 // toTagBriefsInPost converts entity TagBrief slice to DTO TagBriefInPost slice
 func toTagBriefsInPost(tags []entity.TagBrief) []dto.TagBriefInPost {
 	result := make([]dto.TagBriefInPost, len(tags))
@@ -105,3 +130,53 @@ func toTagBriefsInPost(tags []entity.TagBrief) []dto.TagBriefInPost {
 	}
 	return result
 }
+
+// ToPostRevisionResponse converts PostRevision entity to PostRevisionResponse DTO
+func ToPostRevisionResponse(r *entity.PostRevision) *dto.PostRevisionResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &dto.PostRevisionResponse{
+		ID:        r.ID,
+		PostID:    r.PostID,
+		Title:     r.Title,
+		Content:   r.Content,
+		Excerpt:   r.Excerpt,
+		Tags:      toTagBriefsInPost(r.Tags),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// ToPostRevisionListResponses converts a slice of PostRevision to PostRevisionListResponse DTOs
+func ToPostRevisionListResponses(revisions []entity.PostRevision) []dto.PostRevisionListResponse {
+	result := make([]dto.PostRevisionListResponse, len(revisions))
+	for i, r := range revisions {
+		result[i] = dto.PostRevisionListResponse{
+			ID:        r.ID,
+			PostID:    r.PostID,
+			Title:     r.Title,
+			Excerpt:   r.Excerpt,
+			Tags:      toTagBriefsInPost(r.Tags),
+			CreatedAt: r.CreatedAt,
+		}
+	}
+	return result
+}
+
+// ToPostRevisionDiffResponse converts PostRevisionDiff entity to PostRevisionDiffResponse DTO
+func ToPostRevisionDiffResponse(d *entity.PostRevisionDiff) *dto.PostRevisionDiffResponse {
+	if d == nil {
+		return nil
+	}
+
+	return &dto.PostRevisionDiffResponse{
+		PostID:         d.PostID,
+		FromRevisionID: d.FromRevisionID,
+		ToRevisionID:   d.ToRevisionID,
+		Title:          d.Title,
+		Excerpt:        d.Excerpt,
+		Tags:           d.Tags,
+		Content:        d.Content,
+	}
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	"github.com/ydonggwui/blog-api/internal/interfaces/http/dto"
+)
+
+// ToSearchSuggestionResponses converts search suggestions to SearchSuggestionResponse DTOs
+func ToSearchSuggestionResponses(suggestions []entity.SearchSuggestion) []dto.SearchSuggestionResponse {
+	result := make([]dto.SearchSuggestionResponse, len(suggestions))
+	for i, s := range suggestions {
+		result[i] = dto.SearchSuggestionResponse{
+			Type:  string(s.Type),
+			ID:    s.ID,
+			Title: s.Title,
+			Slug:  s.Slug,
+		}
+	}
+	return result
+}
+
+// ToPostSearchFacetsResponse converts PostSearchFacets entity to PostSearchFacetsResponse DTO
+func ToPostSearchFacetsResponse(f *entity.PostSearchFacets) *dto.PostSearchFacetsResponse {
+	if f == nil {
+		return nil
+	}
+
+	return &dto.PostSearchFacetsResponse{
+		Categories: toSearchFacetResponses(f.Categories),
+		Tags:       toSearchFacetResponses(f.Tags),
+		Years:      toSearchFacetResponses(f.Years),
+	}
+}
+
+func toSearchFacetResponses(facets []entity.SearchFacet) []dto.SearchFacetResponse {
+	result := make([]dto.SearchFacetResponse, len(facets))
+	for i, f := range facets {
+		result[i] = dto.SearchFacetResponse{
+			Value: f.Value,
+			Label: f.Label,
+			Count: f.Count,
+		}
+	}
+	return result
+}
@@ -6,17 +6,23 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/ydonggwui/blog-api/internal/domain"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	"github.com/ydonggwui/blog-api/internal/pkg/audit"
 )
 
-type Claims struct {
-	UserID   int64  `json:"user_id"`
-	Username string `json:"username"`
-	jwt.RegisteredClaims
-}
+// APIKeyHeader carries an API key as an alternative to the Bearer access token
+const APIKeyHeader = "X-API-Key"
 
-func Auth(jwtSecret string) gin.HandlerFunc {
+// Auth validates the Bearer access token, including the logout denylist, or an API key
+func Auth(authService domainService.AuthService, apiKeyService domainService.APIKeyService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			authenticateAPIKey(c, apiKeyService, key)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -41,17 +47,18 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		tokenString := parts[1]
-		claims := &Claims{}
-
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("invalid signing method")
+		claims, err := authService.ValidateToken(c.Request.Context(), parts[1])
+		if err != nil {
+			if !errors.Is(err, domain.ErrInvalidToken) && !errors.Is(err, domain.ErrTokenRevoked) {
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": gin.H{
+						"code":    "INTERNAL_ERROR",
+						"message": "Failed to validate token",
+					},
+				})
+				c.Abort()
+				return
 			}
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": gin.H{
 					"code":    "UNAUTHORIZED",
@@ -62,9 +69,95 @@ func Auth(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
-		// Set claims to context
-		c.Set("user_id", claims.UserID)
-		c.Set("username", claims.Username)
+		setClaims(c, claims)
+		c.Next()
+	}
+}
+
+// authenticateAPIKey validates an API key and continues as the admin who created it
+func authenticateAPIKey(c *gin.Context, apiKeyService domainService.APIKeyService, key string) {
+	claims, err := apiKeyService.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidAPIKey) {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Failed to validate API key",
+				},
+			})
+			c.Abort()
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": gin.H{
+				"code":    "UNAUTHORIZED",
+				"message": "Invalid, expired or revoked API key",
+			},
+		})
+		c.Abort()
+		return
+	}
+
+	setClaims(c, claims)
+	c.Next()
+}
+
+// setClaims stores the authenticated admin in the gin context, and in the request
+// context for the audit log
+func setClaims(c *gin.Context, claims *entity.Claims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("role", claims.Role)
+	if claims.APIKeyID != 0 {
+		c.Set("api_key_id", claims.APIKeyID)
+		c.Set("scopes", claims.Scopes)
+	}
+
+	ctx := audit.WithActor(c.Request.Context(), audit.Actor{
+		AdminID:  claims.UserID,
+		Username: claims.Username,
+		APIKeyID: claims.APIKeyID,
+	})
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// RejectAPIKeys rejects requests authenticated with an API key, for account settings
+// that only the admin themselves should change
+func RejectAPIKeys() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("api_key_id"); ok {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "FORBIDDEN",
+					"message": "This action is not available to API keys",
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission rejects requests whose role and API key scopes, set by Auth, do not grant the permission
+func RequirePermission(permission entity.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		scopes, _ := c.Get("scopes")
+		r, ok := role.(entity.AdminRole)
+		actor := entity.Actor{Role: r}
+		actor.Scopes, _ = scopes.([]entity.Permission)
+		if !ok || !actor.Can(permission) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": gin.H{
+					"code":    "FORBIDDEN",
+					"message": "You do not have permission to perform this action",
+				},
+			})
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ydonggwui/blog-api/internal/pkg/logger"
+)
+
+// InvalidateOnWrite calls invalidate after a successful write request to a route
+// under one of the given prefixes, so caches built from that content are rebuilt.
+func InvalidateOnWrite(invalidate func(ctx context.Context) error, prefixes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			return
+		}
+		if c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+
+		path := c.FullPath()
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				if err := invalidate(c.Request.Context()); err != nil {
+					logger.Error(c.Request.Context(), "Cache invalidation failed", "path", path, "error", err)
+				}
+				return
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/ydonggwui/blog-api/internal/pkg/audit"
 	"github.com/ydonggwui/blog-api/internal/pkg/logger"
 )
 
@@ -19,8 +20,9 @@ func Logger() gin.HandlerFunc {
 		requestID := uuid.New().String()
 		c.Set("request_id", requestID)
 
-		// Add request ID to context
+		// Add request ID and client IP to context
 		ctx := context.WithValue(c.Request.Context(), logger.RequestIDKey, requestID)
+		ctx = audit.WithClientIP(ctx, c.ClientIP())
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
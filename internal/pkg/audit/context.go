@@ -0,0 +1,40 @@
+package audit
+
+import "context"
+
+type contextKey string
+
+const (
+	actorKey    contextKey = "audit_actor"
+	clientIPKey contextKey = "audit_client_ip"
+)
+
+// Actor identifies who made a request, for the audit log
+type Actor struct {
+	AdminID  int32
+	Username string
+	// APIKeyID is set when the request was made with an API key
+	APIKeyID int32
+}
+
+// WithActor returns a copy of ctx carrying the authenticated admin
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey, actor)
+}
+
+// ActorFromContext returns the authenticated admin set by WithActor
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey).(Actor)
+	return actor, ok
+}
+
+// WithClientIP returns a copy of ctx carrying the client IP of the request
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// ClientIPFromContext returns the client IP set by WithClientIP, or an empty string
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"context"
 	"database/sql"
+	"log"
 	"net/http"
 	"time"
 
@@ -13,12 +14,15 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/ydonggwui/blog-api/internal/config"
 	"github.com/ydonggwui/blog-api/internal/database/sqlc"
+	"github.com/ydonggwui/blog-api/internal/domain/entity"
 	adminHandler "github.com/ydonggwui/blog-api/internal/handler/admin"
 	publicHandler "github.com/ydonggwui/blog-api/internal/handler/public"
 	"github.com/ydonggwui/blog-api/internal/middleware"
 
 	// Clean Architecture imports
 	appService "github.com/ydonggwui/blog-api/internal/application/service"
+	domainService "github.com/ydonggwui/blog-api/internal/domain/service"
+	oidcIdentity "github.com/ydonggwui/blog-api/internal/infrastructure/identity/oidc"
 	postgresRepo "github.com/ydonggwui/blog-api/internal/infrastructure/persistence/postgres"
 	redisRepo "github.com/ydonggwui/blog-api/internal/infrastructure/persistence/redis"
 	minioStorage "github.com/ydonggwui/blog-api/internal/infrastructure/storage/minio"
@@ -34,24 +38,42 @@ type Router struct {
 	minio   *minio.Client
 	config  *config.Config
 
+	// Services used by middleware
+	sitemapService domainService.SitemapService
+	authService    domainService.AuthService
+	apiKeyService  domainService.APIKeyService
+
 	// Handlers
 	authHandler            *adminHandler.AuthHandler
 	publicPostHandler      *publicHandler.PostHandler
 	publicCategoryHandler  *publicHandler.CategoryHandler
 	publicTagHandler       *publicHandler.TagHandler
 	publicProjectHandler   *publicHandler.ProjectHandler
+	publicFeedHandler      *publicHandler.FeedHandler
+	publicSitemapHandler   *publicHandler.SitemapHandler
+	publicSearchHandler    *publicHandler.SearchHandler
+	publicCommentHandler   *publicHandler.CommentHandler
+	publicJWKSHandler      *publicHandler.JWKSHandler
 	adminPostHandler       *adminHandler.PostHandler
 	adminCategoryHandler   *adminHandler.CategoryHandler
 	adminTagHandler        *adminHandler.TagHandler
 	adminProjectHandler    *adminHandler.ProjectHandler
 	adminMediaHandler      *adminHandler.MediaHandler
 	adminDashboardHandler  *adminHandler.DashboardHandler
+	adminCommentHandler    *adminHandler.CommentHandler
+	adminAdminHandler      *adminHandler.AdminHandler
+	adminAPIKeyHandler     *adminHandler.APIKeyHandler
+	adminAuditHandler      *adminHandler.AuditHandler
 }
 
 func New(cfg *config.Config, db *sql.DB, queries *sqlc.Queries, redisClient *redis.Client, minioClient *minio.Client) *Router {
 	gin.SetMode(cfg.Server.GinMode)
 
 	engine := gin.New()
+	// ClientIP only honours X-Forwarded-For from these proxies; with none it is the peer address
+	if err := engine.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	engine.Use(gin.Recovery())
 	engine.Use(middleware.Logger())
 	engine.Use(middleware.CORS())
@@ -63,23 +85,53 @@ func New(cfg *config.Config, db *sql.DB, queries *sqlc.Queries, redisClient *red
 	// Infrastructure Layer - Repositories
 	categoryRepo := postgresRepo.NewCategoryRepository(queries)
 	tagRepo := postgresRepo.NewTagRepository(queries)
-	postRepo := postgresRepo.NewPostRepository(queries)
+	postRepo := postgresRepo.NewPostRepository(db, queries)
 	projectRepo := postgresRepo.NewProjectRepository(queries)
-	mediaRepo := postgresRepo.NewMediaRepository(queries)
+	mediaRepo := postgresRepo.NewMediaRepository(db, queries)
 	storageRepo := minioStorage.NewStorageRepository(minioClient, &cfg.MinIO)
-	adminRepo := postgresRepo.NewAdminRepository(queries)
+	adminRepo := postgresRepo.NewAdminRepository(queries, cfg.JWT.TOTPEncryptionKey)
 	dashboardRepo := postgresRepo.NewDashboardRepository(queries)
 	viewRepo := redisRepo.NewViewRepository(redisClient)
+	sitemapCacheRepo := redisRepo.NewSitemapCacheRepository(redisClient)
+	suggestRepo := redisRepo.NewSuggestRepository(redisClient)
+	commentRepo := postgresRepo.NewCommentRepository(queries)
+	spamRepo := postgresRepo.NewSpamRepository(queries)
+	refreshTokenRepo := redisRepo.NewRefreshTokenRepository(redisClient)
+	tokenDenylistRepo := redisRepo.NewTokenDenylistRepository(redisClient)
+	adminInviteRepo := redisRepo.NewAdminInviteRepository(redisClient)
+	mfaChallengeRepo := redisRepo.NewMFAChallengeRepository(redisClient)
+	passwordResetRepo := redisRepo.NewPasswordResetRepository(redisClient)
+	loginAttemptRepo := redisRepo.NewLoginAttemptRepository(redisClient)
+	apiKeyRepo := postgresRepo.NewAPIKeyRepository(queries)
+	auditLogRepo := postgresRepo.NewAuditLogRepository(queries)
+	signingKeyRepo := postgresRepo.NewSigningKeyRepository(queries, cfg.JWT.KeyEncryptionKey)
+	lockRepo := redisRepo.NewLockRepository(redisClient)
+	oidcStateRepo := redisRepo.NewOIDCStateRepository(redisClient)
+	oidcProvider := oidcIdentity.NewProvider(&cfg.OIDC)
 
 	// Application Layer - Services (Clean Architecture)
-	categoryServiceNew := appService.NewCategoryService(categoryRepo)
-	tagServiceNew := appService.NewTagService(tagRepo)
-	postServiceNew := appService.NewPostService(postRepo)
-	projectServiceNew := appService.NewProjectService(projectRepo)
-	mediaServiceNew := appService.NewMediaService(mediaRepo, storageRepo)
-	authServiceNew := appService.NewAuthService(adminRepo, &cfg.JWT)
+	categoryServiceNew := appService.NewCategoryService(categoryRepo, suggestRepo, auditLogRepo)
+	tagServiceNew := appService.NewTagService(tagRepo, suggestRepo, auditLogRepo)
+	postServiceNew := appService.NewPostService(postRepo, suggestRepo, mediaRepo, auditLogRepo)
+	projectServiceNew := appService.NewProjectService(projectRepo, mediaRepo, auditLogRepo)
+	mediaServiceNew := appService.NewMediaService(mediaRepo, storageRepo, auditLogRepo, &cfg.Media)
+	signingKeyServiceNew := appService.NewSigningKeyService(signingKeyRepo, lockRepo, &cfg.JWT)
+	authServiceNew := appService.NewAuthService(adminRepo, refreshTokenRepo, tokenDenylistRepo, mfaChallengeRepo, passwordResetRepo, loginAttemptRepo, auditLogRepo, signingKeyServiceNew, oidcProvider, oidcStateRepo, &cfg.JWT, &cfg.Login, &cfg.OIDC)
+	adminServiceNew := appService.NewAdminService(adminRepo, adminInviteRepo, loginAttemptRepo, refreshTokenRepo, tokenDenylistRepo, auditLogRepo, &cfg.Admin, &cfg.JWT)
+	apiKeyServiceNew := appService.NewAPIKeyService(apiKeyRepo, auditLogRepo)
+	auditServiceNew := appService.NewAuditService(auditLogRepo)
 	dashboardServiceNew := appService.NewDashboardService(dashboardRepo)
 	viewServiceNew := appService.NewViewService(viewRepo, postServiceNew)
+	sitemapServiceNew := appService.NewSitemapService(postRepo, categoryRepo, tagRepo, projectRepo, sitemapCacheRepo, &cfg.Site)
+	suggestServiceNew := appService.NewSuggestService(postRepo, categoryRepo, tagRepo, suggestRepo, lockRepo)
+	spamServiceNew := appService.NewSpamService(spamRepo, commentRepo, &cfg.Spam,
+		appService.NewHoneypotSpamFilter(),
+		appService.NewTimingSpamFilter(&cfg.Spam),
+		appService.NewLinkSpamFilter(cfg.Spam.MaxLinks),
+		appService.NewBlacklistSpamFilter(cfg.Spam.Blacklist),
+		appService.NewBayesSpamFilter(spamRepo),
+	)
+	commentServiceNew := appService.NewCommentService(commentRepo, postRepo, spamServiceNew, auditLogRepo, &cfg.Comment)
 
 	// ============================================
 	// Initialize Handlers
@@ -88,6 +140,18 @@ func New(cfg *config.Config, db *sql.DB, queries *sqlc.Queries, redisClient *red
 	// Auth Handler - Clean Architecture 사용
 	authHandler := adminHandler.NewAuthHandlerWithCleanArch(authServiceNew)
 
+	// JWKS Handler - Clean Architecture 사용
+	publicJWKSHandler := publicHandler.NewJWKSHandlerWithCleanArch(signingKeyServiceNew)
+
+	// Admin Management Handler - Clean Architecture 사용
+	adminAdminHandler := adminHandler.NewAdminHandlerWithCleanArch(adminServiceNew)
+
+	// API Key Handler - Clean Architecture 사용
+	adminAPIKeyHandler := adminHandler.NewAPIKeyHandlerWithCleanArch(apiKeyServiceNew)
+
+	// Audit Log Handler - Clean Architecture 사용
+	adminAuditHandler := adminHandler.NewAuditHandlerWithCleanArch(auditServiceNew)
+
 	// Post Handlers - Clean Architecture 사용
 	publicPostHandler := publicHandler.NewPostHandlerWithCleanArch(postServiceNew, viewServiceNew)
 	adminPostHandler := adminHandler.NewPostHandlerWithCleanArch(postServiceNew)
@@ -104,6 +168,19 @@ func New(cfg *config.Config, db *sql.DB, queries *sqlc.Queries, redisClient *red
 	publicProjectHandler := publicHandler.NewProjectHandlerWithCleanArch(projectServiceNew)
 	adminProjectHandler := adminHandler.NewProjectHandlerWithCleanArch(projectServiceNew)
 
+	// Feed Handler - Clean Architecture 사용
+	publicFeedHandler := publicHandler.NewFeedHandlerWithCleanArch(postServiceNew, categoryServiceNew, tagServiceNew, &cfg.Site, &cfg.Feed)
+
+	// Sitemap Handler - Clean Architecture 사용
+	publicSitemapHandler := publicHandler.NewSitemapHandlerWithCleanArch(sitemapServiceNew)
+
+	// Search Handler - Clean Architecture 사용
+	publicSearchHandler := publicHandler.NewSearchHandlerWithCleanArch(suggestServiceNew)
+
+	// Comment Handlers - Clean Architecture 사용
+	publicCommentHandler := publicHandler.NewCommentHandlerWithCleanArch(commentServiceNew)
+	adminCommentHandler := adminHandler.NewCommentHandlerWithCleanArch(commentServiceNew)
+
 	// Media Handler - Clean Architecture 사용
 	adminMediaHandler := adminHandler.NewMediaHandlerWithCleanArch(mediaServiceNew)
 
@@ -117,17 +194,29 @@ func New(cfg *config.Config, db *sql.DB, queries *sqlc.Queries, redisClient *red
 		redis:                 redisClient,
 		minio:                 minioClient,
 		config:                cfg,
+		sitemapService:        sitemapServiceNew,
+		authService:           authServiceNew,
+		apiKeyService:         apiKeyServiceNew,
 		authHandler:           authHandler,
 		publicPostHandler:     publicPostHandler,
 		publicCategoryHandler: publicCategoryHandler,
 		publicTagHandler:      publicTagHandler,
 		publicProjectHandler:  publicProjectHandler,
+		publicFeedHandler:     publicFeedHandler,
+		publicSitemapHandler:  publicSitemapHandler,
+		publicSearchHandler:   publicSearchHandler,
+		publicCommentHandler:  publicCommentHandler,
+		publicJWKSHandler:     publicJWKSHandler,
 		adminPostHandler:      adminPostHandler,
 		adminCategoryHandler:  adminCategoryHandler,
 		adminTagHandler:       adminTagHandler,
 		adminProjectHandler:   adminProjectHandler,
 		adminMediaHandler:     adminMediaHandler,
 		adminDashboardHandler: adminDashboardHandler,
+		adminCommentHandler:   adminCommentHandler,
+		adminAdminHandler:     adminAdminHandler,
+		adminAPIKeyHandler:    adminAPIKeyHandler,
+		adminAuditHandler:     adminAuditHandler,
 	}
 
 	r.setupRoutes()
@@ -139,6 +228,9 @@ func (r *Router) setupRoutes() {
 	// Swagger documentation
 	r.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Public keys for verifying access tokens in other services
+	r.engine.GET("/.well-known/jwks.json", r.publicJWKSHandler.GetJWKS)
+
 	api := r.engine.Group("/api")
 	{
 		// Health check
@@ -150,73 +242,150 @@ func (r *Router) setupRoutes() {
 			// Posts
 			public.GET("/posts", r.publicPostHandler.ListPosts)
 			public.GET("/posts/search", r.publicPostHandler.SearchPosts)
+			public.GET("/search/suggest", r.publicSearchHandler.Suggest)
 			public.GET("/posts/:slug", r.publicPostHandler.GetPost)
 			public.POST("/posts/:slug/view", r.publicPostHandler.RecordView)
+			public.GET("/posts/:slug/comments", r.publicCommentHandler.ListComments)
+			public.POST("/posts/:slug/comments", r.publicCommentHandler.CreateComment)
+			public.GET("/posts/:slug/comments/form-token", r.publicCommentHandler.GetFormToken)
 
 			// Categories
 			public.GET("/categories", r.publicCategoryHandler.ListCategories)
 			public.GET("/categories/:slug/posts", r.publicCategoryHandler.GetCategoryPosts)
+			public.GET("/categories/:slug/feed.xml", r.publicFeedHandler.CategoryRSS)
+			public.GET("/categories/:slug/atom.xml", r.publicFeedHandler.CategoryAtom)
+			public.GET("/categories/:slug/feed.json", r.publicFeedHandler.CategoryJSONFeed)
 
 			// Tags
 			public.GET("/tags", r.publicTagHandler.ListTags)
 			public.GET("/tags/:slug/posts", r.publicTagHandler.GetTagPosts)
+			public.GET("/tags/:slug/feed.xml", r.publicFeedHandler.TagRSS)
+			public.GET("/tags/:slug/atom.xml", r.publicFeedHandler.TagAtom)
+			public.GET("/tags/:slug/feed.json", r.publicFeedHandler.TagJSONFeed)
 
 			// Projects
 			public.GET("/projects", r.publicProjectHandler.ListProjects)
 			public.GET("/projects/:slug", r.publicProjectHandler.GetProject)
+
+			// Feeds
+			public.GET("/feed.xml", r.publicFeedHandler.RSS)
+			public.GET("/atom.xml", r.publicFeedHandler.Atom)
+			public.GET("/feed.json", r.publicFeedHandler.JSONFeed)
+
+			// Sitemap
+			public.GET("/sitemap.xml", r.publicSitemapHandler.GetSitemap)
+			public.GET("/sitemap-:page", r.publicSitemapHandler.GetSitemapPage)
 		}
 
 		// Admin auth routes (no auth required for login)
 		adminAuth := api.Group("/admin/auth")
 		{
 			adminAuth.POST("/login", r.authHandler.Login)
+			adminAuth.POST("/login/mfa", r.authHandler.VerifyMFA)
+			adminAuth.GET("/oidc/authorize", r.authHandler.StartOIDCLogin)
+			adminAuth.POST("/oidc/callback", r.authHandler.CompleteOIDCLogin)
+			adminAuth.POST("/refresh", r.authHandler.Refresh)
 			adminAuth.POST("/logout", r.authHandler.Logout)
+			adminAuth.POST("/accept-invite", r.adminAdminHandler.AcceptInvite)
+			adminAuth.POST("/reset-password", r.authHandler.ResetPassword)
 		}
 
 		// Admin routes (auth required)
 		admin := api.Group("/admin")
-		admin.Use(middleware.Auth(r.config.JWT.Secret))
+		admin.Use(middleware.Auth(r.authService, r.apiKeyService))
+		admin.Use(middleware.InvalidateOnWrite(r.sitemapService.Invalidate,
+			"/api/admin/posts", "/api/admin/categories", "/api/admin/tags", "/api/admin/projects"))
 		{
 			// Auth
 			admin.GET("/auth/me", r.authHandler.Me)
-
-			// Posts
-			admin.GET("/posts", r.adminPostHandler.ListPosts)
-			admin.GET("/posts/:id", r.adminPostHandler.GetPost)
-			admin.POST("/posts", r.adminPostHandler.CreatePost)
-			admin.PUT("/posts/:id", r.adminPostHandler.UpdatePost)
-			admin.DELETE("/posts/:id", r.adminPostHandler.DeletePost)
-			admin.PATCH("/posts/:id/publish", r.adminPostHandler.PublishPost)
-
-			// Categories
-			admin.GET("/categories", r.adminCategoryHandler.ListCategories)
-			admin.GET("/categories/:id", r.adminCategoryHandler.GetCategory)
-			admin.POST("/categories", r.adminCategoryHandler.CreateCategory)
-			admin.PUT("/categories/:id", r.adminCategoryHandler.UpdateCategory)
-			admin.DELETE("/categories/:id", r.adminCategoryHandler.DeleteCategory)
-
-			// Tags
-			admin.GET("/tags", r.adminTagHandler.ListTags)
-			admin.GET("/tags/:id", r.adminTagHandler.GetTag)
-			admin.POST("/tags", r.adminTagHandler.CreateTag)
-			admin.PUT("/tags/:id", r.adminTagHandler.UpdateTag)
-			admin.DELETE("/tags/:id", r.adminTagHandler.DeleteTag)
+			admin.POST("/auth/password", middleware.RejectAPIKeys(), r.authHandler.ChangePassword)
+			totp := admin.Group("/auth/totp", middleware.RejectAPIKeys())
+			{
+				totp.POST("/enroll", r.authHandler.EnrollTOTP)
+				totp.POST("/activate", r.authHandler.ActivateTOTP)
+				totp.POST("/disable", r.authHandler.DisableTOTP)
+			}
+
+			// API keys - every role manages their own keys, owners see all of them
+			apiKeys := admin.Group("/api-keys", middleware.RejectAPIKeys())
+			{
+				apiKeys.GET("", r.adminAPIKeyHandler.ListAPIKeys)
+				apiKeys.POST("", r.adminAPIKeyHandler.CreateAPIKey)
+				apiKeys.PATCH("/:id", r.adminAPIKeyHandler.UpdateAPIKey)
+				apiKeys.DELETE("/:id", r.adminAPIKeyHandler.RevokeAPIKey)
+			}
+
+			// Read-only routes - every role
+			read := admin.Group("", middleware.RequirePermission(entity.PermissionContentRead))
+			{
+				read.GET("/posts", r.adminPostHandler.ListPosts)
+				read.GET("/posts/:id", r.adminPostHandler.GetPost)
+				read.GET("/posts/:id/revisions", r.adminPostHandler.ListRevisions)
+				read.GET("/posts/:id/revisions/diff", r.adminPostHandler.DiffRevisions)
+				read.GET("/posts/:id/revisions/:revisionId", r.adminPostHandler.GetRevision)
+				read.GET("/categories", r.adminCategoryHandler.ListCategories)
+				read.GET("/categories/:id", r.adminCategoryHandler.GetCategory)
+				read.GET("/tags", r.adminTagHandler.ListTags)
+				read.GET("/tags/:id", r.adminTagHandler.GetTag)
+				read.GET("/projects", r.adminProjectHandler.ListProjects)
+				read.GET("/projects/:id", r.adminProjectHandler.GetProject)
+				read.GET("/comments", r.adminCommentHandler.ListComments)
+				read.GET("/media", r.adminMediaHandler.ListMedia)
+				read.GET("/media/:id/usages", r.adminMediaHandler.GetMediaUsages)
+				read.GET("/dashboard/stats", r.adminDashboardHandler.GetStats)
+			}
+
+			// Posts - authors can only change their own posts (checked by PostService)
+			posts := admin.Group("/posts", middleware.RequirePermission(entity.PermissionPostWrite))
+			{
+				posts.POST("", r.adminPostHandler.CreatePost)
+				posts.PUT("/:id", r.adminPostHandler.UpdatePost)
+				posts.DELETE("/:id", r.adminPostHandler.DeletePost)
+				posts.PATCH("/:id/publish", r.adminPostHandler.PublishPost)
+				posts.POST("/:id/revisions/:revisionId/restore", r.adminPostHandler.RestoreRevision)
+			}
+
+			// Categories & Tags
+			taxonomy := admin.Group("", middleware.RequirePermission(entity.PermissionTaxonomyWrite))
+			{
+				taxonomy.POST("/categories", r.adminCategoryHandler.CreateCategory)
+				taxonomy.PUT("/categories/:id", r.adminCategoryHandler.UpdateCategory)
+				taxonomy.DELETE("/categories/:id", r.adminCategoryHandler.DeleteCategory)
+				taxonomy.POST("/tags", r.adminTagHandler.CreateTag)
+				taxonomy.PUT("/tags/:id", r.adminTagHandler.UpdateTag)
+				taxonomy.DELETE("/tags/:id", r.adminTagHandler.DeleteTag)
+			}
 
 			// Projects
-			admin.GET("/projects", r.adminProjectHandler.ListProjects)
-			admin.GET("/projects/:id", r.adminProjectHandler.GetProject)
-			admin.POST("/projects", r.adminProjectHandler.CreateProject)
-			admin.PUT("/projects/:id", r.adminProjectHandler.UpdateProject)
-			admin.DELETE("/projects/:id", r.adminProjectHandler.DeleteProject)
-			admin.PATCH("/projects/reorder", r.adminProjectHandler.ReorderProjects)
+			projects := admin.Group("/projects", middleware.RequirePermission(entity.PermissionProjectWrite))
+			{
+				projects.POST("", r.adminProjectHandler.CreateProject)
+				projects.PUT("/:id", r.adminProjectHandler.UpdateProject)
+				projects.DELETE("/:id", r.adminProjectHandler.DeleteProject)
+				projects.PATCH("/reorder", r.adminProjectHandler.ReorderProjects)
+			}
 
-			// Media
-			admin.GET("/media", r.adminMediaHandler.ListMedia)
-			admin.POST("/media/upload", r.adminMediaHandler.UploadMedia)
-			admin.DELETE("/media/:id", r.adminMediaHandler.DeleteMedia)
+			// Comments
+			admin.POST("/comments/moderate", middleware.RequirePermission(entity.PermissionCommentsModerate), r.adminCommentHandler.ModerateComments)
 
-			// Dashboard
-			admin.GET("/dashboard/stats", r.adminDashboardHandler.GetStats)
+			// Media
+			admin.POST("/media/upload", middleware.RequirePermission(entity.PermissionMediaUpload), r.adminMediaHandler.UploadMedia)
+			admin.DELETE("/media/:id", middleware.RequirePermission(entity.PermissionMediaDelete), r.adminMediaHandler.DeleteMedia)
+
+			// Admins - owner only
+			admins := admin.Group("/admins", middleware.RequirePermission(entity.PermissionAdminsManage))
+			{
+				admins.GET("", r.adminAdminHandler.ListAdmins)
+				admins.POST("/invite", r.adminAdminHandler.InviteAdmin)
+				admins.PATCH("/:id/role", r.adminAdminHandler.UpdateRole)
+				admins.PATCH("/:id/status", r.adminAdminHandler.UpdateStatus)
+				admins.PATCH("/:id/identity", r.adminAdminHandler.UpdateIdentity)
+				admins.GET("/lockouts", r.adminAdminHandler.ListLoginLockouts)
+				admins.DELETE("/lockouts/:kind/:value", r.adminAdminHandler.ClearLoginLockout)
+			}
+
+			// Audit log - owner only
+			admin.GET("/audit", middleware.RequirePermission(entity.PermissionAdminsManage), r.adminAuditHandler.ListAuditLogs)
 		}
 	}
 }
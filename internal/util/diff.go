@@ -0,0 +1,22 @@
+package util
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiff returns a unified diff (3 lines of context) between two texts
+// Returns an empty string when both texts are identical
+func UnifiedDiff(from, to, fromLabel, toLabel string) (string, error) {
+	if from == to {
+		return "", nil
+	}
+
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromLabel,
+		ToFile:   toLabel,
+		Context:  3,
+	}
+	return difflib.GetUnifiedDiffString(diff)
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("identical texts", func(t *testing.T) {
+		diff, err := UnifiedDiff("same\n", "same\n", "a", "b")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if diff != "" {
+			t.Errorf("expected empty diff, got %q", diff)
+		}
+	})
+
+	t.Run("changed line", func(t *testing.T) {
+		diff, err := UnifiedDiff("line1\nline2\nline3\n", "line1\nchanged\nline3\n", "revision 1", "current")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for _, want := range []string{"--- revision 1", "+++ current", "-line2", "+changed", " line1"} {
+			if !strings.Contains(diff, want) {
+				t.Errorf("expected diff to contain %q, got:\n%s", want, diff)
+			}
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidFormToken is returned when a form token is malformed or its signature does not match
+var ErrInvalidFormToken = errors.New("invalid form token")
+
+// SignFormToken returns a token recording when a form for subject was rendered.
+// The token is "<unix seconds>.<signature>", signed with HMAC-SHA256.
+func SignFormToken(secret, subject string, issuedAt time.Time) string {
+	ts := strconv.FormatInt(issuedAt.Unix(), 10)
+	return ts + "." + formTokenSignature(secret, subject, ts)
+}
+
+// ParseFormToken verifies a token created by SignFormToken for the same subject
+// and returns the time it was issued
+func ParseFormToken(secret, subject, token string) (time.Time, error) {
+	ts, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return time.Time{}, ErrInvalidFormToken
+	}
+
+	expected := formTokenSignature(secret, subject, ts)
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return time.Time{}, ErrInvalidFormToken
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrInvalidFormToken
+	}
+	return time.Unix(unix, 0), nil
+}
+
+func formTokenSignature(secret, subject, ts string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(subject + ":" + ts))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFormToken(t *testing.T) {
+	issuedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := SignFormToken("secret", "post:1", issuedAt)
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := ParseFormToken("secret", "post:1", token)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !got.Equal(issuedAt) {
+			t.Errorf("expected %v, got %v", issuedAt, got)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		secret  string
+		subject string
+		token   string
+	}{
+		{"wrong secret", "other", "post:1", token},
+		{"wrong subject", "secret", "post:2", token},
+		{"tampered time", "secret", "post:1", "1" + token},
+		{"malformed", "secret", "post:1", "garbage"},
+		{"empty", "secret", "post:1", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFormToken(tt.secret, tt.subject, tt.token)
+			if !errors.Is(err, ErrInvalidFormToken) {
+				t.Errorf("expected ErrInvalidFormToken, got %v", err)
+			}
+		})
+	}
+}
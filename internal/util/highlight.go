@@ -0,0 +1,128 @@
+package util
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// HighlightPre and HighlightPost wrap every matched term in a fragment
+	HighlightPre  = "<mark>"
+	HighlightPost = "</mark>"
+
+	highlightEllipsis = "…"
+)
+
+type highlightMatch struct {
+	start, end int
+}
+
+// Highlight returns up to maxFragments snippets of text around case-insensitive
+// matches of the query, as a phrase or as individual terms, with each match wrapped
+// in HighlightPre/HighlightPost. HTML is stripped and the snippet text escaped, so
+// the markers are the only markup. A fragmentSize of 0 highlights the whole text.
+// Returns nil when nothing matches.
+func Highlight(text, query string, maxFragments, fragmentSize int) []string {
+	plain := []rune(strings.Join(strings.Fields(html.UnescapeString(stripHTMLTags(text))), " "))
+	matches := findHighlightMatches(plain, highlightTerms(query))
+	if len(matches) == 0 {
+		return nil
+	}
+
+	if fragmentSize <= 0 {
+		return []string{renderHighlight(plain, matches, 0, len(plain))}
+	}
+
+	var fragments []string
+	prevEnd := 0
+	for i := 0; i < len(matches) && len(fragments) < maxFragments; {
+		// Center the fragment on its first match
+		m := matches[i]
+		start := max(prevEnd, m.start-(fragmentSize-(m.end-m.start))/2, 0)
+		end := max(min(len(plain), start+fragmentSize), m.end)
+		start = max(prevEnd, min(start, end-fragmentSize), 0) // fill the window near the end of text
+
+		j := i
+		for j < len(matches) && matches[j].start < end {
+			end = max(end, matches[j].end)
+			j++
+		}
+
+		fragments = append(fragments, renderHighlight(plain, matches[i:j], start, end))
+		prevEnd = end
+		i = j
+	}
+	return fragments
+}
+
+// highlightTerms splits the query into lowercase terms, longest first,
+// keeping the whole query as a phrase when it has several words
+func highlightTerms(query string) [][]rune {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) > 1 {
+		words = append(words, strings.Join(words, " "))
+	}
+
+	var terms [][]rune
+	for _, w := range words {
+		term := []rune(w)
+		if !slices.ContainsFunc(terms, func(t []rune) bool { return slices.Equal(t, term) }) {
+			terms = append(terms, term)
+		}
+	}
+	slices.SortStableFunc(terms, func(a, b []rune) int { return len(b) - len(a) })
+	return terms
+}
+
+// findHighlightMatches scans left to right, preferring the longest term at each position
+func findHighlightMatches(text []rune, terms [][]rune) []highlightMatch {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches []highlightMatch
+	for pos := 0; pos < len(lower); {
+		matched := false
+		for _, term := range terms {
+			if len(term) <= len(lower)-pos && slices.Equal(lower[pos:pos+len(term)], term) {
+				matches = append(matches, highlightMatch{start: pos, end: pos + len(term)})
+				pos += len(term)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			pos++
+		}
+	}
+	return matches
+}
+
+func renderHighlight(text []rune, matches []highlightMatch, start, end int) string {
+	var b strings.Builder
+	pos := start
+	for _, m := range matches {
+		b.WriteString(html.EscapeString(string(text[pos:m.start])))
+		b.WriteString(HighlightPre)
+		b.WriteString(html.EscapeString(string(text[m.start:m.end])))
+		b.WriteString(HighlightPost)
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(text[pos:end])))
+
+	fragment := strings.TrimSpace(b.String())
+	if start > 0 {
+		fragment = highlightEllipsis + fragment
+	}
+	if end < len(text) {
+		fragment += highlightEllipsis
+	}
+	return fragment
+}
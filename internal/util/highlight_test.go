@@ -0,0 +1,73 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		query        string
+		maxFragments int
+		fragmentSize int
+		expected     []string
+	}{
+		{
+			name:     "Whole text",
+			text:     "Learning Go Generics",
+			query:    "go",
+			expected: []string{"Learning <mark>Go</mark> Generics"},
+		},
+		{
+			name:     "Korean text",
+			text:     "Go 언어 튜토리얼",
+			query:    "튜토리얼",
+			expected: []string{"Go 언어 <mark>튜토리얼</mark>"},
+		},
+		{
+			name:     "Phrase preferred over terms",
+			text:     "clean architecture in clean code",
+			query:    "Clean Architecture",
+			expected: []string{"<mark>clean architecture</mark> in <mark>clean</mark> code"},
+		},
+		{
+			name:     "HTML is stripped and escaped",
+			text:     "<p>Use <code>a &lt; b</code> in Go</p>",
+			query:    "go",
+			expected: []string{"Use a &lt; b in <mark>Go</mark>"},
+		},
+		{
+			name:         "Fragments",
+			text:         "go is fun. lorem ipsum dolor sit amet consectetur. go again",
+			query:        "go",
+			maxFragments: 3,
+			fragmentSize: 12,
+			expected:     []string{"<mark>go</mark> is fun. l…", "…tur. <mark>go</mark> agai…"},
+		},
+		{
+			name:         "Fragment limit",
+			text:         "go one two three four five six seven go",
+			query:        "go",
+			maxFragments: 1,
+			fragmentSize: 10,
+			expected:     []string{"<mark>go</mark> one two…"},
+		},
+		{
+			name:     "No match",
+			text:     "Hello World",
+			query:    "rust",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Highlight(tt.text, tt.query, tt.maxFragments, tt.fragmentSize)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Highlight(%q, %q) = %q, want %q", tt.text, tt.query, result, tt.expected)
+			}
+		})
+	}
+}
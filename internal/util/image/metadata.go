@@ -0,0 +1,196 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/exif"
+)
+
+// Kinds of embedded metadata reported as removed from stored files
+const (
+	MetadataGPS      = "gps"      // EXIF GPS location
+	MetadataEXIF     = "exif"     // the rest of the EXIF block (camera serials, maker notes, ...)
+	MetadataXMP      = "xmp"      // XMP packet or RDF block, which may repeat the location
+	MetadataComment  = "comment"  // GIF comment extensions
+	MetadataSVGBlock = "metadata" // SVG <metadata> elements
+)
+
+// EXIF is the camera information read from a photo's EXIF block.
+// Only display-worthy fields are read; the location is never decoded.
+type EXIF struct {
+	CameraMake   string
+	CameraModel  string
+	LensModel    string
+	FocalLength  float64 // millimetres
+	FNumber      float64
+	ExposureTime string // seconds, e.g. "1/250"
+	ISO          int
+	CapturedAt   string // camera-local time as "2006-01-02T15:04:05"; EXIF has no time zone
+	// HasGPS reports whether the block carries a location
+	HasGPS bool
+}
+
+// ReadEXIF parses the EXIF block of a JPEG, PNG or WebP file.
+// It returns nil when the file has none or it can't be parsed.
+func ReadEXIF(data []byte) (info *EXIF) {
+	block := findEXIFBlock(data)
+	if block == nil {
+		return nil
+	}
+	// Uploads are untrusted; a malformed block must not take the request down
+	defer func() {
+		if recover() != nil {
+			info = nil
+		}
+	}()
+
+	x, err := exif.Decode(bytes.NewReader(block))
+	if err != nil && (x == nil || exif.IsCriticalError(err)) {
+		return nil
+	}
+
+	info = &EXIF{
+		CameraMake:   exifString(x, exif.Make),
+		CameraModel:  exifString(x, exif.Model),
+		LensModel:    exifString(x, exif.LensModel),
+		FocalLength:  exifFloat(x, exif.FocalLength),
+		FNumber:      exifFloat(x, exif.FNumber),
+		ExposureTime: exifExposure(x),
+	}
+	if tag, err := x.Get(exif.ISOSpeedRatings); err == nil {
+		info.ISO, _ = tag.Int(0)
+	}
+	if t, err := time.Parse("2006:01:02 15:04:05", exifString(x, exif.DateTimeOriginal)); err == nil {
+		info.CapturedAt = t.Format("2006-01-02T15:04:05")
+	}
+	if _, err := x.Get(exif.GPSInfoIFDPointer); err == nil {
+		info.HasGPS = true
+	}
+	return info
+}
+
+// HasXMP reports whether the file contains an XMP packet
+func HasXMP(data []byte) bool {
+	return bytes.Contains(data, []byte("<x:xmpmeta")) || bytes.Contains(data, []byte("http://ns.adobe.com/xap/1.0/"))
+}
+
+func exifString(x *exif.Exif, name exif.FieldName) string {
+	tag, err := x.Get(name)
+	if err != nil {
+		return ""
+	}
+	s, err := tag.StringVal()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(s, "\x00"))
+}
+
+func exifFloat(x *exif.Exif, name exif.FieldName) float64 {
+	tag, err := x.Get(name)
+	if err != nil {
+		return 0
+	}
+	num, den, err := tag.Rat2(0)
+	if err != nil || den == 0 {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
+
+// exifExposure formats the exposure time the way cameras show it:
+// "1/250" below a second, "2.5" above
+func exifExposure(x *exif.Exif) string {
+	tag, err := x.Get(exif.ExposureTime)
+	if err != nil {
+		return ""
+	}
+	num, den, err := tag.Rat2(0)
+	if err != nil || num <= 0 || den <= 0 {
+		return ""
+	}
+	if num >= den {
+		return fmt.Sprintf("%g", float64(num)/float64(den))
+	}
+	return fmt.Sprintf("1/%d", (den+num/2)/num)
+}
+
+// findEXIFBlock returns the raw EXIF block (TIFF data, optionally prefixed
+// with "Exif\x00\x00") embedded in a JPEG, PNG or WebP file
+func findEXIFBlock(data []byte) []byte {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		return findJPEGEXIF(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return findPNGChunk(data[8:], "eXIf")
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return findRIFFChunk(data[12:], "EXIF")
+	}
+	return nil
+}
+
+// findJPEGEXIF walks the JPEG marker segments up to the image data looking
+// for the APP1 segment holding EXIF (XMP also lives in APP1)
+func findJPEGEXIF(data []byte) []byte {
+	i := 2
+	for i+4 <= len(data) && data[i] == 0xFF {
+		marker := data[i+1]
+		if marker == 0xFF { // fill byte
+			i++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 { // start of scan, end of image
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil
+		}
+		payload := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return payload
+		}
+		i += 2 + length
+	}
+	return nil
+}
+
+// findPNGChunk returns the payload of the first chunk of the given type in a
+// PNG chunk stream (length, type, payload, CRC)
+func findPNGChunk(data []byte, typ string) []byte {
+	for len(data) >= 12 {
+		size := int(binary.BigEndian.Uint32(data))
+		if size > len(data)-12 {
+			return nil
+		}
+		if string(data[4:8]) == typ {
+			return data[8 : 8+size]
+		}
+		data = data[12+size:]
+	}
+	return nil
+}
+
+// findRIFFChunk returns the payload of the first chunk of the given type in a
+// RIFF chunk stream (type, little-endian length, payload padded to even size)
+func findRIFFChunk(data []byte, typ string) []byte {
+	for len(data) >= 8 {
+		size := int(binary.LittleEndian.Uint32(data[4:]))
+		if size > len(data)-8 {
+			return nil
+		}
+		if string(data[:4]) == typ {
+			return data[8 : 8+size]
+		}
+		next := 8 + size + size%2
+		if next > len(data) {
+			return nil
+		}
+		data = data[next:]
+	}
+	return nil
+}
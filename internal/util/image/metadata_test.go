@@ -0,0 +1,304 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// tiffEntry is an IFD entry; value holds the little-endian encoded data
+type tiffEntry struct {
+	tag, typ uint16
+	count    uint32
+	value    []byte
+}
+
+func asciiEntry(tag uint16, s string) tiffEntry {
+	return tiffEntry{tag, 2, uint32(len(s) + 1), append([]byte(s), 0)}
+}
+
+func rationalEntry(tag uint16, pairs ...uint32) tiffEntry {
+	v := make([]byte, 4*len(pairs))
+	for i, p := range pairs {
+		binary.LittleEndian.PutUint32(v[4*i:], p)
+	}
+	return tiffEntry{tag, 5, uint32(len(pairs) / 2), v}
+}
+
+func shortEntry(tag uint16, n uint16) tiffEntry {
+	return tiffEntry{tag, 3, 1, binary.LittleEndian.AppendUint16(nil, n)}
+}
+
+func longEntry(tag uint16, n uint32) tiffEntry {
+	return tiffEntry{tag, 4, 1, binary.LittleEndian.AppendUint32(nil, n)}
+}
+
+func ifdSize(entries []tiffEntry) int {
+	size := 2 + 12*len(entries) + 4
+	for _, e := range entries {
+		if len(e.value) > 4 {
+			size += len(e.value) + len(e.value)%2
+		}
+	}
+	return size
+}
+
+func appendIFD(buf []byte, entries []tiffEntry) []byte {
+	base := len(buf)
+	extra := base + 2 + 12*len(entries) + 4
+	var overflow []byte
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(entries)))
+	for _, e := range entries {
+		buf = binary.LittleEndian.AppendUint16(buf, e.tag)
+		buf = binary.LittleEndian.AppendUint16(buf, e.typ)
+		buf = binary.LittleEndian.AppendUint32(buf, e.count)
+		if len(e.value) <= 4 {
+			buf = append(buf, append(e.value, make([]byte, 4-len(e.value))...)...)
+			continue
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(extra+len(overflow)))
+		overflow = append(overflow, e.value...)
+		if len(e.value)%2 == 1 {
+			overflow = append(overflow, 0)
+		}
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, 0) // no next IFD
+	return append(buf, overflow...)
+}
+
+// testEXIF builds a little-endian TIFF block with camera settings and,
+// optionally, a GPS position
+func testEXIF(withGPS bool) []byte {
+	exifIFD := []tiffEntry{
+		rationalEntry(0x829A, 1, 250),             // ExposureTime
+		rationalEntry(0x829D, 18, 10),             // FNumber
+		shortEntry(0x8827, 400),                   // ISOSpeedRatings
+		asciiEntry(0x9003, "2024:05:01 09:30:15"), // DateTimeOriginal
+		rationalEntry(0x920A, 35, 1),              // FocalLength
+		asciiEntry(0xA434, "RF35mm F1.8"),         // LensModel
+	}
+	gpsIFD := []tiffEntry{
+		asciiEntry(0x0001, "N"),
+		rationalEntry(0x0002, 37, 1, 33, 1, 0, 1), // GPSLatitude
+	}
+	ifd0 := []tiffEntry{
+		asciiEntry(0x010F, "Canon"),
+		asciiEntry(0x0110, "EOS R6"),
+		longEntry(0x8769, 0), // ExifIFDPointer
+	}
+	if withGPS {
+		ifd0 = append(ifd0, longEntry(0x8825, 0)) // GPSInfoIFDPointer
+	}
+
+	exifOffset := 8 + ifdSize(ifd0)
+	ifd0[2].value = binary.LittleEndian.AppendUint32(nil, uint32(exifOffset))
+	if withGPS {
+		ifd0[3].value = binary.LittleEndian.AppendUint32(nil, uint32(exifOffset+ifdSize(exifIFD)))
+	}
+
+	buf := []byte("II*\x00\x08\x00\x00\x00")
+	buf = appendIFD(buf, ifd0)
+	buf = appendIFD(buf, exifIFD)
+	if withGPS {
+		buf = appendIFD(buf, gpsIFD)
+	}
+	return buf
+}
+
+// testJPEGWithEXIF encodes a small JPEG and inserts an APP1 EXIF segment after SOI
+func testJPEGWithEXIF(t *testing.T, tiff []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testPattern(16, 16), nil); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+// testPNGWithEXIF encodes a small PNG and inserts an eXIf chunk after IHDR
+func testPNGWithEXIF(t *testing.T, tiff []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testPattern(16, 16)); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(tiff)))
+	chunk = append(chunk, "eXIf"...)
+	chunk = append(chunk, tiff...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	data := buf.Bytes()
+	ihdrEnd := 8 + 8 + 13 + 4
+	return append(append(append([]byte{}, data[:ihdrEnd]...), chunk...), data[ihdrEnd:]...)
+}
+
+func TestReadEXIF(t *testing.T) {
+	want := EXIF{
+		CameraMake:   "Canon",
+		CameraModel:  "EOS R6",
+		LensModel:    "RF35mm F1.8",
+		FocalLength:  35,
+		FNumber:      1.8,
+		ExposureTime: "1/250",
+		ISO:          400,
+		CapturedAt:   "2024-05-01T09:30:15",
+		HasGPS:       true,
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want *EXIF
+	}{
+		{"jpeg", testJPEGWithEXIF(t, testEXIF(true)), &want},
+		{"png", testPNGWithEXIF(t, testEXIF(true)), &want},
+		{"jpeg without gps", testJPEGWithEXIF(t, testEXIF(false)), func() *EXIF { w := want; w.HasGPS = false; return &w }()},
+		{"jpeg without exif", func() []byte { var b bytes.Buffer; _ = jpeg.Encode(&b, testPattern(8, 8), nil); return b.Bytes() }(), nil},
+		{"corrupt block", testJPEGWithEXIF(t, []byte("II*\x00\xff\xff\xff\x7f")), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ReadEXIF(tt.data)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ReadEXIF() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripGIFMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	palette := color.Palette{color.Black, color.White}
+	frames := &gif.GIF{LoopCount: 0}
+	for i := 0; i < 2; i++ {
+		frames.Image = append(frames.Image, image.NewPaletted(image.Rect(0, 0, 4, 4), palette))
+		frames.Delay = append(frames.Delay, 10)
+	}
+	if err := gif.EncodeAll(&buf, frames); err != nil {
+		t.Fatalf("gif.EncodeAll: %v", err)
+	}
+	clean := buf.Bytes()
+
+	// Insert an XMP application extension and a comment after the logical
+	// screen descriptor; the encoder gives each frame a local color table
+	xmp := []byte{0x21, 0xFF, 0x0B}
+	xmp = append(xmp, "XMP DataXMP"...)
+	packet := `<x:xmpmeta><exif:GPSLatitude>37,33N</exif:GPSLatitude></x:xmpmeta>`
+	xmp = append(xmp, byte(len(packet)))
+	xmp = append(xmp, packet...)
+	xmp = append(xmp, 0x00)
+	comment := []byte{0x21, 0xFE, 0x05, 'h', 'e', 'l', 'l', 'o', 0x00}
+	headerEnd := 13
+	tagged := append(append(append(append([]byte{}, clean[:headerEnd]...), xmp...), comment...), clean[headerEnd:]...)
+	tagged = append(tagged, "trailing"...)
+
+	stripped, removed, err := StripGIFMetadata(tagged)
+	if err != nil {
+		t.Fatalf("StripGIFMetadata: %v", err)
+	}
+	if !bytes.Equal(stripped, clean) {
+		t.Error("stripped GIF differs from the original without metadata")
+	}
+	if len(removed) != 2 || removed[0] != MetadataXMP || removed[1] != MetadataComment {
+		t.Errorf("removed = %v, want [xmp comment]", removed)
+	}
+	decoded, err := gif.DecodeAll(bytes.NewReader(stripped))
+	if err != nil || len(decoded.Image) != 2 {
+		t.Errorf("stripped GIF does not decode with both frames: %v", err)
+	}
+
+	if out, removed, err := StripGIFMetadata(clean); err != nil || !bytes.Equal(out, clean) || len(removed) != 0 {
+		t.Errorf("clean GIF changed: removed %v, err %v", removed, err)
+	}
+	if _, _, err := StripGIFMetadata(testJPEGWithEXIF(t, testEXIF(true))); err != ErrInvalidGIF {
+		t.Errorf("JPEG sent as GIF: err = %v, want ErrInvalidGIF", err)
+	}
+	if _, _, err := StripGIFMetadata(clean[:len(clean)-5]); err != ErrInvalidGIF {
+		t.Errorf("truncated GIF: err = %v, want ErrInvalidGIF", err)
+	}
+}
+
+func TestStripSVGMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		removed bool
+		err     error
+	}{
+		{
+			name:    "metadata element",
+			input:   `<svg xmlns="http://www.w3.org/2000/svg"><metadata><rdf:RDF><exif:GPSLatitude>37,33N</exif:GPSLatitude></rdf:RDF></metadata><rect/></svg>`,
+			want:    `<svg xmlns="http://www.w3.org/2000/svg"><rect/></svg>`,
+			removed: true,
+		},
+		{
+			name:    "several and self-closing",
+			input:   "<?xml version=\"1.0\"?>\n<svg>\n<METADATA id=\"a\">\nx\n</METADATA>\n<metadata/>\n</svg>",
+			want:    "<?xml version=\"1.0\"?>\n<svg>\n\n\n</svg>",
+			removed: true,
+		},
+		{
+			name:    "prefixed metadata element",
+			input:   `<svg:svg xmlns:svg="http://www.w3.org/2000/svg"><svg:metadata><rdf:RDF/></svg:metadata><svg:rect/></svg:svg>`,
+			want:    `<svg:svg xmlns:svg="http://www.w3.org/2000/svg"><svg:rect/></svg:svg>`,
+			removed: true,
+		},
+		{
+			name:    "bare rdf and xmp outside metadata",
+			input:   `<svg xmlns="http://www.w3.org/2000/svg"><g><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><exif:GPSLatitude>37,33N</exif:GPSLatitude></rdf:RDF></g><?xpacket begin=""?><x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF/></x:xmpmeta><?xpacket end="w"?></svg>`,
+			want:    `<svg xmlns="http://www.w3.org/2000/svg"><g></g></svg>`,
+			removed: true,
+		},
+		{
+			name:  "no metadata",
+			input: `<svg><circle r="1"/></svg>`,
+			want:  `<svg><circle r="1"/></svg>`,
+		},
+		{
+			name:  "binary sent as svg",
+			input: "\xFF\xD8\xFF\xE1<svg>",
+			err:   ErrInvalidSVG,
+		},
+		{
+			name:  "not svg",
+			input: `<html></html>`,
+			err:   ErrInvalidSVG,
+		},
+		{
+			name:  "unclosed metadata",
+			input: `<svg><metadata><rdf:RDF></svg>`,
+			err:   ErrInvalidSVG,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, removed, err := StripSVGMetadata([]byte(tt.input))
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("StripSVGMetadata() = %q, want %q", got, tt.want)
+			}
+			if (len(removed) > 0) != tt.removed {
+				t.Errorf("removed = %v", removed)
+			}
+		})
+	}
+}
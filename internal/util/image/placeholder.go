@@ -0,0 +1,89 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	"github.com/disintegration/imaging"
+)
+
+// Placeholder settings
+const (
+	blurHashSampleSize = 32 // longer side of the image the BlurHash is computed from
+	lqipSize           = 16 // longer side of the inline preview
+	lqipQuality        = 40
+)
+
+// Placeholder holds the lightweight previews shown while an image loads
+type Placeholder struct {
+	BlurHash      string // https://blurha.sh
+	LQIP          string // tiny JPEG as a data: URI
+	DominantColor string // #rrggbb
+}
+
+// GeneratePlaceholder computes the BlurHash, inline preview and dominant color of an image
+func (p *Processor) GeneratePlaceholder(img image.Image) (*Placeholder, error) {
+	sample := imaging.Fit(img, blurHashSampleSize, blurHashSampleSize, imaging.Box)
+
+	lqip, err := encodeLQIP(img)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Placeholder{
+		BlurHash:      EncodeBlurHash(sample),
+		LQIP:          lqip,
+		DominantColor: DominantColor(sample),
+	}, nil
+}
+
+// encodeLQIP downscales the image to lqipSize and returns it as a base64 JPEG data URI
+func encodeLQIP(img image.Image) (string, error) {
+	small := imaging.Fit(img, lqipSize, lqipSize, imaging.Box)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, small, &jpeg.Options{Quality: lqipQuality}); err != nil {
+		return "", err
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DominantColor returns the most common color of an image as "#rrggbb".
+// Colors are grouped into 16 levels per channel and the winning group's
+// pixels are averaged, so noise and gradients don't split the vote.
+func DominantColor(img image.Image) string {
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	buckets := make(map[int]*bucket)
+	var best *bucket
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			r, g, b = r>>8, g>>8, b>>8
+			key := int(r>>4)<<8 | int(g>>4)<<4 | int(b>>4)
+			bk, ok := buckets[key]
+			if !ok {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.count++
+			bk.r += int(r)
+			bk.g += int(g)
+			bk.b += int(b)
+			if best == nil || bk.count > best.count {
+				best = bk
+			}
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.count, best.g/best.count, best.b/best.count)
+}
@@ -0,0 +1,121 @@
+package image
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"strings"
+	"testing"
+)
+
+func solid(width, height int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func decodeBase83(s string) int {
+	value := 0
+	for _, c := range s {
+		value = value*83 + strings.IndexRune(base83Chars, c)
+	}
+	return value
+}
+
+func TestEncodeBlurHash(t *testing.T) {
+	t.Run("solid color", func(t *testing.T) {
+		hash := EncodeBlurHash(solid(8, 6, color.NRGBA{R: 255, A: 255}))
+		// Size flag "L" is 4x3 components; the DC term is the average color
+		if hash[0] != 'L' {
+			t.Errorf("size flag = %c, want L", hash[0])
+		}
+		if dc := decodeBase83(hash[2:6]); dc != 0xff0000 {
+			t.Errorf("DC = %06x, want ff0000 in %q", dc, hash)
+		}
+	})
+
+	t.Run("horizontal gradient", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 32, 16))
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 32; x++ {
+				img.SetGray(x, y, color.Gray{Y: uint8(x * 8)})
+			}
+		}
+		hash := EncodeBlurHash(img)
+		if len(hash) != 6+2*(blurHashComponentsX*blurHashComponentsY-1) {
+			t.Fatalf("hash %q has length %d", hash, len(hash))
+		}
+
+		// AC terms follow the DC in row-major order, quantised around 9;
+		// the first horizontal cosine must carry more of the gradient than
+		// the first vertical one
+		red := func(i int) int {
+			q := decodeBase83(hash[6+2*(i-1):8+2*(i-1)]) / (19 * 19)
+			if q < 9 {
+				return 9 - q
+			}
+			return q - 9
+		}
+		if h, v := red(1), red(blurHashComponentsX); h <= v {
+			t.Errorf("horizontal component %d not stronger than vertical %d in %q", h, v, hash)
+		}
+	})
+
+	t.Run("empty image", func(t *testing.T) {
+		if got := EncodeBlurHash(image.NewGray(image.Rect(0, 0, 0, 0))); got != "" {
+			t.Errorf("EncodeBlurHash() = %q, want empty", got)
+		}
+	})
+}
+
+func TestDominantColor(t *testing.T) {
+	img := solid(10, 10, color.NRGBA{R: 20, G: 40, B: 200, A: 255})
+	// A smaller patch of red and slightly varied blues that share a bucket
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 10; x++ {
+			img.Set(x, y, color.NRGBA{R: 240, G: 10, B: 10, A: 255})
+		}
+	}
+	img.Set(9, 9, color.NRGBA{R: 22, G: 42, B: 202, A: 255})
+
+	if got := DominantColor(img); got != "#1428c8" {
+		t.Errorf("DominantColor() = %s, want #1428c8", got)
+	}
+}
+
+func TestProcessor_GeneratePlaceholder(t *testing.T) {
+	p := NewProcessor(85)
+	ph, err := p.GeneratePlaceholder(testPattern(400, 200))
+	if err != nil {
+		t.Fatalf("GeneratePlaceholder: %v", err)
+	}
+
+	if len(ph.BlurHash) != 28 {
+		t.Errorf("BlurHash = %q", ph.BlurHash)
+	}
+	if len(ph.DominantColor) != 7 || ph.DominantColor[0] != '#' {
+		t.Errorf("DominantColor = %q", ph.DominantColor)
+	}
+
+	data, ok := strings.CutPrefix(ph.LQIP, "data:image/jpeg;base64,")
+	if !ok {
+		t.Fatalf("LQIP = %.40q..., want a JPEG data URI", ph.LQIP)
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("LQIP is not base64: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("LQIP is not a JPEG: %v", err)
+	}
+	if cfg.Width != lqipSize || cfg.Height != lqipSize/2 {
+		t.Errorf("LQIP size = %dx%d, want %dx%d", cfg.Width, cfg.Height, lqipSize, lqipSize/2)
+	}
+}
@@ -2,6 +2,7 @@ package image
 
 import (
 	"bytes"
+	"errors"
 	"image"
 	"image/jpeg"
 	"io"
@@ -27,9 +28,10 @@ func NewProcessor(quality int) *Processor {
 
 // ProcessResult contains the processed image data and metadata
 type ProcessResult struct {
-	Data   []byte
-	Width  int
-	Height int
+	Data     []byte // JPEG
+	WebPData []byte // Lossy WebP rendition, when requested
+	Width    int
+	Height   int
 }
 
 // DecodeImage decodes an image from a reader
@@ -60,6 +62,17 @@ func (p *Processor) ResizeToWidth(img image.Image, maxWidth int) image.Image {
 	return imaging.Resize(img, maxWidth, 0, imaging.Lanczos)
 }
 
+// FitWithin downscales an image so neither side exceeds maxDimension while maintaining aspect ratio
+// If the image already fits, it returns the original image unchanged
+func (p *Processor) FitWithin(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= maxDimension && bounds.Dy() <= maxDimension {
+		return img
+	}
+
+	return imaging.Fit(img, maxDimension, maxDimension, imaging.Lanczos)
+}
+
 // EncodeToJPEG encodes an image to JPEG format with the processor's quality setting
 func (p *Processor) EncodeToJPEG(img image.Image) ([]byte, error) {
 	var buf bytes.Buffer
@@ -98,7 +111,7 @@ func (p *Processor) ProcessImage(r io.Reader, maxWidth int) (*ProcessResult, err
 	}, nil
 }
 
-// GenerateThumbnails generates thumbnails at specified widths
+// GenerateThumbnails generates JPEG and WebP thumbnails at specified widths
 // Returns a map of suffix -> ProcessResult
 func (p *Processor) GenerateThumbnails(img image.Image, sizes map[string]int) (map[string]*ProcessResult, error) {
 	results := make(map[string]*ProcessResult)
@@ -112,10 +125,17 @@ func (p *Processor) GenerateThumbnails(img image.Image, sizes map[string]int) (m
 			return nil, err
 		}
 
+		// Skip the WebP rendition of extreme aspect ratios VP8 can't describe
+		webpData, err := p.EncodeToWebP(resized)
+		if err != nil && !errors.Is(err, ErrWebPTooLarge) {
+			return nil, err
+		}
+
 		results[suffix] = &ProcessResult{
-			Data:   data,
-			Width:  width,
-			Height: height,
+			Data:     data,
+			WebPData: webpData,
+			Width:    width,
+			Height:   height,
 		}
 	}
 
@@ -0,0 +1,190 @@
+package image
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidGIF and ErrInvalidSVG are returned when a file stored as-is
+// doesn't have the structure its MIME type promises, so its metadata
+// can't be stripped reliably
+var (
+	ErrInvalidGIF = errors.New("invalid gif")
+	ErrInvalidSVG = errors.New("invalid svg")
+)
+
+// StripGIFMetadata removes XMP application extensions and comment extensions
+// from a GIF, along with anything after the trailer. Frames, palettes and
+// other extensions (animation loop count, frame timing) are copied unchanged.
+// It returns the kinds of metadata that were removed.
+func StripGIFMetadata(data []byte) ([]byte, []string, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, nil, ErrInvalidGIF
+	}
+
+	// Header and logical screen descriptor, followed by the global color table
+	i := 13
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+	if i > len(data) {
+		return nil, nil, ErrInvalidGIF
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+	var removed []string
+	for {
+		if i >= len(data) {
+			return nil, nil, ErrInvalidGIF
+		}
+		start := i
+		switch data[i] {
+		case 0x3B: // trailer
+			out = append(out, 0x3B)
+			return out, removed, nil
+
+		case 0x21: // extension
+			if i+2 > len(data) {
+				return nil, nil, ErrInvalidGIF
+			}
+			label := data[i+1]
+			end, ok := skipSubBlocks(data, i+2)
+			if !ok {
+				return nil, nil, ErrInvalidGIF
+			}
+			i = end
+			switch {
+			case label == 0xFE:
+				removed = appendKind(removed, MetadataComment)
+				continue
+			case label == 0xFF && bytes.HasPrefix(data[start+2:end], []byte("\x0bXMP DataXMP")):
+				removed = appendKind(removed, MetadataXMP)
+				continue
+			}
+
+		case 0x2C: // image descriptor, optional local color table, LZW data
+			if i+10 > len(data) {
+				return nil, nil, ErrInvalidGIF
+			}
+			i += 10
+			if data[i-1]&0x80 != 0 {
+				i += 3 << (data[i-1]&0x07 + 1)
+			}
+			i++ // LZW minimum code size
+			end, ok := skipSubBlocks(data, i)
+			if !ok {
+				return nil, nil, ErrInvalidGIF
+			}
+			i = end
+
+		default:
+			return nil, nil, ErrInvalidGIF
+		}
+		out = append(out, data[start:i]...)
+	}
+}
+
+// skipSubBlocks returns the offset just past the data sub-blocks starting at i
+func skipSubBlocks(data []byte, i int) (int, bool) {
+	for i < len(data) {
+		size := int(data[i])
+		i += 1 + size
+		if size == 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// svgMetadataElements are the local names of elements that hold RDF and XMP
+// metadata. They are matched under any namespace prefix (svg:metadata,
+// rdf:RDF, x:xmpmeta) and wherever they appear in the document.
+var svgMetadataElements = map[string]string{
+	"metadata": MetadataSVGBlock,
+	"rdf":      MetadataXMP,
+	"xmpmeta":  MetadataXMP,
+}
+
+// StripSVGMetadata removes <metadata> elements, where editors keep RDF and
+// XMP (including any location), along with RDF and XMP elements and xpacket
+// processing instructions found elsewhere in an SVG document. Everything else
+// is copied byte for byte. The file must be well-formed UTF-8 XML containing
+// an <svg> element, which also rules out binary files sent with an SVG
+// content type. It returns the kinds of metadata that were removed.
+func StripSVGMetadata(data []byte) ([]byte, []string, error) {
+	text := bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	if !utf8.Valid(text) || !bytes.HasPrefix(bytes.TrimSpace(text), []byte("<")) ||
+		!bytes.Contains(bytes.ToLower(text), []byte("<svg")) {
+		return nil, nil, ErrInvalidSVG
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(text))
+	// Entities declared in a DOCTYPE (common in Illustrator exports) are left as is
+	dec.Strict = false
+	// The bytes were checked to be UTF-8 whatever the declaration says
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:len(data)-len(text)]...)
+	var removed []string
+	copied := int64(0) // text up to here has been copied or dropped
+	depth := 0         // nesting inside a dropped element
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, ErrInvalidSVG
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth > 0 {
+				depth++
+				continue
+			}
+			if kind, ok := svgMetadataElements[strings.ToLower(t.Name.Local)]; ok {
+				out = append(out, text[copied:start]...)
+				removed = appendKind(removed, kind)
+				depth = 1
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				continue
+			}
+			if depth--; depth == 0 {
+				copied = dec.InputOffset()
+			}
+		case xml.ProcInst:
+			if depth == 0 && t.Target == "xpacket" {
+				out = append(out, text[copied:start]...)
+				removed = appendKind(removed, MetadataXMP)
+				copied = dec.InputOffset()
+			}
+		}
+	}
+	if depth > 0 {
+		return nil, nil, ErrInvalidSVG
+	}
+
+	if len(removed) == 0 {
+		return data, nil, nil
+	}
+	return append(out, text[copied:]...), removed, nil
+}
+
+func appendKind(kinds []string, kind string) []string {
+	for _, k := range kinds {
+		if k == kind {
+			return kinds
+		}
+	}
+	return append(kinds, kind)
+}
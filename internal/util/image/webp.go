@@ -0,0 +1,30 @@
+package image
+
+import (
+	"bytes"
+	"errors"
+	"image"
+
+	"github.com/gen2brain/webp"
+)
+
+// MaxWebPDimension is the largest width or height a WebP image can have
+const MaxWebPDimension = 16383
+
+// ErrWebPTooLarge is returned when an image exceeds MaxWebPDimension
+var ErrWebPTooLarge = errors.New("image too large for WebP")
+
+// EncodeToWebP encodes an image to lossy WebP with the processor's quality setting.
+// Encoding runs libwebp compiled to WebAssembly, so it needs no cgo.
+func (p *Processor) EncodeToWebP(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	if bounds.Dx() > MaxWebPDimension || bounds.Dy() > MaxWebPDimension {
+		return nil, ErrWebPTooLarge
+	}
+
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, webp.Options{Quality: p.quality, Method: webp.DefaultMethod}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
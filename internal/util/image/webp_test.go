@@ -0,0 +1,98 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func testPattern(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{
+				R: uint8(x * 255 / width),
+				G: uint8(y * 255 / height),
+				B: uint8(128 + 100*math.Sin(float64(x+y)/7)),
+				A: 255,
+			}
+			// Hard edges exercise large coefficients and every prediction mode
+			if (x/24+y/24)%5 == 0 {
+				c = color.NRGBA{R: 250, G: 20, B: 20, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// lumaPSNR compares the luma of src with the Y plane of a decoded lossy WebP;
+// chroma is subsampled by the format, so it is left out. libwebp stores
+// limited-range BT.601 luma, which color.RGBToYCbCr does not produce
+func lumaPSNR(src *image.NRGBA, decoded *image.YCbCr) float64 {
+	var sse float64
+	bounds := src.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := src.NRGBAAt(x, y)
+			want := (16839*int(c.R) + 33059*int(c.G) + 6420*int(c.B) + (16 << 16) + (1 << 15)) >> 16
+			d := float64(want) - float64(decoded.Y[decoded.YOffset(x, y)])
+			sse += d * d
+		}
+	}
+	if sse == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(255*255*float64(bounds.Dx()*bounds.Dy())/sse)
+}
+
+func TestProcessor_EncodeToWebP(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		quality       int
+		minPSNR       float64
+	}{
+		{"macroblock aligned", 64, 48, 85, 42},
+		{"odd size", 37, 21, 85, 42},
+		{"single pixel", 1, 1, 85, 0},
+		{"high quality", 80, 80, 100, 48},
+		{"low quality", 80, 80, 1, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := testPattern(tt.width, tt.height)
+
+			data, err := NewProcessor(tt.quality).EncodeToWebP(src)
+			if err != nil {
+				t.Fatalf("EncodeToWebP: %v", err)
+			}
+			if !bytes.HasPrefix(data, []byte("RIFF")) || string(data[8:16]) != "WEBPVP8 " {
+				t.Fatalf("expected a lossy WebP, got header %q", data[:16])
+			}
+
+			decoded, err := webp.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("webp.Decode: %v", err)
+			}
+			if got := decoded.Bounds(); got.Dx() != tt.width || got.Dy() != tt.height {
+				t.Fatalf("decoded size = %dx%d, want %dx%d", got.Dx(), got.Dy(), tt.width, tt.height)
+			}
+			if got := lumaPSNR(src, decoded.(*image.YCbCr)); got < tt.minPSNR {
+				t.Errorf("luma PSNR = %.1f dB, want >= %.1f", got, tt.minPSNR)
+			}
+		})
+	}
+}
+
+func TestProcessor_EncodeToWebP_TooLarge(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, MaxWebPDimension+1, 1))
+	if _, err := NewProcessor(85).EncodeToWebP(img); err != ErrWebPTooLarge {
+		t.Fatalf("err = %v, want ErrWebPTooLarge", err)
+	}
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+// absoluteURLRegex matches http(s) URLs up to whitespace, quotes, angle
+// brackets and the parentheses/brackets Markdown puts around links
+var absoluteURLRegex = regexp.MustCompile(`https?://[^\s"'<>()\[\]]+`)
+
+// ExtractURLs returns the absolute URLs in Markdown or HTML content, in order
+// of first appearance and without duplicates. Punctuation that ends a
+// sentence after a bare URL is not part of it.
+func ExtractURLs(content string) []string {
+	matches := absoluteURLRegex.FindAllString(content, -1)
+	urls := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, u := range matches {
+		u = strings.TrimRight(u, ".,;:!?")
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "Markdown image and link",
+			input:    "![cover](https://cdn.example.com/blog/a.jpg \"Cover\") see [docs](http://example.com/docs)",
+			expected: []string{"https://cdn.example.com/blog/a.jpg", "http://example.com/docs"},
+		},
+		{
+			name:     "HTML img with srcset",
+			input:    `<img src='https://cdn.example.com/blog/a.jpg' srcset="https://cdn.example.com/blog/a_sm.jpg 150w, https://cdn.example.com/blog/a_md.jpg 400w">`,
+			expected: []string{"https://cdn.example.com/blog/a.jpg", "https://cdn.example.com/blog/a_sm.jpg", "https://cdn.example.com/blog/a_md.jpg"},
+		},
+		{
+			name:     "Bare URL ending a sentence",
+			input:    "Photo: https://cdn.example.com/blog/b.png. Again https://cdn.example.com/blog/b.png!",
+			expected: []string{"https://cdn.example.com/blog/b.png"},
+		},
+		{
+			name:     "No URLs",
+			input:    "/blog/a.jpg and ftp://example.com/file",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExtractURLs(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExtractURLs() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
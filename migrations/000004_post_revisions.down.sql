@@ -0,0 +1,2 @@
+-- Rollback post revision history
+DROP TABLE IF EXISTS post_revisions;
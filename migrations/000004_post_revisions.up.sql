@@ -0,0 +1,14 @@
+-- Post revision history
+-- 글 수정 시 이전 버전을 스냅샷으로 보관
+
+CREATE TABLE post_revisions (
+    id          SERIAL PRIMARY KEY,
+    post_id     INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    title       VARCHAR(200) NOT NULL,
+    content     TEXT NOT NULL,
+    excerpt     VARCHAR(500),
+    tags        JSONB,
+    created_at  TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_post_revisions_post ON post_revisions(post_id, id DESC);
@@ -0,0 +1,4 @@
+-- 예약 상태는 이전 버전에서 인식하지 못하므로 초안으로 되돌림
+UPDATE posts SET status = 'draft' WHERE status = 'scheduled';
+
+DROP INDEX IF EXISTS idx_posts_scheduled;
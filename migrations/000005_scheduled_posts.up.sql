@@ -0,0 +1,3 @@
+-- 예약 발행 대기 중인 글 조회용 인덱스
+-- Partial index for the scheduled publisher's due-post lookup
+CREATE INDEX IF NOT EXISTS idx_posts_scheduled ON posts(published_at) WHERE status = 'scheduled';
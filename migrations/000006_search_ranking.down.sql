@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_posts_title_bigm;
+DROP INDEX IF EXISTS idx_posts_content_bigm;
+
+CREATE INDEX IF NOT EXISTS idx_posts_title_bigm
+ON posts USING gin (title gin_bigm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_posts_content_bigm
+ON posts USING gin (content gin_bigm_ops);
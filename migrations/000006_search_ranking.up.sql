@@ -0,0 +1,10 @@
+-- 대소문자 구분 없는 검색을 위해 lower() 표현식 인덱스로 교체
+-- pg_bigm only accelerates LIKE, so ILIKE never hit the 000002 indexes
+DROP INDEX IF EXISTS idx_posts_title_bigm;
+DROP INDEX IF EXISTS idx_posts_content_bigm;
+
+CREATE INDEX IF NOT EXISTS idx_posts_title_bigm
+ON posts USING gin (lower(title) gin_bigm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_posts_content_bigm
+ON posts USING gin (lower(content) gin_bigm_ops);
@@ -0,0 +1,2 @@
+-- Rollback comments
+DROP TABLE IF EXISTS comments;
@@ -0,0 +1,22 @@
+-- Comments
+-- 글 댓글 (대댓글 지원, 관리자 승인 후 노출)
+
+CREATE TABLE comments (
+    id            SERIAL PRIMARY KEY,
+    post_id       INT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    parent_id     INT REFERENCES comments(id) ON DELETE CASCADE,
+    author_name   VARCHAR(50) NOT NULL,
+    author_email  VARCHAR(255),
+    github_handle VARCHAR(39),
+    content       TEXT NOT NULL,
+    status        VARCHAR(20) NOT NULL DEFAULT 'pending',
+    ip_hash       VARCHAR(64),
+    created_at    TIMESTAMPTZ DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 글별 댓글 목록 / 댓글 수
+CREATE INDEX idx_comments_post ON comments(post_id, status);
+
+-- 관리자 모더레이션 큐
+CREATE INDEX idx_comments_status ON comments(status, created_at DESC);
@@ -0,0 +1,6 @@
+-- Rollback comment spam filtering
+DROP TABLE IF EXISTS spam_classes;
+DROP TABLE IF EXISTS spam_tokens;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS spam_trained_as;
+ALTER TABLE comments DROP COLUMN IF EXISTS spam_score;
@@ -0,0 +1,22 @@
+-- Comment spam filtering
+-- 댓글 스팸 점수 및 나이브 베이즈 학습 데이터
+
+ALTER TABLE comments ADD COLUMN spam_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- 관리자 판정으로 학습된 클래스 (spam, ham). 판정이 바뀌면 이전 학습을 되돌린다
+ALTER TABLE comments ADD COLUMN spam_trained_as VARCHAR(10);
+
+-- 토큰별로 해당 토큰을 포함한 학습 문서 수
+CREATE TABLE spam_tokens (
+    token      VARCHAR(100) PRIMARY KEY,
+    spam_count INT NOT NULL DEFAULT 0,
+    ham_count  INT NOT NULL DEFAULT 0
+);
+
+-- 클래스별 학습 문서 수
+CREATE TABLE spam_classes (
+    label     VARCHAR(10) PRIMARY KEY,
+    documents INT NOT NULL DEFAULT 0
+);
+
+INSERT INTO spam_classes (label) VALUES ('spam'), ('ham');
@@ -0,0 +1,8 @@
+-- Rollback admin roles and post ownership
+DROP INDEX IF EXISTS idx_posts_author_id;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS author_id;
+
+ALTER TABLE admins ALTER COLUMN password DROP DEFAULT;
+ALTER TABLE admins DROP COLUMN IF EXISTS disabled_at;
+ALTER TABLE admins DROP COLUMN IF EXISTS role;
@@ -0,0 +1,18 @@
+-- Admin roles and post ownership
+-- 관리자 역할(owner, editor, author, viewer)과 글 작성자
+
+-- 기존 관리자는 모든 권한을 가진 owner로 유지한다
+ALTER TABLE admins ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'owner';
+ALTER TABLE admins ALTER COLUMN role SET DEFAULT 'viewer';
+
+-- 비활성화된 관리자는 로그인과 토큰 갱신이 거부된다
+ALTER TABLE admins ADD COLUMN disabled_at TIMESTAMPTZ;
+
+-- 초대 후 비밀번호를 설정하기 전까지 password는 빈 문자열이다
+ALTER TABLE admins ALTER COLUMN password SET DEFAULT '';
+
+-- author 역할은 자신이 작성한 글만 수정할 수 있다
+-- 작성자 정보가 없는 기존 글은 owner와 editor만 수정할 수 있다
+ALTER TABLE posts ADD COLUMN author_id INT REFERENCES admins(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_author_id ON posts(author_id);
@@ -0,0 +1,6 @@
+-- Rollback TOTP two-factor authentication
+DROP TABLE IF EXISTS admin_recovery_codes;
+
+ALTER TABLE admins DROP COLUMN IF EXISTS totp_last_step;
+ALTER TABLE admins DROP COLUMN IF EXISTS totp_enabled_at;
+ALTER TABLE admins DROP COLUMN IF EXISTS totp_secret;
@@ -0,0 +1,19 @@
+-- TOTP two-factor authentication for admins
+-- 관리자 TOTP 2단계 인증
+
+-- 등록을 시작하면 totp_secret이 저장되고, 코드 확인으로 활성화하면 totp_enabled_at이 설정된다
+-- totp_secret은 AES-256-GCM으로 암호화한 값(base64)이다
+ALTER TABLE admins ADD COLUMN totp_secret TEXT;
+ALTER TABLE admins ADD COLUMN totp_enabled_at TIMESTAMPTZ;
+-- 마지막으로 받아들인 코드의 시간 단계, 같은 코드를 다시 쓰지 못하게 한다
+ALTER TABLE admins ADD COLUMN totp_last_step BIGINT;
+
+-- 복구 코드는 SHA-256 해시로만 저장하며 한 번 사용하면 used_at이 설정된다
+CREATE TABLE IF NOT EXISTS admin_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    admin_id INT NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (admin_id, code_hash)
+);
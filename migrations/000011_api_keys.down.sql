@@ -0,0 +1,3 @@
+-- Rollback scoped API keys
+DROP INDEX IF EXISTS idx_api_keys_admin_id;
+DROP TABLE IF EXISTS api_keys;
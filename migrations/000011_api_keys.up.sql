@@ -0,0 +1,20 @@
+-- Scoped API keys for automation clients
+-- CI 등 자동화 클라이언트용 API 키
+
+-- 키 원문은 발급할 때 한 번만 보여주고 SHA-256 해시만 저장한다
+-- prefix는 키 앞부분으로, 목록에서 키를 구분하는 데 사용한다
+-- scopes는 키로 허용할 권한이며 발급한 관리자의 역할 권한을 넘지 않는다
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    admin_id INT NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_admin_id ON api_keys(admin_id);
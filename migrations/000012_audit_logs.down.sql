@@ -0,0 +1,5 @@
+-- Rollback admin audit log
+DROP INDEX IF EXISTS idx_audit_logs_target;
+DROP INDEX IF EXISTS idx_audit_logs_admin_id;
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP TABLE IF EXISTS audit_logs;
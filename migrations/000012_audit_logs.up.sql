@@ -0,0 +1,24 @@
+-- Admin audit log
+-- 관리자 작업 감사 로그
+
+-- 관리자나 API 키가 삭제되어도 기록은 남도록 ON DELETE SET NULL, 사용자명은 기록 시점 값을 복사한다
+-- target_id는 글 ID, 로그인 사용자명 등 대상에 따라 형식이 달라 문자열로 저장한다
+-- before/after에는 변경 전후의 주요 필드 요약을 저장한다
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    admin_id INT REFERENCES admins(id) ON DELETE SET NULL,
+    username VARCHAR(50) NOT NULL DEFAULT '',
+    api_key_id INT REFERENCES api_keys(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(30) NOT NULL DEFAULT '',
+    target_id VARCHAR(100) NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    ip VARCHAR(45) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_admin_id ON audit_logs(admin_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_target ON audit_logs(target_type, target_id);
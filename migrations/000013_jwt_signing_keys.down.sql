@@ -0,0 +1,3 @@
+-- Rollback asymmetric JWT signing keys
+DROP INDEX IF EXISTS idx_jwt_signing_keys_created_at;
+DROP TABLE IF EXISTS jwt_signing_keys;
@@ -0,0 +1,15 @@
+-- Asymmetric JWT signing keys with rotation
+-- RS256/EdDSA 액세스 토큰 서명 키
+
+-- kid는 토큰 헤더와 JWKS에서 키를 구분하는 값이다
+-- private_key는 PKCS#8 PEM이며, 가장 최근의 활성 키로만 서명한다
+-- retired_at 이후에는 서명에 쓰지 않고, 발급된 토큰이 만료될 때까지 검증에만 쓴다
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retired_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_jwt_signing_keys_created_at ON jwt_signing_keys(created_at DESC);
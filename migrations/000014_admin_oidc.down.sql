@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_admins_oidc_subject;
+DROP INDEX IF EXISTS idx_admins_email;
+
+ALTER TABLE admins DROP COLUMN IF EXISTS oidc_subject;
+ALTER TABLE admins DROP COLUMN IF EXISTS email;
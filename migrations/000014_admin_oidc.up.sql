@@ -0,0 +1,10 @@
+-- OpenID Connect login for admins
+-- 외부 IdP 계정과 관리자 계정 연결
+
+-- email은 검증된 IdP 이메일로 관리자를 찾을 때 쓴다 (대소문자 무시)
+-- oidc_subject는 IdP의 sub 클레임이며, 첫 로그인 시 연결된 뒤에는 이 값으로만 찾는다
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS email VARCHAR(255);
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS oidc_subject VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admins_email ON admins(LOWER(email)) WHERE email IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_admins_oidc_subject ON admins(oidc_subject) WHERE oidc_subject IS NOT NULL;
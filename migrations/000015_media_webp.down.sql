@@ -0,0 +1,4 @@
+-- Remove WebP variant columns from media table
+ALTER TABLE media DROP COLUMN IF EXISTS webp_url;
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_sm_webp;
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_md_webp;
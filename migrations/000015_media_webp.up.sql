@@ -0,0 +1,5 @@
+-- WebP variants of processed images
+-- JPEG 원본/썸네일과 함께 저장되는 WebP 파일의 URL (<picture> 소스용)
+ALTER TABLE media ADD COLUMN IF NOT EXISTS webp_url VARCHAR(500);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnail_sm_webp VARCHAR(500);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnail_md_webp VARCHAR(500);
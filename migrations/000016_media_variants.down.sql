@@ -0,0 +1,15 @@
+-- Restore fixed thumbnail columns from media_variants
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnail_sm VARCHAR(500);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnail_md VARCHAR(500);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS webp_url VARCHAR(500);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnail_sm_webp VARCHAR(500);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS thumbnail_md_webp VARCHAR(500);
+
+UPDATE media m SET
+    thumbnail_sm = (SELECT url FROM media_variants v WHERE v.media_id = m.id AND v.name = 'sm' AND v.mime_type = 'image/jpeg'),
+    thumbnail_md = (SELECT url FROM media_variants v WHERE v.media_id = m.id AND v.name = 'md' AND v.mime_type = 'image/jpeg'),
+    webp_url = (SELECT url FROM media_variants v WHERE v.media_id = m.id AND v.name = 'original' AND v.mime_type = 'image/webp'),
+    thumbnail_sm_webp = (SELECT url FROM media_variants v WHERE v.media_id = m.id AND v.name = 'sm' AND v.mime_type = 'image/webp'),
+    thumbnail_md_webp = (SELECT url FROM media_variants v WHERE v.media_id = m.id AND v.name = 'md' AND v.mime_type = 'image/webp');
+
+DROP TABLE IF EXISTS media_variants;
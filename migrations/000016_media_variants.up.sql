@@ -0,0 +1,42 @@
+-- Responsive image variants
+-- 설정된 이름/너비(MEDIA_VARIANTS)별 리사이즈 결과와 포맷별(WebP, JPEG) 변환본을 행 단위로 저장
+-- name = 'original'은 원본 크기의 다른 포맷(WebP) 변환본이다
+
+CREATE TABLE IF NOT EXISTS media_variants (
+    id SERIAL PRIMARY KEY,
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    mime_type VARCHAR(100) NOT NULL,
+    path VARCHAR(500) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    width INT NOT NULL DEFAULT 0,
+    height INT NOT NULL DEFAULT 0,
+    size BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (media_id, name, mime_type)
+);
+
+-- 기존 고정 컬럼(thumbnail_sm/md, WebP URL)을 variant 행으로 옮긴다
+-- 변환본은 원본과 같은 디렉터리에 있으므로 path는 원본 path의 디렉터리 + URL의 파일 이름이다
+-- (MINIO_PUBLIC_URL에 경로가 붙어 있을 수 있어 URL에서 호스트/버킷을 떼어 내는 방식은 쓰지 않는다)
+-- 크기는 당시 고정 너비(150/400px)로 계산한다
+INSERT INTO media_variants (media_id, name, mime_type, path, url, width, height)
+SELECT id, v.name, v.mime_type, regexp_replace(path, '[^/]*$', '') || regexp_replace(v.url, '^.*/', ''), v.url,
+       LEAST(COALESCE(width, 0), v.max_width),
+       CASE WHEN COALESCE(width, 0) > 0 THEN ROUND(height * LEAST(width, v.max_width)::numeric / width)::int ELSE 0 END
+FROM media,
+LATERAL (VALUES
+    ('original', 'image/webp', webp_url, 2147483647),
+    ('sm', 'image/jpeg', thumbnail_sm, 150),
+    ('sm', 'image/webp', thumbnail_sm_webp, 150),
+    ('md', 'image/jpeg', thumbnail_md, 400),
+    ('md', 'image/webp', thumbnail_md_webp, 400)
+) AS v(name, mime_type, url, max_width)
+WHERE v.url IS NOT NULL AND v.url <> ''
+ON CONFLICT (media_id, name, mime_type) DO NOTHING;
+
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_sm;
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_md;
+ALTER TABLE media DROP COLUMN IF EXISTS webp_url;
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_sm_webp;
+ALTER TABLE media DROP COLUMN IF EXISTS thumbnail_md_webp;
@@ -0,0 +1,3 @@
+ALTER TABLE media DROP COLUMN IF EXISTS dominant_color;
+ALTER TABLE media DROP COLUMN IF EXISTS lqip;
+ALTER TABLE media DROP COLUMN IF EXISTS blurhash;
@@ -0,0 +1,9 @@
+-- Image placeholders
+-- 이미지가 로드되기 전에 보여줄 미리보기 값 (처리된 이미지만, GIF/SVG는 NULL)
+
+-- blurhash는 https://blurha.sh 문자열 (4x3 성분)
+-- lqip는 16px 이하로 줄인 JPEG의 data: URI
+-- dominant_color는 가장 많이 쓰인 색 (#rrggbb)
+ALTER TABLE media ADD COLUMN IF NOT EXISTS blurhash VARCHAR(100);
+ALTER TABLE media ADD COLUMN IF NOT EXISTS lqip TEXT;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS dominant_color VARCHAR(7);
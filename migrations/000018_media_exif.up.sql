@@ -0,0 +1,4 @@
+-- Photo EXIF metadata
+-- 업로드 시 읽은 카메라 정보 (제조사, 모델, 렌즈, 노출, 촬영 시각)
+-- GPS 위치 등 민감한 값은 저장하지 않으며 저장되는 파일에서도 제거된다
+ALTER TABLE media ADD COLUMN IF NOT EXISTS exif JSONB;
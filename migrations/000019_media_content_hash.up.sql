@@ -0,0 +1,6 @@
+-- Content-hash deduplication
+-- 업로드된 원본 바이트의 SHA-256 (hex). 같은 파일을 다시 올리면 기존 미디어를 돌려준다
+-- 이 컬럼이 생기기 전에 올라온 파일은 NULL이며 중복 검사 대상이 아니다
+ALTER TABLE media ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_content_hash ON media(content_hash);
@@ -0,0 +1,31 @@
+-- Media usage tracking
+-- 게시글 본문·썸네일, 프로젝트 썸네일·이미지에서 참조하는 미디어를 저장 시점에 기록한다
+-- 변환본(media_variants) URL을 참조해도 원본 미디어의 사용처로 기록된다
+-- source_type/source_id는 게시글·프로젝트를 가리키며, 게시글·프로젝트 삭제 시 서비스에서 지운다
+
+CREATE TABLE IF NOT EXISTS media_usages (
+    media_id INT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    source_type VARCHAR(20) NOT NULL,
+    source_id INT NOT NULL,
+    field VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (media_id, source_type, source_id, field)
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_usages_source ON media_usages(source_type, source_id);
+
+-- 기존 게시글·프로젝트의 참조를 채운다 (본문은 URL 포함 여부, 나머지는 일치 여부로 판단)
+WITH media_urls AS (
+    SELECT id AS media_id, url FROM media
+    UNION ALL
+    SELECT media_id, url FROM media_variants
+)
+INSERT INTO media_usages (media_id, source_type, source_id, field)
+SELECT u.media_id, 'post', p.id, 'content' FROM posts p JOIN media_urls u ON strpos(p.content, u.url) > 0
+UNION
+SELECT u.media_id, 'post', p.id, 'thumbnail' FROM posts p JOIN media_urls u ON p.thumbnail = u.url
+UNION
+SELECT u.media_id, 'project', p.id, 'thumbnail' FROM projects p JOIN media_urls u ON p.thumbnail = u.url
+UNION
+SELECT u.media_id, 'project', p.id, 'images' FROM projects p JOIN media_urls u ON jsonb_typeof(p.images) = 'array' AND p.images ? u.url
+ON CONFLICT DO NOTHING;